@@ -0,0 +1,31 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorCatalogAdminView The remote connector catalog applied to connector types
+type ConnectorCatalogAdminView struct {
+	Id         string    `json:"id,omitempty"`
+	Kind       string    `json:"kind,omitempty"`
+	Href       string    `json:"href,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	ModifiedAt time.Time `json:"modified_at,omitempty"`
+	// URL of the remote catalog index or artifact
+	Source string `json:"source"`
+	// Catalog revision
+	Revision string `json:"revision"`
+	// sha256 digest of the catalog document
+	Checksum string `json:"checksum"`
+	// Ids of the connector types in the catalog
+	ConnectorTypeIds []string `json:"connector_type_ids"`
+}
@@ -0,0 +1,34 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorNamespaceMigration Tracks moving a connector to another namespace
+type ConnectorNamespaceMigration struct {
+	Id                string                `json:"id,omitempty"`
+	Kind              string                `json:"kind,omitempty"`
+	Href              string                `json:"href,omitempty"`
+	CreatedAt         time.Time             `json:"created_at,omitempty"`
+	ModifiedAt        time.Time             `json:"modified_at,omitempty"`
+	ConnectorId       string                `json:"connector_id"`
+	SourceNamespaceId string                `json:"source_namespace_id"`
+	SourceClusterId   string                `json:"source_cluster_id"`
+	TargetNamespaceId string                `json:"target_namespace_id"`
+	TargetClusterId   string                `json:"target_cluster_id"`
+	DesiredState      ConnectorDesiredState `json:"desired_state"`
+	// Migration phase, one of stopping, deploying, completed, rolling_back, rolled_back or failed
+	Phase string `json:"phase"`
+	// Reason for rolling back or failing the migration
+	Reason      string `json:"reason,omitempty"`
+	RequestedBy string `json:"requested_by,omitempty"`
+}
@@ -0,0 +1,16 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorNamespaceMigrationRequest struct for ConnectorNamespaceMigrationRequest
+type ConnectorNamespaceMigrationRequest struct {
+	// The id of the namespace the connector is moved to
+	TargetNamespaceId string `json:"target_namespace_id"`
+}
@@ -21,8 +21,10 @@ type ConnectorTypeAdminView struct {
 	Channels map[string]ConnectorTypeChannel `json:"channels,omitempty"`
 	// A description of the connector.
 	Description string `json:"description,omitempty"`
-	// Connector type is deprecated and removed from the catalog.
+	// Connector type is deprecated, either removed from the catalog or annotated with deprecation details.
 	Deprecated bool `json:"deprecated,omitempty"`
+	// Deprecation details of the connector type.
+	Deprecation *ConnectorTypeDeprecation `json:"deprecation,omitempty"`
 	// URL to an icon of the connector.
 	IconHref string `json:"icon_href,omitempty"`
 	// Labels used to categorize the connector
@@ -0,0 +1,26 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorTypeDeprecation Deprecation details of a connector type
+type ConnectorTypeDeprecation struct {
+	// Time after which remaining connectors of the type are stopped or reported.
+	SunsetAt *time.Time `json:"sunset_at,omitempty"`
+	// Time after which new connectors of the type can't be created.
+	CreateCutoffAt *time.Time `json:"create_cutoff_at,omitempty"`
+	// Id of the connector type replacing the deprecated type.
+	ReplacementTypeId string `json:"replacement_type_id,omitempty"`
+	// Message for users of the deprecated type.
+	Message string `json:"message,omitempty"`
+}
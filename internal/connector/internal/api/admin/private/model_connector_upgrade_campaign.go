@@ -0,0 +1,40 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ConnectorUpgradeCampaign Upgrades connector deployments to the latest shard metadata of their channel in waves
+type ConnectorUpgradeCampaign struct {
+	Id              string    `json:"id,omitempty"`
+	Kind            string    `json:"kind,omitempty"`
+	Href            string    `json:"href,omitempty"`
+	CreatedAt       time.Time `json:"created_at,omitempty"`
+	ModifiedAt      time.Time `json:"modified_at,omitempty"`
+	ConnectorTypeId string    `json:"connector_type_id"`
+	Channel         string    `json:"channel,omitempty"`
+	Revision        int64     `json:"revision,omitempty"`
+	ClusterId       string    `json:"cluster_id,omitempty"`
+	NamespaceId     string    `json:"namespace_id,omitempty"`
+	WaveSize        int32     `json:"wave_size"`
+	FailureBudget   int32     `json:"failure_budget"`
+	WaveTimeout     string    `json:"wave_timeout"`
+	// The wave being upgraded, 0 before the first wave starts
+	CurrentWave int32 `json:"current_wave"`
+	Waves       int32 `json:"waves"`
+	// Campaign phase, one of running, completed, rolling_back or rolled_back
+	Phase string `json:"phase"`
+	// Reason for rolling back the campaign
+	Reason      string                           `json:"reason,omitempty"`
+	RequestedBy string                           `json:"requested_by,omitempty"`
+	Progress    ConnectorUpgradeCampaignProgress `json:"progress"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorUpgradeCampaignProgress Number of targeted deployments in each upgrade phase
+type ConnectorUpgradeCampaignProgress struct {
+	Total      int32 `json:"total"`
+	Pending    int32 `json:"pending"`
+	Upgrading  int32 `json:"upgrading"`
+	Upgraded   int32 `json:"upgraded"`
+	Failed     int32 `json:"failed"`
+	RolledBack int32 `json:"rolled_back"`
+	Skipped    int32 `json:"skipped"`
+}
@@ -0,0 +1,30 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorUpgradeCampaignRequest Selects the connector deployments to upgrade to the latest shard metadata of their channel
+type ConnectorUpgradeCampaignRequest struct {
+	// The id of the connector type of the deployments to upgrade
+	ConnectorTypeId string `json:"connector_type_id"`
+	// Only upgrade deployments in this channel
+	Channel string `json:"channel,omitempty"`
+	// Only upgrade deployments with this shard metadata revision
+	Revision int64 `json:"revision,omitempty"`
+	// Only upgrade deployments in this cluster
+	ClusterId string `json:"cluster_id,omitempty"`
+	// Only upgrade deployments in this namespace
+	NamespaceId string `json:"namespace_id,omitempty"`
+	// Number of deployments upgraded in each wave, defaults to 10
+	WaveSize int32 `json:"wave_size,omitempty"`
+	// Number of failed deployment upgrades tolerated before the campaign is rolled back, defaults to 0
+	FailureBudget int32 `json:"failure_budget,omitempty"`
+	// Time deployments in a wave have to become ready before they are considered failed, in golang duration format, defaults to 30m
+	WaveTimeout string `json:"wave_timeout,omitempty"`
+}
@@ -1,6 +1,8 @@
 package dbapi
 
 import (
+	"time"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 )
@@ -77,6 +79,22 @@ type ConnectorStatus struct {
 	db.Model
 	NamespaceID *string
 	Phase       ConnectorStatusPhase
+	// Reason is set by kas-fleet-manager when the connector can't progress, e.g. no namespace could be found for it
+	Reason string
+	// RestartCount is the number of automatic restarts of a failed connector since RestartWindowStart
+	RestartCount       int
+	RestartWindowStart *time.Time
+	LastRestartAt      *time.Time
+	// LastFailure holds the deployment conditions reported by the agent when the connector was last restarted
+	LastFailure api.JSON `gorm:"type:jsonb"`
+}
+
+// ResetRestarts clears automatic restart tracking, e.g. when the user changes the connector
+func (s *ConnectorStatus) ResetRestarts() {
+	s.RestartCount = 0
+	s.RestartWindowStart = nil
+	s.LastRestartAt = nil
+	s.LastFailure = nil
 }
 
 type ConnectorList []*Connector
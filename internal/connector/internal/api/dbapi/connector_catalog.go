@@ -0,0 +1,20 @@
+package dbapi
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+// RemoteConnectorCatalogID is the id of the last applied remote connector catalog
+const RemoteConnectorCatalogID = "remote"
+
+// ConnectorCatalog is a verified connector catalog loaded from a remote source
+type ConnectorCatalog struct {
+	db.Model
+	Source   string
+	Revision string
+	// Checksum is the sha256 digest of the catalog document
+	Checksum string
+	// CatalogEntries are the catalog entries in the document, in config.ConnectorCatalogEntry format
+	CatalogEntries api.JSON `gorm:"type:jsonb"`
+}
@@ -18,6 +18,10 @@ const (
 	ConnectorClusterPhaseDeleting ConnectorClusterPhaseEnum = "deleting"
 
 	ConnectorClusterOrgIdAnnotation string = "cos.bf2.org/organisation-id"
+	// ConnectorClusterCloudProviderAnnotation - cloud provider of the cluster, used for namespace placement affinity
+	ConnectorClusterCloudProviderAnnotation string = "cos.bf2.org/cloud-provider"
+	// ConnectorClusterRegionAnnotation - cloud region of the cluster, used for namespace placement affinity
+	ConnectorClusterRegionAnnotation string = "cos.bf2.org/region"
 )
 
 var AgentRequestConnectorClusterStatus = []string{
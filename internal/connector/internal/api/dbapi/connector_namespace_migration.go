@@ -0,0 +1,50 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+type ConnectorMigrationPhase string
+
+const (
+	// ConnectorMigrationPhaseStopping - connector deployment in source namespace is being removed
+	ConnectorMigrationPhaseStopping ConnectorMigrationPhase = "stopping"
+	// ConnectorMigrationPhaseDeploying - connector is being deployed in target namespace
+	ConnectorMigrationPhaseDeploying ConnectorMigrationPhase = "deploying"
+	// ConnectorMigrationPhaseCompleted - connector is running in target namespace
+	ConnectorMigrationPhaseCompleted ConnectorMigrationPhase = "completed"
+	// ConnectorMigrationPhaseRollingBack - connector deployment failed in target namespace and is being moved back
+	ConnectorMigrationPhaseRollingBack ConnectorMigrationPhase = "rolling_back"
+	// ConnectorMigrationPhaseRolledBack - connector has been assigned back to its source namespace
+	ConnectorMigrationPhaseRolledBack ConnectorMigrationPhase = "rolled_back"
+	// ConnectorMigrationPhaseFailed - migration was abandoned, e.g. because the connector was deleted
+	ConnectorMigrationPhaseFailed ConnectorMigrationPhase = "failed"
+)
+
+// ActiveConnectorMigrationPhases are the phases of migrations that are still being reconciled
+var ActiveConnectorMigrationPhases = []string{
+	string(ConnectorMigrationPhaseStopping),
+	string(ConnectorMigrationPhaseDeploying),
+	string(ConnectorMigrationPhaseRollingBack),
+}
+
+// ConnectorNamespaceMigration tracks moving a connector from one namespace to another
+type ConnectorNamespaceMigration struct {
+	db.Model
+	ConnectorID       string `gorm:"not null;index"`
+	SourceNamespaceID string `gorm:"not null"`
+	SourceClusterID   string `gorm:"not null"`
+	TargetNamespaceID string `gorm:"not null"`
+	TargetClusterID   string `gorm:"not null"`
+	// desired state of the connector when the migration was requested, restored after redeployment
+	DesiredState ConnectorDesiredState   `gorm:"not null"`
+	Phase        ConnectorMigrationPhase `gorm:"not null;index"`
+	Reason       string
+	RequestedBy  string
+	// time when the connector was assigned to the namespace it's being deployed to
+	DeployingSince *time.Time
+}
+
+type ConnectorNamespaceMigrationList []*ConnectorNamespaceMigration
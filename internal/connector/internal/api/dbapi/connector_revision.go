@@ -0,0 +1,18 @@
+package dbapi
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+// ConnectorRevision is a snapshot of an accepted connector spec, secrets are stored as references to vault keys
+type ConnectorRevision struct {
+	db.Model
+	ConnectorID      string `gorm:"index"`
+	Revision         int64
+	ConnectorVersion int64
+	ConnectorSpec    api.JSON `gorm:"type:jsonb"`
+	Author           string
+}
+
+type ConnectorRevisionList []*ConnectorRevision
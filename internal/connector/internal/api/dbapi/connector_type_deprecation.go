@@ -0,0 +1,122 @@
+package dbapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ConnectorTypeDeprecationSunsetAnnotation is the date after which remaining connectors of a deprecated type are reported or stopped
+	ConnectorTypeDeprecationSunsetAnnotation = "cos.bf2.org/deprecation-sunset"
+	// ConnectorTypeDeprecationCreateCutoffAnnotation is the date after which new connectors of a deprecated type can't be created,
+	// defaults to the sunset date
+	ConnectorTypeDeprecationCreateCutoffAnnotation = "cos.bf2.org/deprecation-create-cutoff"
+	// ConnectorTypeDeprecationReplacementAnnotation is the id of the connector type replacing a deprecated type
+	ConnectorTypeDeprecationReplacementAnnotation = "cos.bf2.org/deprecation-replacement"
+	// ConnectorTypeDeprecationMessageAnnotation is a message for users of a deprecated type
+	ConnectorTypeDeprecationMessageAnnotation = "cos.bf2.org/deprecation-message"
+)
+
+var connectorTypeDeprecationAnnotations = []string{
+	ConnectorTypeDeprecationSunsetAnnotation,
+	ConnectorTypeDeprecationCreateCutoffAnnotation,
+	ConnectorTypeDeprecationReplacementAnnotation,
+	ConnectorTypeDeprecationMessageAnnotation,
+}
+
+// ConnectorTypeDeprecation is the deprecation metadata of a connector type, read from catalog annotations
+type ConnectorTypeDeprecation struct {
+	SunsetAt          *time.Time
+	CreateCutoffAt    *time.Time
+	ReplacementTypeID string
+	Message           string
+}
+
+// IsSunset returns true if the sunset date has passed
+func (d *ConnectorTypeDeprecation) IsSunset(now time.Time) bool {
+	return d.SunsetAt != nil && !now.Before(*d.SunsetAt)
+}
+
+// IsCreateCutoff returns true if new connectors can no longer be created
+func (d *ConnectorTypeDeprecation) IsCreateCutoff(now time.Time) bool {
+	return d.CreateCutoffAt != nil && !now.Before(*d.CreateCutoffAt)
+}
+
+// String returns a user facing description of the deprecation
+func (d *ConnectorTypeDeprecation) String() string {
+	var b strings.Builder
+	b.WriteString("connector type is deprecated")
+	if d.SunsetAt != nil {
+		fmt.Fprintf(&b, " with sunset date %s", d.SunsetAt.Format(time.RFC3339))
+	}
+	if d.ReplacementTypeID != "" {
+		fmt.Fprintf(&b, ", use connector type %s instead", d.ReplacementTypeID)
+	}
+	if d.Message != "" {
+		fmt.Fprintf(&b, ": %s", d.Message)
+	}
+	return b.String()
+}
+
+// HasConnectorTypeDeprecationAnnotations returns true if any deprecation annotation is set
+func HasConnectorTypeDeprecationAnnotations(annotations map[string]string) bool {
+	for _, key := range connectorTypeDeprecationAnnotations {
+		if _, ok := annotations[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConnectorTypeDeprecation reads deprecation metadata from connector type annotations,
+// returns nil if the annotations don't include any deprecation annotation
+func ParseConnectorTypeDeprecation(annotations map[string]string) (*ConnectorTypeDeprecation, error) {
+	if !HasConnectorTypeDeprecationAnnotations(annotations) {
+		return nil, nil
+	}
+
+	result := &ConnectorTypeDeprecation{
+		ReplacementTypeID: annotations[ConnectorTypeDeprecationReplacementAnnotation],
+		Message:           annotations[ConnectorTypeDeprecationMessageAnnotation],
+	}
+	var err error
+	if result.SunsetAt, err = parseDeprecationDate(annotations, ConnectorTypeDeprecationSunsetAnnotation); err != nil {
+		return nil, err
+	}
+	if result.CreateCutoffAt, err = parseDeprecationDate(annotations, ConnectorTypeDeprecationCreateCutoffAnnotation); err != nil {
+		return nil, err
+	}
+	if result.CreateCutoffAt == nil {
+		result.CreateCutoffAt = result.SunsetAt
+	}
+	if result.SunsetAt != nil && result.CreateCutoffAt.After(*result.SunsetAt) {
+		return nil, fmt.Errorf("invalid annotation %s value %q: must not be after %s",
+			ConnectorTypeDeprecationCreateCutoffAnnotation, annotations[ConnectorTypeDeprecationCreateCutoffAnnotation], ConnectorTypeDeprecationSunsetAnnotation)
+	}
+	return result, nil
+}
+
+// parseDeprecationDate accepts either an RFC3339 timestamp or a date, i.e. midnight UTC
+func parseDeprecationDate(annotations map[string]string, key string) (*time.Time, error) {
+	value, ok := annotations[key]
+	if !ok {
+		return nil, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if date, err = time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s value %q: must be an RFC3339 timestamp or a yyyy-mm-dd date", key, value)
+		}
+	}
+	return &date, nil
+}
+
+// GetDeprecation returns the deprecation metadata of the connector type, if any
+func (ct *ConnectorType) GetDeprecation() (*ConnectorTypeDeprecation, error) {
+	annotations := make(map[string]string, len(ct.Annotations))
+	for _, anno := range ct.Annotations {
+		annotations[anno.Key] = anno.Value
+	}
+	return ParseConnectorTypeDeprecation(annotations)
+}
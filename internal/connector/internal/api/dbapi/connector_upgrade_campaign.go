@@ -0,0 +1,94 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+type ConnectorUpgradeCampaignPhase string
+
+const (
+	// ConnectorUpgradeCampaignPhaseRunning - deployments are being upgraded wave by wave
+	ConnectorUpgradeCampaignPhaseRunning ConnectorUpgradeCampaignPhase = "running"
+	// ConnectorUpgradeCampaignPhaseCompleted - all waves have been upgraded within the failure budget
+	ConnectorUpgradeCampaignPhaseCompleted ConnectorUpgradeCampaignPhase = "completed"
+	// ConnectorUpgradeCampaignPhaseRollingBack - upgraded deployments are being moved back to their previous shard metadata
+	ConnectorUpgradeCampaignPhaseRollingBack ConnectorUpgradeCampaignPhase = "rolling_back"
+	// ConnectorUpgradeCampaignPhaseRolledBack - upgraded deployments have been moved back to their previous shard metadata
+	ConnectorUpgradeCampaignPhaseRolledBack ConnectorUpgradeCampaignPhase = "rolled_back"
+)
+
+// ActiveConnectorUpgradeCampaignPhases are the phases of campaigns that are still being reconciled
+var ActiveConnectorUpgradeCampaignPhases = []string{
+	string(ConnectorUpgradeCampaignPhaseRunning),
+	string(ConnectorUpgradeCampaignPhaseRollingBack),
+}
+
+type ConnectorUpgradeTargetPhase string
+
+const (
+	// ConnectorUpgradeTargetPhasePending - deployment is waiting for its wave
+	ConnectorUpgradeTargetPhasePending ConnectorUpgradeTargetPhase = "pending"
+	// ConnectorUpgradeTargetPhaseUpgrading - deployment has been assigned the new shard metadata
+	ConnectorUpgradeTargetPhaseUpgrading ConnectorUpgradeTargetPhase = "upgrading"
+	// ConnectorUpgradeTargetPhaseUpgraded - agent reported the upgraded deployment as ready or stopped
+	ConnectorUpgradeTargetPhaseUpgraded ConnectorUpgradeTargetPhase = "upgraded"
+	// ConnectorUpgradeTargetPhaseFailed - agent reported the upgraded deployment as failed, or it didn't become ready in time
+	ConnectorUpgradeTargetPhaseFailed ConnectorUpgradeTargetPhase = "failed"
+	// ConnectorUpgradeTargetPhaseRolledBack - deployment has been assigned its previous shard metadata again
+	ConnectorUpgradeTargetPhaseRolledBack ConnectorUpgradeTargetPhase = "rolled_back"
+	// ConnectorUpgradeTargetPhaseSkipped - deployment was deleted or changed by someone else before it was upgraded
+	ConnectorUpgradeTargetPhaseSkipped ConnectorUpgradeTargetPhase = "skipped"
+)
+
+// ActiveConnectorUpgradeTargetPhases are the phases of deployments that can't be targeted by another campaign
+var ActiveConnectorUpgradeTargetPhases = []string{
+	string(ConnectorUpgradeTargetPhasePending),
+	string(ConnectorUpgradeTargetPhaseUpgrading),
+}
+
+// ConnectorUpgradeCampaign upgrades matching connector deployments to the latest shard metadata of their channel in waves
+type ConnectorUpgradeCampaign struct {
+	db.Model
+	// deployment selectors, empty values match all deployments
+	ConnectorTypeID string `gorm:"not null"`
+	Channel         string
+	Revision        int64
+	ClusterID       string
+	NamespaceID     string
+
+	WaveSize int
+	// FailureBudget is the number of failed deployment upgrades tolerated before the campaign is rolled back
+	FailureBudget int
+	// WaveTimeout is the time deployments in a wave have to become ready before they are considered failed
+	WaveTimeout time.Duration
+	CurrentWave int
+	Waves       int
+
+	Phase       ConnectorUpgradeCampaignPhase `gorm:"not null;index"`
+	Reason      string
+	RequestedBy string
+
+	// Progress is the number of targeted deployments in each phase
+	Progress map[ConnectorUpgradeTargetPhase]int `gorm:"-"`
+}
+
+type ConnectorUpgradeCampaignList []*ConnectorUpgradeCampaign
+
+// ConnectorUpgradeTarget is a deployment upgraded by a campaign
+type ConnectorUpgradeTarget struct {
+	db.Model
+	CampaignID          string `gorm:"not null;index"`
+	DeploymentID        string `gorm:"not null;index"`
+	Wave                int
+	FromShardMetadataID int64
+	ToShardMetadataID   int64
+	// DeploymentVersion is the deployment version after the upgrade, agents report it once they have applied the upgrade
+	DeploymentVersion int64
+	Phase             ConnectorUpgradeTargetPhase `gorm:"not null;index"`
+	Reason            string
+	UpgradingSince    *time.Time
+}
+
+type ConnectorUpgradeTargetList []*ConnectorUpgradeTarget
@@ -35,4 +35,6 @@ type Connector struct {
 	SchemaRegistry  SchemaRegistryConnectionSettings `json:"schema_registry,omitempty"`
 	Connector       map[string]interface{}           `json:"connector"`
 	Status          ConnectorStatusStatus            `json:"status,omitempty"`
+	// Deprecation details of the connector type, if it is deprecated
+	ConnectorTypeDeprecation *ConnectorTypeDeprecation `json:"connector_type_deprecation,omitempty"`
 }
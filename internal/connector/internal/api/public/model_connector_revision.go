@@ -0,0 +1,27 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorRevision struct for ConnectorRevision
+type ConnectorRevision struct {
+	Kind        string    `json:"kind,omitempty"`
+	Href        string    `json:"href,omitempty"`
+	ConnectorId string    `json:"connector_id"`
+	Revision    int64     `json:"revision"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	// Connector spec of the revision, secret fields are redacted
+	Connector map[string]interface{} `json:"connector"`
+}
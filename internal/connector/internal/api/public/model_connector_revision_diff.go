@@ -0,0 +1,21 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorRevisionDiff struct for ConnectorRevisionDiff
+type ConnectorRevisionDiff struct {
+	Kind         string `json:"kind,omitempty"`
+	ConnectorId  string `json:"connector_id"`
+	FromRevision int64  `json:"from_revision"`
+	ToRevision   int64  `json:"to_revision"`
+	// JSON merge patch (RFC 7386) that changes the connector spec of from_revision into the connector spec of to_revision, secret fields are redacted
+	Patch map[string]interface{} `json:"patch"`
+}
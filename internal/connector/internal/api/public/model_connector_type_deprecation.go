@@ -0,0 +1,27 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// ConnectorTypeDeprecation Deprecation details of a connector type
+type ConnectorTypeDeprecation struct {
+	// Time after which remaining connectors of the type are stopped or reported.
+	SunsetAt *time.Time `json:"sunset_at,omitempty"`
+	// Time after which new connectors of the type can't be created.
+	CreateCutoffAt *time.Time `json:"create_cutoff_at,omitempty"`
+	// Id of the connector type replacing the deprecated type.
+	ReplacementTypeId string `json:"replacement_type_id,omitempty"`
+	// Message for users of the deprecated type.
+	Message string `json:"message,omitempty"`
+}
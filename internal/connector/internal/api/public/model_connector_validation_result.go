@@ -0,0 +1,18 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorValidationResult struct for ConnectorValidationResult
+type ConnectorValidationResult struct {
+	Kind       string                         `json:"kind,omitempty"`
+	Valid      bool                           `json:"valid"`
+	Violations []ConnectorValidationViolation `json:"violations"`
+}
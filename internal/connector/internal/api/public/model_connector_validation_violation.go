@@ -0,0 +1,18 @@
+/*
+ * Connector Management API
+ *
+ * Connector Management API is a REST API to manage connectors.
+ *
+ * API version: 0.1.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ConnectorValidationViolation struct for ConnectorValidationViolation
+type ConnectorValidationViolation struct {
+	// JSON pointer (RFC 6901) to the invalid value in the connector request
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
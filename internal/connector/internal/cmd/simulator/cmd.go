@@ -0,0 +1,216 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	apiURLFlag            = "api-url"
+	clusterIDFlag         = "cluster-id"
+	tokenFlag             = "token"
+	pollIntervalFlag      = "poll-interval"
+	provisioningDelayFlag = "provisioning-delay"
+	deletionDelayFlag     = "deletion-delay"
+	failureRateFlag       = "failure-rate"
+	seedFlag              = "seed"
+	operatorIDFlag        = "operator-id"
+	operatorTypeFlag      = "operator-type"
+	operatorVersionFlag   = "operator-version"
+
+	// the page size used to read the namespaces and deployments assigned to the cluster
+	pageSize = 100
+)
+
+// NewAgentSimulatorCommand creates a command that simulates a cos-fleetshard operator running in a connector cluster,
+// so that the connector lifecycle can be tested end to end without any data plane infrastructure
+func NewAgentSimulatorCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connector-agent-simulator",
+		Short: "Simulate a cos-fleetshard operator",
+		Long: "Simulate a cos-fleetshard operator running in an existing connector cluster. The simulator polls the " +
+			"namespaces and connector deployments assigned to the cluster from the agent API and reports the cluster, " +
+			"namespace and deployment statuses back to the fleet manager, going through provisioning, stop and deletion",
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(clusterService services.ConnectorClusterService, keycloakService sso.KafkaKeycloakService) {
+				runAgentSimulator(cmd, clusterService, keycloakService)
+			})
+		},
+	}
+
+	cmd.Flags().String(apiURLFlag, "http://localhost:8000", "Base URL of the fleet manager API")
+	cmd.Flags().String(clusterIDFlag, "", "ID of the simulated connector cluster")
+	cmd.Flags().String(tokenFlag, "", "Bearer token used to call the agent API instead of the cluster service account credentials")
+	cmd.Flags().Duration(pollIntervalFlag, 15*time.Second, "Interval between two synchronisations with the fleet manager")
+	cmd.Flags().Duration(provisioningDelayFlag, 30*time.Second, "Time a new connector deployment is reported as provisioning before becoming ready")
+	cmd.Flags().Duration(deletionDelayFlag, 15*time.Second, "Time a connector deployment marked for deletion takes to be reported as deleted")
+	cmd.Flags().Float64(failureRateFlag, 0, "Probability, between 0 and 1, that a new connector deployment fails")
+	cmd.Flags().Int64(seedFlag, 0, "Seed of the fault injection, the current time is used when 0")
+	cmd.Flags().String(operatorIDFlag, "camel-k-1.0.0", "ID of the simulated connector operator")
+	cmd.Flags().String(operatorTypeFlag, "camel-k", "Type of the simulated connector operator")
+	cmd.Flags().String(operatorVersionFlag, "1.0.0", "Version of the simulated connector operator")
+
+	return cmd
+}
+
+func runAgentSimulator(cmd *cobra.Command, clusterService services.ConnectorClusterService, keycloakService sso.KafkaKeycloakService) {
+	flags := cmd.Flags()
+	clusterID, _ := flags.GetString(clusterIDFlag)
+	if clusterID == "" {
+		glog.Fatalf("Flag --%s is required", clusterIDFlag)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	apiURL, _ := flags.GetString(apiURLFlag)
+	cfg := private.NewConfiguration()
+	cfg.BasePath = strings.TrimSuffix(apiURL, "/")
+	if token, _ := flags.GetString(tokenFlag); token != "" {
+		cfg.AddDefaultHeader("Authorization", fmt.Sprintf("Bearer %s", token))
+	} else {
+		cluster, svcErr := clusterService.Get(ctx, clusterID)
+		if svcErr != nil {
+			glog.Fatalf("Unable to find connector cluster %s: %s", clusterID, svcErr.Error())
+		}
+		config := clientcredentials.Config{
+			ClientID:     cluster.ClientId,
+			ClientSecret: cluster.ClientSecret,
+			TokenURL:     keycloakService.GetRealmConfig().TokenEndpointURI,
+		}
+		cfg.HTTPClient = config.Client(ctx)
+	}
+	client := private.NewAPIClient(cfg)
+
+	seed, _ := flags.GetInt64(seedFlag)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	provisioningDelay, _ := flags.GetDuration(provisioningDelayFlag)
+	deletionDelay, _ := flags.GetDuration(deletionDelayFlag)
+	failureRate, _ := flags.GetFloat64(failureRateFlag)
+	operatorID, _ := flags.GetString(operatorIDFlag)
+	operatorType, _ := flags.GetString(operatorTypeFlag)
+	operatorVersion, _ := flags.GetString(operatorVersionFlag)
+	simulator := NewSimulator(Options{
+		ProvisioningDelay: provisioningDelay,
+		DeletionDelay:     deletionDelay,
+		FailureRate:       failureRate,
+		Operator: private.ConnectorOperator{
+			Id:      operatorID,
+			Type:    operatorType,
+			Version: operatorVersion,
+		},
+		OperatorNamespace: fmt.Sprintf("openshift-mcs-%s", operatorID),
+		Platform: private.ConnectorClusterPlatform{
+			Type: "OpenShift",
+			Id:   clusterID,
+		},
+	}, rand.New(rand.NewSource(seed)))
+
+	glog.Infof("Simulating the cos-fleetshard operator of connector cluster %s against %s with seed %d", clusterID, cfg.BasePath, seed)
+	pollInterval, _ := flags.GetDuration(pollIntervalFlag)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := synchronize(ctx, client.ConnectorClustersAgentApi, simulator, clusterID); err != nil {
+			glog.Errorf("Failed to synchronize connector cluster %s: %s", clusterID, err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// synchronize does what the cos-fleetshard synchronizer does on each poll: it reports the cluster status, reads the
+// namespaces and deployments assigned to the cluster and reports their statuses
+func synchronize(ctx context.Context, agentApi *private.ConnectorClustersAgentApiService, simulator *Simulator, clusterID string) error {
+	if _, err := agentApi.UpdateKafkaConnectorClusterStatus(ctx, clusterID, simulator.ClusterStatus()); err != nil {
+		return fmt.Errorf("unable to update cluster status: %w", err)
+	}
+
+	namespaces, err := listNamespaces(ctx, agentApi, clusterID)
+	if err != nil {
+		return err
+	}
+	deployments, err := listDeployments(ctx, agentApi, clusterID)
+	if err != nil {
+		return err
+	}
+
+	deploymentStatuses := simulator.DeploymentStatuses(deployments, time.Now())
+	for _, d := range deployments {
+		status := deploymentStatuses[d.Id]
+		if _, err := agentApi.UpdateConnectorDeploymentStatus(ctx, clusterID, d.Id, status); err != nil {
+			return fmt.Errorf("unable to update status of deployment %s: %w", d.Id, err)
+		}
+		glog.V(5).Infof("Reported deployment %s as %s", d.Id, status.Phase)
+	}
+
+	for id, status := range simulator.NamespaceStatuses(namespaces, deployments, deploymentStatuses) {
+		if _, err := agentApi.UpdateConnectorNamespaceStatus(ctx, clusterID, id, status); err != nil {
+			return fmt.Errorf("unable to update status of namespace %s: %w", id, err)
+		}
+		glog.V(5).Infof("Reported namespace %s as %s", id, status.Phase)
+	}
+	return nil
+}
+
+func listNamespaces(ctx context.Context, agentApi *private.ConnectorClustersAgentApiService, clusterID string) ([]private.ConnectorNamespaceDeployment, error) {
+	var namespaces []private.ConnectorNamespaceDeployment
+	for page := 1; ; page++ {
+		list, _, err := agentApi.GetClusterAsignedConnectorNamespaces(ctx, clusterID, &private.GetClusterAsignedConnectorNamespacesOpts{
+			Page: optional.NewString(strconv.Itoa(page)),
+			Size: optional.NewString(strconv.Itoa(pageSize)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get namespaces: %w", err)
+		}
+		namespaces = append(namespaces, list.Items...)
+		if len(list.Items) < pageSize {
+			return namespaces, nil
+		}
+	}
+}
+
+func listDeployments(ctx context.Context, agentApi *private.ConnectorClustersAgentApiService, clusterID string) ([]private.ConnectorDeployment, error) {
+	var deployments []private.ConnectorDeployment
+	for page := 1; ; page++ {
+		list, _, err := agentApi.GetClusterAsignedConnectorDeployments(ctx, clusterID, &private.GetClusterAsignedConnectorDeploymentsOpts{
+			Page: optional.NewString(strconv.Itoa(page)),
+			Size: optional.NewString(strconv.Itoa(pageSize)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get deployments: %w", err)
+		}
+		deployments = append(deployments, list.Items...)
+		if len(list.Items) < pageSize {
+			return deployments, nil
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package simulator
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/private"
+)
+
+const (
+	conditionReady = "Ready"
+
+	conditionStatusTrue  = "True"
+	conditionStatusFalse = "False"
+
+	reasonProvisioning = "Provisioning"
+	reasonError        = "Error"
+
+	simulatedFailureMessage = "simulated failure"
+)
+
+// Options holds the timing and fault injection settings of the simulated connector data plane
+type Options struct {
+	ProvisioningDelay time.Duration
+	DeletionDelay     time.Duration
+	// FailureRate is the probability, between 0 and 1, that a new connector deployment is reported as failed
+	FailureRate float64
+	// Operator is the connector operator installed in the simulated cluster
+	Operator          private.ConnectorOperator
+	OperatorNamespace string
+	Platform          private.ConnectorClusterPlatform
+}
+
+// deploymentState is what the simulator remembers about a connector deployment between two polls
+type deploymentState struct {
+	resourceVersion int64
+	firstSeen       time.Time
+	failed          bool
+	deletedAt       time.Time
+}
+
+// Simulator computes the status reports of a simulated cos-fleetshard operator from the namespaces and connector
+// deployments assigned to the connector cluster. It is not safe for concurrent use.
+type Simulator struct {
+	options     Options
+	random      *rand.Rand
+	deployments map[string]*deploymentState
+}
+
+func NewSimulator(options Options, random *rand.Rand) *Simulator {
+	return &Simulator{
+		options:     options,
+		random:      random,
+		deployments: map[string]*deploymentState{},
+	}
+}
+
+// ClusterStatus returns the status of the connector cluster with the simulated operator
+func (s *Simulator) ClusterStatus() private.ConnectorClusterStatus {
+	return private.ConnectorClusterStatus{
+		Phase: private.CONNECTORCLUSTERSTATE_READY,
+		Conditions: []private.MetaV1Condition{
+			{Type: conditionReady, Status: conditionStatusTrue},
+		},
+		Platform: s.options.Platform,
+		Operators: []private.ConnectorClusterStatusOperators{
+			{Operator: s.options.Operator, Namespace: s.options.OperatorNamespace, Status: string(private.CONNECTORSTATE_READY)},
+		},
+	}
+}
+
+// NamespaceStatuses returns the status of each one of the given namespaces from the statuses reported for the
+// deployments, the namespaces being deleted are reported as deleted once they have no deployments left
+func (s *Simulator) NamespaceStatuses(namespaces []private.ConnectorNamespaceDeployment, deployments []private.ConnectorDeployment, deploymentStatuses map[string]private.ConnectorDeploymentStatus) map[string]private.ConnectorNamespaceDeploymentStatus {
+	deployed := map[string]int32{}
+	for _, d := range deployments {
+		if deploymentStatuses[d.Id].Phase != private.CONNECTORSTATE_DELETED {
+			deployed[d.Spec.NamespaceId]++
+		}
+	}
+
+	statuses := map[string]private.ConnectorNamespaceDeploymentStatus{}
+	for _, ns := range namespaces {
+		phase := private.CONNECTORNAMESPACESTATE_READY
+		if ns.Status.State == private.CONNECTORNAMESPACESTATE_DELETING || ns.Status.State == private.CONNECTORNAMESPACESTATE_DELETED {
+			phase = private.CONNECTORNAMESPACESTATE_DELETING
+			if deployed[ns.Id] == 0 {
+				phase = private.CONNECTORNAMESPACESTATE_DELETED
+			}
+		}
+		statuses[ns.Id] = private.ConnectorNamespaceDeploymentStatus{
+			Id:                 ns.Id,
+			Phase:              phase,
+			Version:            ns.Status.Version,
+			ConnectorsDeployed: deployed[ns.Id],
+		}
+	}
+	return statuses
+}
+
+// DeploymentStatuses returns the status of each one of the given connector deployments at the given time and forgets
+// about the deployments that are no longer assigned to the connector cluster
+func (s *Simulator) DeploymentStatuses(deployments []private.ConnectorDeployment, now time.Time) map[string]private.ConnectorDeploymentStatus {
+	statuses := map[string]private.ConnectorDeploymentStatus{}
+	seen := map[string]bool{}
+	for _, d := range deployments {
+		seen[d.Id] = true
+		statuses[d.Id] = s.deploymentStatus(d, now)
+	}
+	for id := range s.deployments {
+		if !seen[id] {
+			delete(s.deployments, id)
+		}
+	}
+	return statuses
+}
+
+func (s *Simulator) deploymentStatus(deployment private.ConnectorDeployment, now time.Time) private.ConnectorDeploymentStatus {
+	state, ok := s.deployments[deployment.Id]
+	if !ok || state.resourceVersion != deployment.Metadata.ResourceVersion {
+		// every new version of a deployment is provisioned again and can fail again
+		state = &deploymentState{
+			resourceVersion: deployment.Metadata.ResourceVersion,
+			firstSeen:       now,
+			failed:          s.random.Float64() < s.options.FailureRate,
+		}
+		s.deployments[deployment.Id] = state
+	}
+
+	operator := s.options.Operator
+	if deployment.Spec.OperatorId != "" {
+		operator.Id = deployment.Spec.OperatorId
+	}
+	status := private.ConnectorDeploymentStatus{
+		ResourceVersion: deployment.Metadata.ResourceVersion,
+		Operators: private.ConnectorDeploymentStatusOperators{
+			Assigned:  operator,
+			Available: s.options.Operator,
+		},
+	}
+
+	switch deployment.Spec.DesiredState {
+	case private.CONNECTORDESIREDSTATE_DELETED, private.CONNECTORDESIREDSTATE_UNASSIGNED:
+		if state.deletedAt.IsZero() {
+			state.deletedAt = now
+		}
+		status.Phase = private.CONNECTORSTATE_DELETING
+		if now.Sub(state.deletedAt) >= s.options.DeletionDelay {
+			status.Phase = private.CONNECTORSTATE_DELETED
+		}
+	case private.CONNECTORDESIREDSTATE_STOPPED:
+		status.Phase = private.CONNECTORSTATE_STOPPED
+	default:
+		switch {
+		case now.Sub(state.firstSeen) < s.options.ProvisioningDelay:
+			status.Phase = private.CONNECTORSTATE_PROVISIONING
+			status.Conditions = []private.MetaV1Condition{
+				{Type: conditionReady, Status: conditionStatusFalse, Reason: reasonProvisioning},
+			}
+		case state.failed:
+			status.Phase = private.CONNECTORSTATE_FAILED
+			status.Conditions = []private.MetaV1Condition{
+				{Type: conditionReady, Status: conditionStatusFalse, Reason: reasonError, Message: simulatedFailureMessage},
+			}
+		default:
+			status.Phase = private.CONNECTORSTATE_READY
+			status.Conditions = []private.MetaV1Condition{
+				{Type: conditionReady, Status: conditionStatusTrue},
+			}
+		}
+	}
+	return status
+}
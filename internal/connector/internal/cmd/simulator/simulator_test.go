@@ -0,0 +1,118 @@
+package simulator
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/private"
+	"github.com/onsi/gomega"
+)
+
+func newTestDeployment(desiredState private.ConnectorDesiredState, resourceVersion int64) private.ConnectorDeployment {
+	return private.ConnectorDeployment{
+		Id:       "deployment-1",
+		Metadata: private.ConnectorDeploymentAllOfMetadata{ResourceVersion: resourceVersion},
+		Spec: private.ConnectorDeploymentSpec{
+			NamespaceId:  "namespace-1",
+			DesiredState: desiredState,
+		},
+	}
+}
+
+func newTestSimulator(failureRate float64) *Simulator {
+	return NewSimulator(Options{
+		ProvisioningDelay: time.Minute,
+		DeletionDelay:     time.Minute,
+		FailureRate:       failureRate,
+		Operator:          private.ConnectorOperator{Id: "camel-k-1.0.0", Type: "camel-k", Version: "1.0.0"},
+	}, rand.New(rand.NewSource(1)))
+}
+
+func Test_Simulator_DeploymentStatuses(t *testing.T) {
+	start := time.Now()
+
+	type step struct {
+		deployment private.ConnectorDeployment
+		at         time.Duration
+		wantPhase  private.ConnectorState
+	}
+
+	tests := []struct {
+		name        string
+		failureRate float64
+		steps       []step
+	}{
+		{
+			name: "should report the deployment as provisioning and then as ready",
+			steps: []step{
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_READY, 1), at: 0, wantPhase: private.CONNECTORSTATE_PROVISIONING},
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_READY, 1), at: 2 * time.Minute, wantPhase: private.CONNECTORSTATE_READY},
+			},
+		},
+		{
+			name:        "should report the deployment as failed when the failure is injected",
+			failureRate: 1,
+			steps: []step{
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_READY, 1), at: 0, wantPhase: private.CONNECTORSTATE_PROVISIONING},
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_READY, 1), at: 2 * time.Minute, wantPhase: private.CONNECTORSTATE_FAILED},
+			},
+		},
+		{
+			name: "should provision the deployment again when its resource version changes",
+			steps: []step{
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_READY, 1), at: 0, wantPhase: private.CONNECTORSTATE_PROVISIONING},
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_READY, 1), at: 2 * time.Minute, wantPhase: private.CONNECTORSTATE_READY},
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_READY, 2), at: 3 * time.Minute, wantPhase: private.CONNECTORSTATE_PROVISIONING},
+			},
+		},
+		{
+			name: "should report the deployment as stopped",
+			steps: []step{
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_STOPPED, 1), at: 0, wantPhase: private.CONNECTORSTATE_STOPPED},
+			},
+		},
+		{
+			name: "should report the deployment as deleted after the deletion delay",
+			steps: []step{
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_DELETED, 1), at: 0, wantPhase: private.CONNECTORSTATE_DELETING},
+				{deployment: newTestDeployment(private.CONNECTORDESIREDSTATE_DELETED, 1), at: 2 * time.Minute, wantPhase: private.CONNECTORSTATE_DELETED},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			simulator := newTestSimulator(tt.failureRate)
+			for _, s := range tt.steps {
+				statuses := simulator.DeploymentStatuses([]private.ConnectorDeployment{s.deployment}, start.Add(s.at))
+				g.Expect(statuses).To(gomega.HaveKey(s.deployment.Id))
+				status := statuses[s.deployment.Id]
+				g.Expect(status.Phase).To(gomega.Equal(s.wantPhase))
+				g.Expect(status.ResourceVersion).To(gomega.Equal(s.deployment.Metadata.ResourceVersion))
+				g.Expect(status.Operators.Available.Id).To(gomega.Equal("camel-k-1.0.0"))
+			}
+		})
+	}
+}
+
+func Test_Simulator_NamespaceStatuses(t *testing.T) {
+	g := gomega.NewWithT(t)
+	simulator := newTestSimulator(0)
+	namespaces := []private.ConnectorNamespaceDeployment{
+		{Id: "namespace-1", Status: private.ConnectorNamespaceStatus{State: private.CONNECTORNAMESPACESTATE_DELETING}},
+		{Id: "namespace-2", Status: private.ConnectorNamespaceStatus{State: private.CONNECTORNAMESPACESTATE_DELETING}},
+		{Id: "namespace-3", Status: private.ConnectorNamespaceStatus{State: private.CONNECTORNAMESPACESTATE_DISCONNECTED}},
+	}
+	deployments := []private.ConnectorDeployment{newTestDeployment(private.CONNECTORDESIREDSTATE_DELETED, 1)}
+	deploymentStatuses := simulator.DeploymentStatuses(deployments, time.Now())
+
+	statuses := simulator.NamespaceStatuses(namespaces, deployments, deploymentStatuses)
+
+	g.Expect(statuses["namespace-1"].Phase).To(gomega.Equal(private.CONNECTORNAMESPACESTATE_DELETING))
+	g.Expect(statuses["namespace-1"].ConnectorsDeployed).To(gomega.Equal(int32(1)))
+	g.Expect(statuses["namespace-2"].Phase).To(gomega.Equal(private.CONNECTORNAMESPACESTATE_DELETED))
+	g.Expect(statuses["namespace-3"].Phase).To(gomega.Equal(private.CONNECTORNAMESPACESTATE_READY))
+}
@@ -8,11 +8,14 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/files"
 
 	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
@@ -21,17 +24,48 @@ import (
 )
 
 type ConnectorsConfig struct {
-	ConnectorEvalDuration               time.Duration           `json:"connector_eval_duration"`
-	ConnectorEvalOrganizations          []string                `json:"connector_eval_organizations"`
-	ConnectorNamespaceLifecycleAPI      bool                    `json:"connector_namespace_lifecycle_api"`
-	ConnectorEnableUnassignedConnectors bool                    `json:"connector_enable_unassigned_connectors"`
-	ConnectorCatalogDirs                []string                `json:"connector_types"`
-	ConnectorMetadataDirs               []string                `json:"connector_metadata"`
-	CatalogEntries                      []ConnectorCatalogEntry `json:"connector_type_urls"`
-	CatalogChecksums                    map[string]string       `json:"connector_catalog_checksums"`
-	ConnectorsSupportedChannels         []string                `json:"connectors_supported_channels"`
+	ConnectorEvalDuration               time.Duration                `json:"connector_eval_duration"`
+	ConnectorEvalOrganizations          []string                     `json:"connector_eval_organizations"`
+	ConnectorNamespaceLifecycleAPI      bool                         `json:"connector_namespace_lifecycle_api"`
+	ConnectorEnableUnassignedConnectors bool                         `json:"connector_enable_unassigned_connectors"`
+	ConnectorCatalogDirs                []string                     `json:"connector_types"`
+	ConnectorMetadataDirs               []string                     `json:"connector_metadata"`
+	CatalogEntries                      []ConnectorCatalogEntry      `json:"connector_type_urls"`
+	CatalogChecksums                    map[string]string            `json:"connector_catalog_checksums"`
+	ConnectorsSupportedChannels         []string                     `json:"connectors_supported_channels"`
+	ConnectorNamespacePlacement         string                       `json:"connector_namespace_placement"`
+	ConnectorMigrationTimeout           time.Duration                `json:"connector_migration_timeout"`
+	ConnectorRestartPolicy              ConnectorRestartPolicy       `json:"connector_restart_policy"`
+	ConnectorMaxRevisions               int                          `json:"connector_max_revisions"`
+	ConnectorCatalogRemote              ConnectorCatalogRemoteConfig `json:"connector_catalog_remote"`
+	ConnectorSunsetAction               string                       `json:"connector_sunset_action"`
+
+	// guards CatalogEntries and CatalogChecksums, which are updated when a remote catalog is loaded
+	catalogMutex          sync.RWMutex
+	remoteCatalogIds      map[string]struct{}
+	remoteCatalogRevision string
 }
 
+const (
+	// NamespacePlacementNone leaves connectors without a namespace id waiting for one to be assigned
+	NamespacePlacementNone = "none"
+	// NamespacePlacementFirstAvailable places connectors without a namespace id in the first tenant namespace with quota
+	NamespacePlacementFirstAvailable = "first-available"
+	// NamespacePlacementBalanced places connectors without a namespace id based on quota headroom and cloud affinity
+	NamespacePlacementBalanced = "balanced"
+)
+
+var ValidNamespacePlacements = []string{NamespacePlacementNone, NamespacePlacementFirstAvailable, NamespacePlacementBalanced}
+
+const (
+	// SunsetActionReport reports remaining connectors of connector types past their sunset date in the connector status
+	SunsetActionReport = "report"
+	// SunsetActionStop stops remaining connectors of connector types past their sunset date
+	SunsetActionStop = "stop"
+)
+
+var ValidSunsetActions = []string{SunsetActionReport, SunsetActionStop}
+
 var _ environments.ConfigModule = &ConnectorsConfig{}
 
 type ConnectorChannelConfig struct {
@@ -43,6 +77,18 @@ type ConnectorCatalogEntry struct {
 	ConnectorType public.ConnectorType              `json:"connector_type"`
 }
 
+// ApplyDeprecation validates deprecation annotations of the connector type, and flags the type as deprecated if it has any
+func (e *ConnectorCatalogEntry) ApplyDeprecation() error {
+	deprecation, err := dbapi.ParseConnectorTypeDeprecation(e.ConnectorType.Annotations)
+	if err != nil {
+		return fmt.Errorf("invalid deprecation for connector type %s: %v", e.ConnectorType.Id, err)
+	}
+	if deprecation != nil {
+		e.ConnectorType.Deprecated = true
+	}
+	return nil
+}
+
 type ConnectorMetadata struct {
 	ConnectorTypeId string            `json:"id" yaml:"id"`
 	FeaturedRank    int32             `json:"featured-rank" yaml:"featured-rank"`
@@ -52,21 +98,58 @@ type ConnectorMetadata struct {
 
 func NewConnectorsConfig() *ConnectorsConfig {
 	return &ConnectorsConfig{
-		CatalogChecksums: make(map[string]string),
+		CatalogChecksums:            make(map[string]string),
+		ConnectorNamespacePlacement: NamespacePlacementNone,
+		ConnectorMigrationTimeout:   30 * time.Minute,
+		ConnectorMaxRevisions:       20,
+		ConnectorSunsetAction:       SunsetActionReport,
+		ConnectorCatalogRemote: ConnectorCatalogRemoteConfig{
+			Interval: 5 * time.Minute,
+		},
+		ConnectorRestartPolicy: ConnectorRestartPolicy{
+			Backoff: time.Minute,
+			Window:  time.Hour,
+		},
 	}
 }
 
 func (c *ConnectorsConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringArrayVar(&c.ConnectorCatalogDirs, "connector-catalog", c.ConnectorCatalogDirs, "Directory containing connector catalog entries")
+	fs.StringVar(&c.ConnectorCatalogRemote.URL, "connector-catalog-remote-url", c.ConnectorCatalogRemote.URL, "URL of a remote connector catalog index (http, https) or artifact (oci://registry/repository:tag), merged with local connector catalog entries")
+	fs.StringVar(&c.ConnectorCatalogRemote.SigningKeyFile, "connector-catalog-remote-signing-key-file", c.ConnectorCatalogRemote.SigningKeyFile, "File containing the PEM encoded public key used to verify remote connector catalog signatures")
+	fs.DurationVar(&c.ConnectorCatalogRemote.Interval, "connector-catalog-remote-interval", c.ConnectorCatalogRemote.Interval, "Time between remote connector catalog checks")
 	fs.StringArrayVar(&c.ConnectorMetadataDirs, "connector-metadata", c.ConnectorMetadataDirs, "Directory containing connector metadata configuration files")
 	fs.DurationVar(&c.ConnectorEvalDuration, "connector-eval-duration", c.ConnectorEvalDuration, "Connector eval duration in golang duration format")
 	fs.StringSliceVar(&c.ConnectorEvalOrganizations, "connector-eval-organizations", c.ConnectorEvalOrganizations, "Connector eval organization IDs")
 	fs.BoolVar(&c.ConnectorNamespaceLifecycleAPI, "connector-namespace-lifecycle-api", c.ConnectorNamespaceLifecycleAPI, "Enable APIs to create, update, delete non-eval Namespaces")
 	fs.BoolVar(&c.ConnectorEnableUnassignedConnectors, "connector-enable-unassigned-connectors", c.ConnectorEnableUnassignedConnectors, "Enable support for 'unassigned' state for Connectors")
 	fs.StringSliceVar(&c.ConnectorsSupportedChannels, "connectors-supported-channels", c.ConnectorsSupportedChannels, "Connector channels that are visible")
+	fs.DurationVar(&c.ConnectorMigrationTimeout, "connector-migration-timeout", c.ConnectorMigrationTimeout, "Time to wait for a migrated connector to be ready in its target namespace before rolling back")
+	fs.IntVar(&c.ConnectorMaxRevisions, "connector-max-revisions", c.ConnectorMaxRevisions, "Maximum number of connector spec revisions kept for rollback, older revisions are pruned")
+	fs.IntVar(&c.ConnectorRestartPolicy.MaxAttempts, "connector-restart-max-attempts", c.ConnectorRestartPolicy.MaxAttempts, "Maximum automatic restarts of a failed connector within the restart window, 0 disables automatic restarts")
+	fs.DurationVar(&c.ConnectorRestartPolicy.Backoff, "connector-restart-backoff", c.ConnectorRestartPolicy.Backoff, "Initial delay before restarting a failed connector, doubled on every restart")
+	fs.DurationVar(&c.ConnectorRestartPolicy.Window, "connector-restart-window", c.ConnectorRestartPolicy.Window, "Time window for counting automatic restarts of a failed connector")
+	fs.StringVar(&c.ConnectorSunsetAction, "connector-sunset-action", c.ConnectorSunsetAction, fmt.Sprintf("Action for connectors of deprecated connector types past their sunset date, one of %s", ValidSunsetActions))
+	fs.StringVar(&c.ConnectorNamespacePlacement, "connector-namespace-placement", c.ConnectorNamespacePlacement, fmt.Sprintf("Namespace placement strategy for connectors without a namespace id, one of %s", ValidNamespacePlacements))
 }
 
 func (c *ConnectorsConfig) ReadFiles() error {
+	if c.ConnectorNamespacePlacement == "" {
+		c.ConnectorNamespacePlacement = NamespacePlacementNone
+	} else if !arrays.Contains(ValidNamespacePlacements, c.ConnectorNamespacePlacement) {
+		return fmt.Errorf("invalid connector namespace placement '%s', must be one of %s", c.ConnectorNamespacePlacement, ValidNamespacePlacements)
+	}
+
+	if c.ConnectorSunsetAction == "" {
+		c.ConnectorSunsetAction = SunsetActionReport
+	} else if !arrays.Contains(ValidSunsetActions, c.ConnectorSunsetAction) {
+		return fmt.Errorf("invalid connector sunset action '%s', must be one of %s", c.ConnectorSunsetAction, ValidSunsetActions)
+	}
+
+	if err := c.ConnectorCatalogRemote.readFiles(); err != nil {
+		return err
+	}
+
 	// read metadata first to merge with catalog next
 	connectorMetadata, err := c.readConnectorMetadata()
 	if err != nil {
@@ -169,6 +252,9 @@ func (c *ConnectorsConfig) readConnectorCatalog(connectorMetadata map[string]Con
 			} else {
 				return fmt.Errorf("missing metadata for connector %s", id)
 			}
+			if err := entry.ApplyDeprecation(); err != nil {
+				return fmt.Errorf("error reading catalog file %s: %s", path, err)
+			}
 
 			// compute checksum for catalog entry to look for updates
 			sum, err := checksum(entry)
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/golang/glog"
+)
+
+// ConnectorCatalogRemoteConfig configures a connector catalog periodically pulled from a remote source,
+// in addition to the catalog read from ConnectorCatalogDirs
+type ConnectorCatalogRemoteConfig struct {
+	// URL is either an http(s) URL of a catalog index, or an oci://registry/repository:tag reference of a catalog artifact
+	URL string `json:"url"`
+	// SigningKeyFile is a PEM encoded public key used to verify catalog signatures
+	SigningKeyFile string `json:"signing_key_file"`
+	// SigningKey is the public key read from SigningKeyFile
+	SigningKey crypto.PublicKey `json:"-"`
+	// Interval is the time between remote catalog checks
+	Interval time.Duration `json:"interval"`
+}
+
+// Enabled returns true if a remote catalog source is configured
+func (c *ConnectorCatalogRemoteConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+func (c *ConnectorCatalogRemoteConfig) readFiles() error {
+	if !c.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid remote connector catalog url %q: %v", c.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "oci" {
+		return fmt.Errorf("invalid remote connector catalog url %q: scheme must be one of http, https, oci", c.URL)
+	}
+	if c.SigningKeyFile == "" {
+		return fmt.Errorf("a signing key file is required for remote connector catalog %s", c.URL)
+	}
+	buf, err := os.ReadFile(shared.BuildFullFilePath(c.SigningKeyFile))
+	if err != nil {
+		return fmt.Errorf("error reading remote connector catalog signing key: %v", err)
+	}
+	key, err := ParseCatalogSigningKey(buf)
+	if err != nil {
+		return fmt.Errorf("error reading remote connector catalog signing key %s: %v", c.SigningKeyFile, err)
+	}
+	c.SigningKey = key
+	return nil
+}
+
+// ParseCatalogSigningKey parses a PEM encoded PKIX public key, i.e. an ed25519, ECDSA or RSA public key
+func ParseCatalogSigningKey(buf []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(buf)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded public key found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// GetCatalogEntries returns a copy of the current connector catalog, local and remote entries sorted by id
+func (c *ConnectorsConfig) GetCatalogEntries() []ConnectorCatalogEntry {
+	c.catalogMutex.RLock()
+	defer c.catalogMutex.RUnlock()
+	return append([]ConnectorCatalogEntry(nil), c.CatalogEntries...)
+}
+
+// GetCatalogChecksums returns a copy of the current connector catalog checksums by connector type id
+func (c *ConnectorsConfig) GetCatalogChecksums() map[string]string {
+	c.catalogMutex.RLock()
+	defer c.catalogMutex.RUnlock()
+	result := make(map[string]string, len(c.CatalogChecksums))
+	for id, sum := range c.CatalogChecksums {
+		result[id] = sum
+	}
+	return result
+}
+
+// GetRemoteCatalogRevision returns the revision of the remote catalog merged into the connector catalog, if any
+func (c *ConnectorsConfig) GetRemoteCatalogRevision() string {
+	c.catalogMutex.RLock()
+	defer c.catalogMutex.RUnlock()
+	return c.remoteCatalogRevision
+}
+
+// DiffRemoteCatalog returns the checksums of remote catalog entries and the ids of the entries that are new or
+// changed compared to the current catalog. Entries for connector types defined in the local catalog are ignored.
+func (c *ConnectorsConfig) DiffRemoteCatalog(entries []ConnectorCatalogEntry) (checksums map[string]string, changed []string, err error) {
+	c.catalogMutex.RLock()
+	defer c.catalogMutex.RUnlock()
+
+	checksums = make(map[string]string, len(entries))
+	for _, entry := range entries {
+		id := entry.ConnectorType.Id
+		if c.isLocalCatalogEntry(id) {
+			glog.Warningf("ignoring connector type %s in remote catalog, it's defined in the local catalog", id)
+			continue
+		}
+		sum, err := checksum(entry)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error computing checksum for remote connector type %s: %v", id, err)
+		}
+		checksums[id] = sum
+		if c.CatalogChecksums[id] != sum {
+			changed = append(changed, id)
+		}
+	}
+	sort.Strings(changed)
+	return checksums, changed, nil
+}
+
+// SetRemoteCatalog replaces the remote entries in the connector catalog, checksums must be those returned by DiffRemoteCatalog
+func (c *ConnectorsConfig) SetRemoteCatalog(revision string, entries []ConnectorCatalogEntry, checksums map[string]string) {
+	c.catalogMutex.Lock()
+	defer c.catalogMutex.Unlock()
+
+	var catalog []ConnectorCatalogEntry
+	for _, entry := range c.CatalogEntries {
+		if _, remote := c.remoteCatalogIds[entry.ConnectorType.Id]; remote {
+			delete(c.CatalogChecksums, entry.ConnectorType.Id)
+		} else {
+			catalog = append(catalog, entry)
+		}
+	}
+	if c.CatalogChecksums == nil {
+		c.CatalogChecksums = make(map[string]string)
+	}
+
+	remoteIds := make(map[string]struct{}, len(checksums))
+	for _, entry := range entries {
+		id := entry.ConnectorType.Id
+		sum, ok := checksums[id]
+		if !ok {
+			// local connector type
+			continue
+		}
+		remoteIds[id] = struct{}{}
+		c.CatalogChecksums[id] = sum
+		catalog = append(catalog, entry)
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].ConnectorType.Id < catalog[j].ConnectorType.Id
+	})
+
+	c.CatalogEntries = catalog
+	c.remoteCatalogIds = remoteIds
+	c.remoteCatalogRevision = revision
+	glog.Infof("loaded %d connector types from remote catalog revision %s", len(remoteIds), revision)
+}
+
+func (c *ConnectorsConfig) isLocalCatalogEntry(id string) bool {
+	if _, remote := c.remoteCatalogIds[id]; remote {
+		return false
+	}
+	for _, entry := range c.CatalogEntries {
+		if entry.ConnectorType.Id == id {
+			return true
+		}
+	}
+	return false
+}
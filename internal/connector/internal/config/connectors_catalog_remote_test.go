@@ -0,0 +1,69 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/onsi/gomega"
+)
+
+func TestConnectorsConfig_SetRemoteCatalog(t *testing.T) {
+	g := gomega.NewWithT(t)
+	entry := func(id string, version string) ConnectorCatalogEntry {
+		return ConnectorCatalogEntry{ConnectorType: public.ConnectorType{Id: id, Version: version}}
+	}
+	ids := func(entries []ConnectorCatalogEntry) []string {
+		var result []string
+		for _, e := range entries {
+			result = append(result, e.ConnectorType.Id)
+		}
+		return result
+	}
+
+	localSum, err := checksum(entry("local", "1"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	c := &ConnectorsConfig{
+		CatalogEntries:   []ConnectorCatalogEntry{entry("local", "1")},
+		CatalogChecksums: map[string]string{"local": localSum},
+	}
+
+	// local entries take precedence over remote entries
+	checksums, changed, err := c.DiffRemoteCatalog([]ConnectorCatalogEntry{entry("local", "2"), entry("b", "1"), entry("a", "1")})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(changed).To(gomega.Equal([]string{"a", "b"}))
+	g.Expect(checksums).To(gomega.HaveLen(2))
+	c.SetRemoteCatalog("1", []ConnectorCatalogEntry{entry("local", "2"), entry("b", "1"), entry("a", "1")}, checksums)
+	g.Expect(ids(c.GetCatalogEntries())).To(gomega.Equal([]string{"a", "b", "local"}))
+	g.Expect(c.GetCatalogChecksums()).To(gomega.HaveKeyWithValue("local", localSum))
+	g.Expect(c.GetRemoteCatalogRevision()).To(gomega.Equal("1"))
+
+	// only changed entries are reported, removed remote entries are dropped
+	checksums, changed, err = c.DiffRemoteCatalog([]ConnectorCatalogEntry{entry("a", "1"), entry("c", "1"), entry("b", "2")})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(changed).To(gomega.Equal([]string{"b", "c"}))
+	c.SetRemoteCatalog("2", []ConnectorCatalogEntry{entry("a", "1"), entry("c", "1"), entry("b", "2")}, checksums)
+	g.Expect(ids(c.GetCatalogEntries())).To(gomega.Equal([]string{"a", "b", "c", "local"}))
+
+	c.SetRemoteCatalog("3", nil, nil)
+	g.Expect(ids(c.GetCatalogEntries())).To(gomega.Equal([]string{"local"}))
+	g.Expect(c.GetCatalogChecksums()).To(gomega.Equal(map[string]string{"local": localSum}))
+}
+
+func TestParseCatalogSigningKey(t *testing.T) {
+	g := gomega.NewWithT(t)
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(public)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	key, err := ParseCatalogSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(key).To(gomega.Equal(public))
+
+	_, err = ParseCatalogSigningKey([]byte("not a key"))
+	g.Expect(err).To(gomega.MatchError("no PEM encoded public key found"))
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// RestartMaxAttemptsAnnotation overrides the maximum number of automatic restarts of a failed connector within the restart window
+	RestartMaxAttemptsAnnotation = "cos.bf2.org/restart-max-attempts"
+	// RestartBackoffAnnotation overrides the initial delay before restarting a failed connector, doubled on every attempt
+	RestartBackoffAnnotation = "cos.bf2.org/restart-backoff"
+	// RestartWindowAnnotation overrides the time window for counting automatic restarts of a failed connector
+	RestartWindowAnnotation = "cos.bf2.org/restart-window"
+)
+
+// ConnectorRestartPolicy controls automatic restarts of connectors reported as failed by the agent
+type ConnectorRestartPolicy struct {
+	// MaxAttempts is the maximum number of restarts in Window, 0 disables automatic restarts
+	MaxAttempts int
+	// Backoff is the initial delay between restarts, doubled for every restart in Window
+	Backoff time.Duration
+	// Window is the time window in which restarts are counted
+	Window time.Duration
+}
+
+// WithAnnotations returns a copy of the policy overridden by restart annotations, e.g. from a connector type or connector
+func (p ConnectorRestartPolicy) WithAnnotations(annotations map[string]string) (ConnectorRestartPolicy, error) {
+	result := p
+	if value, ok := annotations[RestartMaxAttemptsAnnotation]; ok {
+		attempts, err := strconv.Atoi(value)
+		if err != nil || attempts < 0 {
+			return p, fmt.Errorf("invalid annotation %s value %q: must be a non-negative integer", RestartMaxAttemptsAnnotation, value)
+		}
+		result.MaxAttempts = attempts
+	}
+	if value, ok := annotations[RestartBackoffAnnotation]; ok {
+		backoff, err := time.ParseDuration(value)
+		if err != nil || backoff < 0 {
+			return p, fmt.Errorf("invalid annotation %s value %q: must be a non-negative duration", RestartBackoffAnnotation, value)
+		}
+		result.Backoff = backoff
+	}
+	if value, ok := annotations[RestartWindowAnnotation]; ok {
+		window, err := time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			return p, fmt.Errorf("invalid annotation %s value %q: must be a positive duration", RestartWindowAnnotation, value)
+		}
+		result.Window = window
+	}
+	return result, nil
+}
+
+// Enabled returns true if failed connectors should be restarted automatically
+func (p ConnectorRestartPolicy) Enabled() bool {
+	return p.MaxAttempts > 0
+}
+
+// BackoffFor returns the delay before the next restart after the given number of restarts, capped at Window
+func (p ConnectorRestartPolicy) BackoffFor(restarts int) time.Duration {
+	backoff := p.Backoff
+	for i := 0; i < restarts && backoff < p.Window; i++ {
+		backoff *= 2
+	}
+	if p.Window > 0 && backoff > p.Window {
+		backoff = p.Window
+	}
+	return backoff
+}
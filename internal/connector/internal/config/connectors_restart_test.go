@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_ConnectorRestartPolicy_WithAnnotations(t *testing.T) {
+	defaults := ConnectorRestartPolicy{MaxAttempts: 3, Backoff: time.Minute, Window: time.Hour}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        ConnectorRestartPolicy
+		wantErr     bool
+	}{
+		{
+			name: "no annotations",
+			want: defaults,
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				RestartMaxAttemptsAnnotation: "5",
+				RestartBackoffAnnotation:     "30s",
+				RestartWindowAnnotation:      "2h",
+			},
+			want: ConnectorRestartPolicy{MaxAttempts: 5, Backoff: 30 * time.Second, Window: 2 * time.Hour},
+		},
+		{
+			name:        "disable restarts",
+			annotations: map[string]string{RestartMaxAttemptsAnnotation: "0"},
+			want:        ConnectorRestartPolicy{MaxAttempts: 0, Backoff: time.Minute, Window: time.Hour},
+		},
+		{
+			name:        "invalid attempts",
+			annotations: map[string]string{RestartMaxAttemptsAnnotation: "many"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid window",
+			annotations: map[string]string{RestartWindowAnnotation: "0s"},
+			wantErr:     true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, err := defaults.WithAnnotations(tt.annotations)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(got).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func Test_ConnectorRestartPolicy_BackoffFor(t *testing.T) {
+	g := gomega.NewWithT(t)
+	policy := ConnectorRestartPolicy{MaxAttempts: 10, Backoff: time.Minute, Window: 10 * time.Minute}
+
+	g.Expect(policy.BackoffFor(0)).To(gomega.Equal(time.Minute))
+	g.Expect(policy.BackoffFor(1)).To(gomega.Equal(2 * time.Minute))
+	g.Expect(policy.BackoffFor(3)).To(gomega.Equal(8 * time.Minute))
+	g.Expect(policy.BackoffFor(4)).To(gomega.Equal(10 * time.Minute))
+	g.Expect(policy.BackoffFor(50)).To(gomega.Equal(10 * time.Minute))
+}
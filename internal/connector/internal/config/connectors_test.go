@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/rs/xid"
 
@@ -231,3 +232,72 @@ func createSymLinkedCatalogDir() (string, error) {
 
 	return dir, nil
 }
+
+func TestConnectorCatalogEntry_ApplyDeprecation(t *testing.T) {
+	sunset := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		wantErr        bool
+		wantDeprecated bool
+		wantCutoff     *time.Time
+	}{
+		{
+			name:        "no deprecation",
+			annotations: map[string]string{"cos.bf2.org/pricing-tier": "essentials"},
+		},
+		{
+			name:           "message only",
+			annotations:    map[string]string{dbapi.ConnectorTypeDeprecationMessageAnnotation: "use the v2 connector"},
+			wantDeprecated: true,
+		},
+		{
+			name: "cutoff defaults to sunset",
+			annotations: map[string]string{
+				dbapi.ConnectorTypeDeprecationSunsetAnnotation:      "2023-09-01",
+				dbapi.ConnectorTypeDeprecationReplacementAnnotation: "log_sink_0.2",
+			},
+			wantDeprecated: true,
+			wantCutoff:     &sunset,
+		},
+		{
+			name: "invalid sunset",
+			annotations: map[string]string{
+				dbapi.ConnectorTypeDeprecationSunsetAnnotation: "next month",
+			},
+			wantErr: true,
+		},
+		{
+			name: "cutoff after sunset",
+			annotations: map[string]string{
+				dbapi.ConnectorTypeDeprecationSunsetAnnotation:       "2023-09-01",
+				dbapi.ConnectorTypeDeprecationCreateCutoffAnnotation: "2023-10-01T00:00:00Z",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			entry := ConnectorCatalogEntry{}
+			entry.ConnectorType.Id = "log_sink_0.1"
+			entry.ConnectorType.Annotations = tt.annotations
+
+			err := entry.ApplyDeprecation()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(entry.ConnectorType.Deprecated).To(gomega.Equal(tt.wantDeprecated))
+
+			deprecation, err := dbapi.ParseConnectorTypeDeprecation(tt.annotations)
+			g.Expect(err).To(gomega.BeNil())
+			if tt.wantCutoff != nil {
+				g.Expect(deprecation.CreateCutoffAt).To(gomega.Equal(tt.wantCutoff))
+				g.Expect(deprecation.IsCreateCutoff(tt.wantCutoff.Add(-time.Second))).To(gomega.BeFalse())
+				g.Expect(deprecation.IsSunset(*tt.wantCutoff)).To(gomega.BeTrue())
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/goava/di"
 
@@ -28,14 +29,18 @@ import (
 
 type ConnectorAdminHandler struct {
 	di.Inject
-	ConnectorsConfig      *config.ConnectorsConfig
-	AuthZService          authz.AuthZService
-	Service               services.ConnectorClusterService
-	ConnectorsService     services.ConnectorsService
-	NamespaceService      services.ConnectorNamespaceService
-	QuotaConfig           *config.ConnectorsQuotaConfig
-	ConnectorCluster      *ConnectorClusterHandler //TODO: eventually move deployment handling into a deployment service
-	ConnectorTypesService services.ConnectorTypesService
+	ConnectorsConfig       *config.ConnectorsConfig
+	AuthZService           authz.AuthZService
+	Service                services.ConnectorClusterService
+	ConnectorsService      services.ConnectorsService
+	NamespaceService       services.ConnectorNamespaceService
+	QuotaConfig            *config.ConnectorsQuotaConfig
+	ConnectorCluster       *ConnectorClusterHandler //TODO: eventually move deployment handling into a deployment service
+	ConnectorTypesService  services.ConnectorTypesService
+	MigrationService       services.ConnectorNamespaceMigrationService
+	RevisionsService       services.ConnectorRevisionsService
+	CatalogService         services.ConnectorCatalogService
+	UpgradeCampaignService services.ConnectorUpgradeCampaignService
 }
 
 type operator struct {
@@ -381,6 +386,7 @@ func (h *ConnectorAdminHandler) PatchConnector(writer http.ResponseWriter, reque
 		connectorTypesService: h.ConnectorTypesService,
 		namespaceService:      h.NamespaceService,
 		authZService:          h.AuthZService,
+		revisionsService:      h.RevisionsService,
 		connectorsConfig:      h.ConnectorsConfig,
 	}.Patch(writer, request)
 }
@@ -407,6 +413,69 @@ func (h *ConnectorAdminHandler) DeleteConnector(writer http.ResponseWriter, requ
 	handlers.HandleDelete(writer, request, &cfg, http.StatusNoContent)
 }
 
+func (h *ConnectorAdminHandler) CreateConnectorNamespaceMigration(writer http.ResponseWriter, request *http.Request) {
+	connectorId := mux.Vars(request)["connector_id"]
+	var resource private.ConnectorNamespaceMigrationRequest
+	cfg := handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			handlers.Validation("target_namespace_id", &resource.TargetNamespaceId, handlers.MinLen(1), handlers.MaxLen(maxConnectorNamespaceIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			ctx := request.Context()
+			claims, err := auth.GetClaimsFromContext(ctx)
+			if err != nil {
+				return nil, errors.Unauthenticated("user not authenticated")
+			}
+			username, _ := claims.GetUsername()
+
+			migration, serviceError := h.MigrationService.Create(ctx, connectorId, resource.TargetNamespaceId, username)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorNamespaceMigration(migration), nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusAccepted)
+}
+
+func (h *ConnectorAdminHandler) GetConnectorNamespaceMigrations(writer http.ResponseWriter, request *http.Request) {
+	connectorId := mux.Vars(request)["connector_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			ctx := request.Context()
+			if _, serviceError = h.ConnectorsService.Get(ctx, connectorId); serviceError != nil {
+				return nil, serviceError
+			}
+
+			migrations, serviceError := h.MigrationService.List(ctx, connectorId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+
+			result := private.ConnectorNamespaceMigrationList{
+				Kind:  "ConnectorNamespaceMigrationList",
+				Page:  1,
+				Size:  int32(len(migrations)),
+				Total: int32(len(migrations)),
+			}
+			result.Items = make([]private.ConnectorNamespaceMigration, len(migrations))
+			for i, migration := range migrations {
+				result.Items[i] = presenters.PresentConnectorNamespaceMigration(migration)
+			}
+
+			return result, nil
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
 func (h *ConnectorAdminHandler) GetClusterDeployments(writer http.ResponseWriter, request *http.Request) {
 	clusterId := mux.Vars(request)["connector_cluster_id"]
 	channelUpdates := request.URL.Query().Get("channel_updates")
@@ -558,6 +627,21 @@ func (h *ConnectorAdminHandler) ListConnectorTypes(writer http.ResponseWriter, r
 	handlers.HandleGet(writer, request, &cfg)
 }
 
+// GetConnectorCatalog returns the remote connector catalog revision applied to connector types
+func (h *ConnectorAdminHandler) GetConnectorCatalog(writer http.ResponseWriter, request *http.Request) {
+	cfg := handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			catalog, err := h.CatalogService.GetRemoteCatalog()
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentConnectorCatalogAdminView(catalog)
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
 func (h *ConnectorAdminHandler) GetConnectorType(writer http.ResponseWriter, request *http.Request) {
 	id := mux.Vars(request)["connector_type_id"]
 
@@ -97,6 +97,7 @@ func (h *ConnectorClusterHandler) Get(w http.ResponseWriter, r *http.Request) {
 	user := h.AuthZ.GetValidationUser(ctx)
 
 	connectorClusterId := mux.Vars(r)["connector_cluster_id"]
+	var version string
 	cfg := &handlers.HandlerConfig{
 		Validate: []handlers.Validate{
 			handlers.Validation("connector_cluster_id", &connectorClusterId,
@@ -107,8 +108,12 @@ func (h *ConnectorClusterHandler) Get(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			version = handlers.VersionFromTime(resource.UpdatedAt)
 			return presenters.PresentConnectorCluster(resource), nil
 		},
+		Version: func() string {
+			return version
+		},
 	}
 	handlers.HandleGet(w, r, cfg)
 }
@@ -120,6 +125,8 @@ func (h *ConnectorClusterHandler) Update(w http.ResponseWriter, r *http.Request)
 
 	var resource public.ConnectorClusterRequest
 	connectorClusterId := mux.Vars(r)["connector_cluster_id"]
+	// the connector cluster is loaded once, the update is conditional on the version checked against the If-Match header
+	var existing *dbapi.ConnectorCluster
 	cfg := &handlers.HandlerConfig{
 		MarshalInto: &resource,
 		Validate: []handlers.Validate{
@@ -129,9 +136,11 @@ func (h *ConnectorClusterHandler) Update(w http.ResponseWriter, r *http.Request)
 				handlers.MinLen(1), handlers.MaxLen(maxConnectorClusterNameLength)),
 		},
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
-			existing, err := h.Service.Get(ctx, connectorClusterId)
-			if err != nil {
-				return nil, err
+			if existing == nil {
+				var err *errors.ServiceError
+				if existing, err = h.Service.Get(ctx, connectorClusterId); err != nil {
+					return nil, err
+				}
 			}
 
 			// validate patched annotations
@@ -139,8 +148,7 @@ func (h *ConnectorClusterHandler) Update(w http.ResponseWriter, r *http.Request)
 				return nil, errors.BadRequest("annotations cannot be nil/empty.")
 			}
 			existingAnnotations := presenters.PresentClusterAnnotations(existing.Annotations)
-			err = validatePatchAnnotations(resource.Annotations, existingAnnotations)()
-			if err != nil {
+			if err := validatePatchAnnotations(resource.Annotations, existingAnnotations)(); err != nil {
 				return nil, err
 			}
 
@@ -148,7 +156,25 @@ func (h *ConnectorClusterHandler) Update(w http.ResponseWriter, r *http.Request)
 			existing.Name = resource.Name
 			existing.Annotations = presenters.ConvertClusterAnnotations(connectorClusterId, resource.Annotations)
 
-			return nil, h.Service.Update(ctx, existing)
+			update := h.Service.Update
+			if handlers.HasIfMatch(r) {
+				// the connector cluster must not have been updated since its version was checked against the If-Match header
+				update = h.Service.UpdateIfUnmodified
+			}
+			if err := update(ctx, existing); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+		Version: func() string {
+			if existing == nil {
+				resource, err := h.Service.Get(ctx, connectorClusterId)
+				if err != nil {
+					return ""
+				}
+				existing = resource
+			}
+			return handlers.VersionFromTime(existing.UpdatedAt)
 		},
 	}
 	handlers.Handle(w, r, cfg, http.StatusNoContent)
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/gorilla/mux"
+)
+
+// ListRevisions returns all retained revisions of a connector spec, latest first
+func (h ConnectorsHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			// also checks that the user can access the connector
+			connector, serr := h.connectorsService.Get(ctx, connectorId)
+			if serr != nil {
+				return nil, serr
+			}
+			ct, serr := h.connectorTypesService.Get(connector.ConnectorTypeId)
+			if serr != nil {
+				return nil, serr
+			}
+			revisions, serr := h.revisionsService.List(ctx, connectorId)
+			if serr != nil {
+				return nil, serr
+			}
+
+			resourceList := public.ConnectorRevisionList{
+				Kind:  "ConnectorRevisionList",
+				Page:  1,
+				Size:  int32(len(revisions)),
+				Total: int32(len(revisions)),
+				Items: []public.ConnectorRevision{},
+			}
+			for _, revision := range revisions {
+				converted, serr := presentRedactedRevision(revision, ct)
+				if serr != nil {
+					return nil, serr
+				}
+				resourceList.Items = append(resourceList.Items, converted)
+			}
+			return resourceList, nil
+		},
+	}
+	handlers.HandleList(w, r, cfg)
+}
+
+// GetRevision returns a revision of a connector spec
+func (h ConnectorsHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	revisionParam := mux.Vars(r)["revision"]
+	var revision int64
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			validateRevision("revision", revisionParam, &revision),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			connector, serr := h.connectorsService.Get(ctx, connectorId)
+			if serr != nil {
+				return nil, serr
+			}
+			ct, serr := h.connectorTypesService.Get(connector.ConnectorTypeId)
+			if serr != nil {
+				return nil, serr
+			}
+			resource, serr := h.revisionsService.Get(ctx, connectorId, revision)
+			if serr != nil {
+				return nil, serr
+			}
+			return presentRedactedRevision(resource, ct)
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// DiffRevisions returns the changes between a revision and another revision given by the from query parameter,
+// which defaults to the latest revision, i.e. the changes a rollback to the revision would apply
+func (h ConnectorsHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	revisionParam := mux.Vars(r)["revision"]
+	fromParam := r.URL.Query().Get("from")
+	var revision, from int64
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			validateRevision("revision", revisionParam, &revision),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			connector, serr := h.connectorsService.Get(ctx, connectorId)
+			if serr != nil {
+				return nil, serr
+			}
+			ct, serr := h.connectorTypesService.Get(connector.ConnectorTypeId)
+			if serr != nil {
+				return nil, serr
+			}
+
+			to, serr := h.revisionsService.Get(ctx, connectorId, revision)
+			if serr != nil {
+				return nil, serr
+			}
+			var base *dbapi.ConnectorRevision
+			if fromParam != "" {
+				if serr = validateRevision("from", fromParam, &from)(); serr != nil {
+					return nil, serr
+				}
+				if base, serr = h.revisionsService.Get(ctx, connectorId, from); serr != nil {
+					return nil, serr
+				}
+			} else {
+				revisions, serr := h.revisionsService.List(ctx, connectorId)
+				if serr != nil {
+					return nil, serr
+				}
+				if len(revisions) == 0 {
+					return nil, errors.NotFound("connector %s has no revisions", connectorId)
+				}
+				base = revisions[0]
+			}
+
+			fromSpec, serr := redactRevisionSpec(base, ct)
+			if serr != nil {
+				return nil, serr
+			}
+			toSpec, serr := redactRevisionSpec(to, ct)
+			if serr != nil {
+				return nil, serr
+			}
+			patchBytes, err := jsonpatch.CreateMergePatch(fromSpec, toSpec)
+			if err != nil {
+				return nil, errors.GeneralError("failed to compare connector revisions: %v", err)
+			}
+			patch := make(map[string]interface{})
+			if err := json.Unmarshal(patchBytes, &patch); err != nil {
+				return nil, errors.GeneralError("failed to compare connector revisions: %v", err)
+			}
+
+			return public.ConnectorRevisionDiff{
+				Kind:         presenters.KindConnectorRevisionDiff,
+				ConnectorId:  connectorId,
+				FromRevision: base.Revision,
+				ToRevision:   to.Revision,
+				Patch:        patch,
+			}, nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// RollbackRevision re-applies the connector spec of a previous revision, including its secrets, through the connector update path
+func (h ConnectorsHandler) RollbackRevision(w http.ResponseWriter, r *http.Request) {
+	connectorId := mux.Vars(r)["connector_id"]
+	revisionParam := mux.Vars(r)["revision"]
+	var revision int64
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.ValidateAsyncEnabled(r, "rolling back connector"),
+			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
+			validateRevision("revision", revisionParam, &revision),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			// also checks that the user can access the connector
+			if _, serr := h.connectorsService.Get(ctx, connectorId); serr != nil {
+				return nil, serr
+			}
+			resource, serr := h.revisionsService.Get(ctx, connectorId, revision)
+			if serr != nil {
+				return nil, serr
+			}
+
+			// replace the whole connector spec, so fields added after the revision are removed
+			patchBytes, err := json.Marshal([]map[string]interface{}{
+				{"op": "replace", "path": "/connector", "value": json.RawMessage(resource.ConnectorSpec)},
+			})
+			if err != nil {
+				return nil, errors.GeneralError("failed to create rollback patch: %v", err)
+			}
+			return h.patchConnector(ctx, r.URL.Path, connectorId, JSON_PATCH, patchBytes, 0, true, false)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func validateRevision(name string, value string, revision *int64) handlers.Validate {
+	return func() *errors.ServiceError {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			return errors.BadRequest("%s must be a positive integer", name)
+		}
+		*revision = parsed
+		return nil
+	}
+}
+
+// redactRevisionSpec returns the revision connector spec with secret references removed
+func redactRevisionSpec(revision *dbapi.ConnectorRevision, ct *dbapi.ConnectorType) (api.JSON, *errors.ServiceError) {
+	connector := dbapi.Connector{ConnectorSpec: revision.ConnectorSpec}
+	if err := stripSecretReferences(&connector, ct); err != nil {
+		return nil, err
+	}
+	return connector.ConnectorSpec, nil
+}
+
+func presentRedactedRevision(revision *dbapi.ConnectorRevision, ct *dbapi.ConnectorType) (public.ConnectorRevision, *errors.ServiceError) {
+	spec, serr := redactRevisionSpec(revision, ct)
+	if serr != nil {
+		return public.ConnectorRevision{}, serr
+	}
+	redacted := *revision
+	redacted.ConnectorSpec = spec
+	return presenters.PresentConnectorRevision(&redacted)
+}
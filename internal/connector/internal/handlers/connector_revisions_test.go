@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+// connectorsServiceStub keeps a single connector in memory
+type connectorsServiceStub struct {
+	services.ConnectorsService
+	connector *dbapi.Connector
+	// beforeUpdate simulates changes committed concurrently between reading and updating the connector
+	beforeUpdate func()
+}
+
+func (s *connectorsServiceStub) Get(ctx context.Context, id string) (*dbapi.ConnectorWithConditions, *errors.ServiceError) {
+	if id != s.connector.ID {
+		return nil, errors.NotFound("Connector with id='%s' not found", id)
+	}
+	return &dbapi.ConnectorWithConditions{Connector: *s.connector}, nil
+}
+
+func (s *connectorsServiceStub) Update(ctx context.Context, resource *dbapi.Connector) *errors.ServiceError {
+	if s.beforeUpdate != nil {
+		s.beforeUpdate()
+	}
+	// like the database service, the update is conditional on the version that was read
+	if resource.Version != s.connector.Version {
+		return errors.Conflict("resource version changed")
+	}
+	updated := *resource
+	updated.Version++
+	resource.Version = updated.Version
+	s.connector = &updated
+	return nil
+}
+
+func (s *connectorsServiceStub) SaveStatus(ctx context.Context, resource dbapi.ConnectorStatus) *errors.ServiceError {
+	s.connector.Status = resource
+	return nil
+}
+
+// revisionsServiceStub keeps connector revisions in memory, oldest first, pruning them like the database service
+type revisionsServiceStub struct {
+	services.ConnectorRevisionsService
+	maxRevisions int
+	revisions    dbapi.ConnectorRevisionList
+}
+
+func (s *revisionsServiceStub) Create(ctx context.Context, connector *dbapi.Connector, author string) (dbapi.ConnectorRevisionList, *errors.ServiceError) {
+	var next int64 = 1
+	if n := len(s.revisions); n > 0 {
+		if services.EqualConnectorSpecs(s.revisions[n-1].ConnectorSpec, connector.ConnectorSpec) {
+			return nil, nil
+		}
+		next = s.revisions[n-1].Revision + 1
+	}
+	s.revisions = append(s.revisions, &dbapi.ConnectorRevision{
+		ConnectorID:   connector.ID,
+		Revision:      next,
+		ConnectorSpec: connector.ConnectorSpec,
+		Author:        author,
+	})
+	var pruned dbapi.ConnectorRevisionList
+	if s.maxRevisions > 0 && len(s.revisions) > s.maxRevisions {
+		pruned = s.revisions[:len(s.revisions)-s.maxRevisions]
+		s.revisions = s.revisions[len(s.revisions)-s.maxRevisions:]
+	}
+	return pruned, nil
+}
+
+func (s *revisionsServiceStub) List(ctx context.Context, connectorId string) (dbapi.ConnectorRevisionList, *errors.ServiceError) {
+	var result dbapi.ConnectorRevisionList
+	for i := len(s.revisions) - 1; i >= 0; i-- {
+		result = append(result, s.revisions[i])
+	}
+	return result, nil
+}
+
+func (s *revisionsServiceStub) Get(ctx context.Context, connectorId string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+	for _, r := range s.revisions {
+		if r.Revision == revision {
+			return r, nil
+		}
+	}
+	return nil, errors.NotFound("Connector revision with revision='%d' not found", revision)
+}
+
+// newRevisionsTestHandler returns a handler for a connector with a password secret and a single revision
+func newRevisionsTestHandler(g *gomega.WithT) (*ConnectorsHandler, *connectorsServiceStub, *revisionsServiceStub, *vault.TmpVaultService) {
+	ct := &dbapi.ConnectorType{
+		Model: db.Model{ID: "log_sink_0.1"},
+		JsonSchema: api.JSON(`{"type": "object", "properties": {"topic": {"type": "string"},
+			"password": {"oneOf": [{"type": "string", "format": "password"}, {"type": "object"}]}}}`),
+	}
+	ct.SetChannels([]string{"stable"})
+
+	spec := api.JSON(`{"topic":"a","password":{"kind":"base64","ref":"secret-1"}}`)
+	connectorsService := &connectorsServiceStub{connector: &dbapi.Connector{
+		Model:           db.Model{ID: "connector"},
+		Name:            "test",
+		ConnectorTypeId: ct.ID,
+		ConnectorSpec:   spec,
+		DesiredState:    dbapi.ConnectorReady,
+		Channel:         "stable",
+		Kafka:           dbapi.KafkaConnectionSettings{KafkaID: "kafka", BootstrapServer: "kafka:443"},
+		ServiceAccount:  dbapi.ServiceAccount{ClientId: "client-id", ClientSecretRef: "sa-secret"},
+		Status:          dbapi.ConnectorStatus{Phase: dbapi.ConnectorStatusPhaseReady},
+		Version:         1,
+	}}
+	revisionsService := &revisionsServiceStub{
+		maxRevisions: 2,
+		revisions:    dbapi.ConnectorRevisionList{{ConnectorID: "connector", Revision: 1, ConnectorSpec: spec}},
+	}
+	vaultService, err := vault.NewTmpVaultService()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(vaultService.SetSecretString("secret-1", "password-1", "")).To(gomega.Succeed())
+	g.Expect(vaultService.SetSecretString("sa-secret", "client-secret", "")).To(gomega.Succeed())
+
+	return &ConnectorsHandler{
+		connectorsService:     connectorsService,
+		connectorTypesService: connectorTypesServiceStub{connectorType: ct},
+		namespaceService:      namespaceServiceStub{},
+		vaultService:          vaultService,
+		authZService:          authz.NewAuthZService(nil, namespaceServiceStub{}, nil),
+		revisionsService:      revisionsService,
+		connectorsConfig:      &config.ConnectorsConfig{},
+	}, connectorsService, revisionsService, vaultService
+}
+
+// newRevisionsTestContext returns a user context with a transaction, so post commit secret deletions can be resolved
+func newRevisionsTestContext(g *gomega.WithT) context.Context {
+	connectionFactory := db.NewMockConnectionFactory(nil)
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery("txid_current").WithReply([]map[string]interface{}{{"txid_current": 1}})
+	ctx, err := connectionFactory.NewContext(auth.SetTokenInContext(context.Background(), &jwt.Token{
+		Claims: jwt.MapClaims{"username": "user", "org_id": "org-id"},
+	}))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	return ctx
+}
+
+func newRevisionRequest(ctx context.Context, method string, target string, revision string) *http.Request {
+	r := httptest.NewRequest(method, target, nil).WithContext(ctx)
+	return mux.SetURLVars(r, map[string]string{"connector_id": "connector", "revision": revision})
+}
+
+func secretExists(vaultService *vault.TmpVaultService, name string) bool {
+	_, err := vaultService.GetSecretString(name)
+	return err == nil
+}
+
+func Test_ConnectorsHandler_DiffRevisions(t *testing.T) {
+	g := gomega.NewWithT(t)
+	h, _, revisionsService, _ := newRevisionsTestHandler(g)
+	revisionsService.revisions = append(revisionsService.revisions,
+		&dbapi.ConnectorRevision{ConnectorID: "connector", Revision: 2,
+			ConnectorSpec: api.JSON(`{"topic":"b","password":{"kind":"base64","ref":"secret-2"}}`)},
+		&dbapi.ConnectorRevision{ConnectorID: "connector", Revision: 3,
+			ConnectorSpec: api.JSON(`{"topic":"b","password":{"kind":"base64","ref":"secret-3"},"extra":true}`)})
+
+	tests := []struct {
+		name      string
+		target    string
+		revision  string
+		wantCode  int
+		wantFrom  int64
+		wantPatch map[string]interface{}
+	}{
+		{
+			name:      "should diff against the latest revision by default, without secret references",
+			target:    "/api/connector_mgmt/v1/kafka_connectors/connector/revisions/1/diff",
+			revision:  "1",
+			wantCode:  http.StatusOK,
+			wantFrom:  3,
+			wantPatch: map[string]interface{}{"topic": "a", "extra": nil},
+		},
+		{
+			name:      "should diff against the from revision",
+			target:    "/api/connector_mgmt/v1/kafka_connectors/connector/revisions/1/diff?from=2",
+			revision:  "1",
+			wantCode:  http.StatusOK,
+			wantFrom:  2,
+			wantPatch: map[string]interface{}{"topic": "a"},
+		},
+		{
+			name:     "should reject an invalid from revision",
+			target:   "/api/connector_mgmt/v1/kafka_connectors/connector/revisions/1/diff?from=0",
+			revision: "1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "should not find a pruned revision",
+			target:   "/api/connector_mgmt/v1/kafka_connectors/connector/revisions/4/diff",
+			revision: "4",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			w := httptest.NewRecorder()
+			h.DiffRevisions(w, newRevisionRequest(context.Background(), http.MethodGet, tt.target, tt.revision))
+			g.Expect(w.Code).To(gomega.Equal(tt.wantCode))
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var diff public.ConnectorRevisionDiff
+			g.Expect(json.Unmarshal(w.Body.Bytes(), &diff)).To(gomega.Succeed())
+			g.Expect(diff.FromRevision).To(gomega.Equal(tt.wantFrom))
+			g.Expect(diff.ToRevision).To(gomega.Equal(int64(1)))
+			g.Expect(diff.Patch).To(gomega.Equal(tt.wantPatch))
+		})
+	}
+}
+
+func Test_ConnectorsHandler_RollbackRevision(t *testing.T) {
+	g := gomega.NewWithT(t)
+	h, connectorsService, revisionsService, vaultService := newRevisionsTestHandler(g)
+
+	// change the password, the previous password is retained for rollback
+	ctx := newRevisionsTestContext(g)
+	_, serr := h.patchConnector(ctx, "/api/connector_mgmt/v1/kafka_connectors/connector", "connector", MERGE_PATCH,
+		[]byte(`{"connector": {"topic": "b", "password": "password-2"}}`), 0, false, false)
+	g.Expect(serr).To(gomega.BeNil())
+	g.Expect(db.Resolve(ctx)).To(gomega.Succeed())
+	g.Expect(revisionsService.revisions).To(gomega.HaveLen(2))
+	ct, _ := h.connectorTypesService.Get("log_sink_0.1")
+	secret2 := services.GetConnectorSpecSecretRefs(ct, connectorsService.connector.ConnectorSpec)
+	g.Expect(secret2).To(gomega.HaveLen(1))
+	g.Expect(secretExists(vaultService, "secret-1")).To(gomega.BeTrue())
+	g.Expect(secretExists(vaultService, secret2[0])).To(gomega.BeTrue())
+
+	// rollbacks must be async
+	w := httptest.NewRecorder()
+	h.RollbackRevision(w, newRevisionRequest(newRevisionsTestContext(g), http.MethodPost,
+		"/api/connector_mgmt/v1/kafka_connectors/connector/revisions/1/rollback", "1"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusBadRequest))
+
+	// rolling back restores the whole spec including the secret reference, and prunes revision 1,
+	// whose secret is kept since the connector references it again
+	ctx = newRevisionsTestContext(g)
+	w = httptest.NewRecorder()
+	h.RollbackRevision(w, newRevisionRequest(ctx, http.MethodPost,
+		"/api/connector_mgmt/v1/kafka_connectors/connector/revisions/1/rollback?async=true", "1"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusAccepted))
+	g.Expect(db.Resolve(ctx)).To(gomega.Succeed())
+	g.Expect(services.EqualConnectorSpecs(connectorsService.connector.ConnectorSpec,
+		api.JSON(`{"topic":"a","password":{"kind":"base64","ref":"secret-1"}}`))).To(gomega.BeTrue())
+	g.Expect(connectorsService.connector.Status.Phase).To(gomega.Equal(dbapi.ConnectorStatusPhaseUpdating))
+	g.Expect(revisionsService.revisions).To(gomega.HaveLen(2))
+	g.Expect(revisionsService.revisions[0].Revision).To(gomega.Equal(int64(2)))
+	g.Expect(revisionsService.revisions[1].Revision).To(gomega.Equal(int64(3)))
+	g.Expect(secretExists(vaultService, "secret-1")).To(gomega.BeTrue())
+	g.Expect(secretExists(vaultService, secret2[0])).To(gomega.BeTrue())
+
+	// redacted rollback responses don't expose secret references
+	var connector public.Connector
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &connector)).To(gomega.Succeed())
+	g.Expect(connector.Connector).To(gomega.Equal(map[string]interface{}{"topic": "a", "password": map[string]interface{}{}}))
+
+	// pruning revision 2 deletes its secret, which is no longer referenced
+	ctx = newRevisionsTestContext(g)
+	_, serr = h.patchConnector(ctx, "/api/connector_mgmt/v1/kafka_connectors/connector", "connector", MERGE_PATCH,
+		[]byte(`{"connector": {"topic": "c"}}`), 0, false, false)
+	g.Expect(serr).To(gomega.BeNil())
+	g.Expect(db.Resolve(ctx)).To(gomega.Succeed())
+	g.Expect(revisionsService.revisions).To(gomega.HaveLen(2))
+	g.Expect(revisionsService.revisions[0].Revision).To(gomega.Equal(int64(3)))
+	g.Expect(secretExists(vaultService, "secret-1")).To(gomega.BeTrue())
+	g.Expect(secretExists(vaultService, secret2[0])).To(gomega.BeFalse())
+
+	// rolling back to a pruned revision fails
+	w = httptest.NewRecorder()
+	h.RollbackRevision(w, newRevisionRequest(newRevisionsTestContext(g), http.MethodPost,
+		"/api/connector_mgmt/v1/kafka_connectors/connector/revisions/1/rollback?async=true", "1"))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+}
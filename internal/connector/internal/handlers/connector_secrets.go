@@ -9,7 +9,7 @@ import (
 	"github.com/spyzhov/ajson"
 )
 
-const OwningResourcePrefix = "/v1/connector/"
+const OwningResourcePrefix = vault.OwningResourcePrefix
 
 func stripSecretReferences(resource *dbapi.Connector, ct *dbapi.ConnectorType) *errors.ServiceError {
 	// clear out secrets..
@@ -13,18 +13,20 @@ import (
 )
 
 type ConnectorTypesHandler struct {
-	service services.ConnectorTypesService
-	manager *workers.ConnectorManager
+	service           services.ConnectorTypesService
+	manager           *workers.ConnectorManager
+	connectorsHandler *ConnectorsHandler
 }
 
 var (
 	maxConnectorTypeIdLength = 63
 )
 
-func NewConnectorTypesHandler(service services.ConnectorTypesService, manager *workers.ConnectorManager) *ConnectorTypesHandler {
+func NewConnectorTypesHandler(service services.ConnectorTypesService, manager *workers.ConnectorManager, connectorsHandler *ConnectorsHandler) *ConnectorTypesHandler {
 	return &ConnectorTypesHandler{
-		service: service,
-		manager: manager,
+		service:           service,
+		manager:           manager,
+		connectorsHandler: connectorsHandler,
 	}
 }
 
@@ -104,3 +106,37 @@ func (h ConnectorTypesHandler) ListLabels(w http.ResponseWriter, r *http.Request
 
 	handlers.HandleList(w, r, cfg)
 }
+
+// Validate checks a connector request against a connector type without creating the connector, running the same
+// checks as connector creation, and returns all problems found in the request
+func (h ConnectorTypesHandler) Validate(w http.ResponseWriter, r *http.Request) {
+	connectorTypeId := mux.Vars(r)["connector_type_id"]
+	var resource public.ConnectorRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_type_id", &connectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
+			// same defaults as connector creation
+			handlers.Validation("channel", (*string)(&resource.Channel), handlers.WithDefault("stable")),
+			handlers.Validation("name", &resource.Name, handlers.WithDefault("New Connector")),
+			handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.WithDefault("ready")),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ct, err := h.service.Get(connectorTypeId)
+			if err != nil {
+				return nil, err
+			}
+			user := h.connectorsHandler.authZService.GetValidationUser(r.Context())
+			violations, err := h.connectorsHandler.getConnectorTypeViolations(r.Context(), user, ct, &resource)
+			if err != nil {
+				return nil, err
+			}
+			return public.ConnectorValidationResult{
+				Kind:       "ConnectorValidationResult",
+				Valid:      len(violations) == 0,
+				Violations: violations,
+			}, nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreservices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultUpgradeCampaignWaveSize    = 10
+	defaultUpgradeCampaignWaveTimeout = 30 * time.Minute
+	maxUpgradeCampaignIdLength        = 32
+)
+
+func (h *ConnectorAdminHandler) CreateConnectorUpgradeCampaign(writer http.ResponseWriter, request *http.Request) {
+	var resource private.ConnectorUpgradeCampaignRequest
+	campaign := dbapi.ConnectorUpgradeCampaign{
+		WaveSize:    defaultUpgradeCampaignWaveSize,
+		WaveTimeout: defaultUpgradeCampaignWaveTimeout,
+	}
+	cfg := handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
+			handlers.Validation("cluster_id", &resource.ClusterId, handlers.MaxLen(maxConnectorClusterIdLength)),
+			handlers.Validation("namespace_id", &resource.NamespaceId, handlers.MaxLen(maxConnectorNamespaceIdLength)),
+			func() *errors.ServiceError {
+				if resource.WaveSize < 0 {
+					return errors.BadRequest("wave_size must be a positive integer")
+				} else if resource.WaveSize > 0 {
+					campaign.WaveSize = int(resource.WaveSize)
+				}
+				if resource.FailureBudget < 0 {
+					return errors.BadRequest("failure_budget must not be negative")
+				}
+				if resource.Revision < 0 {
+					return errors.BadRequest("revision must be a positive integer")
+				}
+				if resource.WaveTimeout != "" {
+					timeout, err := time.ParseDuration(resource.WaveTimeout)
+					if err != nil || timeout <= 0 {
+						return errors.BadRequest("wave_timeout must be a positive duration in golang duration format")
+					}
+					campaign.WaveTimeout = timeout
+				}
+				return nil
+			},
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			ctx := request.Context()
+			claims, err := auth.GetClaimsFromContext(ctx)
+			if err != nil {
+				return nil, errors.Unauthenticated("user not authenticated")
+			}
+			username, _ := claims.GetUsername()
+
+			campaign.ConnectorTypeID = resource.ConnectorTypeId
+			campaign.Channel = resource.Channel
+			campaign.Revision = resource.Revision
+			campaign.ClusterID = resource.ClusterId
+			campaign.NamespaceID = resource.NamespaceId
+			campaign.FailureBudget = int(resource.FailureBudget)
+			campaign.RequestedBy = username
+			if serviceError = h.UpgradeCampaignService.Create(ctx, &campaign); serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorUpgradeCampaign(&campaign), nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusAccepted)
+}
+
+func (h *ConnectorAdminHandler) ListConnectorUpgradeCampaigns(writer http.ResponseWriter, request *http.Request) {
+	listArgs := coreservices.NewListArguments(request.URL.Query())
+	cfg := handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			campaigns, paging, serviceError := h.UpgradeCampaignService.List(request.Context(), listArgs)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+
+			result := private.ConnectorUpgradeCampaignList{
+				Kind:  "ConnectorUpgradeCampaignList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+			}
+			result.Items = make([]private.ConnectorUpgradeCampaign, len(campaigns))
+			for i, campaign := range campaigns {
+				result.Items[i] = presenters.PresentConnectorUpgradeCampaign(campaign)
+			}
+
+			return result, nil
+		},
+	}
+
+	handlers.HandleList(writer, request, &cfg)
+}
+
+func (h *ConnectorAdminHandler) GetConnectorUpgradeCampaign(writer http.ResponseWriter, request *http.Request) {
+	campaignId := mux.Vars(request)["campaign_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("campaign_id", &campaignId, handlers.MinLen(1), handlers.MaxLen(maxUpgradeCampaignIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			campaign, serviceError := h.UpgradeCampaignService.Get(request.Context(), campaignId)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorUpgradeCampaign(campaign), nil
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
+// RollbackConnectorUpgradeCampaign moves deployments upgraded by a running or completed campaign back to their previous shard metadata
+func (h *ConnectorAdminHandler) RollbackConnectorUpgradeCampaign(writer http.ResponseWriter, request *http.Request) {
+	campaignId := mux.Vars(request)["campaign_id"]
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("campaign_id", &campaignId, handlers.MinLen(1), handlers.MaxLen(maxUpgradeCampaignIdLength)),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			ctx := request.Context()
+			claims, err := auth.GetClaimsFromContext(ctx)
+			if err != nil {
+				return nil, errors.Unauthenticated("user not authenticated")
+			}
+			username, _ := claims.GetUsername()
+
+			campaign, serviceError := h.UpgradeCampaignService.Rollback(ctx, campaignId, "rollback requested by "+username)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorUpgradeCampaign(campaign), nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusAccepted)
+}
@@ -2,14 +2,24 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
+	"github.com/spyzhov/ajson"
 	"k8s.io/apimachinery/pkg/util/validation"
-	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/xeipuuv/gojsonschema"
@@ -24,6 +34,49 @@ func validateConnectorClusterId(ctx context.Context, clusterService services.Con
 	}
 }
 
+// validateKafkaConnectionSettings checks that the organisation is allowed to attach connectors to the Kafka instance,
+// Kafka instances are only validated when the fleet manager runs the Kafka module
+func validateKafkaConnectionSettings(kafkaAccess kafkaaccess.ConnectorBindingValidator, kafka *public.KafkaConnectionSettings, orgID string) handlers.Validate {
+	return func() *errors.ServiceError {
+		if kafkaAccess == nil || kafka.Id == "" {
+			return nil
+		}
+		if err := kafkaAccess.ValidateConnectorBinding(kafka.Id, orgID); err != nil {
+			if err.Is404() || err.IsForbidden() {
+				return errors.BadRequest("kafka.id is not valid: %s", err.Reason)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// validateServiceAccountScope checks that the service account of a connector can be used with its Kafka instance and
+// the connector cluster of its namespace, service accounts are only validated when the fleet manager runs the Kafka module
+func validateServiceAccountScope(ctx context.Context, scopeValidator kafkaaccess.ServiceAccountScopeValidator, namespaceService services.ConnectorNamespaceService,
+	serviceAccount *public.ServiceAccount, kafka *public.KafkaConnectionSettings, namespaceID string) handlers.Validate {
+	return func() *errors.ServiceError {
+		if scopeValidator == nil || serviceAccount.ClientId == "" {
+			return nil
+		}
+		clusterID := ""
+		if namespaceID != "" {
+			namespace, err := namespaceService.Get(ctx, namespaceID)
+			if err != nil {
+				return err
+			}
+			clusterID = namespace.ClusterId
+		}
+		if err := scopeValidator.ValidateServiceAccountScope(serviceAccount.ClientId, kafka.Id, clusterID); err != nil {
+			if err.IsForbidden() {
+				return errors.BadRequest("service_account.client_id is not valid: %s", err.Reason)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
 func validateConnectorRequest(connectorTypesService services.ConnectorTypesService, resource *public.ConnectorRequest) handlers.Validate {
 	return connectorValidationFunction(connectorTypesService, &resource.ConnectorTypeId, &resource.Channel, &resource.Connector)
 }
@@ -54,6 +107,251 @@ func connectorValidationFunction(connectorTypesService services.ConnectorTypesSe
 	}
 }
 
+// validateConnectorTypeCreateCutoff rejects new connectors of deprecated connector types after their creation cutoff
+func validateConnectorTypeCreateCutoff(ct *dbapi.ConnectorType) *errors.ServiceError {
+	if !ct.Deprecated {
+		return nil
+	}
+	deprecation, err := ct.GetDeprecation()
+	if err != nil {
+		return errors.GeneralError("invalid deprecation for connector type %s: %v", ct.ID, err)
+	}
+	if deprecation != nil && deprecation.IsCreateCutoff(time.Now()) {
+		return errors.BadRequest("connector type %s no longer accepts new connectors since %s, %s", ct.ID,
+			deprecation.CreateCutoffAt.Format(time.RFC3339), deprecation)
+	}
+	return nil
+}
+
+// getDryRunViolations returns all problems found in a connector create request, including the access to its Kafka instance,
+// the scope of its service account and its namespace, client errors of these checks are reported as violations
+func (h ConnectorsHandler) getDryRunViolations(ctx context.Context, user *authz.ValidationUser, resource *public.ConnectorRequest) ([]public.ConnectorValidationViolation, *errors.ServiceError) {
+	var ct *dbapi.ConnectorType
+	if resource.ConnectorTypeId != "" {
+		var serr *errors.ServiceError
+		if ct, serr = h.connectorTypesService.Get(resource.ConnectorTypeId); serr != nil && serr.HttpCode >= http.StatusInternalServerError {
+			return nil, serr
+		}
+	}
+	violations, serr := h.getConnectorTypeViolations(ctx, user, ct, resource)
+	if serr != nil {
+		return nil, serr
+	}
+	if ct == nil && resource.ConnectorTypeId != "" {
+		violations = append(violations, public.ConnectorValidationViolation{
+			Pointer: "/connector_type_id",
+			Message: fmt.Sprintf("invalid connector type id: %s", resource.ConnectorTypeId),
+		})
+	}
+	return violations, nil
+}
+
+// getConnectorTypeViolations returns all problems found in a connector create request for a connector type, running the
+// same checks as connector creation, connector type specific checks are skipped without a connector type
+func (h ConnectorsHandler) getConnectorTypeViolations(ctx context.Context, user *authz.ValidationUser, ct *dbapi.ConnectorType, resource *public.ConnectorRequest) ([]public.ConnectorValidationViolation, *errors.ServiceError) {
+	violations, serr := getConnectorRequestViolations(ct, resource)
+	if serr != nil {
+		return nil, serr
+	}
+
+	var validations []connectorValidation
+	if len(h.connectorsConfig.ConnectorsSupportedChannels) > 0 {
+		validations = append(validations, connectorValidation{"/channel", handlers.Validation("channel", (*string)(&resource.Channel),
+			handlers.IsOneOf(h.connectorsConfig.ConnectorsSupportedChannels...))})
+	}
+	if resource.NamespaceId == "" {
+		if !h.connectorsConfig.ConnectorEnableUnassignedConnectors {
+			validations = append(validations, connectorValidation{"/namespace_id", func() *errors.ServiceError {
+				return errors.BadRequest("namespace id is required")
+			}})
+		}
+	} else {
+		validations = append(validations, connectorValidation{"/namespace_id", handlers.Validation("namespace_id", &resource.NamespaceId,
+			user.AuthorizedNamespaceUser(errors.ErrorBadRequest), user.ValidateNamespaceConnectorQuota())})
+	}
+	validations = append(validations,
+		connectorValidation{"/kafka/id", validateKafkaConnectionSettings(h.kafkaAccess, &resource.Kafka, user.OrgId())},
+		connectorValidation{"/service_account/client_id", validateServiceAccountScope(ctx, h.serviceAccountScope, h.namespaceService,
+			&resource.ServiceAccount, &resource.Kafka, resource.NamespaceId)},
+	)
+	validationViolations, serr := getValidationViolations(validations)
+	if serr != nil {
+		return nil, serr
+	}
+
+	return append(violations, validationViolations...), nil
+}
+
+// getConnectorRequestViolations returns all problems found in a connector request for a connector type,
+// with JSON pointers to the invalid values in the request, connector type specific checks are skipped without a connector type
+func getConnectorRequestViolations(ct *dbapi.ConnectorType, resource *public.ConnectorRequest) ([]public.ConnectorValidationViolation, *errors.ServiceError) {
+	violations := make([]public.ConnectorValidationViolation, 0)
+	addViolation := func(pointer string, format string, args ...interface{}) {
+		violations = append(violations, public.ConnectorValidationViolation{
+			Pointer: pointer,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if resource.ConnectorTypeId == "" {
+		addViolation("/connector_type_id", "connector type id is required")
+	} else if len(resource.ConnectorTypeId) > maxConnectorTypeIdLength {
+		addViolation("/connector_type_id", "maximum length %d exceeded", maxConnectorTypeIdLength)
+	}
+	if ct != nil {
+		if resource.ConnectorTypeId != "" && resource.ConnectorTypeId != ct.ID {
+			addViolation("/connector_type_id", "connector type id must be %s", ct.ID)
+		}
+		if err := validateConnectorTypeCreateCutoff(ct); err != nil {
+			addViolation("/connector_type_id", err.Reason)
+		}
+	}
+	if len(resource.Name) > 100 {
+		addViolation("/name", "maximum length 100 exceeded")
+	}
+	if len(resource.Channel) > 40 {
+		addViolation("/channel", "maximum length 40 exceeded")
+	} else if ct != nil && resource.Channel != "" && !arrays.Contains(ct.ChannelNames(), string(resource.Channel)) {
+		addViolation("/channel", "channel is not valid. Must be one of: %s", strings.Join(ct.ChannelNames(), ", "))
+	}
+	if resource.DesiredState != "" && !arrays.Contains(dbapi.ValidDesiredStates, string(resource.DesiredState)) {
+		addViolation("/desired_state", "desired state is not valid. Must be one of: %s", strings.Join(dbapi.ValidDesiredStates, ", "))
+	}
+	if len(resource.NamespaceId) > maxConnectorNamespaceIdLength {
+		addViolation("/namespace_id", "maximum length %d exceeded", maxConnectorNamespaceIdLength)
+	}
+	if err := validateCreateAnnotations(resource.Annotations)(); err != nil {
+		addViolation("/annotations", err.Reason)
+	}
+
+	// Kafka and service account settings
+	if resource.Kafka.Id == "" {
+		addViolation("/kafka/id", "kafka id is required")
+	} else if len(resource.Kafka.Id) > maxKafkaNameLength {
+		addViolation("/kafka/id", "maximum length %d exceeded", maxKafkaNameLength)
+	}
+	if resource.Kafka.Url == "" {
+		addViolation("/kafka/url", "kafka url is required")
+	}
+	if resource.ServiceAccount.ClientId == "" {
+		addViolation("/service_account/client_id", "service account client id is required")
+	}
+	if resource.ServiceAccount.ClientSecret == "" {
+		addViolation("/service_account/client_secret", "service account client secret is required")
+	}
+	if resource.SchemaRegistry.Id != "" && resource.SchemaRegistry.Url == "" {
+		addViolation("/schema_registry/url", "schema registry url is required with schema registry id")
+	}
+
+	if ct == nil {
+		return violations, nil
+	}
+
+	// connector spec
+	specViolations, serr := getConnectorSpecViolations(ct, resource.Connector)
+	if serr != nil {
+		return nil, serr
+	}
+	violations = append(violations, specViolations...)
+
+	// secret fields must be set to plain strings, which are stored in the vault
+	spec, err := json.Marshal(resource.Connector)
+	if err != nil {
+		return nil, errors.BadRequest("invalid connector spec: %v", err)
+	}
+	if _, err := secrets.ModifySecrets(ct.JsonSchema, spec, func(node *ajson.Node) error {
+		if node.Type() != ajson.String && node.Type() != ajson.Null {
+			addViolation("/connector"+ajsonNodePointer(node), "secret field must be set to a string")
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.BadRequest("invalid connector spec: %v", err)
+	}
+
+	return violations, nil
+}
+
+// getConnectorSpecViolations returns the problems found in a connector spec against the schema of its connector type
+func getConnectorSpecViolations(ct *dbapi.ConnectorType, spec map[string]interface{}) ([]public.ConnectorValidationViolation, *errors.ServiceError) {
+	schemaDom, serr := ct.JsonSchemaAsMap()
+	if serr != nil {
+		return nil, serr
+	}
+	schemaViolations, serr := handlers.GetJsonSchemaViolations("connector type schema", gojsonschema.NewGoLoader(schemaDom),
+		"connector spec", gojsonschema.NewGoLoader(spec))
+	if serr != nil {
+		return nil, serr
+	}
+	violations := make([]public.ConnectorValidationViolation, 0, len(schemaViolations))
+	for _, v := range schemaViolations {
+		violations = append(violations, public.ConnectorValidationViolation{Pointer: "/connector" + v.Pointer, Message: v.Message})
+	}
+	return violations, nil
+}
+
+// connectorValidation is a validation of the value at a JSON pointer in a connector
+type connectorValidation struct {
+	pointer  string
+	validate handlers.Validate
+}
+
+// getValidationViolations runs all validations and returns their client errors as violations, server errors are returned
+func getValidationViolations(validations []connectorValidation) ([]public.ConnectorValidationViolation, *errors.ServiceError) {
+	violations := make([]public.ConnectorValidationViolation, 0)
+	for _, v := range validations {
+		if serr := v.validate(); serr != nil {
+			if serr.HttpCode >= http.StatusInternalServerError {
+				return nil, serr
+			}
+			violations = append(violations, public.ConnectorValidationViolation{Pointer: v.pointer, Message: serr.Reason})
+		}
+	}
+	return violations, nil
+}
+
+// getPatchViolations returns all problems found in a patched connector, with JSON pointers to the invalid values
+func getPatchViolations(ct *dbapi.ConnectorType, patch public.ConnectorRequest, originalResource public.Connector,
+	resource public.Connector, validations []connectorValidation) ([]public.ConnectorValidationViolation, *errors.ServiceError) {
+	violations := make([]public.ConnectorValidationViolation, 0)
+	for _, property := range getPatchedImmutableProperties(patch, originalResource) {
+		violations = append(violations, public.ConnectorValidationViolation{
+			Pointer: "/" + property,
+			Message: fmt.Sprintf("%s is immutable and can't be modified", property),
+		})
+	}
+	validationViolations, serr := getValidationViolations(validations)
+	if serr != nil {
+		return nil, serr
+	}
+	violations = append(violations, validationViolations...)
+	specViolations, serr := getConnectorSpecViolations(ct, resource.Connector)
+	if serr != nil {
+		return nil, serr
+	}
+	return append(violations, specViolations...), nil
+}
+
+// connectorViolationsError returns an error listing all violations in a connector request
+func connectorViolationsError(violations []public.ConnectorValidationViolation) *errors.ServiceError {
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = fmt.Sprintf("%s: %s", v.Pointer, v.Message)
+	}
+	return errors.BadRequest("connector request is not valid, %d errors encountered: %s", len(violations), strings.Join(messages, "; "))
+}
+
+// ajsonNodePointer returns a JSON pointer to a node
+func ajsonNodePointer(node *ajson.Node) string {
+	if node.Parent() == nil {
+		return ""
+	}
+	token := node.Key()
+	if node.Parent().Type() == ajson.Array {
+		token = strconv.Itoa(node.Index())
+	}
+	return ajsonNodePointer(node.Parent()) + "/" + strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
 // annotations are mapped to k8s labels, check that it's not used to set any reserved domain labels
 var reservedDomains = []string{"kubernetes.io/", "k8s.io/", "openshift.io/"}
 
@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+)
+
+func Test_getConnectorRequestViolations(t *testing.T) {
+	ct := &dbapi.ConnectorType{
+		Model: db.Model{ID: "log_sink_0.1"},
+		JsonSchema: api.JSON(`{
+			"type": "object",
+			"required": ["topic", "password"],
+			"properties": {
+				"topic": {"type": "string"},
+				"retries": {"type": "integer"},
+				"password": {"oneOf": [{"type": "string", "format": "password"}, {"type": "object"}]}
+			}
+		}`),
+	}
+	ct.SetChannels([]string{"stable"})
+
+	validRequest := func() public.ConnectorRequest {
+		return public.ConnectorRequest{
+			Name:            "test",
+			ConnectorTypeId: "log_sink_0.1",
+			Channel:         "stable",
+			DesiredState:    "ready",
+			Kafka:           public.KafkaConnectionSettings{Id: "kafka", Url: "kafka:443"},
+			ServiceAccount:  public.ServiceAccount{ClientId: "id", ClientSecret: "secret"},
+			Connector:       map[string]interface{}{"topic": "t", "password": "p"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		request func() public.ConnectorRequest
+		want    []string
+	}{
+		{
+			name:    "valid request",
+			request: validRequest,
+			want:    []string{},
+		},
+		{
+			name: "all violations are reported",
+			request: func() public.ConnectorRequest {
+				r := validRequest()
+				r.Channel = "beta"
+				r.Kafka.Url = ""
+				r.ServiceAccount.ClientSecret = ""
+				r.Connector = map[string]interface{}{"retries": "many", "password": map[string]interface{}{"ref": "key"}}
+				return r
+			},
+			want: []string{"/channel", "/kafka/url", "/service_account/client_secret",
+				"/connector/topic", "/connector/retries", "/connector/password"},
+		},
+		{
+			name: "other connector type",
+			request: func() public.ConnectorRequest {
+				r := validRequest()
+				r.ConnectorTypeId = "log_source_0.1"
+				return r
+			},
+			want: []string{"/connector_type_id"},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			request := tt.request()
+			violations, err := getConnectorRequestViolations(ct, &request)
+			g.Expect(err).To(gomega.BeNil())
+			pointers := make([]string, 0, len(violations))
+			for _, v := range violations {
+				pointers = append(pointers, v.Pointer)
+			}
+			g.Expect(pointers).To(gomega.ConsistOf(tt.want))
+		})
+	}
+}
+
+func Test_validateKafkaConnectionSettings(t *testing.T) {
+	validator := &kafkaaccess.ConnectorBindingValidatorMock{
+		ValidateConnectorBindingFunc: func(kafkaID string, orgID string) *errors.ServiceError {
+			switch kafkaID {
+			case "owned-kafka":
+				return nil
+			case "read-only-kafka":
+				return errors.Forbidden("organisation %s is not allowed to attach connectors to kafka %s", orgID, kafkaID)
+			case "unknown-kafka":
+				return errors.NotFound("KafkaResource with id='%s' not found", kafkaID)
+			default:
+				return errors.GeneralError("unexpected error")
+			}
+		},
+	}
+
+	tests := []struct {
+		name      string
+		validator kafkaaccess.ConnectorBindingValidator
+		kafkaID   string
+		wantCode  errors.ServiceErrorCode
+	}{
+		{name: "should skip the validation without the kafka module", kafkaID: "unknown-kafka"},
+		{name: "should accept a kafka the organisation can attach connectors to", validator: validator, kafkaID: "owned-kafka"},
+		{name: "should reject a kafka the organisation has read only access to", validator: validator, kafkaID: "read-only-kafka", wantCode: errors.ErrorBadRequest},
+		{name: "should reject a kafka the organisation has no access to", validator: validator, kafkaID: "unknown-kafka", wantCode: errors.ErrorBadRequest},
+		{name: "should report unexpected errors", validator: validator, kafkaID: "failing-kafka", wantCode: errors.ErrorGeneral},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := validateKafkaConnectionSettings(tt.validator, &public.KafkaConnectionSettings{Id: tt.kafkaID, Url: "kafka:443"}, "org-id")()
+			if tt.wantCode == 0 {
+				g.Expect(err).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.BeNil())
+			g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+		})
+	}
+}
+
+// namespaceServiceStub returns the namespace of the connector cluster with id "cluster-id"
+type namespaceServiceStub struct {
+	services.ConnectorNamespaceService
+}
+
+func (namespaceServiceStub) Get(ctx context.Context, namespaceID string) (*dbapi.ConnectorNamespace, *errors.ServiceError) {
+	return &dbapi.ConnectorNamespace{Model: db.Model{ID: namespaceID}, ClusterId: "cluster-id"}, nil
+}
+
+func Test_validateServiceAccountScope(t *testing.T) {
+	validator := &kafkaaccess.ServiceAccountScopeValidatorMock{
+		ValidateServiceAccountScopeFunc: func(clientID string, kafkaID string, connectorClusterID string) *errors.ServiceError {
+			switch {
+			case clientID == "failing-client-id":
+				return errors.GeneralError("unexpected error")
+			case kafkaID != "bound-kafka":
+				return errors.Forbidden("service account %s is not bound to kafka %s", clientID, kafkaID)
+			case connectorClusterID != "" && connectorClusterID != "cluster-id":
+				return errors.Forbidden("service account %s is not bound to connector cluster %s", clientID, connectorClusterID)
+			}
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name        string
+		validator   kafkaaccess.ServiceAccountScopeValidator
+		clientID    string
+		kafkaID     string
+		namespaceID string
+		wantCode    errors.ServiceErrorCode
+	}{
+		{name: "should skip the validation without the kafka module", clientID: "client-id", kafkaID: "other-kafka"},
+		{name: "should accept a service account bound to the kafka", validator: validator, clientID: "client-id", kafkaID: "bound-kafka"},
+		{name: "should accept a service account bound to the connector cluster of the namespace", validator: validator, clientID: "client-id", kafkaID: "bound-kafka", namespaceID: "namespace-id"},
+		{name: "should reject a service account not bound to the kafka", validator: validator, clientID: "client-id", kafkaID: "other-kafka", wantCode: errors.ErrorBadRequest},
+		{name: "should report unexpected errors", validator: validator, clientID: "failing-client-id", kafkaID: "bound-kafka", wantCode: errors.ErrorGeneral},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := validateServiceAccountScope(context.Background(), tt.validator, namespaceServiceStub{},
+				&public.ServiceAccount{ClientId: tt.clientID}, &public.KafkaConnectionSettings{Id: tt.kafkaID}, tt.namespaceID)()
+			if tt.wantCode == 0 {
+				g.Expect(err).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.BeNil())
+			g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+		})
+	}
+}
+
+func (namespaceServiceStub) GetNamespaceTenant(namespaceID string) (*dbapi.ConnectorNamespace, *errors.ServiceError) {
+	if namespaceID != "namespace-id" {
+		return nil, errors.NotFound("Connector namespace with id='%s' not found", namespaceID)
+	}
+	orgID := "org-id"
+	return &dbapi.ConnectorNamespace{Model: db.Model{ID: namespaceID}, ClusterId: "cluster-id",
+		TenantOrganisationId: &orgID}, nil
+}
+
+func (namespaceServiceStub) CheckConnectorQuota(namespaceId string) *errors.ServiceError {
+	return nil
+}
+
+type connectorTypesServiceStub struct {
+	services.ConnectorTypesService
+	connectorType *dbapi.ConnectorType
+}
+
+func (s connectorTypesServiceStub) Get(id string) (*dbapi.ConnectorType, *errors.ServiceError) {
+	if id != s.connectorType.ID {
+		return nil, errors.NotFound("Connector type with id='%s' not found", id)
+	}
+	return s.connectorType, nil
+}
+
+func Test_ConnectorsHandler_getDryRunViolations(t *testing.T) {
+	ct := &dbapi.ConnectorType{
+		Model:      db.Model{ID: "log_sink_0.1"},
+		JsonSchema: api.JSON(`{"type": "object", "required": ["topic"], "properties": {"topic": {"type": "string"}}}`),
+	}
+	ct.SetChannels([]string{"stable"})
+
+	h := ConnectorsHandler{
+		connectorTypesService: connectorTypesServiceStub{connectorType: ct},
+		namespaceService:      namespaceServiceStub{},
+		connectorsConfig:      &config.ConnectorsConfig{},
+		kafkaAccess: &kafkaaccess.ConnectorBindingValidatorMock{
+			ValidateConnectorBindingFunc: func(kafkaID string, orgID string) *errors.ServiceError {
+				switch kafkaID {
+				case "kafka":
+					return nil
+				case "failing-kafka":
+					return errors.GeneralError("unexpected error")
+				}
+				return errors.NotFound("KafkaResource with id='%s' not found", kafkaID)
+			},
+		},
+		serviceAccountScope: &kafkaaccess.ServiceAccountScopeValidatorMock{
+			ValidateServiceAccountScopeFunc: func(clientID string, kafkaID string, connectorClusterID string) *errors.ServiceError {
+				if clientID != "client-id" {
+					return errors.Forbidden("service account %s is not bound to kafka %s", clientID, kafkaID)
+				}
+				return nil
+			},
+		},
+	}
+	ctx := auth.SetTokenInContext(context.Background(), &jwt.Token{
+		Claims: jwt.MapClaims{"username": "user", "org_id": "org-id"},
+	})
+	user := authz.NewAuthZService(nil, namespaceServiceStub{}, nil).GetValidationUser(ctx)
+
+	validRequest := func() public.ConnectorRequest {
+		return public.ConnectorRequest{
+			Name:            "test",
+			ConnectorTypeId: "log_sink_0.1",
+			Channel:         "stable",
+			DesiredState:    "ready",
+			NamespaceId:     "namespace-id",
+			Kafka:           public.KafkaConnectionSettings{Id: "kafka", Url: "kafka:443"},
+			ServiceAccount:  public.ServiceAccount{ClientId: "client-id", ClientSecret: "secret"},
+			Connector:       map[string]interface{}{"topic": "t"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		request func() public.ConnectorRequest
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "should accept a valid request",
+			request: validRequest,
+			want:    []string{},
+		},
+		{
+			name: "should report all violations including kafka, service account and namespace access",
+			request: func() public.ConnectorRequest {
+				r := validRequest()
+				r.Kafka.Id = "other-kafka"
+				r.Kafka.Url = ""
+				r.ServiceAccount.ClientId = "other-client-id"
+				r.NamespaceId = "other-namespace-id"
+				r.Connector = map[string]interface{}{}
+				return r
+			},
+			want: []string{"/kafka/id", "/kafka/url", "/service_account/client_id", "/namespace_id", "/connector/topic"},
+		},
+		{
+			name: "should report an unknown connector type",
+			request: func() public.ConnectorRequest {
+				r := validRequest()
+				r.ConnectorTypeId = "log_source_0.1"
+				return r
+			},
+			want: []string{"/connector_type_id"},
+		},
+		{
+			name: "should return unexpected errors",
+			request: func() public.ConnectorRequest {
+				r := validRequest()
+				r.Kafka.Id = "failing-kafka"
+				return r
+			},
+			wantErr: true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			request := tt.request()
+			violations, err := h.getDryRunViolations(ctx, user, &request)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			pointers := make([]string, 0, len(violations))
+			for _, v := range violations {
+				pointers = append(pointers, v.Pointer)
+			}
+			g.Expect(pointers).To(gomega.ConsistOf(tt.want))
+		})
+	}
+}
@@ -18,14 +18,17 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
 	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/goava/di"
 	"github.com/golang/glog"
 	"github.com/gorilla/mux"
 	"github.com/spyzhov/ajson"
 	"io"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -43,7 +46,18 @@ type ConnectorsHandler struct {
 	namespaceService      services.ConnectorNamespaceService
 	vaultService          vault.VaultService
 	authZService          authz.AuthZService
+	revisionsService      services.ConnectorRevisionsService
 	connectorsConfig      *config.ConnectorsConfig
+	kafkaAccess           kafkaaccess.ConnectorBindingValidator
+	serviceAccountScope   kafkaaccess.ServiceAccountScopeValidator
+}
+
+// KafkaAccessOptions holds the validators of the Kafka instances connectors are attached to and of the scope of their
+// service accounts, they are only provided when the fleet manager runs the Kafka module
+type KafkaAccessOptions struct {
+	di.Inject
+	ConnectorBindingValidator    kafkaaccess.ConnectorBindingValidator    `optional:"true"`
+	ServiceAccountScopeValidator kafkaaccess.ServiceAccountScopeValidator `optional:"true"`
 }
 
 // this is an initial guess at what operation is being performed in update
@@ -55,20 +69,24 @@ var stateToOperationsMap = map[public.ConnectorDesiredState]phase.ConnectorOpera
 
 func NewConnectorsHandler(connectorsService services.ConnectorsService, connectorTypesService services.ConnectorTypesService,
 	namespaceService services.ConnectorNamespaceService, vaultService vault.VaultService, authZService authz.AuthZService,
-	connectorsConfig *config.ConnectorsConfig) *ConnectorsHandler {
+	revisionsService services.ConnectorRevisionsService, connectorsConfig *config.ConnectorsConfig, kafkaAccessOptions KafkaAccessOptions) *ConnectorsHandler {
 	return &ConnectorsHandler{
 		connectorsService:     connectorsService,
 		connectorTypesService: connectorTypesService,
 		namespaceService:      namespaceService,
 		vaultService:          vaultService,
 		authZService:          authZService,
+		revisionsService:      revisionsService,
 		connectorsConfig:      connectorsConfig,
+		kafkaAccess:           kafkaAccessOptions.ConnectorBindingValidator,
+		serviceAccountScope:   kafkaAccessOptions.ServiceAccountScopeValidator,
 	}
 }
 
 func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	user := h.authZService.GetValidationUser(r.Context())
+	dryRun := isDryRun(r)
 
 	var resource public.ConnectorRequest
 	cfg := &handlers.HandlerConfig{
@@ -88,15 +106,34 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			handlers.Validation("namespace_id", &resource.NamespaceId,
 				handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest), user.ValidateNamespaceConnectorQuota()),
 			validateCreateAnnotations(resource.Annotations),
+			validateKafkaConnectionSettings(h.kafkaAccess, &resource.Kafka, user.OrgId()),
+			validateServiceAccountScope(r.Context(), h.serviceAccountScope, h.namespaceService, &resource.ServiceAccount, &resource.Kafka, resource.NamespaceId),
 		},
 
 		Action: func() (interface{}, *errors.ServiceError) {
 
+			if dryRun {
+				// report all problems in the request, instead of the first one
+				violations, err := h.getDryRunViolations(r.Context(), user, &resource)
+				if err != nil {
+					return nil, err
+				}
+				if len(violations) > 0 {
+					return nil, connectorViolationsError(violations)
+				}
+			}
+
 			// validate type id first
 			ct, err := h.connectorTypesService.Get(resource.ConnectorTypeId)
 			if err != nil {
 				return nil, errors.BadRequest("invalid connector type id: %s", resource.ConnectorTypeId)
 			}
+			if !dryRun {
+				// dry runs report it as a violation
+				if err := validateConnectorTypeCreateCutoff(ct); err != nil {
+					return nil, err
+				}
+			}
 
 			newID := api.NewID()
 			addSystemAnnotations(&resource.Annotations, user)
@@ -121,6 +158,14 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 				return nil, err
 			}
 
+			if dryRun {
+				// return the connector that would be created
+				if err := stripSecretReferences(convResource, ct); err != nil {
+					return nil, err
+				}
+				return presenters.PresentConnector(convResource)
+			}
+
 			err = moveSecretsToVault(convResource, ct, h.vaultService, true)
 			if err != nil {
 				return nil, err
@@ -129,6 +174,9 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			if svcErr := h.connectorsService.Create(r.Context(), convResource); svcErr != nil {
 				return nil, svcErr
 			}
+			if _, svcErr := h.revisionsService.Create(r.Context(), convResource, user.UserId()); svcErr != nil {
+				return nil, svcErr
+			}
 
 			if err := stripSecretReferences(convResource, ct); err != nil {
 				return nil, err
@@ -142,6 +190,18 @@ func (h ConnectorsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		cfg.Validate = append(cfg.Validate, handlers.Validation("channel", (*string)(&resource.Channel), handlers.WithDefault("stable"), handlers.IsOneOf(h.connectorsConfig.ConnectorsSupportedChannels...)))
 	}
 
+	if dryRun {
+		// dry runs only set defaults before the action reports all problems in the request
+		cfg.Validate = []handlers.Validate{
+			handlers.ValidateAsyncEnabled(r, "creating connector"),
+			handlers.Validation("channel", (*string)(&resource.Channel), handlers.WithDefault("stable")),
+			handlers.Validation("name", &resource.Name, handlers.WithDefault("New Connector")),
+			handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.WithDefault("ready")),
+		}
+		// nothing was created
+		handlers.Handle(w, r, cfg, http.StatusOK)
+		return
+	}
 	// return 202 status accepted
 	handlers.Handle(w, r, cfg, http.StatusAccepted)
 }
@@ -150,184 +210,283 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 
 	connectorId := mux.Vars(r)["connector_id"]
 	contentType := r.Header.Get("Content-Type")
+	dryRun := isDryRun(r)
 
+	var version string
 	cfg := &handlers.HandlerConfig{
 		Validate: []handlers.Validate{
 			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
 			handlers.Validation("Content-Type header", &contentType, handlers.IsOneOf(APPLICATION_JSON, JSON_PATCH, MERGE_PATCH)),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
-			dbresource, serr := h.connectorsService.Get(r.Context(), connectorId)
-			if serr != nil {
-				return nil, serr
-			}
-			originalResource, _ := presenters.PresentConnector(&dbresource.Connector)
-
-			resource, serr := presenters.PresentConnector(&dbresource.Connector)
-			if serr != nil {
-				return nil, serr
-			}
-
-			ct, serr := h.connectorTypesService.Get(dbresource.ConnectorTypeId)
-			if serr != nil {
-				return nil, errors.BadRequest("invalid connector type id: %s", resource.ConnectorTypeId)
-			}
-
-			originalSecrets, err := getSecretRefs(&dbresource.Connector, ct)
-			if err != nil {
-				return nil, errors.GeneralError("could not get existing secrets: %v", err)
-			}
-
-			// Apply the patch..
 			patchBytes, err := io.ReadAll(r.Body)
 			if err != nil {
 				return nil, errors.BadRequest("failed to get patch bytes")
 			}
-
-			// get json resource
-			resourceJson, serr := getResourceJson(resource)
-			if serr != nil {
-				return nil, serr
+			var ifMatchVersion int64
+			if handlers.HasIfMatch(r) {
+				// the patch must be applied to the version checked against the If-Match header
+				ifMatchVersion, _ = strconv.ParseInt(version, 10, 64)
 			}
-
-			// convert json-patch into merge-patch, since validateConnectorPatch can only validate merge-patch
-			if contentType == JSON_PATCH {
-				patchBytes, err = convertToMergePatch(patchBytes, resourceJson)
-				if err != nil {
-					return nil, errors.BadRequest("failed to convert to merge patch: %v", err)
+			result, serr := h.patchConnector(r.Context(), r.URL.Path, connectorId, contentType, patchBytes, ifMatchVersion, false, dryRun)
+			if connector, ok := result.(public.Connector); ok {
+				version = handlers.VersionFromInt(connector.ResourceVersion)
+			}
+			return result, serr
+		},
+		Version: func() string {
+			// the current version is only needed to check the If-Match header before the patch is applied
+			if version == "" {
+				if resource, err := h.connectorsService.Get(r.Context(), connectorId); err == nil {
+					version = handlers.VersionFromInt(resource.Version)
 				}
-				// changed patch bytes to merge patch
-				contentType = MERGE_PATCH
 			}
+			return version
+		},
+	}
 
-			// Don't allow updating connector secrets with values like {"ref": "something"}
-			serr = validateConnectorPatch(patchBytes, ct)
-			if serr != nil {
-				return nil, serr
-			}
+	if dryRun {
+		// nothing was updated
+		handlers.Handle(w, r, cfg, http.StatusOK)
+		return
+	}
+	// return 202 status accepted
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
 
-			patch := public.ConnectorRequest{}
-			serr = PatchResource(resourceJson, contentType, patchBytes, &patch)
-			if serr != nil {
-				return nil, serr
-			}
+// patchConnector applies a patch to a connector through the update path shared by patches and revision rollbacks,
+// rollbacks restore secret references from a previous revision, which user patches are not allowed to set,
+// dry runs validate the patch and return the patched connector without updating it.
+// A non zero ifMatchVersion is the version of the connector the patch is conditional on.
+func (h ConnectorsHandler) patchConnector(ctx context.Context, path string, connectorId string, contentType string,
+	patchBytes []byte, ifMatchVersion int64, restoreSecrets bool, dryRun bool) (interface{}, *errors.ServiceError) {
+	dbresource, serr := h.connectorsService.Get(ctx, connectorId)
+	if serr != nil {
+		return nil, serr
+	}
+	if ifMatchVersion != 0 && dbresource.Version != ifMatchVersion {
+		return nil, errors.PreconditionFailed("connector %s has been updated since its version was checked", connectorId)
+	}
+	originalResource, _ := presenters.PresentConnector(&dbresource.Connector)
 
-			// get and validate patch operation type
-			var operation phase.ConnectorOperation
-			if operation, serr = h.getOperation(resource, patch); err != nil {
-				return nil, serr
-			}
-			if operation == phase.UnassignConnector && !h.connectorsConfig.ConnectorEnableUnassignedConnectors {
-				return nil, errors.FieldValidationError("Unsupported connector state %s", patch.DesiredState)
-			}
-			if serr = ValidateConnectorOperation(r.Context(), h.namespaceService, &dbresource.Connector, operation,
-				func(connector *dbapi.Connector) *errors.ServiceError {
-					resource.DesiredState = public.ConnectorDesiredState(dbresource.DesiredState)
-					return nil
-				}); serr != nil {
-				return nil, serr
-			}
+	resource, serr := presenters.PresentConnector(&dbresource.Connector)
+	if serr != nil {
+		return nil, serr
+	}
 
-			// But we don't want to allow the user to update ALL fields.. so copy
-			// over the fields that they are allowed to modify..
-			resource.Name = patch.Name
-			resource.Connector = patch.Connector
-			resource.Annotations = patch.Annotations
-			resource.Kafka = patch.Kafka
-			resource.ServiceAccount = patch.ServiceAccount
-			resource.SchemaRegistry = patch.SchemaRegistry
-
-			if h.connectorsConfig.ConnectorEnableUnassignedConnectors {
-				// check namespace id change, from unassigned to assigned and vice versa
-				if operation == phase.AssignConnector && patch.NamespaceId != "" && resource.NamespaceId == "" {
-					resource.NamespaceId = patch.NamespaceId
-				}
-				if operation == phase.UnassignConnector && patch.NamespaceId == "" && resource.NamespaceId != "" {
-					resource.NamespaceId = patch.NamespaceId
-				}
-			}
+	ct, serr := h.connectorTypesService.Get(dbresource.ConnectorTypeId)
+	if serr != nil {
+		return nil, errors.BadRequest("invalid connector type id: %s", resource.ConnectorTypeId)
+	}
 
-			// revalidate
-			user := h.authZService.GetValidationUser(r.Context())
-			validates := []handlers.Validate{
-				handlers.Validation("name", &resource.Name, handlers.MinLen(1), handlers.MaxLen(100)),
-				handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
-				handlers.Validation("service_account.client_id", &resource.ServiceAccount.ClientId, handlers.MinLen(1)),
-				handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.IsOneOf(dbapi.ValidDesiredStates...)),
-				validateConnectorImmutableProperties(patch, originalResource),
-				validatePatchAnnotations(resource.Annotations, originalResource.Annotations),
-				validateConnector(h.connectorTypesService, &resource),
-			}
+	originalSecrets, err := getSecretRefs(&dbresource.Connector, ct)
+	if err != nil {
+		return nil, errors.GeneralError("could not get existing secrets: %v", err)
+	}
 
-			// Don't validate user's tenancy in admin api calls
-			if strings.Compare(r.URL.Path, fmt.Sprintf("%s/%s", "/api/connector_mgmt/v1/admin/kafka_connectors", connectorId)) != 0 {
-				validates = append(validates, handlers.Validation("namespace_id", &resource.NamespaceId, handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest)))
-			}
+	// get json resource
+	resourceJson, serr := getResourceJson(resource)
+	if serr != nil {
+		return nil, serr
+	}
 
-			for _, v := range validates {
-				err := v()
-				if err != nil {
-					return nil, err
-				}
-			}
+	// convert json-patch into merge-patch, since validateConnectorPatch can only validate merge-patch
+	if contentType == JSON_PATCH {
+		patchBytes, err = convertToMergePatch(patchBytes, resourceJson)
+		if err != nil {
+			return nil, errors.BadRequest("failed to convert to merge patch: %v", err)
+		}
+		// changed patch bytes to merge patch
+		contentType = MERGE_PATCH
+	}
 
-			// If we didn't change anything, then just skip the update...
-			if reflect.DeepEqual(originalResource, resource) {
-				return originalResource, nil
-			}
+	// Don't allow updating connector secrets with values like {"ref": "something"}
+	if !restoreSecrets {
+		serr = validateConnectorPatch(patchBytes, ct)
+		if serr != nil {
+			return nil, serr
+		}
+	}
 
-			p, svcErr := presenters.ConvertConnector(resource)
-			if svcErr != nil {
-				return nil, svcErr
-			}
+	patch := public.ConnectorRequest{}
+	serr = PatchResource(resourceJson, contentType, patchBytes, &patch)
+	if serr != nil {
+		return nil, serr
+	}
 
-			svcErr = moveSecretsToVault(p, ct, h.vaultService, false)
-			if svcErr != nil {
-				return nil, svcErr
-			}
+	// get and validate patch operation type
+	var operation phase.ConnectorOperation
+	if operation, serr = h.getOperation(resource, patch); err != nil {
+		return nil, serr
+	}
+	if operation == phase.UnassignConnector && !h.connectorsConfig.ConnectorEnableUnassignedConnectors {
+		return nil, errors.FieldValidationError("Unsupported connector state %s", patch.DesiredState)
+	}
+	if serr = ValidateConnectorOperation(ctx, h.namespaceService, &dbresource.Connector, operation,
+		func(connector *dbapi.Connector) *errors.ServiceError {
+			resource.DesiredState = public.ConnectorDesiredState(dbresource.DesiredState)
+			return nil
+		}); serr != nil {
+		return nil, serr
+	}
 
-			// update connector phase before desired state
-			if originalResource.Status.State != public.ConnectorState(dbapi.ConnectorStatusPhaseAssigning) {
-				dbresource.Status.Phase = phase.ConnectorStartingPhase[operation]
-				p.Status.Phase = dbresource.Status.Phase
-				serr = h.connectorsService.SaveStatus(r.Context(), dbresource.Status)
-				if serr != nil {
-					return nil, serr
-				}
-			}
-			// update modified connector including desired state
-			serr = h.connectorsService.Update(r.Context(), p)
-			if serr != nil {
-				return nil, serr
-			}
+	// But we don't want to allow the user to update ALL fields.. so copy
+	// over the fields that they are allowed to modify..
+	resource.Name = patch.Name
+	resource.Connector = patch.Connector
+	resource.Annotations = patch.Annotations
+	resource.Kafka = patch.Kafka
+	resource.ServiceAccount = patch.ServiceAccount
+	resource.SchemaRegistry = patch.SchemaRegistry
+
+	if h.connectorsConfig.ConnectorEnableUnassignedConnectors {
+		// check namespace id change, from unassigned to assigned and vice versa
+		if operation == phase.AssignConnector && patch.NamespaceId != "" && resource.NamespaceId == "" {
+			resource.NamespaceId = patch.NamespaceId
+		}
+		if operation == phase.UnassignConnector && patch.NamespaceId == "" && resource.NamespaceId != "" {
+			resource.NamespaceId = patch.NamespaceId
+		}
+	}
 
-			newSecrets, err := getSecretRefs(p, ct)
-			if err != nil {
-				return nil, errors.GeneralError("could not get existing secrets: %v", err)
-			}
+	// revalidate
+	user := h.authZService.GetValidationUser(ctx)
+	validations := []connectorValidation{
+		{"/name", handlers.Validation("name", &resource.Name, handlers.MinLen(1), handlers.MaxLen(100))},
+		{"/connector_type_id", handlers.Validation("connector_type_id", &resource.ConnectorTypeId, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength))},
+		{"/service_account/client_id", handlers.Validation("service_account.client_id", &resource.ServiceAccount.ClientId, handlers.MinLen(1))},
+		{"/desired_state", handlers.Validation("desired_state", (*string)(&resource.DesiredState), handlers.IsOneOf(dbapi.ValidDesiredStates...))},
+	}
+	if !dryRun {
+		// dry runs report each patched immutable property separately
+		validations = append(validations, connectorValidation{"", validateConnectorImmutableProperties(patch, originalResource)})
+	}
+	validations = append(validations, connectorValidation{"/annotations", validatePatchAnnotations(resource.Annotations, originalResource.Annotations)})
+	if !dryRun {
+		// dry runs report each problem in the connector spec separately
+		validations = append(validations, connectorValidation{"/connector", validateConnector(h.connectorTypesService, &resource)})
+	}
 
-			staleSecrets := StringListSubtract(originalSecrets, newSecrets...)
-			if len(staleSecrets) > 0 {
-				_ = db.AddPostCommitAction(r.Context(), func() {
-					for _, s := range staleSecrets {
-						err = h.vaultService.DeleteSecretString(s)
-						if err != nil {
-							logger.Logger.Errorf("failed to delete vault secret key '%s': %v", s, err)
-						}
-					}
-				})
-			}
+	// Don't validate user's tenancy in admin api calls
+	if strings.Compare(path, fmt.Sprintf("%s/%s", "/api/connector_mgmt/v1/admin/kafka_connectors", connectorId)) != 0 {
+		validations = append(validations, connectorValidation{"/namespace_id", handlers.Validation("namespace_id", &resource.NamespaceId,
+			handlers.MaxLen(maxConnectorNamespaceIdLength), user.AuthorizedNamespaceUser(errors.ErrorBadRequest))})
+		if resource.Kafka.Id != originalResource.Kafka.Id {
+			validations = append(validations, connectorValidation{"/kafka/id", validateKafkaConnectionSettings(h.kafkaAccess, &resource.Kafka, user.OrgId())})
+		}
+		if resource.ServiceAccount.ClientId != originalResource.ServiceAccount.ClientId ||
+			resource.Kafka.Id != originalResource.Kafka.Id || resource.NamespaceId != originalResource.NamespaceId {
+			validations = append(validations, connectorValidation{"/service_account/client_id", validateServiceAccountScope(ctx, h.serviceAccountScope,
+				h.namespaceService, &resource.ServiceAccount, &resource.Kafka, resource.NamespaceId)})
+		}
+	}
 
-			if err := stripSecretReferences(p, ct); err != nil {
+	if dryRun {
+		// report all problems in the patched connector, instead of the first one
+		violations, serr := getPatchViolations(ct, patch, originalResource, resource, validations)
+		if serr != nil {
+			return nil, serr
+		}
+		if len(violations) > 0 {
+			return nil, connectorViolationsError(violations)
+		}
+	} else {
+		for _, v := range validations {
+			if err := v.validate(); err != nil {
 				return nil, err
 			}
+		}
+	}
 
-			return presenters.PresentConnector(p)
-		},
+	// If we didn't change anything, then just skip the update...
+	if reflect.DeepEqual(originalResource, resource) {
+		return originalResource, nil
 	}
 
-	// return 202 status accepted
-	handlers.Handle(w, r, cfg, http.StatusAccepted)
+	p, svcErr := presenters.ConvertConnector(resource)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if dryRun {
+		if err := stripSecretReferences(p, ct); err != nil {
+			return nil, err
+		}
+		return presenters.PresentConnector(p)
+	}
+
+	svcErr = moveSecretsToVault(p, ct, h.vaultService, false)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	// update connector phase before desired state
+	if originalResource.Status.State != public.ConnectorState(dbapi.ConnectorStatusPhaseAssigning) {
+		dbresource.Status.Phase = phase.ConnectorStartingPhase[operation]
+		// user changes start over automatic restarts of failed connectors
+		dbresource.Status.Reason = ""
+		dbresource.Status.ResetRestarts()
+		p.Status.Phase = dbresource.Status.Phase
+		serr = h.connectorsService.SaveStatus(ctx, dbresource.Status)
+		if serr != nil {
+			return nil, serr
+		}
+	}
+	// update modified connector including desired state, the update is conditional on the version that was read
+	serr = h.connectorsService.Update(ctx, p)
+	if serr != nil {
+		if ifMatchVersion != 0 && serr.IsConflict() {
+			return nil, errors.PreconditionFailed("connector %s has been updated since its version was checked", connectorId)
+		}
+		return nil, serr
+	}
+
+	newSecrets, err := getSecretRefs(p, ct)
+	if err != nil {
+		return nil, errors.GeneralError("could not get existing secrets: %v", err)
+	}
+
+	// record spec changes as a new revision, secrets referenced by retained revisions are kept for rollback
+	if !services.EqualConnectorSpecs(dbresource.ConnectorSpec, p.ConnectorSpec) {
+		pruned, serr := h.revisionsService.Create(ctx, p, user.UserId())
+		if serr != nil {
+			return nil, serr
+		}
+		for _, r := range pruned {
+			originalSecrets = append(originalSecrets, services.GetConnectorSpecSecretRefs(ct, r.ConnectorSpec)...)
+		}
+		retained, serr := h.revisionsService.List(ctx, p.ID)
+		if serr != nil {
+			return nil, serr
+		}
+		for _, r := range retained {
+			newSecrets = append(newSecrets, services.GetConnectorSpecSecretRefs(ct, r.ConnectorSpec)...)
+		}
+	}
+
+	staleSecrets := StringListSubtract(originalSecrets, newSecrets...)
+	if len(staleSecrets) > 0 {
+		_ = db.AddPostCommitAction(ctx, func() {
+			for _, s := range staleSecrets {
+				err = h.vaultService.DeleteSecretString(s)
+				if err != nil {
+					logger.Logger.Errorf("failed to delete vault secret key '%s': %v", s, err)
+				}
+			}
+		})
+	}
+
+	if err := stripSecretReferences(p, ct); err != nil {
+		return nil, err
+	}
+
+	return presenters.PresentConnector(p)
+}
+
+// isDryRun returns true if the request should only be validated, without persisting anything
+func isDryRun(r *http.Request) bool {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	return dryRun
 }
 
 func (h ConnectorsHandler) getOperation(resource public.Connector, patch public.ConnectorRequest) (phase.ConnectorOperation, *errors.ServiceError) {
@@ -396,17 +555,7 @@ func validateConnectorPatch(bytes []byte, ct *dbapi.ConnectorType) *errors.Servi
 func validateConnectorImmutableProperties(patch public.ConnectorRequest, originalResource public.Connector) handlers.Validate {
 	// Check User is not attempting to patch an immutable property
 	return func() *errors.ServiceError {
-		immutableProperties := make([]string, 0, 3)
-		if patch.ConnectorTypeId != originalResource.ConnectorTypeId {
-			immutableProperties = append(immutableProperties, "connector_type_id")
-		}
-		if patch.NamespaceId != originalResource.NamespaceId {
-			immutableProperties = append(immutableProperties, "namespace_id")
-		}
-		if patch.Channel != originalResource.Channel {
-			immutableProperties = append(immutableProperties, "channel")
-		}
-
+		immutableProperties := getPatchedImmutableProperties(patch, originalResource)
 		if len(immutableProperties) != 0 {
 			err := errors.BadRequest("An attempt was made to modify one or more immutable field(s): %s", strings.Join(immutableProperties, ", "))
 			err.HttpCode = 409
@@ -416,6 +565,21 @@ func validateConnectorImmutableProperties(patch public.ConnectorRequest, origina
 	}
 }
 
+// getPatchedImmutableProperties returns the immutable properties of a connector changed by a patch
+func getPatchedImmutableProperties(patch public.ConnectorRequest, originalResource public.Connector) []string {
+	immutableProperties := make([]string, 0, 3)
+	if patch.ConnectorTypeId != originalResource.ConnectorTypeId {
+		immutableProperties = append(immutableProperties, "connector_type_id")
+	}
+	if patch.NamespaceId != originalResource.NamespaceId {
+		immutableProperties = append(immutableProperties, "namespace_id")
+	}
+	if patch.Channel != originalResource.Channel {
+		immutableProperties = append(immutableProperties, "channel")
+	}
+	return immutableProperties
+}
+
 func StringListSubtract(l []string, items ...string) (result []string) {
 	m := make(map[string]struct{}, len(l))
 	for _, x := range l {
@@ -480,6 +644,7 @@ func getResourceJson(resource interface{}) ([]byte, *errors.ServiceError) {
 
 func (h ConnectorsHandler) Get(w http.ResponseWriter, r *http.Request) {
 	connectorId := mux.Vars(r)["connector_id"]
+	var version string
 	cfg := &handlers.HandlerConfig{
 		Validate: []handlers.Validate{
 			handlers.Validation("connector_id", &connectorId, handlers.MinLen(1), handlers.MaxLen(maxConnectorIdLength)),
@@ -489,6 +654,7 @@ func (h ConnectorsHandler) Get(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			version = handlers.VersionFromInt(resource.Version)
 
 			ct, serr := h.connectorTypesService.Get(resource.ConnectorTypeId)
 			if serr != nil {
@@ -502,7 +668,17 @@ func (h ConnectorsHandler) Get(w http.ResponseWriter, r *http.Request) {
 				}
 			}
 
-			return presenters.PresentConnectorWithError(resource)
+			converted, serr := presenters.PresentConnectorWithError(resource)
+			if serr != nil {
+				return nil, serr
+			}
+			if ct != nil {
+				converted.ConnectorTypeDeprecation = presenters.PresentConnectorTypeDeprecation(ct)
+			}
+			return converted, nil
+		},
+		Version: func() string {
+			return version
 		},
 	}
 	handlers.HandleGet(w, r, cfg)
@@ -591,6 +767,9 @@ func (h ConnectorsHandler) List(w http.ResponseWriter, r *http.Request) {
 					glog.Errorf("connector id='%s' presentation failed: %v", resource.ID, err)
 					return nil, errors.GeneralError("internal error")
 				}
+				if ct != nil {
+					converted.ConnectorTypeDeprecation = presenters.PresentConnectorTypeDeprecation(ct)
+				}
 				resourceList.Items = append(resourceList.Items, converted)
 
 			}
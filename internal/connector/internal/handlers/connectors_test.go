@@ -1,9 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
+	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
-	"testing"
 )
 
 func TestValidateConnectorImmutableProperties(t *testing.T) {
@@ -275,3 +283,116 @@ func TestValidateConnectorImmutableProperties(t *testing.T) {
 	}
 
 }
+
+func Test_ConnectorsHandler_Patch_IfMatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		ifMatch      string
+		beforeUpdate func(s *connectorsServiceStub)
+		wantCode     int
+		wantVersion  int64
+	}{
+		{
+			name:        "should patch the connector when If-Match has its version",
+			ifMatch:     `"1"`,
+			wantCode:    http.StatusAccepted,
+			wantVersion: 2,
+		},
+		{
+			name:        "should patch the connector without If-Match",
+			wantCode:    http.StatusAccepted,
+			wantVersion: 2,
+		},
+		{
+			name:        "should fail when If-Match has another version",
+			ifMatch:     `"2"`,
+			wantCode:    http.StatusPreconditionFailed,
+			wantVersion: 1,
+		},
+		{
+			name:    "should fail when the connector is updated after its version is checked",
+			ifMatch: `"1"`,
+			beforeUpdate: func(s *connectorsServiceStub) {
+				s.connector.Version++
+			},
+			wantCode:    http.StatusPreconditionFailed,
+			wantVersion: 2,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h, connectorsService, _, _ := newRevisionsTestHandler(g)
+			if tt.beforeUpdate != nil {
+				connectorsService.beforeUpdate = func() { tt.beforeUpdate(connectorsService) }
+			}
+
+			r := httptest.NewRequest(http.MethodPatch, "/api/connector_mgmt/v1/kafka_connectors/connector",
+				strings.NewReader(`{"connector": {"topic": "b"}}`)).WithContext(newRevisionsTestContext(g))
+			r.Header.Set("Content-Type", MERGE_PATCH)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			h.Patch(w, mux.SetURLVars(r, map[string]string{"connector_id": "connector"}))
+
+			g.Expect(w.Code).To(gomega.Equal(tt.wantCode))
+			g.Expect(connectorsService.connector.Version).To(gomega.Equal(tt.wantVersion))
+		})
+	}
+}
+
+func Test_ConnectorsHandler_Patch_DryRun(t *testing.T) {
+	g := gomega.NewWithT(t)
+	h, connectorsService, _, _ := newRevisionsTestHandler(g)
+
+	r := httptest.NewRequest(http.MethodPatch, "/api/connector_mgmt/v1/kafka_connectors/connector?dry_run=true",
+		strings.NewReader(`{"name": "", "channel": "beta", "connector": {"topic": 1}}`)).WithContext(newRevisionsTestContext(g))
+	r.Header.Set("Content-Type", MERGE_PATCH)
+	w := httptest.NewRecorder()
+	h.Patch(w, mux.SetURLVars(r, map[string]string{"connector_id": "connector"}))
+
+	// all problems are reported instead of the first one
+	g.Expect(w.Code).To(gomega.Equal(http.StatusBadRequest))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring("3 errors encountered"))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring("/channel: "))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring("/name: "))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring("/connector/topic: "))
+	g.Expect(connectorsService.connector.Version).To(gomega.Equal(int64(1)))
+}
+
+func Test_ConnectorTypesHandler_Validate(t *testing.T) {
+	g := gomega.NewWithT(t)
+	connectorsHandler, _, _, _ := newRevisionsTestHandler(g)
+	connectorsHandler.kafkaAccess = &kafkaaccess.ConnectorBindingValidatorMock{
+		ValidateConnectorBindingFunc: func(kafkaID string, orgID string) *errors.ServiceError {
+			return errors.Forbidden("organisation %s can't attach connectors to kafka %s", orgID, kafkaID)
+		},
+	}
+	connectorsHandler.serviceAccountScope = &kafkaaccess.ServiceAccountScopeValidatorMock{
+		ValidateServiceAccountScopeFunc: func(clientID string, kafkaID string, connectorClusterID string) *errors.ServiceError {
+			return errors.Forbidden("service account %s is not bound to kafka %s", clientID, kafkaID)
+		},
+	}
+	h := NewConnectorTypesHandler(connectorsHandler.connectorTypesService, nil, connectorsHandler)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/connector_mgmt/v1/kafka_connector_types/log_sink_0.1/validate",
+		strings.NewReader(`{"connector_type_id": "log_sink_0.1", "namespace_id": "namespace-id",
+			"kafka": {"id": "other-kafka", "url": "kafka:443"},
+			"service_account": {"client_id": "other-client-id", "client_secret": "secret"},
+			"connector": {"topic": "t"}}`)).WithContext(newRevisionsTestContext(g))
+	w := httptest.NewRecorder()
+	h.Validate(w, mux.SetURLVars(r, map[string]string{"connector_type_id": "log_sink_0.1"}))
+
+	// the access to the kafka and the scope of the service account are checked as on creation
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	var result public.ConnectorValidationResult
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &result)).To(gomega.Succeed())
+	g.Expect(result.Valid).To(gomega.BeFalse())
+	pointers := make([]string, 0, len(result.Violations))
+	for _, v := range result.Violations {
+		pointers = append(pointers, v.Pointer)
+	}
+	g.Expect(pointers).To(gomega.ConsistOf("/kafka/id", "/service_account/client_id"))
+}
@@ -0,0 +1,58 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addConnectorNamespaceMigrationTable(migrationId string) *gormigrate.Migration {
+	type ConnectorNamespaceMigration struct {
+		db.Model
+		ConnectorID       string `gorm:"not null;index"`
+		SourceNamespaceID string `gorm:"not null"`
+		SourceClusterID   string `gorm:"not null"`
+		TargetNamespaceID string `gorm:"not null"`
+		TargetClusterID   string `gorm:"not null"`
+		DesiredState      string `gorm:"not null"`
+		Phase             string `gorm:"not null;index"`
+		Reason            string
+		RequestedBy       string
+		DeployingSince    *time.Time
+	}
+
+	type LeaderLease struct {
+		db.Model
+		Leader    string
+		LeaseType string
+		Expires   *time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateTableAction(&ConnectorNamespaceMigration{}),
+		db.FuncAction(func(tx *gorm.DB) error {
+			// leader lease table is shared with the kas-fleet-manager, so create it if needed, but don't drop it on rollback
+			err := tx.Migrator().AutoMigrate(&LeaderLease{})
+			if err != nil {
+				return err
+			}
+			now := time.Now().Add(-time.Minute) //set to a expired time
+			return tx.Create(&api.LeaderLease{
+				Expires:   &now,
+				LeaseType: "connector_migration",
+			}).Error
+		}, func(tx *gorm.DB) error {
+			// The leader lease table may have already been dropped, by the kafka migration rollback, ignore error
+			_ = tx.Where("lease_type = ?", "connector_migration").Delete(&LeaderLease{})
+			return nil
+		}),
+	)
+}
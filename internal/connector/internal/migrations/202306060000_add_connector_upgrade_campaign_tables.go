@@ -0,0 +1,49 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addConnectorUpgradeCampaignTables(migrationId string) *gormigrate.Migration {
+	type ConnectorUpgradeCampaign struct {
+		db.Model
+		ConnectorTypeID string `gorm:"not null"`
+		Channel         string
+		Revision        int64
+		ClusterID       string
+		NamespaceID     string
+		WaveSize        int
+		FailureBudget   int
+		WaveTimeout     time.Duration
+		CurrentWave     int
+		Waves           int
+		Phase           string `gorm:"not null;index"`
+		Reason          string
+		RequestedBy     string
+	}
+	type ConnectorUpgradeTarget struct {
+		db.Model
+		CampaignID          string `gorm:"not null;index"`
+		DeploymentID        string `gorm:"not null;index"`
+		Wave                int
+		FromShardMetadataID int64
+		ToShardMetadataID   int64
+		DeploymentVersion   int64
+		Phase               string `gorm:"not null;index"`
+		Reason              string
+		UpgradingSince      *time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateTableAction(&ConnectorUpgradeCampaign{}),
+		db.CreateTableAction(&ConnectorUpgradeTarget{}),
+	)
+}
@@ -0,0 +1,32 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/ratelimit
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addRateLimitBucketsTable(migrationId string) *gormigrate.Migration {
+	type RateLimitBucket struct {
+		ID      string `gorm:"primaryKey"`
+		Tat     int64  `gorm:"not null;index"`
+		Allowed bool   `gorm:"not null"`
+	}
+
+	return &gormigrate.Migration{
+		ID: migrationId,
+		Migrate: func(tx *gorm.DB) error {
+			// We don't want to delete the rate limit buckets table on rollback because it's shared with the kas-fleet-manager
+			// so we just create it here if it does not exist yet.. but we don't drop it on rollback.
+			return tx.AutoMigrate(&RateLimitBucket{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The buckets are shared by the requests of both APIs, there are no rows owned by the connectors to remove
+			return nil
+		},
+	}
+}
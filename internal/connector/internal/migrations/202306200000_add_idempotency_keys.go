@@ -0,0 +1,37 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/handlers
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addIdempotencyKeysTable(migrationId string) *gormigrate.Migration {
+	type IdempotencyKey struct {
+		ID           string `gorm:"primaryKey"`
+		RequestHash  string `gorm:"not null"`
+		StatusCode   int    `gorm:"not null"`
+		ResponseBody []byte
+		CreatedAt    time.Time `gorm:"not null"`
+		ExpiresAt    time.Time `gorm:"not null;index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: migrationId,
+		Migrate: func(tx *gorm.DB) error {
+			// We don't want to delete the idempotency keys table on rollback because it's shared with the kas-fleet-manager
+			// so we just create it here if it does not exist yet.. but we don't drop it on rollback.
+			return tx.AutoMigrate(&IdempotencyKey{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The keys are hashed and can't be told apart from the ones of the kafka requests, they expire on their own
+			return nil
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/workers
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addWorkerShardLeasesTables(migrationId string) *gormigrate.Migration {
+	type WorkerShardLease struct {
+		WorkerType string    `gorm:"primaryKey"`
+		Shard      int       `gorm:"primaryKey;autoIncrement:false"`
+		Owner      string    `gorm:"not null;index"`
+		Expires    time.Time `gorm:"not null"`
+	}
+	type WorkerReplica struct {
+		WorkerType string    `gorm:"primaryKey"`
+		WorkerId   string    `gorm:"primaryKey"`
+		Expires    time.Time `gorm:"not null;index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: migrationId,
+		Migrate: func(tx *gorm.DB) error {
+			// We don't want to delete the worker shard tables on rollback because they're shared with the kas-fleet-manager
+			// so we just create them here if they do not exist yet.. but we don't drop them on rollback.
+			return tx.AutoMigrate(&WorkerShardLease{}, &WorkerReplica{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The tables may have already been dropped, by the kafka migration rollback, ignore error.
+			// All the connector worker types are prefixed with connector.
+			_ = tx.Where("worker_type LIKE ?", "connector%").Delete(&WorkerShardLease{})
+			_ = tx.Where("worker_type LIKE ?", "connector%").Delete(&WorkerReplica{})
+			return nil
+		},
+	}
+}
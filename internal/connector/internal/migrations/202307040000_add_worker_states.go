@@ -0,0 +1,40 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/workers
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addWorkerStatesTable(migrationId string) *gormigrate.Migration {
+	type WorkerState struct {
+		WorkerType            string `gorm:"primaryKey"`
+		Paused                bool   `gorm:"not null;default:false"`
+		LastReconcileStart    *time.Time
+		LastReconcileEnd      *time.Time
+		LastReconcileWorkerId string
+		LastErrors            string `gorm:"type:jsonb"`
+		UpdatedAt             time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: migrationId,
+		Migrate: func(tx *gorm.DB) error {
+			// We don't want to delete the worker states table on rollback because it's shared with the kas-fleet-manager
+			// so we just create it here if it does not exist yet.. but we don't drop it on rollback.
+			return tx.AutoMigrate(&WorkerState{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The table may have already been dropped, by the kafka migration rollback, ignore error.
+			// All the connector worker types are prefixed with connector.
+			_ = tx.Where("worker_type LIKE ?", "connector%").Delete(&WorkerState{})
+			return nil
+		},
+	}
+}
@@ -50,6 +50,16 @@ var migrations = []*gormigrate.Migration{
 	renameNamespaceProfileAnnotations("202211280000"),
 	addOrgIDAnnotations("202212050000"),
 	addConnectorTypeDeprecated("202301180000"),
+	addConnectorStatusReason("202305020000"),
+	addConnectorNamespaceMigrationTable("202305090000"),
+	addConnectorStatusRestarts("202305160000"),
+	addConnectorRevisionsTable("202305230000"),
+	addConnectorCatalogsTable("202305300000"),
+	addConnectorUpgradeCampaignTables("202306060000"),
+	addRateLimitBucketsTable("202306130000"),
+	addIdempotencyKeysTable("202306200000"),
+	addWorkerShardLeasesTables("202306270000"),
+	addWorkerStatesTable("202307040000"),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
@@ -80,7 +80,9 @@ func PresentConnectorWithError(from *dbapi.ConnectorWithConditions) (public.Conn
 		if err != nil {
 			return public.Connector{}, errors.GeneralError("invalid conditions: %v", err)
 		}
-		connector.Status.Error = getStatusError(conditions)
+		if statusError := getStatusError(conditions); statusError != "" {
+			connector.Status.Error = statusError
+		}
 	}
 
 	return connector, nil
@@ -104,6 +106,7 @@ func PresentConnectorAdminView(from *dbapi.ConnectorWithConditions) (admin.Conne
 		ConnectorTypeId: from.ConnectorTypeId,
 		Status: admin.ConnectorStatusStatus{
 			State: admin.ConnectorState(from.Status.Phase),
+			Error: from.Status.Reason,
 		},
 		DesiredState: admin.ConnectorDesiredState(from.DesiredState),
 		Channel:      admin.Channel(from.Channel),
@@ -184,6 +187,7 @@ func PresentConnector(from *dbapi.Connector) (public.Connector, *errors.ServiceE
 		Annotations:     PresentConnectorAnnotations(from.Annotations),
 		Status: public.ConnectorStatusStatus{
 			State: public.ConnectorState(from.Status.Phase),
+			Error: from.Status.Reason,
 		},
 		DesiredState: public.ConnectorDesiredState(from.DesiredState),
 		Channel:      public.Channel(from.Channel),
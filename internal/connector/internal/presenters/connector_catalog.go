@@ -0,0 +1,36 @@
+package presenters
+
+import (
+	"encoding/json"
+
+	admin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+func PresentConnectorCatalogAdminView(from *dbapi.ConnectorCatalog) (admin.ConnectorCatalogAdminView, *errors.ServiceError) {
+	var entries []config.ConnectorCatalogEntry
+	if err := json.Unmarshal(from.CatalogEntries, &entries); err != nil {
+		return admin.ConnectorCatalogAdminView{}, errors.GeneralError("failed to unmarshal connector catalog entries: %v", err)
+	}
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ConnectorType.Id
+	}
+
+	result := admin.ConnectorCatalogAdminView{
+		Id:               from.ID,
+		CreatedAt:        from.CreatedAt,
+		ModifiedAt:       from.UpdatedAt,
+		Source:           from.Source,
+		Revision:         from.Revision,
+		Checksum:         from.Checksum,
+		ConnectorTypeIds: ids,
+	}
+	reference := PresentReference(result.Id, result)
+	result.Kind = reference.Kind
+	result.Href = reference.Href
+
+	return result, nil
+}
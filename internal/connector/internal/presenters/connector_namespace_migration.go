@@ -0,0 +1,28 @@
+package presenters
+
+import (
+	admin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+)
+
+func PresentConnectorNamespaceMigration(from *dbapi.ConnectorNamespaceMigration) admin.ConnectorNamespaceMigration {
+	result := admin.ConnectorNamespaceMigration{
+		Id:                from.ID,
+		CreatedAt:         from.CreatedAt,
+		ModifiedAt:        from.UpdatedAt,
+		ConnectorId:       from.ConnectorID,
+		SourceNamespaceId: from.SourceNamespaceID,
+		SourceClusterId:   from.SourceClusterID,
+		TargetNamespaceId: from.TargetNamespaceID,
+		TargetClusterId:   from.TargetClusterID,
+		DesiredState:      admin.ConnectorDesiredState(from.DesiredState),
+		Phase:             string(from.Phase),
+		Reason:            from.Reason,
+		RequestedBy:       from.RequestedBy,
+	}
+	reference := PresentReference(result.Id, result)
+	result.Kind = reference.Kind
+	result.Href = reference.Href
+
+	return result
+}
@@ -0,0 +1,30 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// PresentConnectorRevision presents a connector revision, secrets must be redacted from the revision spec by the caller
+func PresentConnectorRevision(from *dbapi.ConnectorRevision) (public.ConnectorRevision, *errors.ServiceError) {
+	spec := make(map[string]interface{})
+	if err := from.ConnectorSpec.Unmarshal(&spec); err != nil {
+		return public.ConnectorRevision{}, errors.GeneralError("invalid connector spec in revision %d: %v", from.Revision, err)
+	}
+
+	result := public.ConnectorRevision{
+		ConnectorId: from.ConnectorID,
+		Revision:    from.Revision,
+		CreatedAt:   from.CreatedAt,
+		Author:      from.Author,
+		Connector:   spec,
+	}
+	reference := PresentReference(strconv.FormatInt(result.Revision, 10), result)
+	result.Kind = reference.Kind
+	result.Href = reference.Href
+
+	return result, nil
+}
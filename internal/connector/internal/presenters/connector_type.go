@@ -6,6 +6,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang/glog"
 )
 
 func toStringSlice(channels []public.Channel) []string {
@@ -87,6 +88,7 @@ func PresentConnectorType(from *dbapi.ConnectorType) (*public.ConnectorType, err
 		Name:         from.Name,
 		Version:      from.Version,
 		Deprecated:   from.Deprecated,
+		Deprecation:  PresentConnectorTypeDeprecation(from),
 		Description:  from.Description,
 		FeaturedRank: from.FeaturedRank,
 		Schema:       schemaDom,
@@ -98,6 +100,29 @@ func PresentConnectorType(from *dbapi.ConnectorType) (*public.ConnectorType, err
 	}, nil
 }
 
+// PresentConnectorTypeDeprecation returns deprecation details for deprecated connector types, or nil
+func PresentConnectorTypeDeprecation(from *dbapi.ConnectorType) *public.ConnectorTypeDeprecation {
+	if !from.Deprecated {
+		return nil
+	}
+	deprecation, err := from.GetDeprecation()
+	if err != nil {
+		// annotations are validated when the catalog is loaded
+		glog.Warningf("invalid deprecation annotations for connector type %s: %v", from.ID, err)
+	}
+	if deprecation == nil {
+		return &public.ConnectorTypeDeprecation{
+			Message: "Connector type was removed from the catalog",
+		}
+	}
+	return &public.ConnectorTypeDeprecation{
+		SunsetAt:          deprecation.SunsetAt,
+		CreateCutoffAt:    deprecation.CreateCutoffAt,
+		ReplacementTypeId: deprecation.ReplacementTypeID,
+		Message:           deprecation.Message,
+	}
+}
+
 func PresentTypeAnnotations(annotations []dbapi.ConnectorTypeAnnotation) map[string]string {
 	res := make(map[string]string, len(annotations))
 	for _, ann := range annotations {
@@ -127,6 +152,15 @@ func PresentConnectorTypeAdminView(from dbapi.ConnectorCatalogEntry) (*admin.Con
 		Channels:     make(map[string]admin.ConnectorTypeChannel),
 	}
 
+	if deprecation := PresentConnectorTypeDeprecation(from.ConnectorType); deprecation != nil {
+		view.Deprecation = &admin.ConnectorTypeDeprecation{
+			SunsetAt:          deprecation.SunsetAt,
+			CreateCutoffAt:    deprecation.CreateCutoffAt,
+			ReplacementTypeId: deprecation.ReplacementTypeId,
+			Message:           deprecation.Message,
+		}
+	}
+
 	for i, l := range from.ConnectorType.Labels {
 		view.Labels[i] = l.Label
 	}
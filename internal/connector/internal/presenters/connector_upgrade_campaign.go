@@ -0,0 +1,45 @@
+package presenters
+
+import (
+	admin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+)
+
+func PresentConnectorUpgradeCampaign(from *dbapi.ConnectorUpgradeCampaign) admin.ConnectorUpgradeCampaign {
+	progress := admin.ConnectorUpgradeCampaignProgress{
+		Pending:    int32(from.Progress[dbapi.ConnectorUpgradeTargetPhasePending]),
+		Upgrading:  int32(from.Progress[dbapi.ConnectorUpgradeTargetPhaseUpgrading]),
+		Upgraded:   int32(from.Progress[dbapi.ConnectorUpgradeTargetPhaseUpgraded]),
+		Failed:     int32(from.Progress[dbapi.ConnectorUpgradeTargetPhaseFailed]),
+		RolledBack: int32(from.Progress[dbapi.ConnectorUpgradeTargetPhaseRolledBack]),
+		Skipped:    int32(from.Progress[dbapi.ConnectorUpgradeTargetPhaseSkipped]),
+	}
+	for _, count := range from.Progress {
+		progress.Total += int32(count)
+	}
+
+	result := admin.ConnectorUpgradeCampaign{
+		Id:              from.ID,
+		CreatedAt:       from.CreatedAt,
+		ModifiedAt:      from.UpdatedAt,
+		ConnectorTypeId: from.ConnectorTypeID,
+		Channel:         from.Channel,
+		Revision:        from.Revision,
+		ClusterId:       from.ClusterID,
+		NamespaceId:     from.NamespaceID,
+		WaveSize:        int32(from.WaveSize),
+		FailureBudget:   int32(from.FailureBudget),
+		WaveTimeout:     from.WaveTimeout.String(),
+		CurrentWave:     int32(from.CurrentWave),
+		Waves:           int32(from.Waves),
+		Phase:           string(from.Phase),
+		Reason:          from.Reason,
+		RequestedBy:     from.RequestedBy,
+		Progress:        progress,
+	}
+	reference := PresentReference(result.Id, result)
+	result.Kind = reference.Kind
+	result.Href = reference.Href
+
+	return result
+}
@@ -5,6 +5,7 @@ import (
 
 	admin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/compat"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
@@ -15,6 +16,8 @@ const (
 	KindConnector = "Connector"
 	// KindConnectorAdminView is a string identifier for the type admin.ConnectorAdminView
 	KindConnectorAdminView = "ConnectorAdminView"
+	// KindConnectorCatalogAdminView is a string identifier for the type admin.ConnectorCatalogAdminView
+	KindConnectorCatalogAdminView = "ConnectorCatalogAdminView"
 	// KindConnectorCluster is a string identifier for the type dbapi.ConnectorCluster
 	KindConnectorCluster = "ConnectorCluster"
 	// KindConnectorDeployment is a string identifier for the type dbapi.ConnectorDeployment
@@ -23,6 +26,14 @@ const (
 	KindConnectorDeploymentAdminView = "ConnectorDeploymentAdminView"
 	// KindConnectorNamespace is a string identifier for the type dbapi.ConnectorNamespace
 	KindConnectorNamespace = "ConnectorNamespace"
+	// KindConnectorNamespaceMigration is a string identifier for the type admin.ConnectorNamespaceMigration
+	KindConnectorNamespaceMigration = "ConnectorNamespaceMigration"
+	// KindConnectorRevision is a string identifier for the type public.ConnectorRevision
+	KindConnectorRevision = "ConnectorRevision"
+	// KindConnectorRevisionDiff is a string identifier for the type public.ConnectorRevisionDiff
+	KindConnectorRevisionDiff = "ConnectorRevisionDiff"
+	// KindConnectorUpgradeCampaign is a string identifier for the type admin.ConnectorUpgradeCampaign
+	KindConnectorUpgradeCampaign = "ConnectorUpgradeCampaign"
 	// KindConnectorType is a string identifier for the type dbapi.ConnectorType
 	KindConnectorType = "ConnectorType"
 	// ConnectorTypeAdminView is a string identifier for the type admin.ConnectorTypeAdminView
@@ -41,6 +52,8 @@ func objectKind(i interface{}) string {
 		return KindConnector
 	case admin.ConnectorAdminView, *admin.ConnectorAdminView:
 		return KindConnectorAdminView
+	case admin.ConnectorCatalogAdminView, *admin.ConnectorCatalogAdminView:
+		return KindConnectorCatalogAdminView
 	case dbapi.ConnectorCluster, *dbapi.ConnectorCluster:
 		return KindConnectorCluster
 	case dbapi.ConnectorDeployment, *dbapi.ConnectorDeployment:
@@ -49,6 +62,14 @@ func objectKind(i interface{}) string {
 		return KindConnectorDeploymentAdminView
 	case dbapi.ConnectorNamespace, *dbapi.ConnectorNamespace:
 		return KindConnectorNamespace
+	case admin.ConnectorNamespaceMigration, *admin.ConnectorNamespaceMigration:
+		return KindConnectorNamespaceMigration
+	case public.ConnectorRevision, *public.ConnectorRevision:
+		return KindConnectorRevision
+	case public.ConnectorRevisionDiff, *public.ConnectorRevisionDiff:
+		return KindConnectorRevisionDiff
+	case admin.ConnectorUpgradeCampaign, *admin.ConnectorUpgradeCampaign:
+		return KindConnectorUpgradeCampaign
 	case dbapi.ConnectorType, *dbapi.ConnectorType:
 		return KindConnectorType
 	case admin.ConnectorTypeAdminView:
@@ -70,6 +91,8 @@ func objectPath(id string, obj interface{}) string {
 		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_types/%s", id)
 	case admin.ConnectorTypeAdminView:
 		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connector_types/%s", id)
+	case admin.ConnectorCatalogAdminView, *admin.ConnectorCatalogAdminView:
+		return "/api/connector_mgmt/v1/admin/kafka_connector_catalog"
 	case dbapi.ConnectorCluster, *dbapi.ConnectorCluster:
 		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_clusters/%s", id)
 	case dbapi.ConnectorDeployment:
@@ -82,6 +105,16 @@ func objectPath(id string, obj interface{}) string {
 		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connector_clusters/%s/deployments/%s", obj.Spec.ClusterId, id)
 	case dbapi.ConnectorNamespace, *dbapi.ConnectorNamespace:
 		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_namespaces/%s", id)
+	case admin.ConnectorNamespaceMigration:
+		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connectors/%s/migrations/%s", obj.ConnectorId, id)
+	case *admin.ConnectorNamespaceMigration:
+		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connectors/%s/migrations/%s", obj.ConnectorId, id)
+	case admin.ConnectorUpgradeCampaign, *admin.ConnectorUpgradeCampaign:
+		return fmt.Sprintf("/api/connector_mgmt/v1/admin/kafka_connector_upgrade_campaigns/%s", id)
+	case public.ConnectorRevision:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s/revisions/%s", obj.ConnectorId, id)
+	case *public.ConnectorRevision:
+		return fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s/revisions/%s", obj.ConnectorId, id)
 	default:
 		return ""
 	}
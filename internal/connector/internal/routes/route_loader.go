@@ -14,6 +14,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	kerrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	coreHandlers "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/ratelimit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/goava/di"
@@ -30,6 +31,8 @@ type options struct {
 	ServerConfig              *server.ServerConfig
 	ErrorsHandler             *coreHandlers.ErrorHandler
 	AuthorizeMiddleware       *acl.AccessControlListMiddleware
+	RateLimitMiddleware       *ratelimit.RateLimitMiddleware
+	IdempotencyMiddleware     *coreHandlers.IdempotencyMiddleware
 	KeycloakService           sso.KafkaKeycloakService
 	AuthAgentService          auth.AuthAgentService
 	ConnectorAdminHandler     *handlers.ConnectorAdminHandler
@@ -48,6 +51,8 @@ func NewRouteLoader(s options) environments.RouteLoader {
 func (s *options) AddRoutes(mainRouter *mux.Router) error {
 
 	authorizeMiddleware := s.AuthorizeMiddleware.Authorize
+	rateLimitMiddleware := s.RateLimitMiddleware.RateLimit
+	idempotent := s.IdempotencyMiddleware.Idempotent
 	requireOrgID := auth.NewRequireOrgIDMiddleware().RequireOrgID(kerrors.ErrorUnauthenticated)
 
 	openAPIDefinitions, err := shared.LoadOpenAPISpecFromYAML(openapicontents.ConnectorMgmtOpenAPIYAMLBytes())
@@ -79,9 +84,11 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	apiV1ConnectorTypesRouter := apiV1Router.PathPrefix("/kafka_connector_types").Subrouter()
 	apiV1ConnectorTypesRouter.HandleFunc("/labels", s.ConnectorTypesHandler.ListLabels).Methods(http.MethodGet)
 	apiV1ConnectorTypesRouter.HandleFunc("/{connector_type_id}", s.ConnectorTypesHandler.Get).Methods(http.MethodGet)
+	apiV1ConnectorTypesRouter.HandleFunc("/{connector_type_id}/validate", s.ConnectorTypesHandler.Validate).Methods(http.MethodPost)
 	apiV1ConnectorTypesRouter.HandleFunc("", s.ConnectorTypesHandler.List).Methods(http.MethodGet)
 	apiV1ConnectorTypesRouter.Use(authorizeMiddleware)
 	apiV1ConnectorTypesRouter.Use(requireOrgID)
+	apiV1ConnectorTypesRouter.Use(rateLimitMiddleware)
 
 	//  /api/connector_mgmt/v1/kafka_connectors
 	v1Collections = append(v1Collections, api.CollectionMetadata{
@@ -90,13 +97,18 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	})
 
 	apiV1ConnectorsRouter := apiV1Router.PathPrefix("/kafka_connectors").Subrouter()
-	apiV1ConnectorsRouter.HandleFunc("", s.ConnectorsHandler.Create).Methods(http.MethodPost)
+	apiV1ConnectorsRouter.Handle("", idempotent(http.HandlerFunc(s.ConnectorsHandler.Create))).Methods(http.MethodPost)
 	apiV1ConnectorsRouter.HandleFunc("", s.ConnectorsHandler.List).Methods(http.MethodGet)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Get).Methods(http.MethodGet)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Patch).Methods(http.MethodPatch)
 	apiV1ConnectorsRouter.HandleFunc("/{connector_id}", s.ConnectorsHandler.Delete).Methods(http.MethodDelete)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/revisions", s.ConnectorsHandler.ListRevisions).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/revisions/{revision}", s.ConnectorsHandler.GetRevision).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/revisions/{revision}/diff", s.ConnectorsHandler.DiffRevisions).Methods(http.MethodGet)
+	apiV1ConnectorsRouter.HandleFunc("/{connector_id}/revisions/{revision}/rollback", s.ConnectorsHandler.RollbackRevision).Methods(http.MethodPost)
 	apiV1ConnectorsRouter.Use(authorizeMiddleware)
 	apiV1ConnectorsRouter.Use(requireOrgID)
+	apiV1ConnectorsRouter.Use(rateLimitMiddleware)
 
 	//  /api/connector_mgmt/v1/kafka_connector_clusters
 	v1Collections = append(v1Collections, api.CollectionMetadata{
@@ -105,7 +117,7 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	})
 
 	apiV1ConnectorClustersRouter := apiV1Router.PathPrefix("/kafka_connector_clusters").Subrouter()
-	apiV1ConnectorClustersRouter.HandleFunc("", s.ConnectorClusterHandler.Create).Methods(http.MethodPost)
+	apiV1ConnectorClustersRouter.Handle("", idempotent(http.HandlerFunc(s.ConnectorClusterHandler.Create))).Methods(http.MethodPost)
 	apiV1ConnectorClustersRouter.HandleFunc("", s.ConnectorClusterHandler.List).Methods(http.MethodGet)
 	apiV1ConnectorClustersRouter.HandleFunc("/{connector_cluster_id}", s.ConnectorClusterHandler.Get).Methods(http.MethodGet)
 	apiV1ConnectorClustersRouter.HandleFunc("/{connector_cluster_id}", s.ConnectorClusterHandler.Update).Methods(http.MethodPut)
@@ -114,6 +126,7 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	apiV1ConnectorClustersRouter.HandleFunc("/{connector_cluster_id}/namespaces", s.ConnectorClusterHandler.GetNamespaces).Methods(http.MethodGet)
 	apiV1ConnectorClustersRouter.Use(authorizeMiddleware)
 	apiV1ConnectorClustersRouter.Use(requireOrgID)
+	apiV1ConnectorClustersRouter.Use(rateLimitMiddleware)
 
 	//  /api/connector_mgmt/v1/kafka_connector_namespaces
 	v1Collections = append(v1Collections, api.CollectionMetadata{
@@ -136,6 +149,7 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	}
 	apiV1ConnectorNamespacesRouter.Use(authorizeMiddleware)
 	apiV1ConnectorNamespacesRouter.Use(requireOrgID)
+	apiV1ConnectorNamespacesRouter.Use(rateLimitMiddleware)
 
 	// This section adds the API's accessed by the connector agent...
 	{
@@ -175,6 +189,13 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.GetConnector).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.DeleteConnector).Methods(http.MethodDelete)
 	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.PatchConnector).Methods(http.MethodPatch)
+	adminRouter.HandleFunc("/kafka_connectors/{connector_id}/migrations", s.ConnectorAdminHandler.GetConnectorNamespaceMigrations).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connectors/{connector_id}/migrations", s.ConnectorAdminHandler.CreateConnectorNamespaceMigration).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_connector_catalog", s.ConnectorAdminHandler.GetConnectorCatalog).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_upgrade_campaigns", s.ConnectorAdminHandler.ListConnectorUpgradeCampaigns).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_upgrade_campaigns", s.ConnectorAdminHandler.CreateConnectorUpgradeCampaign).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_connector_upgrade_campaigns/{campaign_id}", s.ConnectorAdminHandler.GetConnectorUpgradeCampaign).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_upgrade_campaigns/{campaign_id}/rollback", s.ConnectorAdminHandler.RollbackConnectorUpgradeCampaign).Methods(http.MethodPost)
 	adminRouter.HandleFunc("/kafka_connector_types", s.ConnectorAdminHandler.ListConnectorTypes).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_types/{connector_type_id}", s.ConnectorAdminHandler.GetConnectorType).Methods(http.MethodGet)
 
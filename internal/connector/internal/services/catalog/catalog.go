@@ -0,0 +1,106 @@
+// Package catalog loads connector catalogs published by remote sources, i.e. http(s) catalog indexes or OCI artifacts,
+// and verifies their signatures.
+package catalog
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+)
+
+// Catalog is a connector catalog document
+type Catalog struct {
+	Revision       string                         `json:"revision"`
+	ConnectorTypes []config.ConnectorCatalogEntry `json:"connector_types"`
+}
+
+// Document is a catalog document and its detached signature as published by a Source
+type Document struct {
+	Content   []byte
+	Signature []byte
+}
+
+// Checksum returns the sha256 digest of the document content
+func (d *Document) Checksum() string {
+	return fmt.Sprintf("%x", sha256.Sum256(d.Content))
+}
+
+// Source fetches a catalog document from a remote location
+type Source interface {
+	Fetch(ctx context.Context) (*Document, error)
+}
+
+// Load fetches a catalog document from source, verifies its signature with key and parses it
+func Load(ctx context.Context, source Source, key crypto.PublicKey) (*Catalog, *Document, error) {
+	document, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := Verify(key, document.Content, document.Signature); err != nil {
+		return nil, nil, err
+	}
+	catalog, err := Parse(document.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return catalog, document, nil
+}
+
+// Parse parses and validates a catalog document
+func Parse(content []byte) (*Catalog, error) {
+	var catalog Catalog
+	if err := json.Unmarshal(content, &catalog); err != nil {
+		return nil, fmt.Errorf("error unmarshaling connector catalog: %v", err)
+	}
+	if catalog.Revision == "" {
+		return nil, fmt.Errorf("connector catalog has no revision")
+	}
+	ids := make(map[string]struct{}, len(catalog.ConnectorTypes))
+	for i, entry := range catalog.ConnectorTypes {
+		id := entry.ConnectorType.Id
+		if id == "" {
+			return nil, fmt.Errorf("connector catalog entry %d has no connector type id", i)
+		}
+		if _, found := ids[id]; found {
+			return nil, fmt.Errorf("connector type '%s' defined more than once in connector catalog", id)
+		}
+		ids[id] = struct{}{}
+		if err := catalog.ConnectorTypes[i].ApplyDeprecation(); err != nil {
+			return nil, err
+		}
+	}
+	return &catalog, nil
+}
+
+// Verify checks a detached signature of content, ed25519 signatures are over the content,
+// ECDSA (ASN.1) and RSA (PKCS #1 v1.5) signatures are over the sha256 digest of the content
+func Verify(key crypto.PublicKey, content []byte, signature []byte) error {
+	if len(signature) == 0 {
+		return fmt.Errorf("connector catalog is not signed")
+	}
+	digest := sha256.Sum256(content)
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, content, signature) {
+			return fmt.Errorf("invalid connector catalog signature")
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest[:], signature) {
+			return fmt.Errorf("invalid connector catalog signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("invalid connector catalog signature")
+		}
+	default:
+		return fmt.Errorf("unsupported connector catalog signing key type %T", key)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package catalog
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+		wantIds []string
+	}{
+		{
+			name:    "valid catalog",
+			content: `{"revision":"1","connector_types":[{"connector_type":{"id":"a"}},{"connector_type":{"id":"b"},"channels":{"stable":{}}}]}`,
+			wantIds: []string{"a", "b"},
+		},
+		{
+			name:    "empty catalog",
+			content: `{"revision":"1"}`,
+		},
+		{
+			name:    "invalid json",
+			content: `[`,
+			wantErr: "error unmarshaling connector catalog: .*",
+		},
+		{
+			name:    "missing revision",
+			content: `{"connector_types":[{"connector_type":{"id":"a"}}]}`,
+			wantErr: "connector catalog has no revision",
+		},
+		{
+			name:    "missing id",
+			content: `{"revision":"1","connector_types":[{"connector_type":{"id":"a"}},{"connector_type":{}}]}`,
+			wantErr: "connector catalog entry 1 has no connector type id",
+		},
+		{
+			name:    "duplicate id",
+			content: `{"revision":"1","connector_types":[{"connector_type":{"id":"a"}},{"connector_type":{"id":"a"}}]}`,
+			wantErr: "connector type 'a' defined more than once in connector catalog",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			catalog, err := Parse([]byte(tt.content))
+			if tt.wantErr != "" {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Error()).To(gomega.MatchRegexp("^" + tt.wantErr + "$"))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			var ids []string
+			for _, entry := range catalog.ConnectorTypes {
+				ids = append(ids, entry.ConnectorType.Id)
+			}
+			g.Expect(ids).To(gomega.Equal(tt.wantIds))
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	g := gomega.NewWithT(t)
+	content := []byte(`{"revision":"1"}`)
+	digest := sha256.Sum256(content)
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	ecSignature, err := ecdsa.SignASN1(rand.Reader, ecPrivate, digest[:])
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaPrivate, crypto.SHA256, digest[:])
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	tests := []struct {
+		name      string
+		key       crypto.PublicKey
+		content   []byte
+		signature []byte
+		wantErr   string
+	}{
+		{
+			name:      "ed25519",
+			key:       edPublic,
+			content:   content,
+			signature: ed25519.Sign(edPrivate, content),
+		},
+		{
+			name:      "ecdsa",
+			key:       &ecPrivate.PublicKey,
+			content:   content,
+			signature: ecSignature,
+		},
+		{
+			name:      "rsa",
+			key:       &rsaPrivate.PublicKey,
+			content:   content,
+			signature: rsaSignature,
+		},
+		{
+			name:      "modified content",
+			key:       edPublic,
+			content:   []byte(`{"revision":"2"}`),
+			signature: ed25519.Sign(edPrivate, content),
+			wantErr:   "invalid connector catalog signature",
+		},
+		{
+			name:      "wrong key",
+			key:       &rsaPrivate.PublicKey,
+			content:   content,
+			signature: ecSignature,
+			wantErr:   "invalid connector catalog signature",
+		},
+		{
+			name:    "missing signature",
+			key:     edPublic,
+			content: content,
+			wantErr: "connector catalog is not signed",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := Verify(tt.key, tt.content, tt.signature)
+			if tt.wantErr != "" {
+				g.Expect(err).To(gomega.MatchError(tt.wantErr))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+		})
+	}
+}
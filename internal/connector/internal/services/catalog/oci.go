@@ -0,0 +1,174 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	// CatalogMediaType is the media type of the catalog document layer in a catalog OCI artifact
+	CatalogMediaType = "application/vnd.bf2.connector-catalog.v1+json"
+	// SignatureMediaType is the media type of the base64 encoded catalog signature layer in a catalog OCI artifact
+	SignatureMediaType = "application/vnd.bf2.connector-catalog.signature.v1"
+
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+)
+
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// ociSource pulls catalog artifacts using the OCI distribution API, with anonymous bearer tokens when the registry requires them
+type ociSource struct {
+	registry   string
+	repository string
+	reference  string
+	client     *http.Client
+	token      string
+}
+
+func newOCISource(u *url.URL, client *http.Client) (*ociSource, error) {
+	path := strings.TrimPrefix(u.Path, "/")
+	repository, reference := path, "latest"
+	if i := strings.Index(path, "@"); i >= 0 {
+		repository, reference = path[:i], path[i+1:]
+	} else if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		repository, reference = path[:i], path[i+1:]
+	}
+	if u.Host == "" || repository == "" || reference == "" {
+		return nil, fmt.Errorf("invalid connector catalog artifact reference %q, expected oci://registry/repository:tag", u.String())
+	}
+	return &ociSource{
+		registry:   u.Host,
+		repository: repository,
+		reference:  reference,
+		client:     client,
+	}, nil
+}
+
+func (s *ociSource) Fetch(ctx context.Context) (*Document, error) {
+	manifestBytes, err := s.get(ctx, s.url("manifests", s.reference), ociManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("error unmarshaling connector catalog artifact manifest: %v", err)
+	}
+
+	document := &Document{}
+	for _, layer := range manifest.Layers {
+		switch layer.MediaType {
+		case CatalogMediaType:
+			if document.Content, err = s.blob(ctx, layer.Digest); err != nil {
+				return nil, err
+			}
+		case SignatureMediaType:
+			signature, err := s.blob(ctx, layer.Digest)
+			if err != nil {
+				return nil, err
+			}
+			if document.Signature, err = decodeSignature(signature); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if document.Content == nil {
+		return nil, fmt.Errorf("connector catalog artifact %s/%s:%s has no %s layer", s.registry, s.repository, s.reference, CatalogMediaType)
+	}
+	return document, nil
+}
+
+func (s *ociSource) url(kind string, reference string) string {
+	return fmt.Sprintf("https://%s/v2/%s/%s/%s", s.registry, s.repository, kind, reference)
+}
+
+func (s *ociSource) blob(ctx context.Context, digest string) ([]byte, error) {
+	content, err := s.get(ctx, s.url("blobs", digest), "")
+	if err != nil {
+		return nil, err
+	}
+	if actual := fmt.Sprintf("sha256:%x", sha256.Sum256(content)); actual != digest {
+		return nil, fmt.Errorf("connector catalog artifact blob digest mismatch, expected %s got %s", digest, actual)
+	}
+	return content, nil
+}
+
+func (s *ociSource) get(ctx context.Context, url string, accept string) ([]byte, error) {
+	header := http.Header{}
+	if accept != "" {
+		header.Set("Accept", accept)
+	}
+	if s.token != "" {
+		header.Set("Authorization", "Bearer "+s.token)
+	}
+	content, err := get(ctx, s.client, url, header)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.response.StatusCode == http.StatusUnauthorized {
+		// get a new token, the previous one may have expired
+		if s.token, err = s.authenticate(ctx, statusErr.response.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+		header.Set("Authorization", "Bearer "+s.token)
+		return get(ctx, s.client, url, header)
+	}
+	return content, err
+}
+
+// authenticate gets an anonymous pull token as described by a registry bearer challenge
+func (s *ociSource) authenticate(ctx context.Context, challenge string) (string, error) {
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return "", fmt.Errorf("unsupported connector catalog registry %s authentication challenge %q", s.registry, challenge)
+	}
+	params := make(map[string]string)
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2]
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid connector catalog registry %s authentication realm %q", s.registry, params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", s.repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	body, err := get(ctx, s.client, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("error unmarshaling connector catalog registry %s token: %v", s.registry, err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("connector catalog registry %s returned no token", s.registry)
+}
@@ -0,0 +1,108 @@
+package catalog
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxDocumentSize limits the size of documents read from remote sources
+const maxDocumentSize = 10 << 20
+
+// NewSource returns a Source for an http(s) catalog index URL, with the signature published at the same URL with a .sig suffix,
+// or for an oci://registry/repository:tag (or @digest) artifact reference. Signatures are base64 encoded.
+func NewSource(rawURL string, client *http.Client) (Source, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connector catalog url %q: %v", rawURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return &httpSource{url: rawURL, client: client}, nil
+	case "oci":
+		return newOCISource(u, client)
+	default:
+		return nil, fmt.Errorf("unsupported connector catalog url scheme %q", u.Scheme)
+	}
+}
+
+type httpSource struct {
+	url    string
+	client *http.Client
+}
+
+func (s *httpSource) Fetch(ctx context.Context) (*Document, error) {
+	content, err := get(ctx, s.client, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := get(ctx, s.client, s.url+".sig", nil)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := decodeSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{Content: content, Signature: decoded}, nil
+}
+
+func decodeSignature(signature []byte) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding connector catalog signature: %v", err)
+	}
+	return decoded, nil
+}
+
+// get reads a document, returning the response for unexpected status codes so callers can handle them
+func get(ctx context.Context, client *http.Client, url string, header http.Header) ([]byte, error) {
+	resp, err := do(ctx, client, url, header)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{url: url, response: resp}
+	}
+	return readBody(url, resp)
+}
+
+func do(ctx context.Context, client *http.Client, url string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for %s: %v", url, err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %v", url, err)
+	}
+	return resp, nil
+}
+
+func readBody(url string, resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", url, err)
+	}
+	if len(body) > maxDocumentSize {
+		return nil, fmt.Errorf("error reading %s: document larger than %d bytes", url, maxDocumentSize)
+	}
+	return body, nil
+}
+
+type statusError struct {
+	url      string
+	response *http.Response
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("error fetching %s: unexpected status %s", e.url, e.response.Status)
+}
@@ -0,0 +1,125 @@
+package catalog
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestHttpSource(t *testing.T) {
+	g := gomega.NewWithT(t)
+	content := []byte(`{"revision":"1","connector_types":[{"connector_type":{"id":"a"}}]}`)
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(private, content))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/catalog.json":
+			_, _ = w.Write(content)
+		case "/catalog.json.sig":
+			_, _ = w.Write([]byte(signature + "\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	source, err := NewSource(server.URL+"/catalog.json", server.Client())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	catalog, document, err := Load(context.Background(), source, public)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(catalog.Revision).To(gomega.Equal("1"))
+	g.Expect(catalog.ConnectorTypes).To(gomega.HaveLen(1))
+	g.Expect(document.Checksum()).To(gomega.Equal(fmt.Sprintf("%x", sha256.Sum256(content))))
+
+	source, err = NewSource(server.URL+"/missing.json", server.Client())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	_, _, err = Load(context.Background(), source, public)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unexpected status 404 Not Found")))
+}
+
+func TestOCISource(t *testing.T) {
+	g := gomega.NewWithT(t)
+	content := []byte(`{"revision":"2","connector_types":[{"connector_type":{"id":"a"}}]}`)
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	signature := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(private, content)))
+	digest := func(b []byte) string { return fmt.Sprintf("sha256:%x", sha256.Sum256(b)) }
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			g.Expect(r.URL.Query().Get("scope")).To(gomega.Equal("repository:org/catalog:pull"))
+			_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/catalog:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/org/catalog/manifests/v2":
+			g.Expect(r.Header.Get("Accept")).To(gomega.Equal(ociManifestMediaType))
+			_, _ = fmt.Fprintf(w, `{"schemaVersion":2,"layers":[{"mediaType":"%s","digest":"%s"},{"mediaType":"%s","digest":"%s"}]}`,
+				CatalogMediaType, digest(content), SignatureMediaType, digest(signature))
+		case "/v2/org/catalog/blobs/" + digest(content):
+			_, _ = w.Write(content)
+		case "/v2/org/catalog/blobs/" + digest(signature):
+			_, _ = w.Write(signature)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	source, err := NewSource("oci://"+registry+"/org/catalog:v2", server.Client())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	catalog, _, err := Load(context.Background(), source, public)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(catalog.Revision).To(gomega.Equal("2"))
+
+	source, err = NewSource("oci://"+registry+"/org/catalog:missing", server.Client())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	_, _, err = Load(context.Background(), source, public)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unexpected status 404 Not Found")))
+}
+
+func TestNewOCISource(t *testing.T) {
+	tests := []struct {
+		url        string
+		repository string
+		reference  string
+		wantErr    bool
+	}{
+		{url: "oci://quay.io/org/catalog:v1", repository: "org/catalog", reference: "v1"},
+		{url: "oci://localhost:5000/org/catalog", repository: "org/catalog", reference: "latest"},
+		{url: "oci://quay.io/org/catalog@sha256:abc", repository: "org/catalog", reference: "sha256:abc"},
+		{url: "oci://quay.io/", wantErr: true},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.url, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			source, err := NewSource(tt.url, http.DefaultClient)
+			if tt.wantErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(source.(*ociSource).repository).To(gomega.Equal(tt.repository))
+			g.Expect(source.(*ociSource).reference).To(gomega.Equal(tt.reference))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"encoding/json"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+)
+
+// ConnectorCatalogService keeps track of the remote connector catalog applied to connector types,
+// so that all fleet manager instances merge the same remote catalog with their local catalog
+type ConnectorCatalogService interface {
+	// GetRemoteCatalog returns the last applied remote connector catalog
+	GetRemoteCatalog() (*dbapi.ConnectorCatalog, *errors.ServiceError)
+	// SaveRemoteCatalog records an applied remote connector catalog
+	SaveRemoteCatalog(source string, revision string, checksum string, entries []config.ConnectorCatalogEntry) *errors.ServiceError
+	// LoadRemoteCatalog merges the last applied remote connector catalog, if any, into the configured connector catalog
+	LoadRemoteCatalog() *errors.ServiceError
+}
+
+var _ ConnectorCatalogService = &connectorCatalogService{}
+
+type connectorCatalogService struct {
+	connectionFactory *db.ConnectionFactory
+	connectorsConfig  *config.ConnectorsConfig
+}
+
+func NewConnectorCatalogService(connectionFactory *db.ConnectionFactory, connectorsConfig *config.ConnectorsConfig) *connectorCatalogService {
+	return &connectorCatalogService{
+		connectionFactory: connectionFactory,
+		connectorsConfig:  connectorsConfig,
+	}
+}
+
+func (k *connectorCatalogService) GetRemoteCatalog() (*dbapi.ConnectorCatalog, *errors.ServiceError) {
+	var result dbapi.ConnectorCatalog
+	if err := k.connectionFactory.New().Where("id = ?", dbapi.RemoteConnectorCatalogID).
+		First(&result).Error; err != nil {
+		if services.IsRecordNotFoundError(err) {
+			return nil, errors.NotFound("no remote connector catalog has been loaded")
+		}
+		return nil, errors.GeneralError("unable to get remote connector catalog: %v", err)
+	}
+	return &result, nil
+}
+
+func (k *connectorCatalogService) SaveRemoteCatalog(source string, revision string, checksum string, entries []config.ConnectorCatalogEntry) *errors.ServiceError {
+	catalogEntries, err := json.Marshal(entries)
+	if err != nil {
+		return errors.GeneralError("failed to marshal remote connector catalog: %v", err)
+	}
+	resource := &dbapi.ConnectorCatalog{
+		Model: db.Model{
+			ID: dbapi.RemoteConnectorCatalogID,
+		},
+		Source:         source,
+		Revision:       revision,
+		Checksum:       checksum,
+		CatalogEntries: catalogEntries,
+	}
+	dbConn := k.connectionFactory.New()
+	var existing dbapi.ConnectorCatalog
+	if err := dbConn.Select("id", "created_at").Where("id = ?", resource.ID).First(&existing).Error; err == nil {
+		resource.CreatedAt = existing.CreatedAt
+	} else if !services.IsRecordNotFoundError(err) {
+		return errors.GeneralError("unable to get remote connector catalog: %v", err)
+	}
+	if err := dbConn.Save(resource).Error; err != nil {
+		return errors.GeneralError("failed to save remote connector catalog: %v", err)
+	}
+	return nil
+}
+
+func (k *connectorCatalogService) LoadRemoteCatalog() *errors.ServiceError {
+	resource, serr := k.GetRemoteCatalog()
+	if serr != nil {
+		if serr.Is404() {
+			return nil
+		}
+		return serr
+	}
+	if resource.Revision == k.connectorsConfig.GetRemoteCatalogRevision() {
+		return nil
+	}
+
+	var entries []config.ConnectorCatalogEntry
+	if err := json.Unmarshal(resource.CatalogEntries, &entries); err != nil {
+		return errors.GeneralError("failed to unmarshal remote connector catalog revision %s: %v", resource.Revision, err)
+	}
+	checksums, _, err := k.connectorsConfig.DiffRemoteCatalog(entries)
+	if err != nil {
+		return errors.GeneralError("failed to load remote connector catalog revision %s: %v", resource.Revision, err)
+	}
+	k.connectorsConfig.SetRemoteCatalog(resource.Revision, entries, checksums)
+	return nil
+}
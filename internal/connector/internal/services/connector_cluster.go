@@ -29,6 +29,9 @@ type ConnectorClusterService interface {
 	Delete(ctx context.Context, id string) *errors.ServiceError
 	List(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorClusterList, *api.PagingMeta, *errors.ServiceError)
 	Update(ctx context.Context, resource *dbapi.ConnectorCluster) *errors.ServiceError
+	// UpdateIfUnmodified is like Update but the update is only applied if the connector cluster hasn't been updated
+	// since it was read, a precondition failed error is returned otherwise
+	UpdateIfUnmodified(ctx context.Context, resource *dbapi.ConnectorCluster) *errors.ServiceError
 	UpdateConnectorClusterStatus(ctx context.Context, id string, status dbapi.ConnectorClusterStatus) *errors.ServiceError
 	GetConnectorClusterStatus(ctx context.Context, id string) (dbapi.ConnectorClusterStatus, *errors.ServiceError)
 
@@ -303,7 +306,27 @@ func isAdmin(ctx context.Context) (bool, *errors.ServiceError) {
 }
 
 func (k *connectorClusterService) Update(ctx context.Context, resource *dbapi.ConnectorCluster) *errors.ServiceError {
+	return k.update(resource, false)
+}
+
+func (k *connectorClusterService) UpdateIfUnmodified(ctx context.Context, resource *dbapi.ConnectorCluster) *errors.ServiceError {
+	return k.update(resource, true)
+}
+
+// update updates the connector cluster and replaces its annotations, when ifUnmodified is set the update is made in the
+// same statement that checks its updated_at column still has the value it had when the connector cluster was read
+func (k *connectorClusterService) update(resource *dbapi.ConnectorCluster, ifUnmodified bool) *errors.ServiceError {
+	updatedAt := resource.UpdatedAt
 	if err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		update := tx.Model(resource).Omit("Annotations").Where("id = ?", resource.ID)
+		if ifUnmodified {
+			update = update.Where("updated_at = ?", updatedAt)
+		}
+		if result := update.Updates(resource); result.Error != nil {
+			return result.Error
+		} else if ifUnmodified && result.RowsAffected == 0 {
+			return errors.PreconditionFailed("connector cluster %s has been updated since it was read", resource.ID)
+		}
 		// remove old associations first
 		if err := tx.Where("connector_cluster_id = ?", resource.ID).
 			Delete(&dbapi.ConnectorClusterAnnotation{}).Error; err != nil {
@@ -313,12 +336,11 @@ func (k *connectorClusterService) Update(ctx context.Context, resource *dbapi.Co
 		if err := tx.Create(&resource.Annotations).Error; err != nil {
 			return errors.GeneralError("failed to update connector cluster annotations %q: %v", resource.ID, err)
 		}
-		if err := tx.Model(resource).Omit("Annotations").
-			Where("id = ?", resource.ID).Updates(resource).Error; err != nil {
-			return err
-		}
 		return nil
 	}); err != nil {
+		if serr, ok := err.(*errors.ServiceError); ok && serr.Code == errors.ErrorPreconditionFailed {
+			return serr
+		}
 		return services.HandleUpdateError("Connector cluster", err)
 	}
 	return nil
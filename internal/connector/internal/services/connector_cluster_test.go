@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_connectorClusterService_UpdateIfUnmodified(t *testing.T) {
+	updatedAt := time.Date(2023, 5, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErrCode  errors.ServiceErrorCode
+	}{
+		{
+			name:         "should update the connector cluster when it hasn't been updated since it was read",
+			rowsAffected: 1,
+		},
+		{
+			name:         "should return a precondition failed error when the connector cluster has been updated concurrently",
+			rowsAffected: 0,
+			wantErrCode:  errors.ErrorPreconditionFailed,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			// the update and the check of the version are made in the same statement
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`UPDATE "connector_clusters" SET "updated_at"=$1,"name"=$2 WHERE id = $3 AND updated_at = $4`).
+				WithRowsNum(tt.rowsAffected)
+			mocket.Catcher.NewMock().WithQuery(`DELETE FROM "connector_cluster_annotations"`)
+			mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_cluster_annotations"`)
+			mocket.Catcher.NewMock().WithQueryException().WithExecException()
+
+			k := connectorClusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			cluster := &dbapi.ConnectorCluster{
+				Model: db.Model{ID: "cluster", UpdatedAt: updatedAt},
+				Name:  "new-name",
+				Annotations: []dbapi.ConnectorClusterAnnotation{
+					{ConnectorClusterID: "cluster", Key: "key", Value: "value"},
+				},
+			}
+			err := k.UpdateIfUnmodified(context.Background(), cluster)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+		})
+	}
+}
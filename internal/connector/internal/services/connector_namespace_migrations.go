@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/phase"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"gorm.io/gorm"
+)
+
+// ConnectorNamespaceMigrationService moves connectors between namespaces, possibly in different clusters
+type ConnectorNamespaceMigrationService interface {
+	Create(ctx context.Context, connectorId string, targetNamespaceId string, requestedBy string) (*dbapi.ConnectorNamespaceMigration, *errors.ServiceError)
+	List(ctx context.Context, connectorId string) (dbapi.ConnectorNamespaceMigrationList, *errors.ServiceError)
+	ListActive() (dbapi.ConnectorNamespaceMigrationList, *errors.ServiceError)
+	Update(ctx context.Context, migration *dbapi.ConnectorNamespaceMigration) *errors.ServiceError
+	// UnassignConnector unassigns the migrated connector and updates the migration in the same transaction
+	UnassignConnector(ctx context.Context, migration *dbapi.ConnectorNamespaceMigration) *errors.ServiceError
+	// AssignConnector assigns the migrated connector to a namespace and updates the migration in the same transaction
+	AssignConnector(ctx context.Context, migration *dbapi.ConnectorNamespaceMigration, namespaceId string) *errors.ServiceError
+}
+
+var _ ConnectorNamespaceMigrationService = &connectorNamespaceMigrationService{}
+
+type connectorNamespaceMigrationService struct {
+	connectionFactory         *db.ConnectionFactory
+	bus                       signalbus.SignalBus
+	connectorNamespaceService ConnectorNamespaceService
+}
+
+func NewConnectorNamespaceMigrationService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus,
+	connectorNamespaceService ConnectorNamespaceService) *connectorNamespaceMigrationService {
+	return &connectorNamespaceMigrationService{
+		connectionFactory:         connectionFactory,
+		bus:                       bus,
+		connectorNamespaceService: connectorNamespaceService,
+	}
+}
+
+// Create validates and starts moving a connector to the target namespace
+func (k *connectorNamespaceMigrationService) Create(ctx context.Context, connectorId string, targetNamespaceId string, requestedBy string) (*dbapi.ConnectorNamespaceMigration, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	var connector dbapi.Connector
+	if err := dbConn.Joins("Status").Where("connectors.id = ?", connectorId).First(&connector).Error; err != nil {
+		return nil, services.HandleGetError("Connector", "id", connectorId, err)
+	}
+	if connector.NamespaceId == nil {
+		return nil, errors.BadRequest("connector %s is not assigned to a namespace", connectorId)
+	}
+	if *connector.NamespaceId == targetNamespaceId {
+		return nil, errors.BadRequest("connector %s is already in namespace %s", connectorId, targetNamespaceId)
+	}
+	if connector.DesiredState != dbapi.ConnectorReady && connector.DesiredState != dbapi.ConnectorStopped {
+		return nil, errors.BadRequest("cannot migrate connector %s in desired state %s", connectorId, connector.DesiredState)
+	}
+	if connector.Status.Phase == dbapi.ConnectorStatusPhaseAssigning || connector.Status.Phase == dbapi.ConnectorStatusPhaseDeleting {
+		return nil, errors.BadRequest("cannot migrate connector %s in phase %s", connectorId, connector.Status.Phase)
+	}
+
+	var active int64
+	if err := dbConn.Model(&dbapi.ConnectorNamespaceMigration{}).
+		Where("connector_id = ? AND phase IN ?", connectorId, dbapi.ActiveConnectorMigrationPhases).
+		Count(&active).Error; err != nil {
+		return nil, services.HandleGetError("Connector namespace migration", "connector_id", connectorId, err)
+	}
+	if active > 0 {
+		return nil, errors.Conflict("connector %s is already being migrated", connectorId)
+	}
+
+	source, serr := k.connectorNamespaceService.Get(ctx, *connector.NamespaceId)
+	if serr != nil {
+		return nil, serr
+	}
+	target, serr := k.connectorNamespaceService.Get(ctx, targetNamespaceId)
+	if serr != nil {
+		return nil, serr
+	}
+	if target.Status.Phase != dbapi.ConnectorNamespacePhaseReady {
+		return nil, errors.BadRequest("target namespace %s is not ready", targetNamespaceId)
+	}
+	if serr := k.connectorNamespaceService.CheckConnectorQuota(targetNamespaceId); serr != nil {
+		return nil, serr
+	}
+
+	migration := &dbapi.ConnectorNamespaceMigration{
+		Model: db.Model{
+			ID: api.NewID(),
+		},
+		ConnectorID:       connectorId,
+		SourceNamespaceID: source.ID,
+		SourceClusterID:   source.ClusterId,
+		TargetNamespaceID: target.ID,
+		TargetClusterID:   target.ClusterId,
+		DesiredState:      connector.DesiredState,
+		Phase:             dbapi.ConnectorMigrationPhaseStopping,
+		RequestedBy:       requestedBy,
+	}
+	// the migration is only started if the deployment is removed from the source namespace
+	if err := dbConn.Transaction(func(dbConn *gorm.DB) error {
+		if err := dbConn.Create(migration).Error; err != nil {
+			return err
+		}
+		return unassignConnector(dbConn, connectorId)
+	}); err != nil {
+		return nil, errors.GeneralError("failed to create connector namespace migration: %v", err)
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		k.bus.Notify("reconcile:connector")
+	})
+	return migration, nil
+}
+
+// List returns all migrations of a connector, latest first
+func (k *connectorNamespaceMigrationService) List(ctx context.Context, connectorId string) (dbapi.ConnectorNamespaceMigrationList, *errors.ServiceError) {
+	var result dbapi.ConnectorNamespaceMigrationList
+	if err := k.connectionFactory.New().Where("connector_id = ?", connectorId).
+		Order("created_at DESC").Find(&result).Error; err != nil {
+		return nil, services.HandleGetError("Connector namespace migration", "connector_id", connectorId, err)
+	}
+	return result, nil
+}
+
+// ListActive returns all migrations that are still in progress
+func (k *connectorNamespaceMigrationService) ListActive() (dbapi.ConnectorNamespaceMigrationList, *errors.ServiceError) {
+	var result dbapi.ConnectorNamespaceMigrationList
+	if err := k.connectionFactory.New().Where("phase IN ?", dbapi.ActiveConnectorMigrationPhases).
+		Order("created_at").Find(&result).Error; err != nil {
+		return nil, services.HandleGetError("Connector namespace migration", "phase", dbapi.ActiveConnectorMigrationPhases, err)
+	}
+	return result, nil
+}
+
+func (k *connectorNamespaceMigrationService) Update(ctx context.Context, migration *dbapi.ConnectorNamespaceMigration) *errors.ServiceError {
+	if err := k.connectionFactory.New().Save(migration).Error; err != nil {
+		return services.HandleUpdateError("Connector namespace migration", err)
+	}
+	return nil
+}
+
+// UnassignConnector sets connector desired state to unassigned, so the agent removes its deployment
+func (k *connectorNamespaceMigrationService) UnassignConnector(ctx context.Context, migration *dbapi.ConnectorNamespaceMigration) *errors.ServiceError {
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		if err := unassignConnector(dbConn, migration.ConnectorID); err != nil {
+			return err
+		}
+		return dbConn.Save(migration).Error
+	}); err != nil {
+		return services.HandleUpdateError("Connector", err)
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		k.bus.Notify("reconcile:connector")
+	})
+	return nil
+}
+
+func unassignConnector(dbConn *gorm.DB, connectorId string) error {
+	if err := dbConn.Model(&dbapi.ConnectorStatus{}).Where("id = ?", connectorId).
+		Update("phase", phase.ConnectorStartingPhase[phase.UnassignConnector]).Error; err != nil {
+		return err
+	}
+	return dbConn.Model(&dbapi.Connector{}).Where("id = ?", connectorId).
+		Update("desired_state", dbapi.ConnectorUnassigned).Error
+}
+
+// AssignConnector assigns an unassigned connector to a namespace, which creates a new deployment with the same spec and secrets
+func (k *connectorNamespaceMigrationService) AssignConnector(ctx context.Context, migration *dbapi.ConnectorNamespaceMigration, namespaceId string) *errors.ServiceError {
+	connectorId := migration.ConnectorID
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		if err := dbConn.Model(&dbapi.ConnectorStatus{}).Where("id = ?", connectorId).
+			Updates(map[string]interface{}{
+				"phase":        phase.ConnectorStartingPhase[phase.AssignConnector],
+				"namespace_id": nil,
+				"reason":       "",
+			}).Error; err != nil {
+			return err
+		}
+		if err := dbConn.Model(&dbapi.Connector{}).Where("id = ?", connectorId).
+			Updates(map[string]interface{}{
+				"namespace_id":  namespaceId,
+				"desired_state": migration.DesiredState,
+			}).Error; err != nil {
+			return err
+		}
+		return dbConn.Save(migration).Error
+	}); err != nil {
+		return services.HandleUpdateError("Connector", err)
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		k.bus.Notify("reconcile:connector")
+	})
+	return nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+type namespaceServiceStub struct {
+	ConnectorNamespaceService
+}
+
+func (s *namespaceServiceStub) Get(ctx context.Context, namespaceID string) (*dbapi.ConnectorNamespace, *errors.ServiceError) {
+	return &dbapi.ConnectorNamespace{
+		Model:     db.Model{ID: namespaceID},
+		ClusterId: "cluster-" + namespaceID,
+		Status:    dbapi.ConnectorNamespaceStatus{Phase: dbapi.ConnectorNamespacePhaseReady},
+	}, nil
+}
+
+func (s *namespaceServiceStub) CheckConnectorQuota(namespaceId string) *errors.ServiceError {
+	return nil
+}
+
+func Test_connectorNamespaceMigrationService_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		namespaceId    interface{}
+		activeCount    int
+		unassignFails  bool
+		wantHttpStatus int
+	}{
+		{
+			name:        "should create the migration and unassign the connector",
+			namespaceId: "source",
+		},
+		{
+			name:           "should not migrate connectors without a namespace",
+			namespaceId:    nil,
+			wantHttpStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should not migrate connectors that are already being migrated",
+			namespaceId:    "source",
+			activeCount:    1,
+			wantHttpStatus: http.StatusConflict,
+		},
+		{
+			name:           "should fail the migration when the connector can't be unassigned",
+			namespaceId:    "source",
+			unassignFails:  true,
+			wantHttpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`FROM "connectors"`).WithReply([]map[string]interface{}{{
+				"id":            "connector",
+				"namespace_id":  tt.namespaceId,
+				"desired_state": string(dbapi.ConnectorReady),
+				"Status__phase": string(dbapi.ConnectorStatusPhaseReady),
+			}})
+			mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "connector_namespace_migrations"`).
+				WithReply([]map[string]interface{}{{"count": tt.activeCount}})
+			var inserted bool
+			mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_namespace_migrations"`).
+				WithCallback(func(string, []driver.NamedValue) { inserted = true })
+			if tt.unassignFails {
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_statuses"`).WithExecException()
+			}
+
+			k := NewConnectorNamespaceMigrationService(connectionFactory, nil, &namespaceServiceStub{})
+			migration, serr := k.Create(context.Background(), "connector", "target", "admin")
+			if tt.wantHttpStatus != 0 {
+				g.Expect(serr).To(gomega.HaveOccurred())
+				g.Expect(serr.HttpCode).To(gomega.Equal(tt.wantHttpStatus))
+				g.Expect(migration).To(gomega.BeNil())
+				return
+			}
+			g.Expect(serr).To(gomega.BeNil())
+			g.Expect(inserted).To(gomega.BeTrue())
+			g.Expect(migration.Phase).To(gomega.Equal(dbapi.ConnectorMigrationPhaseStopping))
+			g.Expect(migration.SourceClusterID).To(gomega.Equal("cluster-source"))
+			g.Expect(migration.TargetClusterID).To(gomega.Equal("cluster-target"))
+		})
+	}
+}
+
+func Test_connectorNamespaceMigrationService_AssignConnector(t *testing.T) {
+	tests := []struct {
+		name           string
+		updateFails    bool
+		wantHttpStatus int
+	}{
+		{
+			name: "should assign the connector and update the migration",
+		},
+		{
+			name:           "should fail when the migration can't be updated",
+			updateFails:    true,
+			wantHttpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			mocket.Catcher.Reset()
+			connectorMock := mocket.Catcher.NewMock().WithQuery(`UPDATE "connectors"`).WithRowsNum(1)
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_statuses"`).WithRowsNum(1)
+			migrationMock := mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_namespace_migrations"`).WithRowsNum(1)
+			if tt.updateFails {
+				migrationMock.WithExecException()
+			}
+
+			k := NewConnectorNamespaceMigrationService(connectionFactory, nil, &namespaceServiceStub{})
+			migration := &dbapi.ConnectorNamespaceMigration{
+				Model:        db.Model{ID: "migration"},
+				ConnectorID:  "connector",
+				DesiredState: dbapi.ConnectorReady,
+				Phase:        dbapi.ConnectorMigrationPhaseDeploying,
+			}
+			serr := k.AssignConnector(context.Background(), migration, "target")
+			g.Expect(connectorMock.Triggered).To(gomega.BeTrue())
+			if tt.wantHttpStatus != 0 {
+				g.Expect(serr).To(gomega.HaveOccurred())
+				g.Expect(serr.HttpCode).To(gomega.Equal(tt.wantHttpStatus))
+				return
+			}
+			g.Expect(serr).To(gomega.BeNil())
+			g.Expect(migrationMock.Triggered).To(gomega.BeTrue())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+)
+
+// ConnectorRevisionsService records the history of accepted connector spec changes
+type ConnectorRevisionsService interface {
+	// Create records the connector's current spec as a new revision, unless it's unchanged from the latest revision,
+	// and returns revisions pruned to stay within the configured maximum
+	Create(ctx context.Context, connector *dbapi.Connector, author string) (dbapi.ConnectorRevisionList, *errors.ServiceError)
+	// List returns all revisions of a connector, latest first
+	List(ctx context.Context, connectorId string) (dbapi.ConnectorRevisionList, *errors.ServiceError)
+	Get(ctx context.Context, connectorId string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError)
+}
+
+var _ ConnectorRevisionsService = &connectorRevisionsService{}
+
+type connectorRevisionsService struct {
+	connectionFactory *db.ConnectionFactory
+	connectorsConfig  *config.ConnectorsConfig
+}
+
+func NewConnectorRevisionsService(connectionFactory *db.ConnectionFactory, connectorsConfig *config.ConnectorsConfig) *connectorRevisionsService {
+	return &connectorRevisionsService{
+		connectionFactory: connectionFactory,
+		connectorsConfig:  connectorsConfig,
+	}
+}
+
+func (k *connectorRevisionsService) Create(ctx context.Context, connector *dbapi.Connector, author string) (dbapi.ConnectorRevisionList, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	var latest dbapi.ConnectorRevisionList
+	if err := dbConn.Where("connector_id = ?", connector.ID).Order("revision DESC").Limit(1).
+		Find(&latest).Error; err != nil {
+		return nil, services.HandleGetError("Connector revision", "connector_id", connector.ID, err)
+	}
+	var next int64 = 1
+	if len(latest) > 0 {
+		if EqualConnectorSpecs(latest[0].ConnectorSpec, connector.ConnectorSpec) {
+			return nil, nil
+		}
+		next = latest[0].Revision + 1
+	}
+
+	revision := &dbapi.ConnectorRevision{
+		Model: db.Model{
+			ID: api.NewID(),
+		},
+		ConnectorID:      connector.ID,
+		Revision:         next,
+		ConnectorVersion: connector.Version,
+		ConnectorSpec:    connector.ConnectorSpec,
+		Author:           author,
+	}
+	if err := dbConn.Create(revision).Error; err != nil {
+		return nil, errors.GeneralError("failed to create connector revision: %v", err)
+	}
+
+	// prune oldest revisions
+	var pruned dbapi.ConnectorRevisionList
+	if max := k.connectorsConfig.ConnectorMaxRevisions; max > 0 && next > int64(max) {
+		if err := dbConn.Where("connector_id = ? AND revision <= ?", connector.ID, next-int64(max)).
+			Find(&pruned).Error; err != nil {
+			return nil, services.HandleGetError("Connector revision", "connector_id", connector.ID, err)
+		}
+		if len(pruned) > 0 {
+			if err := dbConn.Unscoped().Where("connector_id = ? AND revision <= ?", connector.ID, next-int64(max)).
+				Delete(&dbapi.ConnectorRevision{}).Error; err != nil {
+				return nil, errors.GeneralError("failed to prune connector revisions: %v", err)
+			}
+		}
+	}
+
+	return pruned, nil
+}
+
+func (k *connectorRevisionsService) List(ctx context.Context, connectorId string) (dbapi.ConnectorRevisionList, *errors.ServiceError) {
+	var result dbapi.ConnectorRevisionList
+	if err := k.connectionFactory.New().Where("connector_id = ?", connectorId).
+		Order("revision DESC").Find(&result).Error; err != nil {
+		return nil, services.HandleGetError("Connector revision", "connector_id", connectorId, err)
+	}
+	return result, nil
+}
+
+func (k *connectorRevisionsService) Get(ctx context.Context, connectorId string, revision int64) (*dbapi.ConnectorRevision, *errors.ServiceError) {
+	var result dbapi.ConnectorRevision
+	if err := k.connectionFactory.New().Where("connector_id = ? AND revision = ?", connectorId, revision).
+		First(&result).Error; err != nil {
+		return nil, services.HandleGetError("Connector revision", "revision", revision, err)
+	}
+	return &result, nil
+}
+
+// EqualConnectorSpecs compares connector specs ignoring formatting differences
+func EqualConnectorSpecs(a, b api.JSON) bool {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_EqualConnectorSpecs(t *testing.T) {
+	tests := []struct {
+		name string
+		a    api.JSON
+		b    api.JSON
+		want bool
+	}{
+		{
+			name: "formatting and key order are ignored",
+			a:    api.JSON(`{"topic":"a","secret":{"kind":"base64","ref":"key"}}`),
+			b:    api.JSON(`{ "secret": {"ref": "key", "kind": "base64"}, "topic": "a" }`),
+			want: true,
+		},
+		{
+			name: "changed value",
+			a:    api.JSON(`{"topic":"a"}`),
+			b:    api.JSON(`{"topic":"b"}`),
+			want: false,
+		},
+		{
+			name: "changed secret reference",
+			a:    api.JSON(`{"secret":{"ref":"key1"}}`),
+			b:    api.JSON(`{"secret":{"ref":"key2"}}`),
+			want: false,
+		},
+		{
+			name: "invalid json",
+			a:    api.JSON(`{`),
+			b:    api.JSON(`{`),
+			want: false,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(EqualConnectorSpecs(tt.a, tt.b)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_connectorRevisionsService_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		latest       int64
+		latestSpec   string
+		maxRevisions int
+		wantRevision int64
+		wantPruned   []int64
+	}{
+		{
+			name:         "should create the first revision",
+			maxRevisions: 2,
+			wantRevision: 1,
+		},
+		{
+			name:         "should skip unchanged specs",
+			latest:       2,
+			latestSpec:   `{"topic": "b"}`,
+			maxRevisions: 2,
+		},
+		{
+			name:         "should keep revisions within the maximum",
+			latest:       1,
+			latestSpec:   `{"topic": "a"}`,
+			maxRevisions: 2,
+			wantRevision: 2,
+		},
+		{
+			name:         "should prune the oldest revisions over the maximum",
+			latest:       3,
+			latestSpec:   `{"topic": "a"}`,
+			maxRevisions: 2,
+			wantRevision: 4,
+			wantPruned:   []int64{1, 2},
+		},
+		{
+			name:         "should keep all revisions without a maximum",
+			latest:       3,
+			latestSpec:   `{"topic": "a"}`,
+			wantRevision: 4,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			mocket.Catcher.Reset()
+			if tt.latest > 0 {
+				mocket.Catcher.NewMock().WithQuery(`ORDER BY revision DESC LIMIT 1`).
+					WithReply([]map[string]interface{}{{"revision": tt.latest, "connector_spec": []byte(tt.latestSpec)}})
+			}
+			var pruneReply []map[string]interface{}
+			for _, r := range tt.wantPruned {
+				pruneReply = append(pruneReply, map[string]interface{}{"revision": r, "connector_spec": []byte(`{"topic": "a"}`)})
+			}
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "connector_revisions" WHERE (connector_id = $1 AND revision <= $2)`).
+				WithReply(pruneReply)
+			var inserted []driver.NamedValue
+			mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_revisions"`).
+				WithCallback(func(_ string, args []driver.NamedValue) { inserted = args })
+			var deleted []driver.NamedValue
+			mocket.Catcher.NewMock().WithQuery(`DELETE FROM "connector_revisions"`).
+				WithCallback(func(_ string, args []driver.NamedValue) { deleted = args })
+
+			k := NewConnectorRevisionsService(connectionFactory, &config.ConnectorsConfig{ConnectorMaxRevisions: tt.maxRevisions})
+			pruned, serr := k.Create(context.Background(), &dbapi.Connector{
+				Model:         db.Model{ID: "connector"},
+				ConnectorSpec: api.JSON(`{"topic":"b"}`),
+			}, "user")
+			g.Expect(serr).To(gomega.BeNil())
+
+			if tt.wantRevision == 0 {
+				g.Expect(inserted).To(gomega.BeNil())
+			} else {
+				var values []interface{}
+				for _, arg := range inserted {
+					values = append(values, arg.Value)
+				}
+				g.Expect(values).To(gomega.ContainElements("connector", tt.wantRevision, "user"))
+			}
+
+			var prunedRevisions []int64
+			for _, r := range pruned {
+				prunedRevisions = append(prunedRevisions, r.Revision)
+			}
+			g.Expect(prunedRevisions).To(gomega.Equal(tt.wantPruned))
+			if len(tt.wantPruned) == 0 {
+				g.Expect(deleted).To(gomega.BeNil())
+			} else {
+				g.Expect(deleted).To(gomega.HaveLen(2))
+				g.Expect(deleted[1].Value).To(gomega.Equal(tt.wantRevision - int64(tt.maxRevisions)))
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -33,12 +34,16 @@ type ConnectorTypesService interface {
 	List(listArgs *services.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError)
 	ListLabels(listArgs *services.ListArguments) (dbapi.ConnectorTypeLabelCountList, *errors.ServiceError)
 	ForEachConnectorCatalogEntry(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError
+	// PutConnectorCatalogEntry creates or updates a connector type from a catalog entry, calls f for its channels and records its catalog checksum
+	PutConnectorCatalogEntry(entry *config.ConnectorCatalogEntry, checksum string, f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError
 
 	PutConnectorShardMetadata(ctc *dbapi.ConnectorShardMetadata) (int64, *errors.ServiceError)
 	GetConnectorShardMetadata(typeId, channel string, revision int64) (*dbapi.ConnectorShardMetadata, *errors.ServiceError)
 	GetLatestConnectorShardMetadata(typeId, channel string) (*dbapi.ConnectorShardMetadata, *errors.ServiceError)
 	CatalogEntriesReconciled() (bool, *errors.ServiceError)
 	DeleteOrDeprecateRemovedTypes() *errors.ServiceError
+	// ListSunsetTypeIds returns the ids of deprecated connector types past their sunset date
+	ListSunsetTypeIds(now time.Time) ([]string, *errors.ServiceError)
 	ListCatalogEntries(*coreService.ListArguments) ([]dbapi.ConnectorCatalogEntry, *api.PagingMeta, *errors.ServiceError)
 	GetCatalogEntry(tyd string) (*dbapi.ConnectorCatalogEntry, *errors.ServiceError)
 }
@@ -99,6 +104,10 @@ func (cts *connectorTypesService) Create(resource *dbapi.ConnectorType) *errors.
 			if err := dbConn.Session(&gorm.Session{FullSaveAssociations: true}).Updates(resource).Error; err != nil {
 				return errors.GeneralError("failed to update connector type %q: %v", tid, err)
 			}
+			// Updates ignores zero values, and a type in the catalog is no longer deprecated unless annotated
+			if err := dbConn.Model(&dbapi.ConnectorType{}).Where("id = ?", tid).Update("deprecated", resource.Deprecated).Error; err != nil {
+				return errors.GeneralError("failed to update connector type %q: %v", tid, err)
+			}
 
 			// update connector annotations
 			if err := updateConnectorAnnotations(dbConn, oldResource); err != nil {
@@ -320,32 +329,41 @@ func (cts *connectorTypesService) ListLabels(listArgs *services.ListArguments) (
 
 func (cts *connectorTypesService) ForEachConnectorCatalogEntry(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError {
 
-	for _, entry := range cts.connectorsConfig.CatalogEntries {
-		// create/update connector type
-		connectorType, err := presenters.ConvertConnectorType(entry.ConnectorType)
-		if err != nil {
-			return errors.GeneralError("failed to convert connector type %s: %v", entry.ConnectorType.Id, err.Error())
-		}
-		if err := cts.Create(connectorType); err != nil {
+	catalogChecksums := cts.connectorsConfig.GetCatalogChecksums()
+	for _, entry := range cts.connectorsConfig.GetCatalogEntries() {
+		entry := entry
+		if err := cts.PutConnectorCatalogEntry(&entry, catalogChecksums[entry.ConnectorType.Id], f); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		// reconcile channels
-		for channel, ccc := range entry.Channels {
-			ccc := ccc
-			err := f(entry.ConnectorType.Id, channel, &ccc)
-			if err != nil {
-				return err
-			}
-		}
+func (cts *connectorTypesService) PutConnectorCatalogEntry(entry *config.ConnectorCatalogEntry, checksum string, f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError {
+	// create/update connector type
+	connectorType, err := presenters.ConvertConnectorType(entry.ConnectorType)
+	if err != nil {
+		return errors.GeneralError("failed to convert connector type %s: %v", entry.ConnectorType.Id, err.Error())
+	}
+	if err := cts.Create(connectorType); err != nil {
+		return err
+	}
 
-		// update type checksum for latest catalog shard metadata
-		dbConn := cts.connectionFactory.New()
-		if err = dbConn.Model(connectorType).Where("id = ?", connectorType.ID).
-			UpdateColumn("checksum", cts.connectorsConfig.CatalogChecksums[connectorType.ID]).Error; err != nil {
-			return errors.GeneralError("failed to update connector type %s checksum: %v", entry.ConnectorType.Id, err.Error())
+	// reconcile channels
+	for channel, ccc := range entry.Channels {
+		ccc := ccc
+		err := f(entry.ConnectorType.Id, channel, &ccc)
+		if err != nil {
+			return err
 		}
 	}
+
+	// update type checksum for latest catalog shard metadata
+	dbConn := cts.connectionFactory.New()
+	if err = dbConn.Model(connectorType).Where("id = ?", connectorType.ID).
+		UpdateColumn("checksum", checksum).Error; err != nil {
+		return errors.GeneralError("failed to update connector type %s checksum: %v", entry.ConnectorType.Id, err.Error())
+	}
 	return nil
 }
 
@@ -450,7 +468,7 @@ func (cts *connectorTypesService) GetLatestConnectorShardMetadata(typeId, channe
 
 func (cts *connectorTypesService) CatalogEntriesReconciled() (bool, *errors.ServiceError) {
 	var typeIds []string
-	catalogChecksums := cts.connectorsConfig.CatalogChecksums
+	catalogChecksums := cts.connectorsConfig.GetCatalogChecksums()
 	for id := range catalogChecksums {
 		typeIds = append(typeIds, id)
 	}
@@ -474,8 +492,9 @@ func (cts *connectorTypesService) CatalogEntriesReconciled() (bool, *errors.Serv
 }
 
 func (cts *connectorTypesService) DeleteOrDeprecateRemovedTypes() *errors.ServiceError {
-	notToBeDeletedIDs := make([]string, len(cts.connectorsConfig.CatalogEntries))
-	for _, entry := range cts.connectorsConfig.CatalogEntries {
+	catalogEntries := cts.connectorsConfig.GetCatalogEntries()
+	notToBeDeletedIDs := make([]string, len(catalogEntries))
+	for _, entry := range catalogEntries {
 		notToBeDeletedIDs = append(notToBeDeletedIDs, entry.ConnectorType.Id)
 	}
 	glog.V(5).Infof("Connector Type IDs in catalog not to be deleted: %v", notToBeDeletedIDs)
@@ -507,6 +526,26 @@ func (cts *connectorTypesService) DeleteOrDeprecateRemovedTypes() *errors.Servic
 	return nil
 }
 
+func (cts *connectorTypesService) ListSunsetTypeIds(now time.Time) ([]string, *errors.ServiceError) {
+	var deprecatedTypes dbapi.ConnectorTypeList
+	if err := cts.connectionFactory.New().Select("id", "deprecated").Preload("Annotations").
+		Where("deprecated = ?", true).Find(&deprecatedTypes).Error; err != nil {
+		return nil, errors.GeneralError("failed to find deprecated connector types: %v", err)
+	}
+	ids := make([]string, 0)
+	for _, ct := range deprecatedTypes {
+		deprecation, err := ct.GetDeprecation()
+		if err != nil {
+			glog.Warningf("invalid deprecation for connector type %s: %v", ct.ID, err)
+			continue
+		}
+		if deprecation != nil && deprecation.IsSunset(now) {
+			ids = append(ids, ct.ID)
+		}
+	}
+	return ids, nil
+}
+
 // get ids that are used, but not in the latest catalog, i.e. deprecated
 func getDeprecatedTypes(usedConnectorTypeIDs []string, notToBeDeletedIDs []string) []string {
 	latestIds := make(map[string]struct{})
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"gorm.io/gorm"
+)
+
+// ConnectorUpgradeCampaignService upgrades connector deployments matching campaign selectors to the latest shard metadata of their channel
+type ConnectorUpgradeCampaignService interface {
+	// Create selects the deployments with available shard metadata upgrades that match the campaign, and assigns them to waves
+	Create(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign) *errors.ServiceError
+	Get(ctx context.Context, id string) (*dbapi.ConnectorUpgradeCampaign, *errors.ServiceError)
+	List(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorUpgradeCampaignList, *api.PagingMeta, *errors.ServiceError)
+	ListActive() (dbapi.ConnectorUpgradeCampaignList, *errors.ServiceError)
+	Update(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign) *errors.ServiceError
+	// Rollback requests moving all upgraded deployments of a campaign back to their previous shard metadata
+	Rollback(ctx context.Context, id string, reason string) (*dbapi.ConnectorUpgradeCampaign, *errors.ServiceError)
+	ListTargets(ctx context.Context, campaignId string) (dbapi.ConnectorUpgradeTargetList, *errors.ServiceError)
+	UpdateTarget(ctx context.Context, target *dbapi.ConnectorUpgradeTarget) *errors.ServiceError
+	// UpdateTargetDeployment assigns the shard metadata to the deployment of a target and updates the target in the same
+	// transaction, the target deployment version is set to the version of the updated deployment
+	UpdateTargetDeployment(ctx context.Context, target *dbapi.ConnectorUpgradeTarget, shardMetadataID int64) *errors.ServiceError
+}
+
+var _ ConnectorUpgradeCampaignService = &connectorUpgradeCampaignService{}
+
+type connectorUpgradeCampaignService struct {
+	connectionFactory     *db.ConnectionFactory
+	connectorTypesService ConnectorTypesService
+}
+
+func NewConnectorUpgradeCampaignService(connectionFactory *db.ConnectionFactory, connectorTypesService ConnectorTypesService) *connectorUpgradeCampaignService {
+	return &connectorUpgradeCampaignService{
+		connectionFactory:     connectionFactory,
+		connectorTypesService: connectorTypesService,
+	}
+}
+
+func GetValidUpgradeCampaignColumns() []string {
+	return []string{"connector_type_id", "channel", "cluster_id", "namespace_id", "phase", "requested_by", "created_at", "updated_at"}
+}
+
+func (k *connectorUpgradeCampaignService) Create(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign) *errors.ServiceError {
+	if campaign.WaveSize < 1 {
+		return errors.BadRequest("wave size must be at least 1")
+	}
+
+	dbConn := k.connectionFactory.New().Joins("ConnectorShardMetadata").Joins("Connector").
+		Where(`"ConnectorShardMetadata"."connector_type_id" = ?`, campaign.ConnectorTypeID).
+		Where(`"ConnectorShardMetadata"."latest_revision" IS NOT NULL`).
+		Where(`"Connector"."deleted_at" IS NULL`).
+		// deployments can't be upgraded by more than one campaign at a time
+		Where("connector_deployments.id NOT IN (?)", k.connectionFactory.New().Model(&dbapi.ConnectorUpgradeTarget{}).
+			Select("deployment_id").Where("phase IN ?", dbapi.ActiveConnectorUpgradeTargetPhases))
+	if campaign.Channel != "" {
+		dbConn = dbConn.Where(`"ConnectorShardMetadata"."channel" = ?`, campaign.Channel)
+	}
+	if campaign.Revision != 0 {
+		dbConn = dbConn.Where(`"ConnectorShardMetadata"."revision" = ?`, campaign.Revision)
+	}
+	if campaign.ClusterID != "" {
+		dbConn = dbConn.Where("connector_deployments.cluster_id = ?", campaign.ClusterID)
+	}
+	if campaign.NamespaceID != "" {
+		dbConn = dbConn.Where("connector_deployments.namespace_id = ?", campaign.NamespaceID)
+	}
+	var deployments dbapi.ConnectorDeploymentList
+	if err := dbConn.Order("connector_deployments.created_at").Find(&deployments).Error; err != nil {
+		return services.HandleGetError("Connector deployment", "connector_type_id", campaign.ConnectorTypeID, err)
+	}
+	if len(deployments) == 0 {
+		return errors.BadRequest("no connector deployments with available upgrades match the campaign")
+	}
+
+	campaign.ID = api.NewID()
+	campaign.Phase = dbapi.ConnectorUpgradeCampaignPhaseRunning
+	campaign.CurrentWave = 0
+	campaign.Waves = (len(deployments) + campaign.WaveSize - 1) / campaign.WaveSize
+
+	latest := make(map[string]*dbapi.ConnectorShardMetadata)
+	targets := make(dbapi.ConnectorUpgradeTargetList, len(deployments))
+	for i, deployment := range deployments {
+		channel := deployment.ConnectorShardMetadata.Channel
+		to, found := latest[channel]
+		if !found {
+			var serr *errors.ServiceError
+			if to, serr = k.connectorTypesService.GetLatestConnectorShardMetadata(campaign.ConnectorTypeID, channel); serr != nil {
+				return serr
+			}
+			latest[channel] = to
+		}
+		targets[i] = &dbapi.ConnectorUpgradeTarget{
+			Model: db.Model{
+				ID: api.NewID(),
+			},
+			CampaignID:          campaign.ID,
+			DeploymentID:        deployment.ID,
+			Wave:                i/campaign.WaveSize + 1,
+			FromShardMetadataID: deployment.ConnectorShardMetadataID,
+			ToShardMetadataID:   to.ID,
+			Phase:               dbapi.ConnectorUpgradeTargetPhasePending,
+		}
+	}
+
+	if err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(campaign).Error; err != nil {
+			return err
+		}
+		return tx.CreateInBatches(targets, 100).Error
+	}); err != nil {
+		return errors.GeneralError("failed to create connector upgrade campaign: %v", err)
+	}
+	campaign.Progress = map[dbapi.ConnectorUpgradeTargetPhase]int{
+		dbapi.ConnectorUpgradeTargetPhasePending: len(targets),
+	}
+
+	return nil
+}
+
+func (k *connectorUpgradeCampaignService) Get(ctx context.Context, id string) (*dbapi.ConnectorUpgradeCampaign, *errors.ServiceError) {
+	var result dbapi.ConnectorUpgradeCampaign
+	if err := k.connectionFactory.New().Where("id = ?", id).First(&result).Error; err != nil {
+		return nil, services.HandleGetError("Connector upgrade campaign", "id", id, err)
+	}
+	if err := k.setProgress(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (k *connectorUpgradeCampaignService) List(ctx context.Context, listArgs *services.ListArguments) (dbapi.ConnectorUpgradeCampaignList, *api.PagingMeta, *errors.ServiceError) {
+	if err := listArgs.Validate(GetValidUpgradeCampaignColumns()); err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list connector upgrade campaigns: %s", err.Error())
+	}
+	var resourceList dbapi.ConnectorUpgradeCampaignList
+	dbConn := k.connectionFactory.New()
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	// Apply search query
+	if len(listArgs.Search) > 0 {
+		queryParser := queryparser.NewQueryParser(GetValidUpgradeCampaignColumns()...)
+		searchDbQuery, err := queryParser.Parse(listArgs.Search)
+		if err != nil {
+			return resourceList, pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list connector upgrade campaigns: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	total := int64(pagingMeta.Total)
+	dbConn.Model(&resourceList).Count(&total)
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+	dbConn = dbConn.Offset((pagingMeta.Page - 1) * pagingMeta.Size).Limit(pagingMeta.Size)
+
+	if len(listArgs.OrderBy) == 0 {
+		// default orderBy latest first
+		dbConn = dbConn.Order("created_at DESC")
+	} else {
+		dbConn = dbConn.Order(strings.Join(listArgs.OrderBy, ","))
+	}
+
+	if err := dbConn.Find(&resourceList).Error; err != nil {
+		return resourceList, pagingMeta, services.HandleGetError("Connector upgrade campaign", "query", listArgs.Search, err)
+	}
+	for _, campaign := range resourceList {
+		if err := k.setProgress(campaign); err != nil {
+			return resourceList, pagingMeta, err
+		}
+	}
+
+	return resourceList, pagingMeta, nil
+}
+
+// ListActive returns all campaigns that are still in progress
+func (k *connectorUpgradeCampaignService) ListActive() (dbapi.ConnectorUpgradeCampaignList, *errors.ServiceError) {
+	var result dbapi.ConnectorUpgradeCampaignList
+	if err := k.connectionFactory.New().Where("phase IN ?", dbapi.ActiveConnectorUpgradeCampaignPhases).
+		Order("created_at").Find(&result).Error; err != nil {
+		return nil, services.HandleGetError("Connector upgrade campaign", "phase", dbapi.ActiveConnectorUpgradeCampaignPhases, err)
+	}
+	return result, nil
+}
+
+func (k *connectorUpgradeCampaignService) Update(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign) *errors.ServiceError {
+	if err := k.connectionFactory.New().Save(campaign).Error; err != nil {
+		return services.HandleUpdateError("Connector upgrade campaign", err)
+	}
+	return nil
+}
+
+func (k *connectorUpgradeCampaignService) Rollback(ctx context.Context, id string, reason string) (*dbapi.ConnectorUpgradeCampaign, *errors.ServiceError) {
+	campaign, serr := k.Get(ctx, id)
+	if serr != nil {
+		return nil, serr
+	}
+	if campaign.Phase != dbapi.ConnectorUpgradeCampaignPhaseRunning && campaign.Phase != dbapi.ConnectorUpgradeCampaignPhaseCompleted {
+		return nil, errors.Conflict("cannot roll back connector upgrade campaign %s in phase %s", id, campaign.Phase)
+	}
+	campaign.Phase = dbapi.ConnectorUpgradeCampaignPhaseRollingBack
+	campaign.Reason = reason
+	if serr := k.Update(ctx, campaign); serr != nil {
+		return nil, serr
+	}
+	return campaign, nil
+}
+
+func (k *connectorUpgradeCampaignService) ListTargets(ctx context.Context, campaignId string) (dbapi.ConnectorUpgradeTargetList, *errors.ServiceError) {
+	var result dbapi.ConnectorUpgradeTargetList
+	if err := k.connectionFactory.New().Where("campaign_id = ?", campaignId).
+		Order("wave, created_at").Find(&result).Error; err != nil {
+		return nil, services.HandleGetError("Connector upgrade target", "campaign_id", campaignId, err)
+	}
+	return result, nil
+}
+
+func (k *connectorUpgradeCampaignService) UpdateTarget(ctx context.Context, target *dbapi.ConnectorUpgradeTarget) *errors.ServiceError {
+	if err := k.connectionFactory.New().Save(target).Error; err != nil {
+		return services.HandleUpdateError("Connector upgrade target", err)
+	}
+	return nil
+}
+
+func (k *connectorUpgradeCampaignService) UpdateTargetDeployment(ctx context.Context, target *dbapi.ConnectorUpgradeTarget, shardMetadataID int64) *errors.ServiceError {
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		if err := dbConn.Model(&dbapi.ConnectorDeployment{}).Where("id = ?", target.DeploymentID).
+			Update("connector_shard_metadata_id", shardMetadataID).Error; err != nil {
+			return err
+		}
+		// agents report this version once they have applied the update
+		var deployment dbapi.ConnectorDeployment
+		if err := dbConn.Select("version").Where("id = ?", target.DeploymentID).First(&deployment).Error; err != nil {
+			return err
+		}
+		target.DeploymentVersion = deployment.Version
+		return dbConn.Save(target).Error
+	}); err != nil {
+		return services.HandleUpdateError("Connector upgrade target", err)
+	}
+	return nil
+}
+
+func (k *connectorUpgradeCampaignService) setProgress(campaign *dbapi.ConnectorUpgradeCampaign) *errors.ServiceError {
+	var counts []struct {
+		Phase dbapi.ConnectorUpgradeTargetPhase
+		Count int
+	}
+	if err := k.connectionFactory.New().Model(&dbapi.ConnectorUpgradeTarget{}).
+		Select("phase, count(*) as count").Where("campaign_id = ?", campaign.ID).
+		Group("phase").Scan(&counts).Error; err != nil {
+		return services.HandleGetError("Connector upgrade target", "campaign_id", campaign.ID, err)
+	}
+	campaign.Progress = make(map[dbapi.ConnectorUpgradeTargetPhase]int, len(counts))
+	for _, c := range counts {
+		campaign.Progress[c.Phase] = c.Count
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_connectorUpgradeCampaignService_UpdateTargetDeployment(t *testing.T) {
+	tests := []struct {
+		name             string
+		updateTargetFail bool
+		wantErr          bool
+	}{
+		{
+			name: "should update the deployment and the target",
+		},
+		{
+			name:             "should fail when the target can't be updated",
+			updateTargetFail: true,
+			wantErr:          true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			deploymentMock := mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "connector_deployments" SET "connector_shard_metadata_id"=$1,"updated_at"=$2 WHERE id = $3`).
+				WithRowsNum(1)
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT "version" FROM "connector_deployments" WHERE id = $1`).
+				WithReply([]map[string]interface{}{{"version": 3}})
+			targetMock := mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_upgrade_targets"`)
+			if tt.updateTargetFail {
+				targetMock.WithExecException()
+			}
+
+			k := connectorUpgradeCampaignService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			target := &dbapi.ConnectorUpgradeTarget{
+				Model:        db.Model{ID: "target"},
+				DeploymentID: "deployment",
+				Phase:        dbapi.ConnectorUpgradeTargetPhaseUpgrading,
+			}
+			err := k.UpdateTargetDeployment(context.Background(), target, 2)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(deploymentMock.Triggered).To(gomega.BeTrue())
+			if !tt.wantErr {
+				g.Expect(targetMock.Triggered).To(gomega.BeTrue())
+				g.Expect(target.DeploymentVersion).To(gomega.Equal(int64(3)))
+			}
+		})
+	}
+}
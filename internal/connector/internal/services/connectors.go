@@ -168,6 +168,13 @@ func (k *connectorsService) Delete(ctx context.Context, id string) *errors.Servi
 	if err := dbConn.Where("id = ?", id).Delete(&dbapi.ConnectorStatus{}).Error; err != nil {
 		return services.HandleGetError("ConnectorStatus", "id", id, err)
 	}
+	var revisions dbapi.ConnectorRevisionList
+	if err := dbConn.Where("connector_id = ?", id).Find(&revisions).Error; err != nil {
+		return services.HandleGetError("Connector revision", "connector_id", id, err)
+	}
+	if err := dbConn.Where("connector_id = ?", id).Delete(&dbapi.ConnectorRevision{}).Error; err != nil {
+		return errors.GeneralError("unable to delete revisions of connector with id %s: %s", resource.ID, err)
+	}
 
 	_ = db.AddPostCommitAction(ctx, func() {
 		// delete related distributed resources...
@@ -179,26 +186,23 @@ func (k *connectorsService) Delete(ctx context.Context, id string) *errors.Servi
 			}
 		}
 
-		if len(resource.ConnectorSpec) != 0 {
-			if ct, err := k.connectorTypesService.Get(resource.ConnectorTypeId); err == nil {
-				_, _ = secrets.ModifySecrets(ct.JsonSchema, resource.ConnectorSpec, func(node *ajson.Node) error {
-					if node.Type() != ajson.Object {
-						return nil
-					}
-					ref, err := node.GetKey("ref")
-					if err != nil {
-						return nil
-					}
-					r, err := ref.GetString()
-					if err != nil {
-						return nil
+		if ct, err := k.connectorTypesService.Get(resource.ConnectorTypeId); err == nil {
+			// secrets of previous revisions are kept in the vault for rollback
+			specs := []api.JSON{resource.ConnectorSpec}
+			for _, r := range revisions {
+				specs = append(specs, r.ConnectorSpec)
+			}
+			deleted := make(map[string]bool)
+			for _, spec := range specs {
+				for _, r := range GetConnectorSpecSecretRefs(ct, spec) {
+					if deleted[r] {
+						continue
 					}
-					err = k.vaultService.DeleteSecretString(r)
-					if err != nil {
+					deleted[r] = true
+					if err := k.vaultService.DeleteSecretString(r); err != nil {
 						logger.Logger.Errorf("failed to delete vault secret key '%s': %v", r, err)
 					}
-					return nil
-				})
+				}
 			}
 		}
 	})
@@ -206,6 +210,29 @@ func (k *connectorsService) Delete(ctx context.Context, id string) *errors.Servi
 	return nil
 }
 
+// GetConnectorSpecSecretRefs returns the vault keys referenced by secrets in a connector spec
+func GetConnectorSpecSecretRefs(ct *dbapi.ConnectorType, spec api.JSON) (result []string) {
+	if len(spec) == 0 {
+		return nil
+	}
+	_, _ = secrets.ModifySecrets(ct.JsonSchema, spec, func(node *ajson.Node) error {
+		if node.Type() != ajson.Object {
+			return nil
+		}
+		ref, err := node.GetKey("ref")
+		if err != nil {
+			return nil
+		}
+		r, err := ref.GetString()
+		if err != nil {
+			return nil
+		}
+		result = append(result, r)
+		return nil
+	})
+	return result
+}
+
 func GetValidConnectorColumns() []string {
 	// state should be replaced with column name connector_statuses.phase
 	return []string{"id", "created_at", "updated_at", "name", "owner", "organisation_id", "kafka_id", "connector_type_id", "desired_state", "state", "channel", "kafka_bootstrap_server", "service_account_client_id", "schema_registry_id", "schema_registry_url", "namespace_id"}
@@ -331,6 +358,11 @@ func (k *connectorsService) Update(ctx context.Context, resource *dbapi.Connecto
 			return errors.Conflict("resource version changed")
 		}
 
+		// the version is incremented by the connectors_version_trigger, read it back for the response and its entity tag
+		if err := dbConn.Table("connectors").Select("version").Where("id = ?", resource.ID).Scan(&resource.Version).Error; err != nil {
+			return services.HandleGetError("Connector", "id", resource.ID, err)
+		}
+
 		return nil
 
 	}); err != nil {
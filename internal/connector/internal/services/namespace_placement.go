@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/profiles"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
+	"github.com/goava/di"
+	"gorm.io/gorm/clause"
+)
+
+// NamespacePlacementStrategy selects a namespace for connectors created without a namespace id
+type NamespacePlacementStrategy interface {
+	// Enabled returns false if connectors without a namespace id should not be placed automatically
+	Enabled() bool
+	// PlaceConnector returns the namespace selected for the connector,
+	// or nil and the reasons why no namespace could be selected
+	PlaceConnector(ctx context.Context, connector *dbapi.Connector) (*dbapi.ConnectorNamespace, []string, *errors.ServiceError)
+}
+
+var _ NamespacePlacementStrategy = &namespacePlacementStrategy{}
+
+type namespacePlacementStrategy struct {
+	connectionFactory *db.ConnectionFactory
+	connectorsConfig  *config.ConnectorsConfig
+	quotaConfig       *config.ConnectorsQuotaConfig
+	kafkaLocator      kafkaaccess.KafkaLocator
+}
+
+// KafkaLocationOptions holds the locator of the Kafka instances connectors are attached to,
+// it is only provided when the fleet manager runs the Kafka module
+type KafkaLocationOptions struct {
+	di.Inject
+	KafkaLocator kafkaaccess.KafkaLocator `optional:"true"`
+}
+
+func NewNamespacePlacementStrategy(connectionFactory *db.ConnectionFactory, connectorsConfig *config.ConnectorsConfig,
+	quotaConfig *config.ConnectorsQuotaConfig, kafkaLocationOptions KafkaLocationOptions) *namespacePlacementStrategy {
+	return &namespacePlacementStrategy{
+		connectionFactory: connectionFactory,
+		connectorsConfig:  connectorsConfig,
+		quotaConfig:       quotaConfig,
+		kafkaLocator:      kafkaLocationOptions.KafkaLocator,
+	}
+}
+
+// namespaceCandidate holds a tenant namespace along with the information used to rank it for placement
+type namespaceCandidate struct {
+	namespace          *dbapi.ConnectorNamespace
+	clusterPhase       dbapi.ConnectorClusterPhaseEnum
+	clusterProvider    string
+	clusterRegion      string
+	connectors         int64
+	connectorsQuota    int32
+	affinity           int
+	availableHeadroom  float64
+	placementCandidate bool
+}
+
+func (p *namespacePlacementStrategy) Enabled() bool {
+	return p.connectorsConfig.ConnectorNamespacePlacement != "" &&
+		p.connectorsConfig.ConnectorNamespacePlacement != config.NamespacePlacementNone
+}
+
+func (p *namespacePlacementStrategy) PlaceConnector(ctx context.Context, connector *dbapi.Connector) (*dbapi.ConnectorNamespace, []string, *errors.ServiceError) {
+	candidates, err := p.getCandidates(connector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider, region, err := p.getKafkaLocation(connector)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch p.connectorsConfig.ConnectorNamespacePlacement {
+	case config.NamespacePlacementFirstAvailable:
+		namespace, reasons := placeFirstAvailable(candidates)
+		return namespace, reasons, nil
+	case config.NamespacePlacementBalanced:
+		namespace, reasons := placeBalanced(candidates, provider, region)
+		return namespace, reasons, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// getCandidates returns all ready namespaces of the connector's tenant along with their cluster and quota usage
+func (p *namespacePlacementStrategy) getCandidates(connector *dbapi.Connector) ([]*namespaceCandidate, *errors.ServiceError) {
+	dbConn := p.connectionFactory.New()
+
+	var namespaces dbapi.ConnectorNamespaceList
+	query := dbConn.Preload(clause.Associations).Where("status_phase = ?", dbapi.ConnectorNamespacePhaseReady)
+	if connector.OrganisationId != "" {
+		query = query.Where("(tenant_organisation_id = ? OR tenant_user_id = ?)", connector.OrganisationId, connector.Owner)
+	} else {
+		query = query.Where("tenant_user_id = ?", connector.Owner)
+	}
+	if err := query.Order("created_at").Find(&namespaces).Error; err != nil {
+		return nil, services.HandleGetError("Connector namespace", "tenant", connector.Owner, err)
+	}
+	if len(namespaces) == 0 {
+		return nil, nil
+	}
+
+	namespaceIds := make([]string, len(namespaces))
+	clusterIds := make([]string, 0, len(namespaces))
+	for i, ns := range namespaces {
+		namespaceIds[i] = ns.ID
+		clusterIds = append(clusterIds, ns.ClusterId)
+	}
+
+	var clusters dbapi.ConnectorClusterList
+	if err := dbConn.Preload("Annotations").Where("id IN ?", clusterIds).Find(&clusters).Error; err != nil {
+		return nil, services.HandleGetError("Connector cluster", "id", clusterIds, err)
+	}
+	clusterMap := make(map[string]*dbapi.ConnectorCluster, len(clusters))
+	for i := range clusters {
+		clusterMap[clusters[i].ID] = &clusters[i]
+	}
+
+	type namespaceCount struct {
+		NamespaceId string
+		Count       int64
+	}
+	var counts []namespaceCount
+	if err := dbConn.Model(&dbapi.Connector{}).Select("namespace_id, count(*) as count").
+		Where("namespace_id IN ?", namespaceIds).Group("namespace_id").
+		Scan(&counts).Error; err != nil {
+		return nil, services.HandleGetError("Connector", "namespace_id", namespaceIds, err)
+	}
+	countMap := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		countMap[c.NamespaceId] = c.Count
+	}
+
+	candidates := make([]*namespaceCandidate, len(namespaces))
+	for i, ns := range namespaces {
+		candidate := &namespaceCandidate{
+			namespace:  ns,
+			connectors: countMap[ns.ID],
+		}
+		for _, anno := range ns.Annotations {
+			if anno.Key == profiles.AnnotationProfileKey {
+				quota, _ := p.quotaConfig.GetNamespaceQuota(anno.Value)
+				candidate.connectorsQuota = quota.Connectors
+			}
+		}
+		if cluster, ok := clusterMap[ns.ClusterId]; ok {
+			candidate.clusterPhase = cluster.Status.Phase
+			for _, anno := range cluster.Annotations {
+				switch anno.Key {
+				case dbapi.ConnectorClusterCloudProviderAnnotation:
+					candidate.clusterProvider = anno.Value
+				case dbapi.ConnectorClusterRegionAnnotation:
+					candidate.clusterRegion = anno.Value
+				}
+			}
+		}
+		candidates[i] = candidate
+	}
+
+	return candidates, nil
+}
+
+// getKafkaLocation returns the cloud provider and region of the connector's Kafka,
+// the location is unknown when the Kafka instance is not managed by the fleet manager
+func (p *namespacePlacementStrategy) getKafkaLocation(connector *dbapi.Connector) (provider string, region string, err *errors.ServiceError) {
+	if p.kafkaLocator == nil || connector.Kafka.KafkaID == "" {
+		return "", "", nil
+	}
+	provider, region, err = p.kafkaLocator.GetKafkaLocation(connector.Kafka.KafkaID)
+	if err != nil {
+		if err.Is404() {
+			return "", "", nil
+		}
+		return "", "", errors.NewWithCause(errors.ErrorGeneral, err, "failed to get location of kafka %s for connector %s", connector.Kafka.KafkaID, connector.ID)
+	}
+	return provider, region, nil
+}
+
+// filterCandidates marks candidates that can accept another connector and returns reasons for the rest
+func filterCandidates(candidates []*namespaceCandidate) (reasons []string) {
+	if len(candidates) == 0 {
+		return []string{"no ready namespaces available for connector tenant"}
+	}
+	for _, c := range candidates {
+		c.placementCandidate = false
+		if c.clusterPhase != dbapi.ConnectorClusterPhaseReady {
+			reasons = append(reasons, fmt.Sprintf("namespace %s: cluster %s is in phase %s",
+				c.namespace.ID, c.namespace.ClusterId, c.clusterPhase))
+			continue
+		}
+		if c.connectorsQuota > 0 {
+			if c.connectors >= int64(c.connectorsQuota) {
+				reasons = append(reasons, fmt.Sprintf("namespace %s: connector quota %d reached",
+					c.namespace.ID, c.connectorsQuota))
+				continue
+			}
+			c.availableHeadroom = float64(int64(c.connectorsQuota)-c.connectors) / float64(c.connectorsQuota)
+		} else {
+			// namespaces without a connector quota are treated as fully available
+			c.availableHeadroom = 1
+		}
+		c.placementCandidate = true
+	}
+	return reasons
+}
+
+// placeFirstAvailable selects the oldest namespace that can accept another connector
+func placeFirstAvailable(candidates []*namespaceCandidate) (*dbapi.ConnectorNamespace, []string) {
+	reasons := filterCandidates(candidates)
+	for _, c := range candidates {
+		if c.placementCandidate {
+			return c.namespace, nil
+		}
+	}
+	return nil, reasons
+}
+
+// placeBalanced selects the namespace with the best cloud affinity with the connector's Kafka,
+// breaking ties by the most available quota headroom and the least number of connectors
+func placeBalanced(candidates []*namespaceCandidate, provider string, region string) (*dbapi.ConnectorNamespace, []string) {
+	reasons := filterCandidates(candidates)
+
+	var eligible []*namespaceCandidate
+	for _, c := range candidates {
+		if c.placementCandidate {
+			c.affinity = 0
+			if provider != "" && c.clusterProvider == provider {
+				c.affinity++
+				if region != "" && c.clusterRegion == region {
+					c.affinity++
+				}
+			}
+			eligible = append(eligible, c)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil, reasons
+	}
+
+	sort.SliceStable(eligible, func(i, j int) bool {
+		a, b := eligible[i], eligible[j]
+		if a.affinity != b.affinity {
+			return a.affinity > b.affinity
+		}
+		if a.availableHeadroom != b.availableHeadroom {
+			return a.availableHeadroom > b.availableHeadroom
+		}
+		return a.connectors < b.connectors
+	})
+
+	return eligible[0].namespace, nil
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
+	"github.com/onsi/gomega"
+)
+
+func newTestCandidate(id string, clusterPhase dbapi.ConnectorClusterPhaseEnum, provider string, region string,
+	connectors int64, quota int32) *namespaceCandidate {
+	return &namespaceCandidate{
+		namespace: &dbapi.ConnectorNamespace{
+			Model:     db.Model{ID: id},
+			ClusterId: "cluster-" + id,
+		},
+		clusterPhase:    clusterPhase,
+		clusterProvider: provider,
+		clusterRegion:   region,
+		connectors:      connectors,
+		connectorsQuota: quota,
+	}
+}
+
+func Test_placeFirstAvailable(t *testing.T) {
+	tests := []struct {
+		scenario    string
+		candidates  []*namespaceCandidate
+		wantID      string
+		wantReasons int
+	}{
+		{
+			scenario:    "no namespaces",
+			candidates:  nil,
+			wantReasons: 1,
+		},
+		{
+			scenario: "skips full and disconnected namespaces",
+			candidates: []*namespaceCandidate{
+				newTestCandidate("full", dbapi.ConnectorClusterPhaseReady, "", "", 4, 4),
+				newTestCandidate("disconnected", dbapi.ConnectorClusterPhaseDisconnected, "", "", 0, 4),
+				newTestCandidate("available", dbapi.ConnectorClusterPhaseReady, "", "", 3, 4),
+			},
+			wantID: "available",
+		},
+		{
+			scenario: "all namespaces full",
+			candidates: []*namespaceCandidate{
+				newTestCandidate("full1", dbapi.ConnectorClusterPhaseReady, "", "", 4, 4),
+				newTestCandidate("full2", dbapi.ConnectorClusterPhaseReady, "", "", 2, 2),
+			},
+			wantReasons: 2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.scenario, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			namespace, reasons := placeFirstAvailable(tt.candidates)
+			if tt.wantID == "" {
+				g.Expect(namespace).To(gomega.BeNil())
+				g.Expect(reasons).To(gomega.HaveLen(tt.wantReasons))
+			} else {
+				g.Expect(namespace).ToNot(gomega.BeNil())
+				g.Expect(namespace.ID).To(gomega.Equal(tt.wantID))
+			}
+		})
+	}
+}
+
+func Test_placeBalanced(t *testing.T) {
+	tests := []struct {
+		scenario   string
+		candidates []*namespaceCandidate
+		provider   string
+		region     string
+		wantID     string
+	}{
+		{
+			scenario: "prefers most headroom",
+			candidates: []*namespaceCandidate{
+				newTestCandidate("busy", dbapi.ConnectorClusterPhaseReady, "", "", 3, 4),
+				newTestCandidate("idle", dbapi.ConnectorClusterPhaseReady, "", "", 0, 4),
+			},
+			wantID: "idle",
+		},
+		{
+			scenario: "prefers region affinity over headroom",
+			candidates: []*namespaceCandidate{
+				newTestCandidate("other-region", dbapi.ConnectorClusterPhaseReady, "aws", "eu-west-1", 0, 4),
+				newTestCandidate("same-region", dbapi.ConnectorClusterPhaseReady, "aws", "us-east-1", 3, 4),
+				newTestCandidate("other-cloud", dbapi.ConnectorClusterPhaseReady, "gcp", "us-east-1", 0, 4),
+			},
+			provider: "aws",
+			region:   "us-east-1",
+			wantID:   "same-region",
+		},
+		{
+			scenario: "ignores affinity for full namespaces",
+			candidates: []*namespaceCandidate{
+				newTestCandidate("same-region", dbapi.ConnectorClusterPhaseReady, "aws", "us-east-1", 4, 4),
+				newTestCandidate("other-cloud", dbapi.ConnectorClusterPhaseReady, "gcp", "us-east-1", 1, 4),
+			},
+			provider: "aws",
+			region:   "us-east-1",
+			wantID:   "other-cloud",
+		},
+		{
+			scenario: "no namespace fits",
+			candidates: []*namespaceCandidate{
+				newTestCandidate("full", dbapi.ConnectorClusterPhaseReady, "aws", "us-east-1", 4, 4),
+				newTestCandidate("deleting", dbapi.ConnectorClusterPhaseDeleting, "aws", "us-east-1", 0, 4),
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.scenario, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			namespace, reasons := placeBalanced(tt.candidates, tt.provider, tt.region)
+			if tt.wantID == "" {
+				g.Expect(namespace).To(gomega.BeNil())
+				g.Expect(reasons).To(gomega.HaveLen(len(tt.candidates)))
+			} else {
+				g.Expect(namespace).ToNot(gomega.BeNil())
+				g.Expect(namespace.ID).To(gomega.Equal(tt.wantID))
+			}
+		})
+	}
+}
+
+func Test_namespacePlacementStrategy_getKafkaLocation(t *testing.T) {
+	locator := &kafkaaccess.KafkaLocatorMock{
+		GetKafkaLocationFunc: func(kafkaID string) (string, string, *errors.ServiceError) {
+			switch kafkaID {
+			case "kafka":
+				return "aws", "us-east-1", nil
+			case "failing-kafka":
+				return "", "", errors.GeneralError("unexpected error")
+			}
+			return "", "", errors.NotFound("KafkaResource with id='%s' not found", kafkaID)
+		},
+	}
+
+	tests := []struct {
+		name         string
+		locator      kafkaaccess.KafkaLocator
+		kafkaID      string
+		wantProvider string
+		wantRegion   string
+		wantErr      bool
+	}{
+		{name: "should use the location of the connector's kafka", locator: locator, kafkaID: "kafka", wantProvider: "aws", wantRegion: "us-east-1"},
+		{name: "should have no location without the kafka module", kafkaID: "kafka"},
+		{name: "should have no location for kafkas not managed by the fleet manager", locator: locator, kafkaID: "external-kafka"},
+		{name: "should return unexpected errors", locator: locator, kafkaID: "failing-kafka", wantErr: true},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			p := NewNamespacePlacementStrategy(nil, &config.ConnectorsConfig{}, nil, KafkaLocationOptions{KafkaLocator: tt.locator})
+			provider, region, err := p.getKafkaLocation(&dbapi.Connector{
+				Kafka: dbapi.KafkaConnectionSettings{KafkaID: tt.kafkaID, BootstrapServer: tt.kafkaID + ":443"},
+			})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(provider).To(gomega.Equal(tt.wantProvider))
+			g.Expect(region).To(gomega.Equal(tt.wantRegion))
+		})
+	}
+}
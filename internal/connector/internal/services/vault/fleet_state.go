@@ -0,0 +1,53 @@
+package vault
+
+import (
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/fleetstate"
+)
+
+// fleetStateSecretStore exports and imports the vault secrets of connectors with the fleet state
+type fleetStateSecretStore struct {
+	vault VaultService
+}
+
+var _ fleetstate.SecretStore = &fleetStateSecretStore{}
+
+func NewFleetStateSecretStore(vault VaultService) fleetstate.SecretStore {
+	return &fleetStateSecretStore{vault: vault}
+}
+
+func (s *fleetStateSecretStore) Name() string {
+	return "connectors-vault"
+}
+
+func (s *fleetStateSecretStore) ListSecrets() ([]fleetstate.Secret, error) {
+	var secrets []fleetstate.Secret
+	err := s.vault.ForEachSecret(func(name string, owningResource string) bool {
+		// the vault can hold secrets of other services, e.g. without a secret prefix
+		if strings.HasPrefix(owningResource, OwningResourcePrefix) {
+			secrets = append(secrets, fleetstate.Secret{Name: name, OwningResource: owningResource})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range secrets {
+		value, err := s.vault.GetSecretString(secrets[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		secrets[i].Value = value
+	}
+	return secrets, nil
+}
+
+func (s *fleetStateSecretStore) ImportSecret(secret fleetstate.Secret) error {
+	return s.vault.SetSecretString(secret.Name, secret.Value, secret.OwningResource)
+}
+
+func (s *fleetStateSecretStore) DeleteSecret(name string) error {
+	// the secret is created again when the import is retried
+	return s.vault.ForceDeleteSecretString(name)
+}
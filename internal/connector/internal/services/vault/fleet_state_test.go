@@ -0,0 +1,41 @@
+package vault_test
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/fleetstate"
+	"github.com/onsi/gomega"
+)
+
+func TestFleetStateSecretStore(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	source, err := vault.NewTmpVaultService()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(source.SetSecretString("secret-1", "value-1", vault.OwningResourcePrefix+"connector-1")).To(gomega.Succeed())
+	g.Expect(source.SetSecretString("secret-2", "value-2", vault.OwningResourcePrefix+"connector-2")).To(gomega.Succeed())
+	// secrets not owned by connectors aren't exported
+	g.Expect(source.SetSecretString("secret-3", "value-3", "")).To(gomega.Succeed())
+
+	secrets, err := vault.NewFleetStateSecretStore(source).ListSecrets()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(secrets).To(gomega.ConsistOf(
+		fleetstate.Secret{Name: "secret-1", OwningResource: vault.OwningResourcePrefix + "connector-1", Value: "value-1"},
+		fleetstate.Secret{Name: "secret-2", OwningResource: vault.OwningResourcePrefix + "connector-2", Value: "value-2"},
+	))
+
+	target, err := vault.NewTmpVaultService()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	store := vault.NewFleetStateSecretStore(target)
+	for _, secret := range secrets {
+		g.Expect(store.ImportSecret(secret)).To(gomega.Succeed())
+	}
+	value, err := target.GetSecretString("secret-2")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(value).To(gomega.Equal("value-2"))
+
+	g.Expect(store.DeleteSecret("secret-2")).To(gomega.Succeed())
+	_, err = target.GetSecretString("secret-2")
+	g.Expect(err).To(gomega.MatchError(vault.NotFound))
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
 )
 
+// OwningResourcePrefix is the prefix of the owning resource of the secrets of connectors
+const OwningResourcePrefix = "/v1/connector/"
+
 const (
 	KindTmp = "tmp"
 	KindAws = "aws"
@@ -16,6 +19,8 @@ type VaultService interface {
 	SetSecretString(name string, value string, owningResource string) error
 	GetSecretString(name string) (string, error)
 	DeleteSecretString(name string) error
+	// ForceDeleteSecretString deletes the secret without a recovery window, so that it can be created again right away
+	ForceDeleteSecretString(name string) error
 	ForEachSecret(f func(name string, owningResource string) bool) error
 	Kind() string
 }
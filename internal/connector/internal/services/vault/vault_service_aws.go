@@ -1,11 +1,14 @@
 package vault
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-secretsmanager-caching-go/secretcache"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
 )
@@ -16,7 +19,7 @@ var _ VaultService = &awsVaultService{}
 
 type awsVaultService struct {
 	secretCache        *secretcache.Cache
-	secretClient       *secretsmanager.SecretsManager
+	secretClient       secretsmanageriface.SecretsManagerAPI
 	secretPrefixEnable bool
 	secretPrefix       string
 }
@@ -121,6 +124,13 @@ func (k *awsVaultService) ForEachSecret(f func(name string, owningResource strin
 				name = *entry.Name
 			}
 			metrics.IncreaseVaultServiceSuccessCount("get")
+			if k.secretPrefixEnable {
+				// the name filter isn't case sensitive, and the names passed to the other methods don't have the prefix
+				if !strings.HasPrefix(name, k.secretPrefix) {
+					continue
+				}
+				name = strings.TrimPrefix(name, k.secretPrefix)
+			}
 			if !f(name, owner) {
 				return false
 			}
@@ -144,11 +154,24 @@ func getTag(tags []*secretsmanager.Tag, key string) string {
 }
 
 func (k *awsVaultService) DeleteSecretString(name string) error {
+	return k.deleteSecret(name, false)
+}
+
+func (k *awsVaultService) ForceDeleteSecretString(name string) error {
+	return k.deleteSecret(name, true)
+}
+
+// deleteSecret deletes the secret after the default recovery window, or right away if force is set
+func (k *awsVaultService) deleteSecret(name string, force bool) error {
 	name = k.getVaultSecretName(name)
 	metrics.IncreaseVaultServiceTotalCount("delete")
-	_, err := k.secretClient.DeleteSecret(&secretsmanager.DeleteSecretInput{
+	input := &secretsmanager.DeleteSecretInput{
 		SecretId: &name,
-	})
+	}
+	if force {
+		input.ForceDeleteWithoutRecovery = aws.Bool(true)
+	}
+	_, err := k.secretClient.DeleteSecret(input)
 	if err != nil {
 		switch err.(type) {
 		case *secretsmanager.ResourceNotFoundException:
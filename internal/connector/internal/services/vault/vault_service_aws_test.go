@@ -2,12 +2,20 @@ package vault
 
 import (
 	"fmt"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
-	"github.com/onsi/gomega"
 	"math/rand"
 	"os"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/aws/aws-secretsmanager-caching-go/secretcache"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/fleetstate"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/onsi/gomega"
 )
 
 func init() {
@@ -108,3 +116,126 @@ func Test_awsVaultService(t *testing.T) {
 		}
 	}
 }
+
+type fakeSecret struct {
+	value string
+	owner string
+	// deleted secrets are in their recovery window
+	deleted bool
+}
+
+// fakeSecretsManager keeps secrets in memory, like secrets manager deleted secrets can't be created again
+// until their recovery window is over, unless they are deleted without recovery
+type fakeSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+	secrets map[string]*fakeSecret
+}
+
+func (f *fakeSecretsManager) CreateSecret(input *secretsmanager.CreateSecretInput) (*secretsmanager.CreateSecretOutput, error) {
+	if secret, ok := f.secrets[*input.Name]; ok {
+		if secret.deleted {
+			return nil, &secretsmanager.InvalidRequestException{Message_: aws.String("secret is scheduled for deletion")}
+		}
+		return nil, &secretsmanager.ResourceExistsException{Message_: aws.String("secret already exists")}
+	}
+	secret := &fakeSecret{value: *input.SecretString}
+	for _, tag := range input.Tags {
+		if *tag.Key == OwnerResourceTagKey {
+			secret.owner = *tag.Value
+		}
+	}
+	f.secrets[*input.Name] = secret
+	return &secretsmanager.CreateSecretOutput{Name: input.Name}, nil
+}
+
+func (f *fakeSecretsManager) DeleteSecret(input *secretsmanager.DeleteSecretInput) (*secretsmanager.DeleteSecretOutput, error) {
+	secret, ok := f.secrets[*input.SecretId]
+	if !ok || secret.deleted {
+		return nil, &secretsmanager.ResourceNotFoundException{Message_: aws.String("secret not found")}
+	}
+	if aws.BoolValue(input.ForceDeleteWithoutRecovery) {
+		delete(f.secrets, *input.SecretId)
+	} else {
+		secret.deleted = true
+	}
+	return &secretsmanager.DeleteSecretOutput{Name: input.SecretId}, nil
+}
+
+func (f *fakeSecretsManager) ListSecretsPages(input *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error {
+	output := &secretsmanager.ListSecretsOutput{}
+	for name, secret := range f.secrets {
+		if secret.deleted {
+			continue
+		}
+		// the name filter is a prefix match that isn't case sensitive
+		if len(input.Filters) > 0 && !strings.HasPrefix(strings.ToLower(name), strings.ToLower(*input.Filters[0].Values[0])) {
+			continue
+		}
+		entry := &secretsmanager.SecretListEntry{Name: aws.String(name)}
+		if secret.owner != "" {
+			entry.Tags = []*secretsmanager.Tag{{Key: aws.String(OwnerResourceTagKey), Value: aws.String(secret.owner)}}
+		}
+		output.SecretList = append(output.SecretList, entry)
+	}
+	fn(output, true)
+	return nil
+}
+
+func (f *fakeSecretsManager) DescribeSecretWithContext(ctx aws.Context, input *secretsmanager.DescribeSecretInput, opts ...request.Option) (*secretsmanager.DescribeSecretOutput, error) {
+	if secret, ok := f.secrets[*input.SecretId]; !ok || secret.deleted {
+		return nil, &secretsmanager.ResourceNotFoundException{Message_: aws.String("secret not found")}
+	}
+	return &secretsmanager.DescribeSecretOutput{
+		Name:               input.SecretId,
+		VersionIdsToStages: map[string][]*string{"version": {aws.String("AWSCURRENT")}},
+	}, nil
+}
+
+func (f *fakeSecretsManager) GetSecretValueWithContext(ctx aws.Context, input *secretsmanager.GetSecretValueInput, opts ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	secret, ok := f.secrets[*input.SecretId]
+	if !ok || secret.deleted {
+		return nil, &secretsmanager.ResourceNotFoundException{Message_: aws.String("secret not found")}
+	}
+	return &secretsmanager.GetSecretValueOutput{Name: input.SecretId, SecretString: aws.String(secret.value)}, nil
+}
+
+func newFakeAwsVaultService(g *gomega.WithT, client *fakeSecretsManager) *awsVaultService {
+	cache, err := secretcache.New(func(cache *secretcache.Cache) {
+		cache.Client = client
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	return &awsVaultService{
+		secretClient:       client,
+		secretCache:        cache,
+		secretPrefixEnable: true,
+		secretPrefix:       "managed-connectors/",
+	}
+}
+
+func Test_awsVaultService_FleetStateWithPrefix(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	source := &fakeSecretsManager{secrets: map[string]*fakeSecret{
+		"managed-connectors/secret-1": {value: "value-1", owner: OwningResourcePrefix + "connector-1"},
+		// not owned by a connector
+		"managed-connectors/secret-2": {value: "value-2"},
+		// not under the secret prefix
+		"other/secret-3": {value: "value-3", owner: OwningResourcePrefix + "connector-3"},
+	}}
+	secrets, err := NewFleetStateSecretStore(newFakeAwsVaultService(g, source)).ListSecrets()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(secrets).To(gomega.ConsistOf(
+		fleetstate.Secret{Name: "secret-1", OwningResource: OwningResourcePrefix + "connector-1", Value: "value-1"},
+	))
+
+	target := &fakeSecretsManager{secrets: map[string]*fakeSecret{}}
+	store := NewFleetStateSecretStore(newFakeAwsVaultService(g, target))
+	g.Expect(store.ImportSecret(secrets[0])).To(gomega.Succeed())
+	g.Expect(target.secrets).To(gomega.HaveKey("managed-connectors/secret-1"))
+
+	// the secrets of a failed import are deleted right away, so that the import can be retried
+	g.Expect(store.DeleteSecret("secret-1")).To(gomega.Succeed())
+	g.Expect(target.secrets).To(gomega.BeEmpty())
+	g.Expect(store.ImportSecret(secrets[0])).To(gomega.Succeed())
+	g.Expect(target.secrets["managed-connectors/secret-1"].value).To(gomega.Equal("value-1"))
+}
@@ -34,6 +34,7 @@ func NewClusterManager(clusterService services.ConnectorClusterService, db *db.C
 			Id:         uuid.New().String(),
 			WorkerType: "connector_cluster",
 			Reconciler: reconciler,
+			Sharded:    true,
 		},
 		clusterService: clusterService,
 		db:             db,
@@ -75,6 +76,7 @@ func (m *ClusterManager) doReconcile(errs *[]error, kind string,
 		glog.Errorf("Error retrieving %s clusters: %s", kind, err)
 		*errs = append(*errs, err)
 	}
+	clusterIds = m.inShard(clusterIds)
 	if len(clusterIds) == 0 {
 		glog.V(5).Infof("No %s clusters", kind)
 		return
@@ -100,3 +102,14 @@ func (m *ClusterManager) doReconcile(errs *[]error, kind string,
 		glog.Errorf("Error reconciling %s clusters: %v", kind, derr)
 	}
 }
+
+// inShard returns the cluster ids in the shards of the worker
+func (m *ClusterManager) inShard(clusterIds []string) []string {
+	var result []string
+	for _, id := range clusterIds {
+		if m.InShard(id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,125 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/catalog"
+	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+const remoteCatalogTimeout = 30 * time.Second
+
+var _ workers.Worker = &ConnectorCatalogManager{}
+
+// ConnectorCatalogManager periodically loads the configured remote connector catalog, verifies its signature,
+// and applies new and changed connector types, deprecating or deleting connector types removed from the catalog.
+type ConnectorCatalogManager struct {
+	workers.BaseWorker
+	connectorsConfig        *config.ConnectorsConfig
+	connectorTypesService   services.ConnectorTypesService
+	connectorCatalogService services.ConnectorCatalogService
+	source                  catalog.Source
+	lastCheck               time.Time
+}
+
+func NewConnectorCatalogManager(connectorsConfig *config.ConnectorsConfig, connectorTypesService services.ConnectorTypesService,
+	connectorCatalogService services.ConnectorCatalogService, reconciler workers.Reconciler) *ConnectorCatalogManager {
+	return &ConnectorCatalogManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_catalog",
+			Reconciler: reconciler,
+		},
+		connectorsConfig:        connectorsConfig,
+		connectorTypesService:   connectorTypesService,
+		connectorCatalogService: connectorCatalogService,
+	}
+}
+
+func (k *ConnectorCatalogManager) Start() {
+	k.StartWorker(k)
+}
+
+func (k *ConnectorCatalogManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *ConnectorCatalogManager) Reconcile() []error {
+	remoteConfig := &k.connectorsConfig.ConnectorCatalogRemote
+	if !remoteConfig.Enabled() || time.Since(k.lastCheck) < remoteConfig.Interval {
+		return nil
+	}
+	glog.V(5).Infof("Reconciling remote connector catalog %s...", remoteConfig.URL)
+
+	if k.source == nil {
+		source, err := catalog.NewSource(remoteConfig.URL, &http.Client{Timeout: remoteCatalogTimeout})
+		if err != nil {
+			return []error{err}
+		}
+		k.source = source
+	}
+
+	// merge the catalog applied by another instance before looking for changes
+	if err := k.connectorCatalogService.LoadRemoteCatalog(); err != nil {
+		return []error{err}
+	}
+	k.lastCheck = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteCatalogTimeout)
+	defer cancel()
+	remote, document, err := catalog.Load(ctx, k.source, remoteConfig.SigningKey)
+	if err != nil {
+		return []error{fmt.Errorf("failed to load remote connector catalog %s: %w", remoteConfig.URL, err)}
+	}
+
+	applied, serr := k.connectorCatalogService.GetRemoteCatalog()
+	if serr != nil && !serr.Is404() {
+		return []error{serr}
+	}
+	if applied != nil && applied.Checksum == document.Checksum() {
+		glog.V(5).Infof("Remote connector catalog revision %s is unchanged", applied.Revision)
+		return nil
+	}
+
+	checksums, changed, err := k.connectorsConfig.DiffRemoteCatalog(remote.ConnectorTypes)
+	if err != nil {
+		return []error{err}
+	}
+	changedIds := make(map[string]struct{}, len(changed))
+	for _, id := range changed {
+		changedIds[id] = struct{}{}
+	}
+	for _, entry := range remote.ConnectorTypes {
+		entry := entry
+		if _, ok := changedIds[entry.ConnectorType.Id]; !ok {
+			continue
+		}
+		if err := k.connectorTypesService.PutConnectorCatalogEntry(&entry, checksums[entry.ConnectorType.Id],
+			func(id string, channel string, ccc *config.ConnectorChannelConfig) *serviceError.ServiceError {
+				return reconcileConnectorCatalogEntry(k.connectorTypesService, id, channel, ccc)
+			}); err != nil {
+			return []error{err}
+		}
+	}
+
+	// types removed from the catalog are only removed once the catalog has been updated
+	k.connectorsConfig.SetRemoteCatalog(remote.Revision, remote.ConnectorTypes, checksums)
+	if err := k.connectorTypesService.DeleteOrDeprecateRemovedTypes(); err != nil {
+		return []error{err}
+	}
+	if err := k.connectorCatalogService.SaveRemoteCatalog(remoteConfig.URL, remote.Revision, document.Checksum(), remote.ConnectorTypes); err != nil {
+		return []error{err}
+	}
+
+	glog.Infof("Applied remote connector catalog revision %s from %s with %d new or changed connector types",
+		remote.Revision, remoteConfig.URL, len(changed))
+	return nil
+}
@@ -3,11 +3,15 @@ package workers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/phase"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
@@ -26,7 +30,10 @@ type ConnectorManager struct {
 	connectorClusterService services.ConnectorClusterService
 	connectorTypesService   services.ConnectorTypesService
 	vaultService            vault.VaultService
+	placementStrategy       services.NamespacePlacementStrategy
+	connectorsConfig        *config.ConnectorsConfig
 	lastVersion             int64
+	lastShards              *workers.Shards
 	db                      *db.ConnectionFactory
 	ctx                     context.Context
 }
@@ -37,6 +44,8 @@ func NewConnectorManager(
 	connectorService services.ConnectorsService,
 	connectorClusterService services.ConnectorClusterService,
 	vaultService vault.VaultService,
+	placementStrategy services.NamespacePlacementStrategy,
+	connectorsConfig *config.ConnectorsConfig,
 	db *db.ConnectionFactory,
 	reconciler workers.Reconciler,
 ) *ConnectorManager {
@@ -45,11 +54,14 @@ func NewConnectorManager(
 			Id:         uuid.New().String(),
 			WorkerType: "connector",
 			Reconciler: reconciler,
+			Sharded:    true,
 		},
 		connectorService:        connectorService,
 		connectorClusterService: connectorClusterService,
 		connectorTypesService:   connectorTypesService,
 		vaultService:            vaultService,
+		placementStrategy:       placementStrategy,
+		connectorsConfig:        connectorsConfig,
 		db:                      db,
 	}
 
@@ -78,9 +90,25 @@ func (k *ConnectorManager) Reconcile() []error {
 		k.ctx = ctx
 	}
 
-	// reconcile assigning connectors in "ready" desired state with "assigning" phase and a valid namespace id
+	// reconcile all the connector updates again when the shards of the worker change,
+	// since the updates of the connectors of the new shards may be older than the last version
+	if shards := k.GetShards(); !shards.Equal(k.lastShards) {
+		k.lastShards = shards
+		k.lastVersion = 0
+	}
+
+	// place connectors in "ready" desired state with "assigning" phase and no namespace id,
+	// placement is done by a single replica in the sharded mode to avoid racing for namespaces
+	if k.placementStrategy.Enabled() && k.IsShardLeader() {
+		k.doReconcile(&errs, "placing", k.reconcilePlacing,
+			"desired_state = ? AND phase = ? AND connectors.namespace_id IS NULL", dbapi.ConnectorReady, dbapi.ConnectorStatusPhaseAssigning)
+	}
+
+	// reconcile assigning connectors in "ready" desired state, or "stopped" desired state while being migrated,
+	// with "assigning" phase and a valid namespace id
 	k.doReconcile(&errs, "assigning", k.reconcileAssigning,
-		"desired_state = ? AND phase = ? AND connectors.namespace_id IS NOT NULL", dbapi.ConnectorReady, dbapi.ConnectorStatusPhaseAssigning)
+		"(desired_state = ? OR desired_state = ? AND connectors.id IN (SELECT connector_id FROM connector_namespace_migrations WHERE phase IN ? AND deleted_at IS NULL)) AND phase = ? AND connectors.namespace_id IS NOT NULL",
+		dbapi.ConnectorReady, dbapi.ConnectorStopped, dbapi.ActiveConnectorMigrationPhases, dbapi.ConnectorStatusPhaseAssigning)
 
 	// reconcile unassigned connectors in "unassigned" desired state and "deleted" phase
 	k.doReconcile(&errs, "unassigned", k.reconcileUnassigned,
@@ -95,6 +123,30 @@ func (k *ConnectorManager) Reconcile() []error {
 		"desired_state = ? AND phase IN ?", dbapi.ConnectorDeleted,
 		[]string{string(dbapi.ConnectorStatusPhaseAssigning), string(dbapi.ConnectorStatusPhaseDeleted)})
 
+	sunsetTypeIds, serr := k.connectorTypesService.ListSunsetTypeIds(time.Now())
+	if serr != nil {
+		errs = append(errs, serr)
+	}
+
+	// restart connectors in "ready" desired state reported as "failed" by the agent, unless they are being migrated,
+	// or are being stopped because their connector type is past its sunset date
+	stopSunset := k.connectorsConfig.ConnectorSunsetAction == config.SunsetActionStop
+	if serr == nil || !stopSunset {
+		failedQuery := "desired_state = ? AND phase = ? AND connectors.id NOT IN (SELECT connector_id FROM connector_namespace_migrations WHERE phase IN ? AND deleted_at IS NULL)"
+		failedArgs := []interface{}{dbapi.ConnectorReady, dbapi.ConnectorStatusPhaseFailed, dbapi.ActiveConnectorMigrationPhases}
+		if stopSunset && len(sunsetTypeIds) > 0 {
+			failedQuery += " AND connectors.connector_type_id NOT IN ?"
+			failedArgs = append(failedArgs, sunsetTypeIds)
+		}
+		k.doReconcile(&errs, "failed", k.reconcileFailed, failedQuery, failedArgs...)
+	}
+
+	// report or stop connectors in "ready" desired state of deprecated connector types past their sunset date
+	if len(sunsetTypeIds) > 0 {
+		k.doReconcile(&errs, "sunset", k.reconcileSunset,
+			"desired_state = ? AND connectors.namespace_id IS NOT NULL AND connectors.connector_type_id IN ?", dbapi.ConnectorReady, sunsetTypeIds)
+	}
+
 	// reconcile connector updates for assigned connectors that aren't being deleted...
 	k.doReconcile(&errs, "updated", k.reconcileConnectorUpdate,
 		"version > ? AND phase NOT IN ?", k.lastVersion,
@@ -144,6 +196,43 @@ func GetShardMetadataRevision(connectorShardMetadata map[string]interface{}) (in
 	}
 }
 
+func (k *ConnectorManager) reconcilePlacing(ctx context.Context, connector *dbapi.Connector) error {
+	if err := k.db.New().Model(&dbapi.ConnectorAnnotation{}).
+		Where("connector_id = ?", connector.ID).Find(&connector.Annotations).Error; err != nil {
+		return errors.Wrapf(err, "failed to get annotations for connector %s", connector.ID)
+	}
+
+	namespace, reasons, err := k.placementStrategy.PlaceConnector(ctx, connector)
+	if err != nil {
+		return errors.Wrapf(err, "failed to place connector %s", connector.ID)
+	}
+
+	if namespace == nil {
+		// report why the connector can't be placed, and try again in the next reconcile
+		reason := strings.Join(reasons, "; ")
+		if reason != connector.Status.Reason {
+			connector.Status.Reason = reason
+			if err = k.connectorService.SaveStatus(ctx, connector.Status); err != nil {
+				return errors.Wrapf(err, "failed to update placement reason for connector %s", connector.ID)
+			}
+		}
+		return nil
+	}
+
+	glog.V(5).Infof("Placing connector %s in namespace %s", connector.ID, namespace.ID)
+	if err := k.db.New().Model(&connector).Where("id = ?", connector.ID).
+		Update("namespace_id", namespace.ID).Error; err != nil {
+		return errors.Wrapf(err, "failed to update namespace_id for connector %s", connector.ID)
+	}
+	connector.NamespaceId = &namespace.ID
+	connector.Status.Reason = ""
+	if err = k.connectorService.SaveStatus(ctx, connector.Status); err != nil {
+		return errors.Wrapf(err, "failed to clear placement reason for connector %s", connector.ID)
+	}
+
+	return nil
+}
+
 func (k *ConnectorManager) reconcileAssigning(ctx context.Context, connector *dbapi.Connector) error {
 	var namespace *dbapi.ConnectorNamespace
 	namespace, err := k.connectorClusterService.FindAvailableNamespace(connector.Owner, connector.OrganisationId, connector.NamespaceId)
@@ -232,6 +321,159 @@ func (k *ConnectorManager) reconcileDeleted(ctx context.Context, connector *dbap
 	return nil
 }
 
+func (k *ConnectorManager) reconcileFailed(ctx context.Context, connector *dbapi.Connector) error {
+	policy, invalid, err := k.getRestartPolicy(connector)
+	if err != nil {
+		return err
+	}
+	if invalid != nil {
+		return k.giveUpRestarts(ctx, connector, invalid.Error())
+	}
+	if !policy.Enabled() {
+		return nil
+	}
+
+	now := time.Now()
+	status := &connector.Status
+	if status.RestartWindowStart == nil || now.Sub(*status.RestartWindowStart) > policy.Window {
+		// restarts in an expired window no longer count
+		status.RestartCount = 0
+		status.RestartWindowStart = nil
+	}
+	if status.RestartCount >= policy.MaxAttempts {
+		return k.giveUpRestarts(ctx, connector, fmt.Sprintf("connector failed after %d automatic restarts in %s, not restarting it again",
+			status.RestartCount, policy.Window))
+	}
+	if status.LastRestartAt != nil && status.RestartCount > 0 &&
+		now.Sub(*status.LastRestartAt) < policy.BackoffFor(status.RestartCount-1) {
+		// wait for backoff to expire
+		return nil
+	}
+
+	var namespace dbapi.ConnectorNamespace
+	if err := k.db.New().Where("id = ?", connector.NamespaceId).First(&namespace).Error; err != nil {
+		return errors.Wrapf(err, "failed to get namespace for connector %s", connector.ID)
+	}
+	if _, serr := phase.PerformConnectorOperation(&namespace, connector, phase.RestartConnector); serr != nil {
+		return k.giveUpRestarts(ctx, connector, fmt.Sprintf("connector can't be restarted: %s", serr.Reason))
+	}
+
+	// record conditions reported by the agent for the failed deployment
+	deployment, serr := k.connectorClusterService.GetDeploymentByConnectorId(ctx, connector.ID)
+	if serr != nil && !serr.Is404() {
+		return errors.Wrapf(serr, "failed to get deployment for connector %s", connector.ID)
+	}
+	status.LastFailure = deployment.Status.Conditions
+
+	glog.Infof("Restarting failed connector %s, restart %d of %d", connector.ID, status.RestartCount+1, policy.MaxAttempts)
+	if status.RestartWindowStart == nil {
+		status.RestartWindowStart = &now
+	}
+	status.RestartCount++
+	status.LastRestartAt = &now
+	status.Phase = phase.ConnectorStartingPhase[phase.RestartConnector]
+	status.Reason = ""
+	if err := k.connectorService.SaveStatus(ctx, *status); err != nil {
+		return errors.Wrapf(err, "failed to update restart status for connector %s", connector.ID)
+	}
+
+	// touching the connector bumps its version, which is propagated to the deployment so the agent restarts it
+	if err := k.db.New().Model(&dbapi.Connector{}).Where("id = ?", connector.ID).
+		Update("desired_state", connector.DesiredState).Error; err != nil {
+		return errors.Wrapf(err, "failed to update version for connector %s", connector.ID)
+	}
+
+	return nil
+}
+
+// getRestartPolicy returns the configured restart policy overridden by connector type and connector annotations,
+// an invalid annotation is returned as a reason for not restarting the connector
+func (k *ConnectorManager) getRestartPolicy(connector *dbapi.Connector) (policy config.ConnectorRestartPolicy, invalid error, err error) {
+	connectorType, serr := k.connectorTypesService.Get(connector.ConnectorTypeId)
+	if serr != nil {
+		return policy, nil, errors.Wrapf(serr, "failed to get connector type for connector %s", connector.ID)
+	}
+	var annotations []dbapi.ConnectorAnnotation
+	if err := k.db.New().Where("connector_id = ?", connector.ID).Find(&annotations).Error; err != nil {
+		return policy, nil, errors.Wrapf(err, "failed to get annotations for connector %s", connector.ID)
+	}
+
+	typeAnnotations := make(map[string]string, len(connectorType.Annotations))
+	for _, anno := range connectorType.Annotations {
+		typeAnnotations[anno.Key] = anno.Value
+	}
+	connectorAnnotations := make(map[string]string, len(annotations))
+	for _, anno := range annotations {
+		connectorAnnotations[anno.Key] = anno.Value
+	}
+
+	policy = k.connectorsConfig.ConnectorRestartPolicy
+	if policy, invalid = policy.WithAnnotations(typeAnnotations); invalid == nil {
+		policy, invalid = policy.WithAnnotations(connectorAnnotations)
+	}
+	return policy, invalid, nil
+}
+
+// giveUpRestarts reports why a failed connector is not restarted automatically
+func (k *ConnectorManager) giveUpRestarts(ctx context.Context, connector *dbapi.Connector, reason string) error {
+	if connector.Status.Reason == reason {
+		return nil
+	}
+	glog.Warningf("Not restarting failed connector %s: %s", connector.ID, reason)
+	connector.Status.Reason = reason
+	if err := k.connectorService.SaveStatus(ctx, connector.Status); err != nil {
+		return errors.Wrapf(err, "failed to update restart reason for connector %s", connector.ID)
+	}
+	return nil
+}
+
+// reconcileSunset reports or stops a connector of a deprecated connector type past its sunset date, depending on the sunset action
+func (k *ConnectorManager) reconcileSunset(ctx context.Context, connector *dbapi.Connector) error {
+	connectorType, serr := k.connectorTypesService.Get(connector.ConnectorTypeId)
+	if serr != nil {
+		return errors.Wrapf(serr, "failed to get connector type for connector %s", connector.ID)
+	}
+	deprecation, err := connectorType.GetDeprecation()
+	if err != nil || deprecation == nil {
+		return errors.Errorf("invalid deprecation for connector type %s: %v", connectorType.ID, err)
+	}
+
+	if k.connectorsConfig.ConnectorSunsetAction != config.SunsetActionStop {
+		reason := fmt.Sprintf("connector type %s reached its sunset date, %s", connectorType.ID, deprecation)
+		if connector.Status.Reason == reason {
+			return nil
+		}
+		glog.Warningf("Connector %s: %s", connector.ID, reason)
+		connector.Status.Reason = reason
+		if err := k.connectorService.SaveStatus(ctx, connector.Status); err != nil {
+			return errors.Wrapf(err, "failed to update sunset reason for connector %s", connector.ID)
+		}
+		return nil
+	}
+
+	var namespace dbapi.ConnectorNamespace
+	if err := k.db.New().Where("id = ?", connector.NamespaceId).First(&namespace).Error; err != nil {
+		return errors.Wrapf(err, "failed to get namespace for connector %s", connector.ID)
+	}
+	if _, serr := phase.PerformConnectorOperation(&namespace, connector, phase.StopConnector); serr != nil {
+		return errors.Wrapf(serr, "failed to stop connector %s", connector.ID)
+	}
+
+	glog.Infof("Stopping connector %s, connector type %s reached its sunset date", connector.ID, connectorType.ID)
+	connector.Status.Phase = phase.ConnectorStartingPhase[phase.StopConnector]
+	connector.Status.ResetRestarts()
+	connector.Status.Reason = fmt.Sprintf("connector was stopped, connector type %s reached its sunset date, %s", connectorType.ID, deprecation)
+	if err := k.connectorService.SaveStatus(ctx, connector.Status); err != nil {
+		return errors.Wrapf(err, "failed to update sunset status for connector %s", connector.ID)
+	}
+	if err := k.db.New().Model(&dbapi.Connector{}).Where("id = ?", connector.ID).
+		Update("desired_state", connector.DesiredState).Error; err != nil {
+		return errors.Wrapf(err, "failed to stop connector %s", connector.ID)
+	}
+
+	return nil
+}
+
 func (k *ConnectorManager) reconcileConnectorUpdate(ctx context.Context, connector *dbapi.Connector) (err error) {
 
 	// Get the deployment for the connector...
@@ -267,6 +509,9 @@ func (k *ConnectorManager) doReconcile(errs *[]error, reconcilePhase string, rec
 	var serviceErrs []error
 	glog.V(5).Infof("Reconciling %s connectors...", reconcilePhase)
 	if serviceErrs = k.connectorService.ForEach(func(connector *dbapi.Connector) *serviceError.ServiceError {
+		if reconcilePhase != "placing" && !k.InShard(connector.ID) {
+			return nil
+		}
 		return InDBTransaction(k.ctx, func(ctx context.Context) error {
 			if err := reconcileFunc(ctx, connector); err != nil {
 				glog.Errorf("Failed to reconcile %s connector %s in phase %s: %v", reconcilePhase,
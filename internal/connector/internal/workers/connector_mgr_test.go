@@ -0,0 +1,394 @@
+package workers
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+type placementStrategyStub struct {
+	services.NamespacePlacementStrategy
+}
+
+func (s *placementStrategyStub) Enabled() bool {
+	return false
+}
+
+type connectorTypesServiceStub struct {
+	services.ConnectorTypesService
+	connectorType *dbapi.ConnectorType
+	sunsetTypeIds []string
+}
+
+func (s *connectorTypesServiceStub) Get(id string) (*dbapi.ConnectorType, *serviceError.ServiceError) {
+	return s.connectorType, nil
+}
+
+func (s *connectorTypesServiceStub) ListSunsetTypeIds(now time.Time) ([]string, *serviceError.ServiceError) {
+	return s.sunsetTypeIds, nil
+}
+
+// connectorsServiceStub records saved connector statuses
+type connectorsServiceStub struct {
+	services.ConnectorsService
+	saved []dbapi.ConnectorStatus
+}
+
+func (s *connectorsServiceStub) SaveStatus(ctx context.Context, resource dbapi.ConnectorStatus) *serviceError.ServiceError {
+	s.saved = append(s.saved, resource)
+	return nil
+}
+
+func (s *deploymentServiceStub) GetDeploymentByConnectorId(ctx context.Context, connectorID string) (dbapi.ConnectorDeployment, *serviceError.ServiceError) {
+	for _, deployment := range s.deployments {
+		if deployment.ConnectorID == connectorID {
+			return *deployment, nil
+		}
+	}
+	return dbapi.ConnectorDeployment{}, serviceError.NotFound("connector deployment for connector %s not found", connectorID)
+}
+
+func newTestConnectorManager(connectionFactory *db.ConnectionFactory, connectorService services.ConnectorsService,
+	connectorTypesService services.ConnectorTypesService, connectorsConfig *config.ConnectorsConfig) *ConnectorManager {
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery("txid_current").WithReply([]map[string]interface{}{{"txid_current": 1}})
+	return NewConnectorManager(connectorTypesService, connectorService, &deploymentServiceStub{}, nil,
+		&placementStrategyStub{}, connectorsConfig, connectionFactory, workers.Reconciler{})
+}
+
+func Test_ConnectorManager_Reconcile_assigningMigratedConnectors(t *testing.T) {
+	g := gomega.NewWithT(t)
+	connectionFactory := db.NewMockConnectionFactory(nil)
+	m := newTestConnectorManager(connectionFactory, services.NewConnectorsService(connectionFactory, nil, nil, nil),
+		&connectorTypesServiceStub{}, &config.ConnectorsConfig{})
+
+	var assigningArgs []driver.NamedValue
+	mocket.Catcher.NewMock().WithQuery(`SELECT "connectors"."id"`).WithCallback(func(query string, args []driver.NamedValue) {
+		if strings.Contains(query, "desired_state = $1 OR desired_state = $2 AND connectors.id IN (SELECT connector_id FROM connector_namespace_migrations") {
+			assigningArgs = args
+		}
+	})
+
+	g.Expect(m.Reconcile()).To(gomega.BeEmpty())
+	// only stopped connectors with active migrations are assigned, stopped connectors are otherwise left unassigned
+	g.Expect(assigningArgs).ToNot(gomega.BeEmpty())
+	var values []interface{}
+	for _, arg := range assigningArgs {
+		values = append(values, arg.Value)
+	}
+	g.Expect(values).To(gomega.ContainElements(string(dbapi.ConnectorReady), string(dbapi.ConnectorStopped),
+		string(dbapi.ConnectorMigrationPhaseStopping), string(dbapi.ConnectorStatusPhaseAssigning)))
+}
+
+func Test_ConnectorManager_Reconcile_failedSunsetConnectors(t *testing.T) {
+	tests := []struct {
+		name             string
+		sunsetAction     string
+		wantSunsetFailed bool
+	}{
+		{
+			name:             "should restart failed connectors of sunset connector types when they are only reported",
+			sunsetAction:     config.SunsetActionReport,
+			wantSunsetFailed: true,
+		},
+		{
+			name:             "should not restart failed connectors of sunset connector types when they are stopped",
+			sunsetAction:     config.SunsetActionStop,
+			wantSunsetFailed: false,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			m := newTestConnectorManager(connectionFactory, services.NewConnectorsService(connectionFactory, nil, nil, nil),
+				&connectorTypesServiceStub{sunsetTypeIds: []string{"sunset-type"}},
+				&config.ConnectorsConfig{ConnectorSunsetAction: tt.sunsetAction})
+
+			var failedQuery string
+			var failedArgs []interface{}
+			mocket.Catcher.NewMock().WithQuery(`SELECT "connectors"."id"`).WithCallback(func(query string, args []driver.NamedValue) {
+				if strings.Contains(query, "connectors.id NOT IN (SELECT connector_id FROM connector_namespace_migrations") {
+					failedQuery = query
+					for _, arg := range args {
+						failedArgs = append(failedArgs, arg.Value)
+					}
+				}
+			})
+
+			g.Expect(m.Reconcile()).To(gomega.BeEmpty())
+			g.Expect(failedQuery).ToNot(gomega.BeEmpty())
+			if tt.wantSunsetFailed {
+				g.Expect(failedQuery).ToNot(gomega.ContainSubstring("connector_type_id NOT IN"))
+				g.Expect(failedArgs).ToNot(gomega.ContainElement("sunset-type"))
+			} else {
+				g.Expect(failedQuery).To(gomega.ContainSubstring("connector_type_id NOT IN"))
+				g.Expect(failedArgs).To(gomega.ContainElement("sunset-type"))
+			}
+		})
+	}
+}
+
+func Test_ConnectorManager_reconcileSunset(t *testing.T) {
+	tests := []struct {
+		name         string
+		sunsetAction string
+		wantStopped  bool
+		wantReason   string
+	}{
+		{
+			name:         "should report connectors of sunset connector types",
+			sunsetAction: config.SunsetActionReport,
+			wantReason:   "connector type connector-type reached its sunset date",
+		},
+		{
+			name:         "should stop connectors of sunset connector types and reset their restarts",
+			sunsetAction: config.SunsetActionStop,
+			wantStopped:  true,
+			wantReason:   "connector was stopped, connector type connector-type reached its sunset date",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			connectorService := &connectorsServiceStub{}
+			m := newTestConnectorManager(connectionFactory, connectorService,
+				&connectorTypesServiceStub{connectorType: &dbapi.ConnectorType{
+					Model: db.Model{ID: "connector-type"},
+					Annotations: []dbapi.ConnectorTypeAnnotation{
+						{ConnectorTypeID: "connector-type", Key: dbapi.ConnectorTypeDeprecationSunsetAnnotation, Value: "2020-01-01"},
+					},
+				}},
+				&config.ConnectorsConfig{ConnectorSunsetAction: tt.sunsetAction})
+
+			mocket.Catcher.NewMock().WithQuery(`FROM "connector_namespaces"`).
+				WithReply([]map[string]interface{}{{"id": "namespace", "status_phase": string(dbapi.ConnectorNamespacePhaseReady)}})
+			var stopped bool
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "connectors" SET "desired_state"`).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					stopped = args[0].Value == string(dbapi.ConnectorStopped)
+				})
+
+			namespaceID := "namespace"
+			now := time.Now()
+			connector := &dbapi.Connector{
+				Model:           db.Model{ID: "connector"},
+				NamespaceId:     &namespaceID,
+				ConnectorTypeId: "connector-type",
+				DesiredState:    dbapi.ConnectorReady,
+				Status: dbapi.ConnectorStatus{
+					Model:              db.Model{ID: "connector"},
+					Phase:              dbapi.ConnectorStatusPhaseFailed,
+					RestartCount:       2,
+					RestartWindowStart: &now,
+					LastRestartAt:      &now,
+				},
+			}
+			g.Expect(m.reconcileSunset(context.Background(), connector)).To(gomega.Succeed())
+
+			g.Expect(stopped).To(gomega.Equal(tt.wantStopped))
+			g.Expect(connectorService.saved).To(gomega.HaveLen(1))
+			saved := connectorService.saved[0]
+			g.Expect(saved.Reason).To(gomega.HavePrefix(tt.wantReason))
+			if !tt.wantStopped {
+				g.Expect(saved.Phase).To(gomega.Equal(dbapi.ConnectorStatusPhaseFailed))
+				g.Expect(saved.RestartCount).To(gomega.Equal(2))
+				return
+			}
+			g.Expect(saved.Phase).To(gomega.Equal(dbapi.ConnectorStatusPhaseAssigned))
+			g.Expect(saved.RestartCount).To(gomega.BeZero())
+			g.Expect(saved.RestartWindowStart).To(gomega.BeNil())
+			g.Expect(saved.LastRestartAt).To(gomega.BeNil())
+		})
+	}
+}
+
+func Test_ConnectorManager_reconcileFailed(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	policy := config.ConnectorRestartPolicy{MaxAttempts: 3, Backoff: time.Minute, Window: time.Hour}
+	conditions := api.JSON(`[{"type":"Ready","status":"False","reason":"Error"}]`)
+
+	tests := []struct {
+		name            string
+		policy          config.ConnectorRestartPolicy
+		status          dbapi.ConnectorStatus
+		annotations     map[string]string
+		namespacePhase  dbapi.ConnectorNamespacePhaseEnum
+		noDeployment    bool
+		wantRestarts    int
+		wantRestarted   bool
+		wantReason      string
+		wantNoStatus    bool
+		wantWindowReset bool
+	}{
+		{
+			name:         "should not restart connectors when restarts are disabled",
+			policy:       config.ConnectorRestartPolicy{Backoff: time.Minute, Window: time.Hour},
+			wantNoStatus: true,
+		},
+		{
+			name:          "should restart a failed connector and record the deployment conditions",
+			policy:        policy,
+			wantRestarts:  1,
+			wantRestarted: true,
+		},
+		{
+			name:          "should restart a failed connector without a deployment",
+			policy:        policy,
+			noDeployment:  true,
+			wantRestarts:  1,
+			wantRestarted: true,
+		},
+		{
+			name:         "should wait for the backoff to expire",
+			policy:       policy,
+			status:       dbapi.ConnectorStatus{RestartCount: 2, RestartWindowStart: ago(10 * time.Minute), LastRestartAt: ago(90 * time.Second)},
+			wantNoStatus: true,
+		},
+		{
+			name:          "should restart again once the doubled backoff expired",
+			policy:        policy,
+			status:        dbapi.ConnectorStatus{RestartCount: 2, RestartWindowStart: ago(10 * time.Minute), LastRestartAt: ago(3 * time.Minute)},
+			wantRestarts:  3,
+			wantRestarted: true,
+		},
+		{
+			name:       "should give up after the maximum restarts in the window",
+			policy:     policy,
+			status:     dbapi.ConnectorStatus{RestartCount: 3, RestartWindowStart: ago(10 * time.Minute), LastRestartAt: ago(5 * time.Minute)},
+			wantReason: "connector failed after 3 automatic restarts in 1h0m0s, not restarting it again",
+		},
+		{
+			name:   "should not update a connector it already gave up on",
+			policy: policy,
+			status: dbapi.ConnectorStatus{RestartCount: 3, RestartWindowStart: ago(10 * time.Minute), LastRestartAt: ago(5 * time.Minute),
+				Reason: "connector failed after 3 automatic restarts in 1h0m0s, not restarting it again"},
+			wantNoStatus: true,
+		},
+		{
+			name:            "should start counting restarts again once the window expired",
+			policy:          policy,
+			status:          dbapi.ConnectorStatus{RestartCount: 3, RestartWindowStart: ago(2 * time.Hour), LastRestartAt: ago(90 * time.Minute)},
+			wantRestarts:    1,
+			wantRestarted:   true,
+			wantWindowReset: true,
+		},
+		{
+			name:          "should apply connector annotations over the configured policy",
+			policy:        policy,
+			status:        dbapi.ConnectorStatus{RestartCount: 3, RestartWindowStart: ago(10 * time.Minute), LastRestartAt: ago(5 * time.Minute)},
+			annotations:   map[string]string{config.RestartMaxAttemptsAnnotation: "5", config.RestartBackoffAnnotation: "1m"},
+			wantRestarts:  4,
+			wantRestarted: true,
+		},
+		{
+			name:        "should report invalid restart annotations",
+			policy:      policy,
+			annotations: map[string]string{config.RestartMaxAttemptsAnnotation: "many"},
+			wantReason:  `invalid annotation cos.bf2.org/restart-max-attempts value "many": must be a non-negative integer`,
+		},
+		{
+			name:           "should give up restarting connectors in namespaces that can't restart them",
+			policy:         policy,
+			namespacePhase: dbapi.ConnectorNamespacePhaseDeleting,
+			wantReason:     "connector can't be restarted: ",
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			connectorService := &connectorsServiceStub{}
+			m := newTestConnectorManager(connectionFactory, connectorService,
+				&connectorTypesServiceStub{connectorType: &dbapi.ConnectorType{Model: db.Model{ID: "connector-type"}}},
+				&config.ConnectorsConfig{ConnectorRestartPolicy: tt.policy})
+			deployments := &deploymentServiceStub{deployments: map[string]*dbapi.ConnectorDeployment{}}
+			if !tt.noDeployment {
+				deployments.deployments["deployment"] = &dbapi.ConnectorDeployment{
+					Model:       db.Model{ID: "deployment"},
+					ConnectorID: "connector",
+					Status:      dbapi.ConnectorDeploymentStatus{Phase: dbapi.ConnectorStatusPhaseFailed, Conditions: conditions},
+				}
+			}
+			m.connectorClusterService = deployments
+
+			var annotations []map[string]interface{}
+			for k, v := range tt.annotations {
+				annotations = append(annotations, map[string]interface{}{"connector_id": "connector", "key": k, "value": v})
+			}
+			mocket.Catcher.NewMock().WithQuery(`FROM "connector_annotations"`).WithReply(annotations)
+			namespacePhase := tt.namespacePhase
+			if namespacePhase == "" {
+				namespacePhase = dbapi.ConnectorNamespacePhaseReady
+			}
+			mocket.Catcher.NewMock().WithQuery(`FROM "connector_namespaces"`).
+				WithReply([]map[string]interface{}{{"id": "namespace", "status_phase": string(namespacePhase)}})
+			var touched bool
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "connectors" SET "desired_state"`).
+				WithCallback(func(string, []driver.NamedValue) { touched = true })
+
+			namespaceID := "namespace"
+			status := tt.status
+			status.ID = "connector"
+			status.Phase = dbapi.ConnectorStatusPhaseFailed
+			connector := &dbapi.Connector{
+				Model:           db.Model{ID: "connector"},
+				NamespaceId:     &namespaceID,
+				ConnectorTypeId: "connector-type",
+				DesiredState:    dbapi.ConnectorReady,
+				Status:          status,
+			}
+			g.Expect(m.reconcileFailed(context.Background(), connector)).To(gomega.Succeed())
+
+			g.Expect(touched).To(gomega.Equal(tt.wantRestarted))
+			if tt.wantNoStatus {
+				g.Expect(connectorService.saved).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(connectorService.saved).To(gomega.HaveLen(1))
+			saved := connectorService.saved[0]
+			if !tt.wantRestarted {
+				g.Expect(saved.Reason).To(gomega.HavePrefix(tt.wantReason))
+				g.Expect(saved.Phase).To(gomega.Equal(dbapi.ConnectorStatusPhaseFailed))
+				g.Expect(saved.RestartCount).To(gomega.Equal(tt.status.RestartCount))
+				return
+			}
+			g.Expect(saved.Reason).To(gomega.BeEmpty())
+			g.Expect(saved.Phase).To(gomega.Equal(dbapi.ConnectorStatusPhaseAssigned))
+			g.Expect(saved.RestartCount).To(gomega.Equal(tt.wantRestarts))
+			g.Expect(saved.LastRestartAt).ToNot(gomega.BeNil())
+			g.Expect(*saved.LastRestartAt).To(gomega.BeTemporally(">=", now))
+			g.Expect(saved.RestartWindowStart).ToNot(gomega.BeNil())
+			if tt.status.RestartWindowStart == nil || tt.wantWindowReset {
+				g.Expect(*saved.RestartWindowStart).To(gomega.Equal(*saved.LastRestartAt))
+			} else {
+				g.Expect(*saved.RestartWindowStart).To(gomega.Equal(*tt.status.RestartWindowStart))
+			}
+			if tt.noDeployment {
+				g.Expect(saved.LastFailure).To(gomega.BeNil())
+			} else {
+				g.Expect(saved.LastFailure).To(gomega.Equal(conditions))
+			}
+		})
+	}
+}
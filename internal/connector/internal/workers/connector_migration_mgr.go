@@ -0,0 +1,175 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+var _ workers.Worker = &ConnectorMigrationManager{}
+
+// ConnectorMigrationManager moves connectors between namespaces by removing the deployment in the source namespace,
+// waiting for the agent to delete it, and assigning the connector to the target namespace.
+// Connectors that don't become ready in the target namespace are moved back to their source namespace.
+type ConnectorMigrationManager struct {
+	workers.BaseWorker
+	migrationService services.ConnectorNamespaceMigrationService
+	connectorsConfig *config.ConnectorsConfig
+	db               *db.ConnectionFactory
+	ctx              context.Context
+}
+
+func NewConnectorMigrationManager(migrationService services.ConnectorNamespaceMigrationService,
+	connectorsConfig *config.ConnectorsConfig, db *db.ConnectionFactory, reconciler workers.Reconciler) *ConnectorMigrationManager {
+	return &ConnectorMigrationManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_migration",
+			Reconciler: reconciler,
+		},
+		migrationService: migrationService,
+		connectorsConfig: connectorsConfig,
+		db:               db,
+	}
+}
+
+func (m *ConnectorMigrationManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *ConnectorMigrationManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *ConnectorMigrationManager) Reconcile() []error {
+	glog.V(5).Infoln("Reconciling connector migrations...")
+	if m.ctx == nil {
+		ctx, err := m.db.NewContext(context.Background())
+		if err != nil {
+			return []error{err}
+		}
+		m.ctx = ctx
+	}
+
+	migrations, serr := m.migrationService.ListActive()
+	if serr != nil {
+		return []error{serr}
+	}
+	if len(migrations) == 0 {
+		glog.V(5).Infoln("No active connector migrations")
+		return nil
+	}
+
+	var errs []error
+	for _, migration := range migrations {
+		if err := InDBTransaction(m.ctx, func(ctx context.Context) error {
+			return m.reconcileMigration(ctx, migration)
+		}); err != nil {
+			glog.Errorf("Failed to reconcile migration %s of connector %s in phase %s: %v",
+				migration.ID, migration.ConnectorID, migration.Phase, err)
+			errs = append(errs, err)
+		}
+	}
+	glog.V(5).Infof("Reconciled %d connector migrations with %d errors", len(migrations), len(errs))
+
+	return errs
+}
+
+func (m *ConnectorMigrationManager) reconcileMigration(ctx context.Context, migration *dbapi.ConnectorNamespaceMigration) error {
+	var connector dbapi.Connector
+	if err := m.db.New().Unscoped().Joins("Status").
+		Where("connectors.id = ?", migration.ConnectorID).First(&connector).Error; err != nil {
+		return errors.Wrapf(err, "failed to get connector %s", migration.ConnectorID)
+	}
+	if connector.DeletedAt.Valid || connector.DesiredState == dbapi.ConnectorDeleted {
+		return m.updatePhase(ctx, migration, dbapi.ConnectorMigrationPhaseFailed, "connector was deleted")
+	}
+
+	// connector deployment has been removed once the connector has been unassigned from its namespace
+	unassigned := connector.DesiredState == dbapi.ConnectorUnassigned && connector.NamespaceId == nil &&
+		connector.Status.Phase == dbapi.ConnectorStatusPhaseAssigning
+
+	switch migration.Phase {
+	case dbapi.ConnectorMigrationPhaseStopping:
+		if !unassigned {
+			// waiting for the agent to remove the source deployment
+			return nil
+		}
+		now := time.Now()
+		migration.DeployingSince = &now
+		setMigrationPhase(migration, dbapi.ConnectorMigrationPhaseDeploying, "")
+		return m.migrationService.AssignConnector(ctx, migration, migration.TargetNamespaceID)
+
+	case dbapi.ConnectorMigrationPhaseDeploying:
+		reason := ""
+		switch {
+		case isMigratedConnectorRunning(migration, &connector):
+			return m.updatePhase(ctx, migration, dbapi.ConnectorMigrationPhaseCompleted, "")
+		case connector.Status.Phase == dbapi.ConnectorStatusPhaseFailed:
+			reason = fmt.Sprintf("connector failed in target namespace %s", migration.TargetNamespaceID)
+		case isMigrationTimedOut(migration, m.connectorsConfig.ConnectorMigrationTimeout):
+			reason = fmt.Sprintf("connector was not ready in target namespace %s after %s",
+				migration.TargetNamespaceID, m.connectorsConfig.ConnectorMigrationTimeout)
+		default:
+			// still deploying
+			return nil
+		}
+		glog.Warningf("Rolling back migration %s of connector %s: %s", migration.ID, connector.ID, reason)
+		migration.DeployingSince = nil
+		setMigrationPhase(migration, dbapi.ConnectorMigrationPhaseRollingBack, reason)
+		return m.migrationService.UnassignConnector(ctx, migration)
+
+	case dbapi.ConnectorMigrationPhaseRollingBack:
+		if !unassigned {
+			// waiting for the agent to remove the target deployment
+			return nil
+		}
+		setMigrationPhase(migration, dbapi.ConnectorMigrationPhaseRolledBack, migration.Reason)
+		return m.migrationService.AssignConnector(ctx, migration, migration.SourceNamespaceID)
+	}
+
+	return nil
+}
+
+func (m *ConnectorMigrationManager) updatePhase(ctx context.Context, migration *dbapi.ConnectorNamespaceMigration,
+	phase dbapi.ConnectorMigrationPhase, reason string) error {
+	setMigrationPhase(migration, phase, reason)
+	if err := m.migrationService.Update(ctx, migration); err != nil {
+		return err
+	}
+	return nil
+}
+
+// setMigrationPhase sets the migration phase, connector assignments update the migration in the same transaction
+func setMigrationPhase(migration *dbapi.ConnectorNamespaceMigration, phase dbapi.ConnectorMigrationPhase, reason string) {
+	glog.V(5).Infof("Connector %s migration %s phase changed from %s to %s", migration.ConnectorID, migration.ID, migration.Phase, phase)
+	migration.Phase = phase
+	migration.Reason = reason
+}
+
+// isMigratedConnectorRunning returns true if the connector reached its desired state in the target namespace
+func isMigratedConnectorRunning(migration *dbapi.ConnectorNamespaceMigration, connector *dbapi.Connector) bool {
+	if connector.NamespaceId == nil || *connector.NamespaceId != migration.TargetNamespaceID {
+		return false
+	}
+	switch migration.DesiredState {
+	case dbapi.ConnectorStopped:
+		return connector.Status.Phase == dbapi.ConnectorStatusPhaseStopped
+	default:
+		return connector.Status.Phase == dbapi.ConnectorStatusPhaseReady
+	}
+}
+
+// isMigrationTimedOut returns true if the connector has been deploying in the target namespace for longer than the timeout
+func isMigrationTimedOut(migration *dbapi.ConnectorNamespaceMigration, timeout time.Duration) bool {
+	return migration.DeployingSince != nil && timeout > 0 && time.Since(*migration.DeployingSince) > timeout
+}
@@ -27,6 +27,7 @@ type ConnectorTypeManager struct {
 	workers.BaseWorker
 	connectorClusterService services.ConnectorClusterService
 	connectorTypesService   services.ConnectorTypesService
+	connectorCatalogService services.ConnectorCatalogService
 	startupReconcileDone    bool
 	startupReconcileWG      sync.WaitGroup
 }
@@ -42,6 +43,7 @@ func NewConnectorTypeManager(
 	connectorTypesService services.ConnectorTypesService,
 	connectorService services.ConnectorsService,
 	connectorClusterService services.ConnectorClusterService,
+	connectorCatalogService services.ConnectorCatalogService,
 	vaultService vault.VaultService,
 	db *db.ConnectionFactory,
 	reconciler workers.Reconciler,
@@ -55,6 +57,7 @@ func NewConnectorTypeManager(
 		},
 		connectorClusterService: connectorClusterService,
 		connectorTypesService:   connectorTypesService,
+		connectorCatalogService: connectorCatalogService,
 		startupReconcileDone:    false,
 	}
 
@@ -90,6 +93,11 @@ func (k *ConnectorTypeManager) Reconcile() []error {
 	if !k.startupReconcileDone {
 		glog.V(5).Infoln("Reconciling startup connector catalog updates...")
 
+		// merge the remote catalog applied by a previous run, so that its types aren't removed
+		if err := k.connectorCatalogService.LoadRemoteCatalog(); err != nil {
+			return []error{err}
+		}
+
 		// the assumption here is that this runs on one instance only of fleetmanager,
 		// runs only at startup and while requests are not being served
 		// this call handles types that are not in catalog anymore,
@@ -119,6 +127,10 @@ func (k *ConnectorTypeManager) Reconcile() []error {
 }
 
 func (k *ConnectorTypeManager) ReconcileConnectorCatalogEntry(id string, channel string, connectorChannelConfig *config.ConnectorChannelConfig) *serviceError.ServiceError {
+	return reconcileConnectorCatalogEntry(k.connectorTypesService, id, channel, connectorChannelConfig)
+}
+
+func reconcileConnectorCatalogEntry(connectorTypesService services.ConnectorTypesService, id string, channel string, connectorChannelConfig *config.ConnectorChannelConfig) *serviceError.ServiceError {
 
 	connectorShardMetadata := dbapi.ConnectorShardMetadata{
 		ConnectorTypeId: id,
@@ -137,7 +149,7 @@ func (k *ConnectorTypeManager) ReconcileConnectorCatalogEntry(id string, channel
 
 	// We store connector type channels so that we can track changes and trigger redeployment of
 	// associated connectors upon connector type channel changes.
-	_, serr := k.connectorTypesService.PutConnectorShardMetadata(&connectorShardMetadata)
+	_, serr := connectorTypesService.PutConnectorShardMetadata(&connectorShardMetadata)
 	if serr != nil {
 		return serr
 	}
@@ -150,8 +162,12 @@ func (k *ConnectorTypeManager) runStartupReconcileCheckWorker() {
 		for !k.startupReconcileDone {
 			glog.V(5).Infoln("Waiting for startup connector catalog updates...")
 			// this check that ConnectorTypes in the current configured catalog have the same checksum of the one
-			// stored in the db (comparing them by id).
-			done, err := k.connectorTypesService.CatalogEntriesReconciled()
+			// stored in the db (comparing them by id), including types from the applied remote catalog.
+			var done bool
+			err := k.connectorCatalogService.LoadRemoteCatalog()
+			if err == nil {
+				done, err = k.connectorTypesService.CatalogEntriesReconciled()
+			}
 			if err != nil {
 				glog.Errorf("Error checking catalog entry checksums: %s", err)
 			} else if done {
@@ -0,0 +1,249 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+var _ workers.Worker = &ConnectorUpgradeCampaignManager{}
+
+// ConnectorUpgradeCampaignManager upgrades the deployments targeted by upgrade campaigns one wave at a time.
+// A wave starts when all deployments in the previous wave have been upgraded, and campaigns are rolled back
+// when more deployments fail to upgrade than their failure budget allows.
+type ConnectorUpgradeCampaignManager struct {
+	workers.BaseWorker
+	campaignService         services.ConnectorUpgradeCampaignService
+	connectorClusterService services.ConnectorClusterService
+	db                      *db.ConnectionFactory
+	ctx                     context.Context
+}
+
+func NewConnectorUpgradeCampaignManager(campaignService services.ConnectorUpgradeCampaignService,
+	connectorClusterService services.ConnectorClusterService, db *db.ConnectionFactory, reconciler workers.Reconciler) *ConnectorUpgradeCampaignManager {
+	return &ConnectorUpgradeCampaignManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "connector_upgrade_campaign",
+			Reconciler: reconciler,
+		},
+		campaignService:         campaignService,
+		connectorClusterService: connectorClusterService,
+		db:                      db,
+	}
+}
+
+func (m *ConnectorUpgradeCampaignManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *ConnectorUpgradeCampaignManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *ConnectorUpgradeCampaignManager) Reconcile() []error {
+	glog.V(5).Infoln("Reconciling connector upgrade campaigns...")
+	if m.ctx == nil {
+		ctx, err := m.db.NewContext(context.Background())
+		if err != nil {
+			return []error{err}
+		}
+		m.ctx = ctx
+	}
+
+	campaigns, serr := m.campaignService.ListActive()
+	if serr != nil {
+		return []error{serr}
+	}
+	if len(campaigns) == 0 {
+		glog.V(5).Infoln("No active connector upgrade campaigns")
+		return nil
+	}
+
+	var errs []error
+	for _, campaign := range campaigns {
+		if err := m.reconcileCampaign(m.ctx, campaign); err != nil {
+			glog.Errorf("Failed to reconcile upgrade campaign %s in phase %s: %v", campaign.ID, campaign.Phase, err)
+			errs = append(errs, err)
+		}
+	}
+	glog.V(5).Infof("Reconciled %d connector upgrade campaigns with %d errors", len(campaigns), len(errs))
+
+	return errs
+}
+
+func (m *ConnectorUpgradeCampaignManager) reconcileCampaign(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign) error {
+	targets, serr := m.campaignService.ListTargets(ctx, campaign.ID)
+	if serr != nil {
+		return serr
+	}
+
+	// the deployment and target updates of each upgrade are committed together, and the campaign is only updated once
+	// all the upgrades of a step are, so a failed step is retried from the same wave on the next reconcile
+	if campaign.Phase == dbapi.ConnectorUpgradeCampaignPhaseRunning {
+		if err := m.reconcileWave(ctx, campaign, targets); err != nil {
+			return err
+		}
+	}
+
+	if campaign.Phase == dbapi.ConnectorUpgradeCampaignPhaseRollingBack {
+		if err := m.reconcileRollback(ctx, campaign, targets); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileWave checks the upgrades in the current wave, and starts the next wave once they are all done,
+// or rolls back the campaign when its failure budget is exceeded
+func (m *ConnectorUpgradeCampaignManager) reconcileWave(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign, targets dbapi.ConnectorUpgradeTargetList) error {
+	var failed, inProgress int
+	for _, target := range targets {
+		if target.Phase == dbapi.ConnectorUpgradeTargetPhaseUpgrading {
+			if err := m.checkUpgrade(ctx, campaign, target); err != nil {
+				return err
+			}
+		}
+		switch target.Phase {
+		case dbapi.ConnectorUpgradeTargetPhaseFailed:
+			failed++
+		case dbapi.ConnectorUpgradeTargetPhaseUpgrading:
+			inProgress++
+		}
+	}
+
+	switch {
+	case failed > campaign.FailureBudget:
+		campaign.Phase = dbapi.ConnectorUpgradeCampaignPhaseRollingBack
+		campaign.Reason = fmt.Sprintf("%d deployments failed to upgrade, exceeding the failure budget of %d", failed, campaign.FailureBudget)
+		glog.Warningf("Rolling back connector upgrade campaign %s: %s", campaign.ID, campaign.Reason)
+	case inProgress > 0:
+		// wait for the current wave
+		return nil
+	case campaign.CurrentWave < campaign.Waves:
+		campaign.CurrentWave++
+		for _, target := range targets {
+			if target.Wave == campaign.CurrentWave && target.Phase == dbapi.ConnectorUpgradeTargetPhasePending {
+				if err := m.startUpgrade(ctx, target); err != nil {
+					return err
+				}
+			}
+		}
+		glog.Infof("Started wave %d of %d of connector upgrade campaign %s", campaign.CurrentWave, campaign.Waves, campaign.ID)
+	default:
+		campaign.Phase = dbapi.ConnectorUpgradeCampaignPhaseCompleted
+		glog.Infof("Completed connector upgrade campaign %s with %d failed deployments", campaign.ID, failed)
+	}
+	if serr := m.campaignService.Update(ctx, campaign); serr != nil {
+		return serr
+	}
+	return nil
+}
+
+// reconcileRollback moves all upgraded deployments of a campaign back to their previous shard metadata
+func (m *ConnectorUpgradeCampaignManager) reconcileRollback(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign, targets dbapi.ConnectorUpgradeTargetList) error {
+	for _, target := range targets {
+		if err := m.rollbackUpgrade(ctx, target); err != nil {
+			return err
+		}
+	}
+	campaign.Phase = dbapi.ConnectorUpgradeCampaignPhaseRolledBack
+	if serr := m.campaignService.Update(ctx, campaign); serr != nil {
+		return serr
+	}
+	glog.Infof("Rolled back connector upgrade campaign %s", campaign.ID)
+	return nil
+}
+
+// startUpgrade assigns the latest shard metadata to a deployment, unless it has been deleted or changed since the campaign started
+func (m *ConnectorUpgradeCampaignManager) startUpgrade(ctx context.Context, target *dbapi.ConnectorUpgradeTarget) error {
+	deployment, serr := m.connectorClusterService.GetDeployment(ctx, target.DeploymentID)
+	if serr != nil {
+		if serr.Is404() || serr.Code == serviceError.ErrorGone {
+			return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseSkipped, "deployment was deleted")
+		}
+		return serr
+	}
+	if deployment.ConnectorShardMetadataID != target.FromShardMetadataID {
+		return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseSkipped, "deployment shard metadata changed after the campaign started")
+	}
+
+	now := time.Now()
+	target.Phase = dbapi.ConnectorUpgradeTargetPhaseUpgrading
+	target.Reason = ""
+	target.UpgradingSince = &now
+	if serr := m.campaignService.UpdateTargetDeployment(ctx, target, target.ToShardMetadataID); serr != nil {
+		return serr
+	}
+	return nil
+}
+
+// checkUpgrade updates the target phase from the status reported by the agent for the upgraded deployment
+func (m *ConnectorUpgradeCampaignManager) checkUpgrade(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign, target *dbapi.ConnectorUpgradeTarget) error {
+	deployment, serr := m.connectorClusterService.GetDeployment(ctx, target.DeploymentID)
+	if serr != nil {
+		if serr.Is404() || serr.Code == serviceError.ErrorGone {
+			return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseSkipped, "deployment was deleted")
+		}
+		return serr
+	}
+
+	if deployment.Status.Version >= target.DeploymentVersion {
+		switch deployment.Status.Phase {
+		case dbapi.ConnectorStatusPhaseReady, dbapi.ConnectorStatusPhaseStopped:
+			return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseUpgraded, "")
+		case dbapi.ConnectorStatusPhaseFailed:
+			return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseFailed, "deployment failed after the upgrade")
+		}
+	}
+	if target.UpgradingSince != nil && time.Since(*target.UpgradingSince) > campaign.WaveTimeout {
+		return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseFailed,
+			fmt.Sprintf("deployment was not ready %s after the upgrade", campaign.WaveTimeout))
+	}
+	return nil
+}
+
+// rollbackUpgrade assigns the previous shard metadata to an upgraded deployment, unless it has been changed since
+func (m *ConnectorUpgradeCampaignManager) rollbackUpgrade(ctx context.Context, target *dbapi.ConnectorUpgradeTarget) error {
+	switch target.Phase {
+	case dbapi.ConnectorUpgradeTargetPhasePending:
+		return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseSkipped, "campaign was rolled back")
+	case dbapi.ConnectorUpgradeTargetPhaseUpgrading, dbapi.ConnectorUpgradeTargetPhaseUpgraded, dbapi.ConnectorUpgradeTargetPhaseFailed:
+	default:
+		return nil
+	}
+
+	deployment, serr := m.connectorClusterService.GetDeployment(ctx, target.DeploymentID)
+	if serr != nil {
+		if serr.Is404() || serr.Code == serviceError.ErrorGone {
+			return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseSkipped, "deployment was deleted")
+		}
+		return serr
+	}
+	if deployment.ConnectorShardMetadataID != target.ToShardMetadataID {
+		return m.updateTarget(ctx, target, dbapi.ConnectorUpgradeTargetPhaseSkipped, "deployment shard metadata changed after the upgrade")
+	}
+	target.Phase = dbapi.ConnectorUpgradeTargetPhaseRolledBack
+	if serr := m.campaignService.UpdateTargetDeployment(ctx, target, target.FromShardMetadataID); serr != nil {
+		return serr
+	}
+	return nil
+}
+
+func (m *ConnectorUpgradeCampaignManager) updateTarget(ctx context.Context, target *dbapi.ConnectorUpgradeTarget, phase dbapi.ConnectorUpgradeTargetPhase, reason string) error {
+	target.Phase = phase
+	target.Reason = reason
+	if serr := m.campaignService.UpdateTarget(ctx, target); serr != nil {
+		return serr
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+type campaignServiceStub struct {
+	services.ConnectorUpgradeCampaignService
+	targets          dbapi.ConnectorUpgradeTargetList
+	deployments      map[string]*dbapi.ConnectorDeployment
+	updated          []dbapi.ConnectorUpgradeCampaign
+	updateTargetFail bool
+}
+
+func (s *campaignServiceStub) ListTargets(ctx context.Context, campaignId string) (dbapi.ConnectorUpgradeTargetList, *serviceError.ServiceError) {
+	return s.targets, nil
+}
+
+func (s *campaignServiceStub) Update(ctx context.Context, campaign *dbapi.ConnectorUpgradeCampaign) *serviceError.ServiceError {
+	s.updated = append(s.updated, *campaign)
+	return nil
+}
+
+func (s *campaignServiceStub) UpdateTarget(ctx context.Context, target *dbapi.ConnectorUpgradeTarget) *serviceError.ServiceError {
+	if s.updateTargetFail {
+		return serviceError.GeneralError("failed to update target")
+	}
+	return nil
+}
+
+func (s *campaignServiceStub) UpdateTargetDeployment(ctx context.Context, target *dbapi.ConnectorUpgradeTarget, shardMetadataID int64) *serviceError.ServiceError {
+	if s.updateTargetFail {
+		// the deployment update is rolled back with the target update
+		return serviceError.GeneralError("failed to update target")
+	}
+	deployment := s.deployments[target.DeploymentID]
+	deployment.ConnectorShardMetadataID = shardMetadataID
+	deployment.Version++
+	target.DeploymentVersion = deployment.Version
+	return nil
+}
+
+type deploymentServiceStub struct {
+	services.ConnectorClusterService
+	deployments map[string]*dbapi.ConnectorDeployment
+}
+
+func (s *deploymentServiceStub) GetDeployment(ctx context.Context, id string) (dbapi.ConnectorDeployment, *serviceError.ServiceError) {
+	deployment, ok := s.deployments[id]
+	if !ok {
+		return dbapi.ConnectorDeployment{}, serviceError.NotFound("connector deployment %s not found", id)
+	}
+	return *deployment, nil
+}
+
+func newTestDeployment(id string, shardMetadataID int64, version int64, phase dbapi.ConnectorStatusPhase) *dbapi.ConnectorDeployment {
+	return &dbapi.ConnectorDeployment{
+		Model:                    db.Model{ID: id},
+		Version:                  version,
+		ConnectorShardMetadataID: shardMetadataID,
+		Status: dbapi.ConnectorDeploymentStatus{
+			Phase:   phase,
+			Version: version,
+		},
+	}
+}
+
+func newTestTarget(deploymentID string, wave int, phase dbapi.ConnectorUpgradeTargetPhase, upgradingSince *time.Time) *dbapi.ConnectorUpgradeTarget {
+	return &dbapi.ConnectorUpgradeTarget{
+		Model:               db.Model{ID: "target-" + deploymentID},
+		CampaignID:          "campaign",
+		DeploymentID:        deploymentID,
+		Wave:                wave,
+		FromShardMetadataID: 1,
+		ToShardMetadataID:   2,
+		Phase:               phase,
+		DeploymentVersion:   2,
+		UpgradingSince:      upgradingSince,
+	}
+}
+
+func Test_ConnectorUpgradeCampaignManager_reconcileCampaign(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name              string
+		campaign          *dbapi.ConnectorUpgradeCampaign
+		targets           dbapi.ConnectorUpgradeTargetList
+		deployments       map[string]*dbapi.ConnectorDeployment
+		updateTargetFail  bool
+		wantErr           bool
+		wantPhase         dbapi.ConnectorUpgradeCampaignPhase
+		wantWave          int
+		wantUpdates       int
+		wantTargetPhases  []dbapi.ConnectorUpgradeTargetPhase
+		wantShardMetadata map[string]int64
+	}{
+		{
+			name: "should start the next wave when the current wave is upgraded",
+			campaign: &dbapi.ConnectorUpgradeCampaign{Phase: dbapi.ConnectorUpgradeCampaignPhaseRunning,
+				CurrentWave: 1, Waves: 2, WaveTimeout: time.Hour},
+			targets: dbapi.ConnectorUpgradeTargetList{
+				newTestTarget("d1", 1, dbapi.ConnectorUpgradeTargetPhaseUpgrading, &now),
+				newTestTarget("d2", 2, dbapi.ConnectorUpgradeTargetPhasePending, nil),
+			},
+			deployments: map[string]*dbapi.ConnectorDeployment{
+				"d1": newTestDeployment("d1", 2, 2, dbapi.ConnectorStatusPhaseReady),
+				"d2": newTestDeployment("d2", 1, 1, dbapi.ConnectorStatusPhaseReady),
+			},
+			wantPhase:         dbapi.ConnectorUpgradeCampaignPhaseRunning,
+			wantWave:          2,
+			wantUpdates:       1,
+			wantTargetPhases:  []dbapi.ConnectorUpgradeTargetPhase{dbapi.ConnectorUpgradeTargetPhaseUpgraded, dbapi.ConnectorUpgradeTargetPhaseUpgrading},
+			wantShardMetadata: map[string]int64{"d1": 2, "d2": 2},
+		},
+		{
+			name: "should wait for the current wave to be upgraded",
+			campaign: &dbapi.ConnectorUpgradeCampaign{Phase: dbapi.ConnectorUpgradeCampaignPhaseRunning,
+				CurrentWave: 1, Waves: 2, WaveTimeout: time.Hour},
+			targets: dbapi.ConnectorUpgradeTargetList{
+				newTestTarget("d1", 1, dbapi.ConnectorUpgradeTargetPhaseUpgrading, &now),
+				newTestTarget("d2", 2, dbapi.ConnectorUpgradeTargetPhasePending, nil),
+			},
+			deployments: map[string]*dbapi.ConnectorDeployment{
+				"d1": newTestDeployment("d1", 2, 1, dbapi.ConnectorStatusPhaseReady),
+				"d2": newTestDeployment("d2", 1, 1, dbapi.ConnectorStatusPhaseReady),
+			},
+			wantPhase:         dbapi.ConnectorUpgradeCampaignPhaseRunning,
+			wantWave:          1,
+			wantTargetPhases:  []dbapi.ConnectorUpgradeTargetPhase{dbapi.ConnectorUpgradeTargetPhaseUpgrading, dbapi.ConnectorUpgradeTargetPhasePending},
+			wantShardMetadata: map[string]int64{"d1": 2, "d2": 1},
+		},
+		{
+			name: "should fail deployments that are not ready within the wave timeout and complete within the failure budget",
+			campaign: &dbapi.ConnectorUpgradeCampaign{Phase: dbapi.ConnectorUpgradeCampaignPhaseRunning,
+				CurrentWave: 1, Waves: 1, FailureBudget: 1, WaveTimeout: time.Hour},
+			targets: dbapi.ConnectorUpgradeTargetList{
+				newTestTarget("d1", 1, dbapi.ConnectorUpgradeTargetPhaseUpgrading, &expired),
+			},
+			deployments: map[string]*dbapi.ConnectorDeployment{
+				"d1": newTestDeployment("d1", 2, 1, dbapi.ConnectorStatusPhaseReady),
+			},
+			wantPhase:         dbapi.ConnectorUpgradeCampaignPhaseCompleted,
+			wantWave:          1,
+			wantUpdates:       1,
+			wantTargetPhases:  []dbapi.ConnectorUpgradeTargetPhase{dbapi.ConnectorUpgradeTargetPhaseFailed},
+			wantShardMetadata: map[string]int64{"d1": 2},
+		},
+		{
+			name: "should roll back the campaign when the failure budget is exceeded",
+			campaign: &dbapi.ConnectorUpgradeCampaign{Phase: dbapi.ConnectorUpgradeCampaignPhaseRunning,
+				CurrentWave: 1, Waves: 2, WaveTimeout: time.Hour},
+			targets: dbapi.ConnectorUpgradeTargetList{
+				newTestTarget("d1", 1, dbapi.ConnectorUpgradeTargetPhaseUpgrading, &now),
+				newTestTarget("d2", 1, dbapi.ConnectorUpgradeTargetPhaseUpgraded, &now),
+				newTestTarget("d3", 2, dbapi.ConnectorUpgradeTargetPhasePending, nil),
+			},
+			deployments: map[string]*dbapi.ConnectorDeployment{
+				"d1": newTestDeployment("d1", 2, 2, dbapi.ConnectorStatusPhaseFailed),
+				"d2": newTestDeployment("d2", 2, 2, dbapi.ConnectorStatusPhaseReady),
+				"d3": newTestDeployment("d3", 1, 1, dbapi.ConnectorStatusPhaseReady),
+			},
+			wantPhase:   dbapi.ConnectorUpgradeCampaignPhaseRolledBack,
+			wantWave:    1,
+			wantUpdates: 2,
+			wantTargetPhases: []dbapi.ConnectorUpgradeTargetPhase{dbapi.ConnectorUpgradeTargetPhaseRolledBack,
+				dbapi.ConnectorUpgradeTargetPhaseRolledBack, dbapi.ConnectorUpgradeTargetPhaseSkipped},
+			wantShardMetadata: map[string]int64{"d1": 1, "d2": 1, "d3": 1},
+		},
+		{
+			name: "should skip rolling back deployments that were changed after the upgrade",
+			campaign: &dbapi.ConnectorUpgradeCampaign{Phase: dbapi.ConnectorUpgradeCampaignPhaseRollingBack,
+				CurrentWave: 1, Waves: 1, WaveTimeout: time.Hour},
+			targets: dbapi.ConnectorUpgradeTargetList{
+				newTestTarget("d1", 1, dbapi.ConnectorUpgradeTargetPhaseUpgraded, &now),
+				newTestTarget("d2", 1, dbapi.ConnectorUpgradeTargetPhaseUpgraded, &now),
+			},
+			deployments: map[string]*dbapi.ConnectorDeployment{
+				"d1": newTestDeployment("d1", 3, 3, dbapi.ConnectorStatusPhaseReady),
+			},
+			wantPhase:         dbapi.ConnectorUpgradeCampaignPhaseRolledBack,
+			wantWave:          1,
+			wantUpdates:       1,
+			wantTargetPhases:  []dbapi.ConnectorUpgradeTargetPhase{dbapi.ConnectorUpgradeTargetPhaseSkipped, dbapi.ConnectorUpgradeTargetPhaseSkipped},
+			wantShardMetadata: map[string]int64{"d1": 3},
+		},
+		{
+			name: "should not update the campaign when a wave step fails",
+			campaign: &dbapi.ConnectorUpgradeCampaign{Phase: dbapi.ConnectorUpgradeCampaignPhaseRunning,
+				CurrentWave: 0, Waves: 1, WaveTimeout: time.Hour},
+			targets: dbapi.ConnectorUpgradeTargetList{
+				newTestTarget("d1", 1, dbapi.ConnectorUpgradeTargetPhasePending, nil),
+			},
+			deployments: map[string]*dbapi.ConnectorDeployment{
+				"d1": newTestDeployment("d1", 1, 1, dbapi.ConnectorStatusPhaseReady),
+			},
+			updateTargetFail:  true,
+			wantErr:           true,
+			wantPhase:         dbapi.ConnectorUpgradeCampaignPhaseRunning,
+			wantWave:          1,
+			wantShardMetadata: map[string]int64{"d1": 1},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery("txid_current").WithReply([]map[string]interface{}{{"txid_current": 1}})
+			ctx, err := connectionFactory.NewContext(context.Background())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			campaignService := &campaignServiceStub{targets: tt.targets, deployments: tt.deployments, updateTargetFail: tt.updateTargetFail}
+			deploymentService := &deploymentServiceStub{deployments: tt.deployments}
+			m := NewConnectorUpgradeCampaignManager(campaignService, deploymentService, connectionFactory, workers.Reconciler{})
+
+			err = m.reconcileCampaign(ctx, tt.campaign)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(tt.campaign.Phase).To(gomega.Equal(tt.wantPhase))
+			g.Expect(tt.campaign.CurrentWave).To(gomega.Equal(tt.wantWave))
+			g.Expect(campaignService.updated).To(gomega.HaveLen(tt.wantUpdates))
+			for i, phase := range tt.wantTargetPhases {
+				g.Expect(tt.targets[i].Phase).To(gomega.Equal(phase), "target %s", tt.targets[i].DeploymentID)
+			}
+			for id, shardMetadataID := range tt.wantShardMetadata {
+				g.Expect(tt.deployments[id].ConnectorShardMetadataID).To(gomega.Equal(shardMetadataID), "deployment %s", id)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package connector
 
 import (
+	cmdsimulator "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/cmd/simulator"
 	cmdvault "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/cmd/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/environments"
@@ -27,6 +28,7 @@ func ConfigProviders(kafkaEnabled bool) di.Option {
 		di.Provide(environments2.Func(serviceProviders)),
 		di.Provide(migrations.New),
 		di.Provide(cmdvault.NewVaultCommand),
+		di.Provide(cmdsimulator.NewAgentSimulatorCommand),
 	)
 
 	// If we are not running in the kas-fleet-manager.. we need to inject more types into the DI container
@@ -50,9 +52,14 @@ func ConfigProviders(kafkaEnabled bool) di.Option {
 func serviceProviders() di.Option {
 	return di.Options(
 		di.Provide(services.NewConnectorsService, di.As(new(services.ConnectorsService))),
+		di.Provide(services.NewConnectorRevisionsService, di.As(new(services.ConnectorRevisionsService))),
 		di.Provide(services.NewConnectorTypesService, di.As(new(services.ConnectorTypesService))),
+		di.Provide(services.NewConnectorCatalogService, di.As(new(services.ConnectorCatalogService))),
 		di.Provide(services.NewConnectorClusterService, di.As(new(services.ConnectorClusterService)), di.As(new(auth.AuthAgentService))),
 		di.Provide(services.NewConnectorNamespaceService, di.As(new(services.ConnectorNamespaceService))),
+		di.Provide(services.NewNamespacePlacementStrategy, di.As(new(services.NamespacePlacementStrategy))),
+		di.Provide(services.NewConnectorNamespaceMigrationService, di.As(new(services.ConnectorNamespaceMigrationService))),
+		di.Provide(services.NewConnectorUpgradeCampaignService, di.As(new(services.ConnectorUpgradeCampaignService))),
 		di.Provide(authz.NewAuthZService, di.As(new(authz.AuthZService))),
 		di.Provide(handlers.NewConnectorNamespaceHandler),
 		di.Provide(handlers.NewConnectorAdminHandler),
@@ -64,6 +71,9 @@ func serviceProviders() di.Option {
 		di.Provide(workers.NewClusterManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewConnectorManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewNamespaceManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorMigrationManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorCatalogManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewConnectorUpgradeCampaignManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewApiServerReadyCondition),
 	)
 }
@@ -53,4 +53,6 @@ type Kafka struct {
 	Namespace              string                           `json:"namespace,omitempty"`
 	SizeId                 string                           `json:"size_id,omitempty"`
 	MaxDataRetentionSize   SupportedKafkaSizeBytesValueItem `json:"max_data_retention_size,omitempty"`
+	// User defined key/value labels of the Kafka instance
+	Labels map[string]string `json:"labels,omitempty"`
 }
@@ -0,0 +1,35 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// Worker struct for Worker
+type Worker struct {
+	// The type of the worker, e.g. ready_kafka
+	WorkerType string `json:"worker_type"`
+	// The ID of the worker instance leading the worker type, or owning its first shard when the workers are sharded
+	Leader string `json:"leader,omitempty"`
+	// The expiry time of the lease of the leader
+	LeaseExpires time.Time `json:"lease_expires,omitempty"`
+	// Whether the worker type has a leader whose lease hasn't expired and isn't paused
+	Running bool `json:"running"`
+	// Whether the reconciles of the worker type are paused on all the replicas
+	Paused             bool      `json:"paused"`
+	LastReconcileStart time.Time `json:"last_reconcile_start,omitempty"`
+	LastReconcileEnd   time.Time `json:"last_reconcile_end,omitempty"`
+	// The ID of the worker instance that ran the last reconcile
+	LastReconcileInstance string `json:"last_reconcile_instance,omitempty"`
+	// The errors of the last reconcile
+	LastErrors []string `json:"last_errors,omitempty"`
+}
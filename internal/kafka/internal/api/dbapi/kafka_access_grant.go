@@ -0,0 +1,42 @@
+package dbapi
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+)
+
+type KafkaAccessLevel string
+
+const (
+	// KafkaAccessLevelReadOnly allows the grantee organisation to see the Kafka instance
+	KafkaAccessLevelReadOnly KafkaAccessLevel = "read_only"
+	// KafkaAccessLevelConnectorBinding allows the grantee organisation to see the Kafka instance and to attach
+	// connectors to it
+	KafkaAccessLevelConnectorBinding KafkaAccessLevel = "connector_binding"
+)
+
+var ValidKafkaAccessLevels = []string{
+	string(KafkaAccessLevelReadOnly),
+	string(KafkaAccessLevelConnectorBinding),
+}
+
+func (l KafkaAccessLevel) String() string {
+	return string(l)
+}
+
+// KafkaAccessGrant gives an organisation other than the owning one access to a Kafka instance.
+// Grants are revoked by soft deleting them.
+type KafkaAccessGrant struct {
+	api.Meta
+	KafkaID string `json:"kafka_id" gorm:"index;uniqueIndex:uix_kafka_access_grants_kafka_id_organisation_id,where:deleted_at IS NULL"`
+	// OrganisationId is the organisation the access is granted to
+	OrganisationId string           `json:"organisation_id" gorm:"index;uniqueIndex:uix_kafka_access_grants_kafka_id_organisation_id,where:deleted_at IS NULL"`
+	AccessLevel    KafkaAccessLevel `json:"access_level"`
+	CreatedBy      string           `json:"created_by"`
+}
+
+type KafkaAccessGrantList []*KafkaAccessGrant
+
+// AllowsConnectorBinding returns true if the grant allows connectors to be attached to the Kafka instance
+func (g *KafkaAccessGrant) AllowsConnectorBinding() bool {
+	return g.AccessLevel == KafkaAccessLevelConnectorBinding
+}
@@ -75,6 +75,8 @@ type KafkaRequest struct {
 	KafkasRoutesBaseDomainTLSKeyRef string
 	// KafkasRoutesBaseDomainTLSCrtRef is the key referencing the TLS certificate crt (public part of the certificate) for the base kafka domain
 	KafkasRoutesBaseDomainTLSCrtRef string
+	// Labels are the user defined key/value labels of the kafka instance, stored as a JSON object so that they can be searched
+	Labels api.JSON `json:"labels"`
 }
 
 type KafkaPromotionStatus string
@@ -146,6 +148,31 @@ func (k *KafkaRequest) SetRoutes(routes []DataPlaneKafkaRoute) error {
 	}
 }
 
+func (k *KafkaRequest) GetLabels() (map[string]string, error) {
+	var labels map[string]string
+	if k.Labels == nil {
+		return labels, nil
+	}
+	if err := json.Unmarshal(k.Labels, &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// SetLabels sets the labels of the kafka request, the labels are removed when the given map is empty
+func (k *KafkaRequest) SetLabels(labels map[string]string) error {
+	if len(labels) == 0 {
+		k.Labels = nil
+		return nil
+	}
+	l, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	k.Labels = l
+	return nil
+}
+
 // GetExpirationTime returns when the Kafka request will expire based on the
 // provided lifespanSeconds value. lifespanSeconds is assumed to be greater
 // than 0
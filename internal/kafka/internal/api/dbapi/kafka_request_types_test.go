@@ -184,3 +184,35 @@ func TestKafkaRequest_IsADeveloperInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaRequest_SetLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		labels     map[string]string
+		wantLabels map[string]string
+	}{
+		{
+			name:       "should store and return the labels",
+			labels:     map[string]string{"cost-centre": "1234", "env": "dev"},
+			wantLabels: map[string]string{"cost-centre": "1234", "env": "dev"},
+		},
+		{
+			name:       "should remove the labels when they are empty",
+			labels:     map[string]string{},
+			wantLabels: nil,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &KafkaRequest{}
+			g.Expect(k.SetLabels(map[string]string{"old": "label"})).To(gomega.Succeed())
+			g.Expect(k.SetLabels(tt.labels)).To(gomega.Succeed())
+			labels, err := k.GetLabels()
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(labels).To(gomega.Equal(tt.wantLabels))
+		})
+	}
+}
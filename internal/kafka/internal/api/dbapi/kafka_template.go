@@ -0,0 +1,23 @@
+package dbapi
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+)
+
+// KafkaTemplate holds the settings an organisation reuses to create its Kafka instances.
+// Empty settings are left to the Kafka request or to their defaults.
+type KafkaTemplate struct {
+	api.Meta
+	Name                    string `json:"name" gorm:"uniqueIndex:uix_kafka_templates_organisation_id_name,where:deleted_at IS NULL"`
+	OrganisationId          string `json:"organisation_id" gorm:"index;uniqueIndex:uix_kafka_templates_organisation_id_name,where:deleted_at IS NULL"`
+	CreatedBy               string `json:"created_by"`
+	CloudProvider           string `json:"cloud_provider"`
+	Region                  string `json:"region"`
+	Plan                    string `json:"plan"`
+	BillingModel            string `json:"billing_model"`
+	BillingCloudAccountId   string `json:"billing_cloud_account_id"`
+	Marketplace             string `json:"marketplace"`
+	ReauthenticationEnabled *bool  `json:"reauthentication_enabled"`
+}
+
+type KafkaTemplateList []*KafkaTemplate
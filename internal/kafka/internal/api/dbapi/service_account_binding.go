@@ -0,0 +1,76 @@
+package dbapi
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+)
+
+type ServiceAccountBindingResourceType string
+
+const (
+	ServiceAccountBindingResourceTypeKafka            ServiceAccountBindingResourceType = "kafka"
+	ServiceAccountBindingResourceTypeConnectorCluster ServiceAccountBindingResourceType = "connector_cluster"
+)
+
+type ServiceAccountRole string
+
+const (
+	// ServiceAccountRoleConsumer allows the service account to read from the topics of the Kafka instance
+	ServiceAccountRoleConsumer ServiceAccountRole = "consumer"
+	// ServiceAccountRoleProducer allows the service account to read from and write to the topics of the Kafka instance
+	ServiceAccountRoleProducer ServiceAccountRole = "producer"
+	// ServiceAccountRoleAdmin allows the service account to manage the topics, consumer groups and ACLs of the Kafka
+	// instance
+	ServiceAccountRoleAdmin ServiceAccountRole = "admin"
+)
+
+// DefaultServiceAccountRole is the role of the scoped service accounts when none is requested
+const DefaultServiceAccountRole = ServiceAccountRoleConsumer
+
+var ValidServiceAccountRoles = []string{
+	string(ServiceAccountRoleConsumer),
+	string(ServiceAccountRoleProducer),
+	string(ServiceAccountRoleAdmin),
+}
+
+func (r ServiceAccountRole) String() string {
+	return string(r)
+}
+
+// ServiceAccountBinding binds a service account to a Kafka instance or a connector cluster. A service account with
+// bindings can't be used with the resources it is not bound to, one without bindings is organisation-wide.
+type ServiceAccountBinding struct {
+	api.Meta
+	// ServiceAccountID is the id of the service account in the SSO provider
+	ServiceAccountID string `json:"service_account_id" gorm:"index"`
+	// ClientID is the principal of the service account in the Kafka instances
+	ClientID       string                            `json:"client_id" gorm:"index"`
+	OrganisationId string                            `json:"organisation_id" gorm:"index"`
+	ResourceType   ServiceAccountBindingResourceType `json:"resource_type"`
+	ResourceID     string                            `json:"resource_id" gorm:"index"`
+	Role           ServiceAccountRole                `json:"role"`
+}
+
+type ServiceAccountBindingList []*ServiceAccountBinding
+
+// Scope returns the scope of the service accounts made of the bindings, indexed by service account id
+func (l ServiceAccountBindingList) Scope() map[string]*api.ServiceAccountScope {
+	scopes := map[string]*api.ServiceAccountScope{}
+	for _, binding := range l {
+		scope, ok := scopes[binding.ServiceAccountID]
+		if !ok {
+			scope = &api.ServiceAccountScope{
+				KafkaIDs:            []string{},
+				ConnectorClusterIDs: []string{},
+				Role:                binding.Role.String(),
+			}
+			scopes[binding.ServiceAccountID] = scope
+		}
+		switch binding.ResourceType {
+		case ServiceAccountBindingResourceTypeKafka:
+			scope.KafkaIDs = append(scope.KafkaIDs, binding.ResourceID)
+		case ServiceAccountBindingResourceTypeConnectorCluster:
+			scope.ConnectorClusterIDs = append(scope.ConnectorClusterIDs, binding.ResourceID)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,24 @@
+package dbapi
+
+import "time"
+
+// ServiceAccountExpiry is the expiry of the credentials of a service account. Service accounts whose credentials
+// never expire have none.
+type ServiceAccountExpiry struct {
+	// ID is the id of the service account in the SSO provider
+	ID             string `gorm:"primaryKey"`
+	ClientID       string
+	OrganisationId string `gorm:"index"`
+	// CredentialsIssuedAt is when the current credentials were created
+	CredentialsIssuedAt time.Time
+	CredentialsExpireAt time.Time `gorm:"index"`
+	// CredentialsRevokedAt is set once the expired credentials have been revoked in the SSO provider
+	CredentialsRevokedAt *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// Lifetime returns how long the credentials are valid for after being issued
+func (e *ServiceAccountExpiry) Lifetime() time.Duration {
+	return e.CredentialsExpireAt.Sub(e.CredentialsIssuedAt)
+}
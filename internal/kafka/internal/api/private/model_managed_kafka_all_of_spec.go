@@ -11,11 +11,12 @@ package private
 
 // ManagedKafkaAllOfSpec struct for ManagedKafkaAllOfSpec
 type ManagedKafkaAllOfSpec struct {
-	ServiceAccounts []ManagedKafkaAllOfSpecServiceAccounts `json:"serviceAccounts,omitempty"`
-	Capacity        ManagedKafkaCapacity                   `json:"capacity,omitempty"`
-	Oauth           ManagedKafkaAllOfSpecOauth             `json:"oauth,omitempty"`
-	Owners          []string                               `json:"owners,omitempty"`
-	Endpoint        ManagedKafkaAllOfSpecEndpoint          `json:"endpoint,omitempty"`
-	Versions        ManagedKafkaVersions                   `json:"versions,omitempty"`
-	Deleted         bool                                   `json:"deleted"`
+	ServiceAccounts      []ManagedKafkaAllOfSpecServiceAccounts     `json:"serviceAccounts,omitempty"`
+	Capacity             ManagedKafkaCapacity                       `json:"capacity,omitempty"`
+	Oauth                ManagedKafkaAllOfSpecOauth                 `json:"oauth,omitempty"`
+	Owners               []string                                   `json:"owners,omitempty"`
+	Endpoint             ManagedKafkaAllOfSpecEndpoint              `json:"endpoint,omitempty"`
+	Versions             ManagedKafkaVersions                       `json:"versions,omitempty"`
+	Deleted              bool                                       `json:"deleted"`
+	ServiceAccountScopes *ManagedKafkaAllOfSpecServiceAccountScopes `json:"serviceAccountScopes,omitempty"`
 }
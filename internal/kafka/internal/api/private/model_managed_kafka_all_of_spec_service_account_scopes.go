@@ -0,0 +1,16 @@
+/*
+ * Kafka Service Fleet Manager
+ *
+ * Kafka Service Fleet Manager APIs that are used by internal services e.g kas-fleetshard operators.
+ *
+ * API version: 1.8.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ManagedKafkaAllOfSpecServiceAccountScopes struct for ManagedKafkaAllOfSpecServiceAccountScopes
+type ManagedKafkaAllOfSpecServiceAccountScopes struct {
+	Bindings         []ManagedKafkaAllOfSpecServiceAccountScopesBindings `json:"bindings"`
+	DeniedPrincipals []string                                            `json:"deniedPrincipals"`
+}
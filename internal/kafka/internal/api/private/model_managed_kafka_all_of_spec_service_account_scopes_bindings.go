@@ -0,0 +1,16 @@
+/*
+ * Kafka Service Fleet Manager
+ *
+ * Kafka Service Fleet Manager APIs that are used by internal services e.g kas-fleetshard operators.
+ *
+ * API version: 1.8.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ManagedKafkaAllOfSpecServiceAccountScopesBindings struct for ManagedKafkaAllOfSpecServiceAccountScopesBindings
+type ManagedKafkaAllOfSpecServiceAccountScopesBindings struct {
+	Principal string `json:"principal"`
+	Role      string `json:"role"`
+}
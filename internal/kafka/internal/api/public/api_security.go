@@ -29,7 +29,7 @@ type SecurityApiService service
 
 /*
 CreateServiceAccount Method for CreateServiceAccount
-Creates a service account
+Creates a service account. As its response contains the secret of the service account, a request made with the `Idempotency-Key` of a completed request gets a 409 response instead of the replay of the response.
   - @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
   - @param serviceAccountRequest Service account request
 
@@ -0,0 +1,31 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.16.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// KafkaAccessGrant Access to a Kafka instance granted by its owning organisation to another organisation
+type KafkaAccessGrant struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// ID of the Kafka instance the access is granted to
+	KafkaId string `json:"kafka_id,omitempty"`
+	// ID of the organisation the access is granted to
+	OrganisationId string `json:"organisation_id,omitempty"`
+	// Level of the granted access. Accepted values: ['read_only', 'connector_binding']. 'read_only' allows the organisation to see the Kafka instance, 'connector_binding' also allows it to attach connectors to the Kafka instance
+	AccessLevel string `json:"access_level,omitempty"`
+	// User that granted the access
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.16.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// KafkaAccessGrantRequest Request to grant the access to a Kafka instance to another organisation
+type KafkaAccessGrantRequest struct {
+	// ID of the organisation the access is granted to
+	OrganisationId string `json:"organisation_id"`
+	// Level of the granted access. Accepted values: ['read_only', 'connector_binding']
+	AccessLevel string `json:"access_level"`
+}
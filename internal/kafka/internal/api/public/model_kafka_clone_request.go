@@ -0,0 +1,25 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.16.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// KafkaCloneRequest Schema for the request body sent to /kafkas/{id}/clone POST. The settings left empty are taken from the cloned Kafka instance.
+type KafkaCloneRequest struct {
+	// The name of the new Kafka cluster. It must consist of lower-case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character, and can not be longer than 32 characters.
+	Name string `json:"name"`
+	// The cloud provider where the new Kafka cluster will be created in. The default region of the cloud provider is used when it differs from the one of the cloned Kafka instance and no region is given
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	// The region where the new Kafka cluster will be created in
+	Region string `json:"region,omitempty"`
+	// kafka plan in a format of <instance_type>.<size_id>
+	Plan string `json:"plan,omitempty"`
+	// enterprise OSD cluster ID to be used for the creation of the new kafka, only for enterprise Kafka instances
+	ClusterId *string `json:"cluster_id,omitempty"`
+}
@@ -71,4 +71,6 @@ type KafkaRequest struct {
 	ClusterId *string `json:"cluster_id,omitempty"`
 	// Details of the Kafka request promotion. It can be set when a Kafka request promotion is in progress or has failed
 	PromotionDetails string `json:"promotion_details,omitempty"`
+	// User defined key/value labels of the Kafka instance, e.g. to tag it with a cost centre or an environment. Keys must be valid Kubernetes qualified names and values valid Kubernetes label values. Kafka instances can be searched by their labels with the `labels.<key>` search column.
+	Labels map[string]string `json:"labels,omitempty"`
 }
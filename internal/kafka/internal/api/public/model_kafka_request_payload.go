@@ -30,4 +30,8 @@ type KafkaRequestPayload struct {
 	BillingModel *string `json:"billing_model,omitempty"`
 	// enterprise OSD cluster ID to be used for kafka creation
 	ClusterId *string `json:"cluster_id,omitempty"`
+	// User defined key/value labels of the Kafka instance, e.g. to tag it with a cost centre or an environment. Keys must be valid Kubernetes qualified names and values valid Kubernetes label values. Kafka instances can be searched by their labels with the `labels.<key>` search column.
+	Labels map[string]string `json:"labels,omitempty"`
+	// ID of the Kafka template of the organisation to create the Kafka cluster from. The settings of the template are used for the fields that are not set in the request.
+	TemplateId *string `json:"template_id,omitempty"`
 }
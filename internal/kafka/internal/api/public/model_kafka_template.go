@@ -0,0 +1,41 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.16.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// KafkaTemplate Settings reused by an organisation to create its Kafka instances
+type KafkaTemplate struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// The name of the Kafka template, it is unique within the organisation
+	Name string `json:"name,omitempty"`
+	// The cloud provider where the Kafka clusters will be created in
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	// The region where the Kafka clusters will be created in
+	Region string `json:"region,omitempty"`
+	// Whether connection reauthentication is enabled or not
+	ReauthenticationEnabled *bool `json:"reauthentication_enabled,omitempty"`
+	// kafka plan in a format of <instance_type>.<size_id>
+	Plan string `json:"plan,omitempty"`
+	// cloud account id used to purchase the instances
+	BillingCloudAccountId string `json:"billing_cloud_account_id,omitempty"`
+	// marketplace where the instances are purchased on
+	Marketplace string `json:"marketplace,omitempty"`
+	// billing model to use
+	BillingModel string `json:"billing_model,omitempty"`
+	// User that created the template
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
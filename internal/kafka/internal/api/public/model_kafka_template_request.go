@@ -0,0 +1,31 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.16.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// KafkaTemplateRequest Request to create a Kafka template. The settings left empty are taken from the Kafka requests created from the template or get their default values.
+type KafkaTemplateRequest struct {
+	// The name of the Kafka template, it is unique within the organisation
+	Name string `json:"name"`
+	// The cloud provider where the Kafka clusters will be created in
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	// The region where the Kafka clusters will be created in
+	Region string `json:"region,omitempty"`
+	// Whether connection reauthentication is enabled or not
+	ReauthenticationEnabled *bool `json:"reauthentication_enabled,omitempty"`
+	// kafka plan in a format of <instance_type>.<size_id>
+	Plan string `json:"plan,omitempty"`
+	// cloud account id used to purchase the instances
+	BillingCloudAccountId string `json:"billing_cloud_account_id,omitempty"`
+	// marketplace where the instances are purchased on
+	Marketplace string `json:"marketplace,omitempty"`
+	// billing model to use
+	BillingModel string `json:"billing_model,omitempty"`
+}
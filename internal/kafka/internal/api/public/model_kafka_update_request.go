@@ -15,4 +15,6 @@ type KafkaUpdateRequest struct {
 	Owner *string `json:"owner,omitempty"`
 	// Whether connection reauthentication is enabled or not. If set to true, connection reauthentication on the Kafka instance will be required every 5 minutes.
 	ReauthenticationEnabled *bool `json:"reauthentication_enabled,omitempty"`
+	// The labels of the Kafka instance. When set, they replace all the existing labels of the Kafka instance.
+	Labels *map[string]string `json:"labels,omitempty"`
 }
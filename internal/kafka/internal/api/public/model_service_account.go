@@ -28,4 +28,9 @@ type ServiceAccount struct {
 	DeprecatedOwner string    `json:"owner,omitempty"`
 	CreatedBy       string    `json:"created_by,omitempty"`
 	CreatedAt       time.Time `json:"created_at,omitempty"`
+	// The expiry of the credentials of the service account, the credentials never expire when not set
+	CredentialsExpireAt *time.Time `json:"credentials_expire_at,omitempty"`
+	// Whether the credentials of the service account expire soon or have expired, and must be reset
+	CredentialsRotationDue bool                 `json:"credentials_rotation_due,omitempty"`
+	Scope                  *ServiceAccountScope `json:"scope,omitempty"`
 }
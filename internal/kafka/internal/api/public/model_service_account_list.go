@@ -13,5 +13,7 @@ package public
 // ServiceAccountList struct for ServiceAccountList
 type ServiceAccountList struct {
 	Kind  string                   `json:"kind"`
+	Page  int32                    `json:"page,omitempty"`
+	Size  int32                    `json:"size,omitempty"`
 	Items []ServiceAccountListItem `json:"items"`
 }
@@ -33,4 +33,9 @@ type ServiceAccountListItem struct {
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// description of the service account
 	Description string `json:"description,omitempty"`
+	// The expiry of the credentials of the service account, the credentials never expire when not set
+	CredentialsExpireAt *time.Time `json:"credentials_expire_at,omitempty"`
+	// Whether the credentials of the service account expire soon or have expired, and must be reset
+	CredentialsRotationDue bool                 `json:"credentials_rotation_due,omitempty"`
+	Scope                  *ServiceAccountScope `json:"scope,omitempty"`
 }
@@ -10,10 +10,17 @@
 
 package public
 
+import (
+	"time"
+)
+
 // ServiceAccountRequest Schema for the request to create a service account
 type ServiceAccountRequest struct {
 	// The name of the service account
 	Name string `json:"name"`
 	// A description for the service account
 	Description string `json:"description,omitempty"`
+	// The expiry of the credentials of the service account. When not set, the credentials expire after the lifetime configured by the service, if any.
+	CredentialsExpireAt *time.Time           `json:"credentials_expire_at,omitempty"`
+	Scope               *ServiceAccountScope `json:"scope,omitempty"`
 }
@@ -0,0 +1,21 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.16.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ServiceAccountScope Kafka instances and connector clusters a service account is bound to. The service account can't be used with any other Kafka instance or connector cluster.
+type ServiceAccountScope struct {
+	// IDs of the Kafka instances the service account is bound to
+	KafkaIds []string `json:"kafka_ids,omitempty"`
+	// IDs of the connector clusters the service account is bound to
+	ConnectorClusterIds []string `json:"connector_cluster_ids,omitempty"`
+	// Role of the service account on the Kafka instances it is bound to
+	Role string `json:"role,omitempty"`
+}
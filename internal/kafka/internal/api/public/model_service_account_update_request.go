@@ -0,0 +1,21 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.16.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// ServiceAccountUpdateRequest Schema for the request to update a service account
+type ServiceAccountUpdateRequest struct {
+	// The new name of the service account
+	Name *string `json:"name,omitempty"`
+	// The new description of the service account
+	Description *string `json:"description,omitempty"`
+	// The new scope of the service account, an empty scope makes the service account organisation-wide
+	Scope *ServiceAccountScope `json:"scope,omitempty"`
+}
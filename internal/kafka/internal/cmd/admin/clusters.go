@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewClustersCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clusters",
+		Short: "Manage data plane clusters",
+		Long:  "Manage the data plane clusters",
+	}
+
+	// add sub-commands
+	cmd.AddCommand(newClustersListCommand(env))
+
+	return cmd
+}
+
+func newClustersListCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List data plane clusters with their capacity",
+		Long:  "List the data plane clusters with the streaming units used and available for each instance type. Failed clusters are not listed",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			output := getOutputFlag(cmd, outputTable, outputJSON)
+			env.MustInvoke(func(clusterService services.ClusterService) {
+				runClustersList(clusterService, output)
+			})
+		},
+	}
+	addOutputFlag(cmd, outputTable, outputTable, outputJSON)
+	return cmd
+}
+
+func runClustersList(clusterService services.ClusterService, output string) {
+	capacity, err := clusterService.FindStreamingUnitCountByClusterAndInstanceType()
+	if err != nil {
+		glog.Fatalf("Unable to list clusters: %s", err.Error())
+	}
+	sort.SliceStable(capacity, func(i, j int) bool {
+		if capacity[i].ClusterId != capacity[j].ClusterId {
+			return capacity[i].ClusterId < capacity[j].ClusterId
+		}
+		return capacity[i].InstanceType < capacity[j].InstanceType
+	})
+
+	if output == outputJSON {
+		printObject(output, capacity)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Cluster ID", "Cluster Type", "Status", "Cloud Provider", "Region", "Instance Type", "Used Streaming Units", "Max Streaming Units", "Free Streaming Units"})
+	table.SetAutoMergeCellsByColumnIndex([]int{0, 1, 2, 3, 4})
+	for _, c := range capacity {
+		table.Append([]string{c.ClusterId, c.ClusterType, c.Status, c.CloudProvider, c.Region, c.InstanceType,
+			strconv.Itoa(int(c.Count)), strconv.Itoa(int(c.MaxUnits)), strconv.Itoa(int(c.FreeStreamingUnits()))})
+	}
+	table.Render()
+}
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	outputFlag = "output"
+
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+
+	// cliUsername is the username recorded for the operations performed with the admin commands
+	cliUsername = "kas-fleet-manager-admin-cli"
+)
+
+// NewAdminCommand creates the admin command tree, its sub-commands run against the configured database and services
+func NewAdminCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Run kas-fleet-manager administrative operations",
+		Long:  "Run Kafka Service Fleet Manager administrative operations against the configured database and services",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+	}
+
+	// add sub-commands
+	cmd.AddCommand(
+		NewKafkasCommand(env),
+		NewClustersCommand(env),
+		NewReconcileCommand(env),
+	)
+
+	return cmd
+}
+
+// newAdminContext returns a context that is allowed to access the resources of every organisation,
+// like the requests to the admin API.
+func newAdminContext() context.Context {
+	ctx := auth.SetTokenInContext(context.Background(), &jwt.Token{
+		Claims: jwt.MapClaims{
+			"username": cliUsername,
+		},
+	})
+	return auth.SetIsAdminContext(ctx, true)
+}
+
+func addOutputFlag(cmd *cobra.Command, defaultOutput string, outputs ...string) {
+	cmd.Flags().StringP(outputFlag, "o", defaultOutput, fmt.Sprintf("Output format, one of: %v", outputs))
+}
+
+func getOutputFlag(cmd *cobra.Command, outputs ...string) string {
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		glog.Fatalf("Unable to read flag %s: %s", outputFlag, err.Error())
+	}
+	for _, o := range outputs {
+		if o == output {
+			return output
+		}
+	}
+	glog.Fatalf("Invalid output format %q, one of %v is expected", output, outputs)
+	return ""
+}
+
+func printObject(output string, obj interface{}) {
+	var data []byte
+	var err error
+	switch output {
+	case outputYAML:
+		data, err = yaml.Marshal(obj)
+	default:
+		data, err = json.MarshalIndent(obj, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		glog.Fatalf("Unable to print %s: %s", output, err.Error())
+	}
+	_, _ = os.Stdout.Write(data)
+}
@@ -0,0 +1,209 @@
+package admin
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewKafkasCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kafkas",
+		Short: "Manage Kafka instances",
+		Long:  "Manage the Kafka instances of every organisation",
+	}
+
+	// add sub-commands
+	cmd.AddCommand(
+		newKafkasListCommand(env),
+		newKafkasGetCommand(env),
+		newKafkasSuspendCommand(env),
+		newKafkasResumeCommand(env),
+		newKafkasDeleteCommand(env),
+		newKafkasManagedKafkaCommand(env),
+	)
+
+	return cmd
+}
+
+func newKafkasListCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Kafka instances",
+		Long:  "List the Kafka instances of every organisation",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			output := getOutputFlag(cmd, outputTable, outputJSON)
+			params := url.Values{}
+			for _, name := range []string{"page", "size", "search", "orderBy"} {
+				if value, _ := cmd.Flags().GetString(name); value != "" {
+					params.Set(name, value)
+				}
+			}
+			env.MustInvoke(func(kafkaService services.KafkaService) {
+				runKafkasList(kafkaService, coreServices.NewListArguments(params), output)
+			})
+		},
+	}
+	cmd.Flags().String("page", "1", "Page index")
+	cmd.Flags().String("size", "100", "Number of items in each page")
+	cmd.Flags().String("search", "", "Search criteria, with the same syntax as the search query parameter of the API")
+	cmd.Flags().String("orderBy", "", "Order by criteria, with the same syntax as the orderBy query parameter of the API")
+	addOutputFlag(cmd, outputTable, outputTable, outputJSON)
+	return cmd
+}
+
+func runKafkasList(kafkaService services.KafkaService, listArgs *coreServices.ListArguments, output string) {
+	kafkas, paging, err := kafkaService.List(newAdminContext(), listArgs)
+	if err != nil {
+		glog.Fatalf("Unable to list kafkas: %s", err.Error())
+	}
+
+	if output == outputJSON {
+		printObject(output, kafkas)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Name", "Owner", "Organisation", "Status", "Cloud Provider", "Region", "Instance Type", "Size", "Cluster ID"})
+	for _, kafka := range kafkas {
+		table.Append([]string{kafka.ID, kafka.Name, kafka.Owner, kafka.OrganisationId, kafka.Status, kafka.CloudProvider,
+			kafka.Region, kafka.InstanceType, kafka.SizeId, kafka.ClusterID})
+	}
+	table.SetFooter([]string{"", "", "", "", "", "", "", "", "Total", strconv.Itoa(paging.Total)})
+	table.Render()
+}
+
+func newKafkasGetCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <kafka id>",
+		Short: "Inspect a Kafka instance",
+		Long:  "Print the admin API view of a Kafka instance",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			output := getOutputFlag(cmd, outputJSON, outputYAML)
+			env.MustInvoke(func(kafkaService services.KafkaService, accountService account.AccountService) {
+				runKafkasGet(kafkaService, accountService, args[0], output)
+			})
+		},
+	}
+	addOutputFlag(cmd, outputJSON, outputJSON, outputYAML)
+	return cmd
+}
+
+func runKafkasGet(kafkaService services.KafkaService, accountService account.AccountService, id string, output string) {
+	kafka, err := kafkaService.GetByID(id)
+	if err != nil {
+		glog.Fatalf("Unable to get kafka %q: %s", id, err.Error())
+	}
+	adminKafka, err := presenters.PresentKafkaRequestAdminEndpoint(kafka, accountService)
+	if err != nil {
+		glog.Fatalf("Unable to present kafka %q: %s", id, err.Error())
+	}
+	printObject(output, adminKafka)
+}
+
+func newKafkasSuspendCommand(env *environments.Env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "suspend <kafka id>",
+		Short: "Suspend a Kafka instance",
+		Long:  "Suspend a ready Kafka instance, its resources are removed from the data plane cluster but its data is kept",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(kafkaService services.KafkaService) {
+				runKafkasUpdateStatus(kafkaService, args[0], constants.KafkaRequestStatusSuspending, services.ValidateKafkaCanBeSuspended)
+			})
+		},
+	}
+}
+
+func newKafkasResumeCommand(env *environments.Env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <kafka id>",
+		Short: "Resume a suspended Kafka instance",
+		Long:  "Resume a suspended Kafka instance, unless it is within the grace period before its expiration",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(kafkaService services.KafkaService, kafkaConfig *config.KafkaConfig) {
+				runKafkasUpdateStatus(kafkaService, args[0], constants.KafkaRequestStatusResuming, func(kafka *dbapi.KafkaRequest) *errors.ServiceError {
+					return services.ValidateKafkaCanBeResumed(kafka, kafkaConfig)
+				})
+			})
+		},
+	}
+}
+
+// runKafkasUpdateStatus moves a kafka to the given status, the kafka is validated like when it's suspended or resumed
+// with the admin API
+func runKafkasUpdateStatus(kafkaService services.KafkaService, id string, status constants.KafkaStatus, validate func(kafka *dbapi.KafkaRequest) *errors.ServiceError) {
+	if err := updateKafkaStatus(kafkaService, id, status, validate); err != nil {
+		glog.Fatalf("Unable to move kafka %q to %s: %s", id, status, err.Error())
+	}
+	fmt.Printf("kafka %s is %s\n", id, status)
+}
+
+func updateKafkaStatus(kafkaService services.KafkaService, id string, status constants.KafkaStatus, validate func(kafka *dbapi.KafkaRequest) *errors.ServiceError) *errors.ServiceError {
+	kafka, err := kafkaService.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := validate(kafka); err != nil {
+		return err
+	}
+	if _, err := kafkaService.UpdateStatus(id, status); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newKafkasDeleteCommand(env *environments.Env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <kafka id>",
+		Short: "Delete a Kafka instance",
+		Long:  "Register a Kafka instance for deprovisioning, like a delete request to the API",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(kafkaService services.KafkaService) {
+				if err := kafkaService.RegisterKafkaDeprovisionJob(newAdminContext(), args[0]); err != nil {
+					glog.Fatalf("Unable to delete kafka %q: %s", args[0], err.Error())
+				}
+				fmt.Printf("kafka %s is %s\n", args[0], constants.KafkaRequestStatusDeprovision)
+			})
+		},
+	}
+}
+
+func newKafkasManagedKafkaCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "managed-kafka <kafka id>",
+		Short: "Print the ManagedKafka CR of a Kafka instance",
+		Long:  "Print the ManagedKafka custom resource generated for a Kafka instance, as sent to the fleetshard operator",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			output := getOutputFlag(cmd, outputJSON, outputYAML)
+			env.MustInvoke(func(kafkaService services.KafkaService) {
+				managedKafka, err := kafkaService.GetManagedKafkaByID(args[0])
+				if err != nil {
+					glog.Fatalf("Unable to generate the ManagedKafka of kafka %q: %s", args[0], err.Error())
+				}
+				printObject(output, managedKafka)
+			})
+		},
+	}
+	addOutputFlag(cmd, outputYAML, outputYAML, outputJSON)
+	return cmd
+}
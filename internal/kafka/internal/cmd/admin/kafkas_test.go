@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func Test_updateKafkaStatus(t *testing.T) {
+	kafkaConfig := &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: "developer",
+						SupportedBillingModels: []config.KafkaBillingModel{
+							{ID: "standard", GracePeriodDays: 10},
+						},
+					},
+				},
+			},
+		},
+	}
+	canBeResumed := func(kafka *dbapi.KafkaRequest) *errors.ServiceError {
+		return services.ValidateKafkaCanBeResumed(kafka, kafkaConfig)
+	}
+
+	tests := []struct {
+		name       string
+		kafka      *dbapi.KafkaRequest
+		status     constants.KafkaStatus
+		validate   func(kafka *dbapi.KafkaRequest) *errors.ServiceError
+		wantErr    bool
+		wantUpdate bool
+	}{
+		{
+			name:       "should suspend a ready kafka",
+			kafka:      &dbapi.KafkaRequest{Status: constants.KafkaRequestStatusReady.String()},
+			status:     constants.KafkaRequestStatusSuspending,
+			validate:   services.ValidateKafkaCanBeSuspended,
+			wantUpdate: true,
+		},
+		{
+			name:     "should not suspend a kafka that isn't ready",
+			kafka:    &dbapi.KafkaRequest{Status: constants.KafkaRequestStatusSuspended.String()},
+			status:   constants.KafkaRequestStatusSuspending,
+			validate: services.ValidateKafkaCanBeSuspended,
+			wantErr:  true,
+		},
+		{
+			name:       "should resume a suspended kafka",
+			kafka:      &dbapi.KafkaRequest{Status: constants.KafkaRequestStatusSuspended.String()},
+			status:     constants.KafkaRequestStatusResuming,
+			validate:   canBeResumed,
+			wantUpdate: true,
+		},
+		{
+			name:     "should not resume a kafka that is still suspending",
+			kafka:    &dbapi.KafkaRequest{Status: constants.KafkaRequestStatusSuspending.String()},
+			status:   constants.KafkaRequestStatusResuming,
+			validate: canBeResumed,
+			wantErr:  true,
+		},
+		{
+			name: "should not resume a suspended kafka within its grace period",
+			kafka: &dbapi.KafkaRequest{
+				Status:                  constants.KafkaRequestStatusSuspended.String(),
+				InstanceType:            "developer",
+				ActualKafkaBillingModel: "standard",
+				ExpiresAt:               sql.NullTime{Time: time.Now().Add(24 * time.Hour), Valid: true},
+			},
+			status:   constants.KafkaRequestStatusResuming,
+			validate: canBeResumed,
+			wantErr:  true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaService := &services.KafkaServiceMock{
+				GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					tt.kafka.Meta = api.Meta{ID: id}
+					return tt.kafka, nil
+				},
+				UpdateStatusFunc: func(id string, status constants.KafkaStatus) (bool, *errors.ServiceError) {
+					return true, nil
+				},
+			}
+			err := updateKafkaStatus(kafkaService, "kafka-id", tt.status, tt.validate)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantUpdate {
+				g.Expect(kafkaService.UpdateStatusCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(kafkaService.UpdateStatusCalls()).To(gomega.HaveLen(1))
+			g.Expect(kafkaService.UpdateStatusCalls()[0].Status).To(gomega.Equal(tt.status))
+		})
+	}
+}
+
+func Test_updateKafkaStatus_GetError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	kafkaService := &services.KafkaServiceMock{
+		GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+			return nil, errors.NotFound("KafkaResource with id='%s' not found", id)
+		},
+	}
+	err := updateKafkaStatus(kafkaService, "kafka-id", constants.KafkaRequestStatusResuming, services.ValidateKafkaCanBeSuspended)
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(err.Is404()).To(gomega.BeTrue())
+}
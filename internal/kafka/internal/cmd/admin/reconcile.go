@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+func NewReconcileCommand(env *environments.Env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reconcile <worker type>",
+		Short: "Trigger the reconcile of a worker",
+		Long: "Trigger the reconcile of a worker through the signal bus. " +
+			"The worker reconciles as soon as possible in the kas-fleet-manager instance that is its leader",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(signalBus signalbus.SignalBus, workerList []workers.Worker) {
+				runReconcile(signalBus, workerList, args[0])
+			})
+		},
+	}
+}
+
+func runReconcile(signalBus signalbus.SignalBus, workerList []workers.Worker, workerType string) {
+	var workerTypes []string
+	for _, w := range workerList {
+		if w.GetWorkerType() == workerType {
+			signalBus.Notify("reconcile:" + workerType)
+			fmt.Printf("reconcile of worker %s triggered\n", workerType)
+			return
+		}
+		workerTypes = append(workerTypes, w.GetWorkerType())
+	}
+	sort.Strings(workerTypes)
+	glog.Fatalf("Unknown worker %q, one of %s is expected", workerType, strings.Join(workerTypes, ", "))
+}
@@ -0,0 +1,248 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	apiURLFlag                 = "api-url"
+	clusterIDFlag              = "cluster-id"
+	registerFlag               = "register"
+	cloudProviderFlag          = "cloud-provider"
+	regionFlag                 = "region"
+	multiAZFlag                = "multi-az"
+	supportedInstanceTypesFlag = "supported-instance-types"
+	tokenFlag                  = "token"
+	pollIntervalFlag           = "poll-interval"
+	provisioningDelayFlag      = "provisioning-delay"
+	deletionDelayFlag          = "deletion-delay"
+	upgradeDelayFlag           = "upgrade-delay"
+	failureRateFlag            = "failure-rate"
+	seedFlag                   = "seed"
+	maxUnitsFlag               = "max-units"
+	strimziVersionsFlag        = "strimzi-versions"
+	kafkaVersionsFlag          = "kafka-versions"
+	kafkaIbpVersionsFlag       = "kafka-ibp-versions"
+)
+
+// NewAgentSimulatorCommand creates a command that simulates a kas-fleetshard operator running in a standalone data plane
+// cluster, so that the Kafka lifecycle can be tested end to end without any data plane infrastructure
+func NewAgentSimulatorCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kafka-agent-simulator",
+		Short: "Simulate a kas-fleetshard operator",
+		Long: "Simulate a kas-fleetshard operator running in a standalone data plane cluster. The simulator polls the " +
+			"ManagedKafkas assigned to the cluster from the agent API and reports the cluster and Kafka statuses " +
+			"back to kas-fleet-manager, going through installation, upgrades, suspension and deletion",
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(clusterService services.ClusterService, addon services.KasFleetshardOperatorAddon, keycloakService sso.KafkaKeycloakService) {
+				runAgentSimulator(cmd, clusterService, addon, keycloakService)
+			})
+		},
+	}
+
+	cmd.Flags().String(apiURLFlag, "http://localhost:8000", "Base URL of the kas-fleet-manager API")
+	cmd.Flags().String(clusterIDFlag, "", "ID of the simulated data plane cluster")
+	cmd.Flags().Bool(registerFlag, false, "Register the cluster as a standalone data plane cluster when it does not exist")
+	cmd.Flags().String(cloudProviderFlag, "aws", "Cloud provider of the registered cluster")
+	cmd.Flags().String(regionFlag, "us-east-1", "Region of the registered cluster")
+	cmd.Flags().Bool(multiAZFlag, true, "Whether the registered cluster is multi AZ")
+	cmd.Flags().StringSlice(supportedInstanceTypesFlag, []string{api.StandardTypeSupport.String(), api.DeveloperTypeSupport.String()}, "Instance types supported by the cluster")
+	cmd.Flags().String(tokenFlag, "", "Bearer token used to call the agent API instead of the cluster service account credentials")
+	cmd.Flags().Duration(pollIntervalFlag, 15*time.Second, "Interval between two synchronisations with kas-fleet-manager")
+	cmd.Flags().Duration(provisioningDelayFlag, 1*time.Minute, "Time a new Kafka is reported as installing before becoming ready")
+	cmd.Flags().Duration(deletionDelayFlag, 30*time.Second, "Time a Kafka marked for deletion takes to be reported as deleted")
+	cmd.Flags().Duration(upgradeDelayFlag, 1*time.Minute, "Time each Strimzi, Kafka and Kafka IBP version upgrade takes")
+	cmd.Flags().Float64(failureRateFlag, 0, "Probability, between 0 and 1, that a new Kafka fails to install")
+	cmd.Flags().Int64(seedFlag, 0, "Seed of the fault injection, the current time is used when 0")
+	cmd.Flags().Int32(maxUnitsFlag, 10, "Maximum number of streaming units of each instance type")
+	cmd.Flags().StringSlice(strimziVersionsFlag, []string{"strimzi-cluster-operator.v0.23.0-0"}, "Strimzi versions reported as ready")
+	cmd.Flags().StringSlice(kafkaVersionsFlag, []string{"2.8.1", "3.1.0"}, "Kafka versions supported by each Strimzi version")
+	cmd.Flags().StringSlice(kafkaIbpVersionsFlag, []string{"2.8", "3.1"}, "Kafka IBP versions supported by each Strimzi version")
+
+	return cmd
+}
+
+func runAgentSimulator(cmd *cobra.Command, clusterService services.ClusterService, addon services.KasFleetshardOperatorAddon, keycloakService sso.KafkaKeycloakService) {
+	flags := cmd.Flags()
+	clusterID := mustGetString(cmd, clusterIDFlag)
+	if clusterID == "" {
+		glog.Fatalf("Flag --%s is required", clusterIDFlag)
+	}
+
+	cluster := mustFindOrRegisterCluster(cmd, clusterService, clusterID)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg := private.NewConfiguration()
+	cfg.BasePath = strings.TrimSuffix(mustGetString(cmd, apiURLFlag), "/")
+	if token := mustGetString(cmd, tokenFlag); token != "" {
+		cfg.AddDefaultHeader("Authorization", fmt.Sprintf("Bearer %s", token))
+	} else {
+		cfg.HTTPClient = mustCreateOAuthClient(ctx, clusterService, addon, keycloakService, cluster)
+	}
+	client := private.NewAPIClient(cfg)
+
+	seed, _ := flags.GetInt64(seedFlag)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	maxUnits, _ := flags.GetInt32(maxUnitsFlag)
+	failureRate, _ := flags.GetFloat64(failureRateFlag)
+	simulator := NewSimulator(Options{
+		ClusterDNS:        cluster.ClusterDNS,
+		ProvisioningDelay: mustGetDuration(cmd, provisioningDelayFlag),
+		DeletionDelay:     mustGetDuration(cmd, deletionDelayFlag),
+		UpgradeDelay:      mustGetDuration(cmd, upgradeDelayFlag),
+		FailureRate:       failureRate,
+		MaxUnits:          maxUnits,
+		InstanceTypes:     cluster.GetSupportedInstanceTypes(),
+		StrimziVersions:   mustGetStringSlice(cmd, strimziVersionsFlag),
+		KafkaVersions:     mustGetStringSlice(cmd, kafkaVersionsFlag),
+		KafkaIbpVersions:  mustGetStringSlice(cmd, kafkaIbpVersionsFlag),
+	}, rand.New(rand.NewSource(seed)))
+
+	glog.Infof("Simulating the kas-fleetshard operator of cluster %s against %s with seed %d", clusterID, cfg.BasePath, seed)
+	ticker := time.NewTicker(mustGetDuration(cmd, pollIntervalFlag))
+	defer ticker.Stop()
+	for {
+		if err := synchronize(ctx, client, simulator, clusterID); err != nil {
+			glog.Errorf("Failed to synchronize cluster %s: %s", clusterID, err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// synchronize does what the kas-fleetshard synchronizer does on each poll: it reports the cluster status, reads
+// the ManagedKafkas assigned to the cluster and reports their statuses
+func synchronize(ctx context.Context, client *private.APIClient, simulator *Simulator, clusterID string) error {
+	kafkas, _, err := client.AgentClustersApi.GetKafkas(ctx, clusterID)
+	if err != nil {
+		return fmt.Errorf("unable to get kafkas: %w", err)
+	}
+
+	if _, err := client.AgentClustersApi.UpdateAgentClusterStatus(ctx, clusterID, simulator.ClusterStatus(kafkas.Items)); err != nil {
+		return fmt.Errorf("unable to update cluster status: %w", err)
+	}
+
+	statuses := simulator.KafkaStatuses(kafkas.Items, time.Now())
+	if len(statuses) == 0 {
+		return nil
+	}
+	if _, err := client.AgentClustersApi.UpdateKafkaClusterStatus(ctx, clusterID, statuses); err != nil {
+		return fmt.Errorf("unable to update kafka statuses: %w", err)
+	}
+	for id, status := range statuses {
+		condition := status.Conditions[0]
+		glog.V(5).Infof("Reported kafka %s as %s=%s %s", id, condition.Type, condition.Status, condition.Reason)
+	}
+	return nil
+}
+
+func mustFindOrRegisterCluster(cmd *cobra.Command, clusterService services.ClusterService, clusterID string) *api.Cluster {
+	cluster, svcErr := clusterService.FindClusterByID(clusterID)
+	if svcErr != nil {
+		glog.Fatalf("Unable to find cluster %s: %s", clusterID, svcErr.Error())
+	}
+	if cluster != nil {
+		return cluster
+	}
+
+	register, _ := cmd.Flags().GetBool(registerFlag)
+	if !register {
+		glog.Fatalf("Cluster %s does not exist, use --%s to register it", clusterID, registerFlag)
+	}
+	multiAZ, _ := cmd.Flags().GetBool(multiAZFlag)
+	cluster = &api.Cluster{
+		ClusterID:             clusterID,
+		CloudProvider:         mustGetString(cmd, cloudProviderFlag),
+		Region:                mustGetString(cmd, regionFlag),
+		MultiAZ:               multiAZ,
+		Status:                api.ClusterWaitingForKasFleetShardOperator,
+		ProviderType:          api.ClusterProviderStandalone,
+		ClusterType:           api.ManagedDataPlaneClusterType.String(),
+		ClusterDNS:            fmt.Sprintf("%s.simulator.local", clusterID),
+		SupportedInstanceType: strings.Join(mustGetStringSlice(cmd, supportedInstanceTypesFlag), ","),
+	}
+	if svcErr := clusterService.RegisterClusterJob(cluster); svcErr != nil {
+		glog.Fatalf("Unable to register cluster %s: %s", clusterID, svcErr.Error())
+	}
+	glog.Infof("Registered standalone cluster %s", clusterID)
+	return cluster
+}
+
+// mustCreateOAuthClient returns an http client authenticated with the service account of the cluster, like the one
+// the kas-fleetshard operator receives through the addon parameters. The service account is created when missing.
+func mustCreateOAuthClient(ctx context.Context, clusterService services.ClusterService, addon services.KasFleetshardOperatorAddon, keycloakService sso.KafkaKeycloakService, cluster *api.Cluster) *http.Client {
+	if cluster.ClientID == "" || cluster.ClientSecret == "" {
+		params, svcErr := addon.GetAddonParams(cluster)
+		if svcErr != nil {
+			glog.Fatalf("Unable to create the service account of cluster %s: %s", cluster.ClusterID, svcErr.Error())
+		}
+		cluster.ClientID = params.GetParam(services.KasFleetshardOperatorParamServiceAccountId)
+		cluster.ClientSecret = params.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret)
+		if svcErr := clusterService.Update(*cluster); svcErr != nil {
+			glog.Fatalf("Unable to store the service account of cluster %s: %s", cluster.ClusterID, svcErr.Error())
+		}
+	}
+
+	config := clientcredentials.Config{
+		ClientID:     cluster.ClientID,
+		ClientSecret: cluster.ClientSecret,
+		TokenURL:     keycloakService.GetRealmConfig().TokenEndpointURI,
+	}
+	return config.Client(ctx)
+}
+
+func mustGetString(cmd *cobra.Command, name string) string {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil {
+		glog.Fatalf("Unable to read flag %s: %s", name, err.Error())
+	}
+	return value
+}
+
+func mustGetStringSlice(cmd *cobra.Command, name string) []string {
+	value, err := cmd.Flags().GetStringSlice(name)
+	if err != nil {
+		glog.Fatalf("Unable to read flag %s: %s", name, err.Error())
+	}
+	return value
+}
+
+func mustGetDuration(cmd *cobra.Command, name string) time.Duration {
+	value, err := cmd.Flags().GetDuration(name)
+	if err != nil {
+		glog.Fatalf("Unable to read flag %s: %s", name, err.Error())
+	}
+	return value
+}
@@ -0,0 +1,240 @@
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/private"
+)
+
+const (
+	conditionReady = "Ready"
+
+	conditionStatusTrue  = "True"
+	conditionStatusFalse = "False"
+
+	reasonInstalling       = "Installing"
+	reasonSuspended        = "Suspended"
+	reasonDeleted          = "Deleted"
+	reasonError            = "Error"
+	reasonStrimziUpdating  = "StrimziUpdating"
+	reasonKafkaUpdating    = "KafkaUpdating"
+	reasonKafkaIbpUpdating = "KafkaIbpUpdating"
+
+	simulatedFailureMessage = "simulated failure"
+
+	// the number of broker routes reported for each Kafka
+	brokerRoutes = 3
+)
+
+// Options holds the timing and fault injection settings of the simulated data plane
+type Options struct {
+	ClusterDNS        string
+	ProvisioningDelay time.Duration
+	DeletionDelay     time.Duration
+	UpgradeDelay      time.Duration
+	// FailureRate is the probability, between 0 and 1, that a new Kafka is reported as failed
+	FailureRate      float64
+	MaxUnits         int32
+	InstanceTypes    []string
+	StrimziVersions  []string
+	KafkaVersions    []string
+	KafkaIbpVersions []string
+}
+
+// kafkaState is what the simulator remembers about a Kafka between two polls
+type kafkaState struct {
+	firstSeen        time.Time
+	failed           bool
+	deletedAt        time.Time
+	upgradeStartedAt time.Time
+	versions         private.ManagedKafkaVersions
+}
+
+// Simulator computes the status reports of a simulated kas-fleetshard operator from the ManagedKafkas
+// assigned to the data plane cluster. It is not safe for concurrent use.
+type Simulator struct {
+	options Options
+	random  *rand.Rand
+	kafkas  map[string]*kafkaState
+}
+
+func NewSimulator(options Options, random *rand.Rand) *Simulator {
+	return &Simulator{
+		options: options,
+		random:  random,
+		kafkas:  map[string]*kafkaState{},
+	}
+}
+
+// ClusterStatus returns the status of the data plane cluster, the remaining capacity of each instance type is
+// computed from the quota consumed by the Kafkas that are not deleted
+func (s *Simulator) ClusterStatus(kafkas []private.ManagedKafka) private.DataPlaneClusterUpdateStatusRequest {
+	consumed := map[string]int32{}
+	for _, k := range kafkas {
+		if k.Spec.Deleted {
+			continue
+		}
+		units, err := strconv.Atoi(k.Metadata.Labels.Bf2OrgKafkaInstanceProfileQuotaConsumed)
+		if err != nil {
+			units = 1
+		}
+		consumed[k.Metadata.Labels.Bf2OrgKafkaInstanceProfileType] += int32(units)
+	}
+
+	capacity := map[string]private.DataPlaneClusterUpdateStatusRequestCapacity{}
+	for _, instanceType := range s.options.InstanceTypes {
+		remaining := s.options.MaxUnits - consumed[instanceType]
+		if remaining < 0 {
+			remaining = 0
+		}
+		capacity[instanceType] = private.DataPlaneClusterUpdateStatusRequestCapacity{
+			MaxUnits:       s.options.MaxUnits,
+			RemainingUnits: remaining,
+		}
+	}
+
+	var strimzi []private.DataPlaneClusterUpdateStatusRequestStrimzi
+	for _, version := range s.options.StrimziVersions {
+		strimzi = append(strimzi, private.DataPlaneClusterUpdateStatusRequestStrimzi{
+			Ready:            true,
+			Version:          version,
+			KafkaVersions:    s.options.KafkaVersions,
+			KafkaIbpVersions: s.options.KafkaIbpVersions,
+		})
+	}
+
+	return private.DataPlaneClusterUpdateStatusRequest{
+		Conditions: []private.DataPlaneClusterUpdateStatusRequestConditions{
+			{Type: conditionReady, Status: conditionStatusTrue},
+		},
+		Capacity: capacity,
+		Strimzi:  strimzi,
+	}
+}
+
+// KafkaStatuses returns the status of each one of the given Kafkas at the given time and forgets about the Kafkas
+// that are no longer assigned to the data plane cluster
+func (s *Simulator) KafkaStatuses(kafkas []private.ManagedKafka, now time.Time) map[string]private.DataPlaneKafkaStatus {
+	statuses := map[string]private.DataPlaneKafkaStatus{}
+	seen := map[string]bool{}
+	for _, k := range kafkas {
+		seen[k.Id] = true
+		statuses[k.Id] = s.kafkaStatus(k, now)
+	}
+	for id := range s.kafkas {
+		if !seen[id] {
+			delete(s.kafkas, id)
+		}
+	}
+	return statuses
+}
+
+func (s *Simulator) kafkaStatus(kafka private.ManagedKafka, now time.Time) private.DataPlaneKafkaStatus {
+	state, ok := s.kafkas[kafka.Id]
+	if !ok {
+		// the failure is decided once so that a failed Kafka keeps failing, like a real one would
+		state = &kafkaState{
+			firstSeen: now,
+			failed:    s.random.Float64() < s.options.FailureRate,
+			versions:  kafka.Spec.Versions,
+		}
+		s.kafkas[kafka.Id] = state
+	}
+
+	if kafka.Spec.Deleted {
+		if state.deletedAt.IsZero() {
+			state.deletedAt = now
+		}
+		if now.Sub(state.deletedAt) >= s.options.DeletionDelay {
+			return notReadyStatus(reasonDeleted, "")
+		}
+	}
+
+	if state.failed {
+		return notReadyStatus(reasonError, simulatedFailureMessage)
+	}
+
+	if now.Sub(state.firstSeen) < s.options.ProvisioningDelay {
+		return notReadyStatus(reasonInstalling, "")
+	}
+
+	if kafka.Metadata.Labels.Bf2OrgSuspended == "true" {
+		return notReadyStatus(reasonSuspended, "")
+	}
+
+	return s.readyStatus(kafka, state, s.upgradeReason(kafka, state, now))
+}
+
+// upgradeReason moves the reported versions towards the desired ones, one component at a time and each one taking
+// the upgrade delay, and returns the reason of the Ready condition while an upgrade is in progress
+func (s *Simulator) upgradeReason(kafka private.ManagedKafka, state *kafkaState, now time.Time) string {
+	desired := kafka.Spec.Versions
+	var reason string
+	switch {
+	case state.versions.Strimzi != desired.Strimzi:
+		reason = reasonStrimziUpdating
+	case state.versions.Kafka != desired.Kafka:
+		reason = reasonKafkaUpdating
+	case state.versions.KafkaIbp != desired.KafkaIbp:
+		reason = reasonKafkaIbpUpdating
+	default:
+		return ""
+	}
+
+	if state.upgradeStartedAt.IsZero() {
+		state.upgradeStartedAt = now
+	}
+	if now.Sub(state.upgradeStartedAt) < s.options.UpgradeDelay {
+		return reason
+	}
+
+	state.upgradeStartedAt = time.Time{}
+	switch reason {
+	case reasonStrimziUpdating:
+		state.versions.Strimzi = desired.Strimzi
+	case reasonKafkaUpdating:
+		state.versions.Kafka = desired.Kafka
+	default:
+		state.versions.KafkaIbp = desired.KafkaIbp
+	}
+	return s.upgradeReason(kafka, state, now)
+}
+
+func (s *Simulator) readyStatus(kafka private.ManagedKafka, state *kafkaState, reason string) private.DataPlaneKafkaStatus {
+	router := fmt.Sprintf("ingresscontroller.kas.%s", s.options.ClusterDNS)
+	routes := []private.DataPlaneKafkaStatusRoutes{
+		{Name: "admin-server", Prefix: "admin-server", Router: router},
+		{Name: "bootstrap", Prefix: "", Router: router},
+	}
+	for i := 0; i < brokerRoutes; i++ {
+		routes = append(routes, private.DataPlaneKafkaStatusRoutes{
+			Name:   fmt.Sprintf("broker-%d", i),
+			Prefix: fmt.Sprintf("broker-%d", i),
+			Router: router,
+		})
+	}
+
+	return private.DataPlaneKafkaStatus{
+		Conditions: []private.DataPlaneClusterUpdateStatusRequestConditions{
+			{Type: conditionReady, Status: conditionStatusTrue, Reason: reason},
+		},
+		Versions: private.DataPlaneKafkaStatusVersions{
+			Kafka:    state.versions.Kafka,
+			KafkaIbp: state.versions.KafkaIbp,
+			Strimzi:  state.versions.Strimzi,
+		},
+		Routes:         &routes,
+		AdminServerURI: fmt.Sprintf("https://admin-server-%s", kafka.Spec.Endpoint.BootstrapServerHost),
+	}
+}
+
+func notReadyStatus(reason string, message string) private.DataPlaneKafkaStatus {
+	return private.DataPlaneKafkaStatus{
+		Conditions: []private.DataPlaneClusterUpdateStatusRequestConditions{
+			{Type: conditionReady, Status: conditionStatusFalse, Reason: reason, Message: message},
+		},
+	}
+}
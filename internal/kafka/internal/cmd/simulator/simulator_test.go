@@ -0,0 +1,192 @@
+package simulator
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/private"
+	"github.com/onsi/gomega"
+)
+
+func newTestManagedKafka(mutateFn func(*private.ManagedKafka)) private.ManagedKafka {
+	kafka := private.ManagedKafka{
+		Id: "kafka-1",
+		Metadata: private.ManagedKafkaAllOfMetadata{
+			Labels: private.ManagedKafkaAllOfMetadataLabels{
+				Bf2OrgKafkaInstanceProfileType:          "standard",
+				Bf2OrgKafkaInstanceProfileQuotaConsumed: "2",
+			},
+		},
+		Spec: private.ManagedKafkaAllOfSpec{
+			Endpoint: private.ManagedKafkaAllOfSpecEndpoint{BootstrapServerHost: "kafka-1.example.com"},
+			Versions: private.ManagedKafkaVersions{Kafka: "2.8.1", KafkaIbp: "2.8", Strimzi: "strimzi-cluster-operator.v0.23.0-0"},
+		},
+	}
+	if mutateFn != nil {
+		mutateFn(&kafka)
+	}
+	return kafka
+}
+
+func newTestSimulator(failureRate float64) *Simulator {
+	return NewSimulator(Options{
+		ClusterDNS:        "cluster.example.com",
+		ProvisioningDelay: time.Minute,
+		DeletionDelay:     time.Minute,
+		UpgradeDelay:      time.Minute,
+		FailureRate:       failureRate,
+		MaxUnits:          5,
+		InstanceTypes:     []string{"standard", "developer"},
+	}, rand.New(rand.NewSource(1)))
+}
+
+// kafkaStatusStep is a poll of the simulator at the given time since the start of the test, the Ready condition
+// reported for the kafka is checked after each one
+type kafkaStatusStep struct {
+	kafka      private.ManagedKafka
+	at         time.Duration
+	wantStatus string
+	wantReason string
+}
+
+func Test_Simulator_KafkaStatuses(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name        string
+		failureRate float64
+		steps       []kafkaStatusStep
+	}{
+		{
+			name: "should report the kafka as installing and then as ready",
+			steps: []kafkaStatusStep{
+				{kafka: newTestManagedKafka(nil), at: 0, wantStatus: conditionStatusFalse, wantReason: reasonInstalling},
+				{kafka: newTestManagedKafka(nil), at: 2 * time.Minute, wantStatus: conditionStatusTrue},
+			},
+		},
+		{
+			name:        "should report the kafka as failed when the failure is injected",
+			failureRate: 1,
+			steps: []kafkaStatusStep{
+				{kafka: newTestManagedKafka(nil), at: 0, wantStatus: conditionStatusFalse, wantReason: reasonError},
+				{kafka: newTestManagedKafka(nil), at: 2 * time.Minute, wantStatus: conditionStatusFalse, wantReason: reasonError},
+			},
+		},
+		{
+			name: "should report the kafka as suspended",
+			steps: []kafkaStatusStep{
+				{kafka: newTestManagedKafka(nil), at: 0, wantStatus: conditionStatusFalse, wantReason: reasonInstalling},
+				{
+					kafka: newTestManagedKafka(func(k *private.ManagedKafka) {
+						k.Metadata.Labels.Bf2OrgSuspended = "true"
+					}),
+					at:         2 * time.Minute,
+					wantStatus: conditionStatusFalse,
+					wantReason: reasonSuspended,
+				},
+			},
+		},
+		{
+			name: "should report the kafka as deleted after the deletion delay",
+			steps: []kafkaStatusStep{
+				{kafka: newTestManagedKafka(nil), at: 2 * time.Minute, wantStatus: conditionStatusFalse, wantReason: reasonInstalling},
+				{kafka: newTestManagedKafka(func(k *private.ManagedKafka) { k.Spec.Deleted = true }), at: 4 * time.Minute, wantStatus: conditionStatusTrue},
+				{kafka: newTestManagedKafka(func(k *private.ManagedKafka) { k.Spec.Deleted = true }), at: 5 * time.Minute, wantStatus: conditionStatusFalse, wantReason: reasonDeleted},
+			},
+		},
+		{
+			name: "should upgrade the strimzi and then the kafka version",
+			steps: []kafkaStatusStep{
+				{kafka: newTestManagedKafka(nil), at: 2 * time.Minute, wantStatus: conditionStatusFalse, wantReason: reasonInstalling},
+				{
+					kafka: newTestManagedKafka(func(k *private.ManagedKafka) {
+						k.Spec.Versions.Strimzi = "strimzi-cluster-operator.v0.24.0-0"
+						k.Spec.Versions.Kafka = "3.1.0"
+					}),
+					at:         4 * time.Minute,
+					wantStatus: conditionStatusTrue,
+					wantReason: reasonStrimziUpdating,
+				},
+				{
+					kafka: newTestManagedKafka(func(k *private.ManagedKafka) {
+						k.Spec.Versions.Strimzi = "strimzi-cluster-operator.v0.24.0-0"
+						k.Spec.Versions.Kafka = "3.1.0"
+					}),
+					at:         5 * time.Minute,
+					wantStatus: conditionStatusTrue,
+					wantReason: reasonKafkaUpdating,
+				},
+				{
+					kafka: newTestManagedKafka(func(k *private.ManagedKafka) {
+						k.Spec.Versions.Strimzi = "strimzi-cluster-operator.v0.24.0-0"
+						k.Spec.Versions.Kafka = "3.1.0"
+					}),
+					at:         6 * time.Minute,
+					wantStatus: conditionStatusTrue,
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			simulator := newTestSimulator(tt.failureRate)
+			for _, step := range tt.steps {
+				statuses := simulator.KafkaStatuses([]private.ManagedKafka{step.kafka}, start.Add(step.at))
+				g.Expect(statuses).To(gomega.HaveKey(step.kafka.Id))
+				condition := statuses[step.kafka.Id].Conditions[0]
+				g.Expect(condition.Status).To(gomega.Equal(step.wantStatus))
+				g.Expect(condition.Reason).To(gomega.Equal(step.wantReason))
+			}
+		})
+	}
+}
+
+func Test_Simulator_KafkaStatuses_Ready(t *testing.T) {
+	g := gomega.NewWithT(t)
+	simulator := newTestSimulator(0)
+	kafka := newTestManagedKafka(nil)
+	now := time.Now()
+
+	simulator.KafkaStatuses([]private.ManagedKafka{kafka}, now)
+	status := simulator.KafkaStatuses([]private.ManagedKafka{kafka}, now.Add(time.Hour))[kafka.Id]
+
+	g.Expect(status.Versions).To(gomega.Equal(private.DataPlaneKafkaStatusVersions{
+		Kafka:    "2.8.1",
+		KafkaIbp: "2.8",
+		Strimzi:  "strimzi-cluster-operator.v0.23.0-0",
+	}))
+	g.Expect(status.AdminServerURI).To(gomega.Equal("https://admin-server-kafka-1.example.com"))
+	g.Expect(status.Routes).ToNot(gomega.BeNil())
+	g.Expect(*status.Routes).To(gomega.HaveLen(2 + brokerRoutes))
+	g.Expect((*status.Routes)[0].Router).To(gomega.Equal("ingresscontroller.kas.cluster.example.com"))
+
+	// the kafka is forgotten when it is no longer assigned to the cluster
+	simulator.KafkaStatuses(nil, now.Add(time.Hour))
+	g.Expect(simulator.kafkas).To(gomega.BeEmpty())
+}
+
+func Test_Simulator_ClusterStatus(t *testing.T) {
+	g := gomega.NewWithT(t)
+	simulator := newTestSimulator(0)
+
+	status := simulator.ClusterStatus([]private.ManagedKafka{
+		newTestManagedKafka(nil),
+		newTestManagedKafka(func(k *private.ManagedKafka) { k.Id = "kafka-2" }),
+		newTestManagedKafka(func(k *private.ManagedKafka) {
+			k.Id = "kafka-3"
+			k.Spec.Deleted = true
+		}),
+	})
+
+	g.Expect(status.Conditions).To(gomega.Equal([]private.DataPlaneClusterUpdateStatusRequestConditions{
+		{Type: conditionReady, Status: conditionStatusTrue},
+	}))
+	g.Expect(status.Capacity).To(gomega.Equal(map[string]private.DataPlaneClusterUpdateStatusRequestCapacity{
+		"standard":  {MaxUnits: 5, RemainingUnits: 1},
+		"developer": {MaxUnits: 5, RemainingUnits: 5},
+	}))
+}
@@ -343,19 +343,25 @@ func (c *DataplaneClusterConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.NodePrewarmingConfig.filePath, "node-prewarming-config-file", c.NodePrewarmingConfig.filePath, "File path to a file containing the node prewarming configuration")
 }
 
+func (c *DataplaneClusterConfig) ValidationDependencies(env *environments.Env) []environments.ConfigModule {
+	var kafkaConfig *KafkaConfig
+	env.MustResolve(&kafkaConfig)
+	return []environments.ConfigModule{kafkaConfig}
+}
+
+// Validate returns the errors of the dynamic scaling and node prewarming configurations, prefixed with the path of their file
 func (c *DataplaneClusterConfig) Validate(env *environments.Env) error {
 
 	var kafkaConfig *KafkaConfig
 	env.MustResolve(&kafkaConfig)
 
+	var errs environments.ConfigErrors
 	if c.IsDataPlaneAutoScalingEnabled() {
-		err := c.DynamicScalingConfig.validate()
-		if err != nil {
-			return err
-		}
+		errs = append(errs, environments.FileErrors(c.DynamicScalingConfig.filePath, c.DynamicScalingConfig.validate())...)
 	}
 
-	return c.NodePrewarmingConfig.validate(kafkaConfig)
+	errs = append(errs, environments.FileErrors(c.NodePrewarmingConfig.filePath, c.NodePrewarmingConfig.validate(kafkaConfig))...)
+	return errs.ErrorOrNil()
 }
 
 func (c *DataplaneClusterConfig) ReadFiles() error {
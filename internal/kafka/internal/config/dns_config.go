@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/spf13/pflag"
+)
+
+// DNSConfig selects the DNS provider that manages the route records of a Kafka
+type DNSConfig struct {
+	// DefaultProvider is used when no provider is configured for the base domain or cloud provider of a Kafka
+	DefaultProvider string
+	// ProvidersByCloudProvider maps cloud provider ids to DNS providers
+	ProvidersByCloudProvider map[string]string
+	// ProvidersByDomain maps base domains to DNS providers, they take precedence over cloud providers
+	ProvidersByDomain map[string]string
+	RFC2136           RFC2136DNSConfig
+	CloudDNS          CloudDNSConfig
+}
+
+type RFC2136DNSConfig struct {
+	Server             string
+	Zone               string
+	TSIGKeyName        string
+	TSIGSecret         string
+	TSIGAlgorithm      string
+	tsigSecretFilePath string
+}
+
+type CloudDNSConfig struct {
+	ProjectID           string
+	ManagedZone         string
+	Credentials         []byte
+	credentialsFilePath string
+}
+
+func NewDNSConfig() *DNSConfig {
+	return &DNSConfig{
+		DefaultProvider:          dns.ProviderTypeRoute53.String(),
+		ProvidersByCloudProvider: map[string]string{},
+		ProvidersByDomain:        map[string]string{},
+		RFC2136: RFC2136DNSConfig{
+			TSIGAlgorithm:      dns.DefaultTSIGAlgorithm,
+			tsigSecretFilePath: "secrets/dns.rfc2136-tsig-secret",
+		},
+		CloudDNS: CloudDNSConfig{
+			credentialsFilePath: defaultGCPCredentialsFilePath,
+		},
+	}
+}
+
+func (c *DNSConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.DefaultProvider, "dns-provider", c.DefaultProvider, fmt.Sprintf("The default DNS provider for Kafka routes, one of: %s", strings.Join(dns.ValidProviderTypes, ", ")))
+	fs.StringToStringVar(&c.ProvidersByCloudProvider, "dns-providers-by-cloud-provider", c.ProvidersByCloudProvider, "DNS providers for Kafka routes by cloud provider, e.g. gcp=clouddns")
+	fs.StringToStringVar(&c.ProvidersByDomain, "dns-providers-by-domain", c.ProvidersByDomain, "DNS providers for Kafka routes by base domain, e.g. kafka.example.com=rfc2136. They take precedence over cloud providers")
+	fs.StringVar(&c.RFC2136.Server, "dns-rfc2136-server", c.RFC2136.Server, "The host:port of the name server that accepts RFC 2136 dynamic DNS updates")
+	fs.StringVar(&c.RFC2136.Zone, "dns-rfc2136-zone", c.RFC2136.Zone, "The zone to update with RFC 2136 dynamic DNS updates, it defaults to the base domain of the Kafka routes")
+	fs.StringVar(&c.RFC2136.TSIGKeyName, "dns-rfc2136-tsig-key-name", c.RFC2136.TSIGKeyName, "The name of the TSIG key used to sign RFC 2136 dynamic DNS updates")
+	fs.StringVar(&c.RFC2136.tsigSecretFilePath, "dns-rfc2136-tsig-secret-file", c.RFC2136.tsigSecretFilePath, "File containing the base64 encoded TSIG key used to sign RFC 2136 dynamic DNS updates")
+	fs.StringVar(&c.RFC2136.TSIGAlgorithm, "dns-rfc2136-tsig-algorithm", c.RFC2136.TSIGAlgorithm, "The algorithm of the TSIG key used to sign RFC 2136 dynamic DNS updates")
+	fs.StringVar(&c.CloudDNS.ProjectID, "dns-gcp-project-id", c.CloudDNS.ProjectID, "The GCP project of the Cloud DNS managed zones, it defaults to the project of the credentials")
+	fs.StringVar(&c.CloudDNS.ManagedZone, "dns-gcp-managed-zone", c.CloudDNS.ManagedZone, "The Cloud DNS managed zone to update, it defaults to the public managed zone of the base domain of the Kafka routes")
+	fs.StringVar(&c.CloudDNS.credentialsFilePath, "dns-gcp-credentials-file", c.CloudDNS.credentialsFilePath, "Path to a file containing GCP API Credentials in JSON format for Cloud DNS")
+}
+
+func (c *DNSConfig) ReadFiles() error {
+	if c.isProviderUsed(dns.ProviderTypeRFC2136) && c.RFC2136.TSIGKeyName != "" {
+		err := shared.ReadFileValueString(c.RFC2136.tsigSecretFilePath, &c.RFC2136.TSIGSecret)
+		if err != nil {
+			return err
+		}
+	}
+	if c.isProviderUsed(dns.ProviderTypeCloudDNS) {
+		credentials, err := os.ReadFile(shared.BuildFullFilePath(c.CloudDNS.credentialsFilePath))
+		if err != nil {
+			return fmt.Errorf("error reading file %q: %v", c.CloudDNS.credentialsFilePath, err)
+		}
+		c.CloudDNS.Credentials = credentials
+	}
+	return nil
+}
+
+func (c *DNSConfig) Validate(env *environments.Env) error {
+	if !arrays.Contains(dns.ValidProviderTypes, c.DefaultProvider) {
+		return fmt.Errorf("invalid dns provider %q, valid providers are: %s", c.DefaultProvider, strings.Join(dns.ValidProviderTypes, ", "))
+	}
+	for _, providers := range []map[string]string{c.ProvidersByCloudProvider, c.ProvidersByDomain} {
+		for key, provider := range providers {
+			if !arrays.Contains(dns.ValidProviderTypes, provider) {
+				return fmt.Errorf("invalid dns provider %q for %q, valid providers are: %s", provider, key, strings.Join(dns.ValidProviderTypes, ", "))
+			}
+		}
+	}
+	if c.isProviderUsed(dns.ProviderTypeRFC2136) && c.RFC2136.Server == "" {
+		return fmt.Errorf("dns-rfc2136-server is required when the rfc2136 dns provider is used")
+	}
+	return nil
+}
+
+// GetProviderType returns the DNS provider for a Kafka with routes in the given domain on the given cloud provider.
+// The most specific matching base domain wins, then the cloud provider, then the default provider.
+func (c *DNSConfig) GetProviderType(cloudProvider string, domain string) dns.ProviderType {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	baseDomains := make([]string, 0, len(c.ProvidersByDomain))
+	for baseDomain := range c.ProvidersByDomain {
+		baseDomains = append(baseDomains, baseDomain)
+	}
+	sort.Slice(baseDomains, func(i, j int) bool {
+		return len(baseDomains[i]) > len(baseDomains[j])
+	})
+	for _, baseDomain := range baseDomains {
+		d := strings.TrimSuffix(strings.ToLower(baseDomain), ".")
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return dns.ProviderType(c.ProvidersByDomain[baseDomain])
+		}
+	}
+	if provider, ok := c.ProvidersByCloudProvider[cloudProvider]; ok {
+		return dns.ProviderType(provider)
+	}
+	return dns.ProviderType(c.DefaultProvider)
+}
+
+func (c *DNSConfig) isProviderUsed(providerType dns.ProviderType) bool {
+	if c.DefaultProvider == providerType.String() {
+		return true
+	}
+	for _, providers := range []map[string]string{c.ProvidersByCloudProvider, c.ProvidersByDomain} {
+		for _, provider := range providers {
+			if provider == providerType.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
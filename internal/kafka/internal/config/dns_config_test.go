@@ -0,0 +1,118 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/onsi/gomega"
+)
+
+func Test_DNSConfig_GetProviderType(t *testing.T) {
+	dnsConfig := &DNSConfig{
+		DefaultProvider: dns.ProviderTypeRoute53.String(),
+		ProvidersByCloudProvider: map[string]string{
+			cloudproviders.GCP.String(): dns.ProviderTypeCloudDNS.String(),
+		},
+		ProvidersByDomain: map[string]string{
+			"example.com":        dns.ProviderTypeRoute53.String(),
+			"onprem.example.com": dns.ProviderTypeRFC2136.String(),
+		},
+	}
+
+	type args struct {
+		cloudProvider string
+		domain        string
+	}
+	tests := []struct {
+		name string
+		args args
+		want dns.ProviderType
+	}{
+		{
+			name: "should return the provider of the most specific base domain",
+			args: args{cloudProvider: cloudproviders.GCP.String(), domain: "kafka.onprem.example.com"},
+			want: dns.ProviderTypeRFC2136,
+		},
+		{
+			name: "should match base domains regardless of case and trailing dot",
+			args: args{cloudProvider: cloudproviders.GCP.String(), domain: "OnPrem.Example.com."},
+			want: dns.ProviderTypeRFC2136,
+		},
+		{
+			name: "should prefer base domains over cloud providers",
+			args: args{cloudProvider: cloudproviders.GCP.String(), domain: "kafka.example.com"},
+			want: dns.ProviderTypeRoute53,
+		},
+		{
+			name: "should return the provider of the cloud provider when no base domain matches",
+			args: args{cloudProvider: cloudproviders.GCP.String(), domain: "kafka.notexample.com"},
+			want: dns.ProviderTypeCloudDNS,
+		},
+		{
+			name: "should return the default provider",
+			args: args{cloudProvider: cloudproviders.AWS.String(), domain: "kafka.notexample.com"},
+			want: dns.ProviderTypeRoute53,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(dnsConfig.GetProviderType(tt.args.cloudProvider, tt.args.domain)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_DNSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifyFn func(c *DNSConfig)
+		wantErr  bool
+	}{
+		{
+			name:     "should return no error with the default config",
+			modifyFn: func(c *DNSConfig) {},
+		},
+		{
+			name: "should return an error for an unknown default provider",
+			modifyFn: func(c *DNSConfig) {
+				c.DefaultProvider = "unknown"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error for an unknown provider of a cloud provider",
+			modifyFn: func(c *DNSConfig) {
+				c.ProvidersByCloudProvider[cloudproviders.GCP.String()] = "unknown"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the rfc2136 provider is used without a name server",
+			modifyFn: func(c *DNSConfig) {
+				c.ProvidersByDomain["onprem.example.com"] = dns.ProviderTypeRFC2136.String()
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return no error when the rfc2136 provider is used with a name server",
+			modifyFn: func(c *DNSConfig) {
+				c.ProvidersByDomain["onprem.example.com"] = dns.ProviderTypeRFC2136.String()
+				c.RFC2136.Server = "127.0.0.1:53"
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			c := NewDNSConfig()
+			tt.modifyFn(c)
+			g.Expect(c.Validate(&environments.Env{}) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
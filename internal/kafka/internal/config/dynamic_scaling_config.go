@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/pkg/errors"
 )
 
@@ -43,14 +44,15 @@ func (c *DynamicScalingConfig) validate() error {
 		return errors.Wrap(err, "error validating dynamic scaling configuration")
 	}
 
+	var errs environments.ConfigErrors
 	for k, v := range c.ComputeMachinePerCloudProvider {
 		err := v.validate(k)
 		if err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errs.ErrorOrNil()
 }
 
 type ComputeNodesAutoscalingConfig struct {
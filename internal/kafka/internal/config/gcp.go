@@ -51,6 +51,12 @@ func (c *GCPConfig) ReadFiles() error {
 	return nil
 }
 
+func (c *GCPConfig) ValidationDependencies(env *environments.Env) []environments.ConfigModule {
+	var providersConfig *ProviderConfig
+	env.MustResolve(&providersConfig)
+	return []environments.ConfigModule{providersConfig}
+}
+
 func (c *GCPConfig) Validate(env *environments.Env) error {
 	var providersConfig *ProviderConfig
 	env.MustResolve(&providersConfig)
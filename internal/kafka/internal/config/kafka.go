@@ -62,7 +62,7 @@ func (c *KafkaConfig) ReadFiles() error {
 }
 
 func (c *KafkaConfig) Validate(env *environments.Env) error {
-	return c.SupportedInstanceTypes.Configuration.validate()
+	return environments.FileErrors(c.SupportedInstanceTypes.ConfigurationFile, c.SupportedInstanceTypes.Configuration.validate()).ErrorOrNil()
 }
 
 func (c *KafkaConfig) GetFirstAvailableSize(instanceType string) (*KafkaInstanceSize, error) {
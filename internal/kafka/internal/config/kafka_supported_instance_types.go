@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
@@ -246,21 +247,24 @@ func (s *SupportedKafkaInstanceTypesConfig) GetKafkaInstanceTypeByID(instanceTyp
 	return nil, fmt.Errorf("unable to find kafka instance type for '%s'", instanceType)
 }
 
+// validate returns an environments.ConfigErrors with the errors of all the instance types
 func (s *SupportedKafkaInstanceTypesConfig) validate() error {
+	var errs environments.ConfigErrors
 	existingInstanceTypes := make(map[string]int, len(s.SupportedKafkaInstanceTypes))
 
 	for _, KafkaInstanceType := range s.SupportedKafkaInstanceTypes {
 		if _, ok := existingInstanceTypes[KafkaInstanceType.Id]; ok {
-			return fmt.Errorf("kafka instance type id '%s' was defined more than once", KafkaInstanceType.Id)
+			errs = append(errs, fmt.Errorf("kafka instance type id '%s' was defined more than once", KafkaInstanceType.Id))
+			continue
 		}
 		existingInstanceTypes[KafkaInstanceType.Id]++
 
 		if err := KafkaInstanceType.validate(); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errs.ErrorOrNil()
 }
 
 type KafkaSupportedInstanceTypesConfig struct {
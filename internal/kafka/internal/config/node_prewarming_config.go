@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/pkg/errors"
@@ -37,15 +38,17 @@ func (c *NodePrewarmingConfig) ForInstanceType(instanceTypeID string) (InstanceT
 	return instanceTypeConfig, true
 }
 
+// validate returns an environments.ConfigErrors with the errors of all the instance types
 func (c *NodePrewarmingConfig) validate(kafkaConfig *KafkaConfig) error {
+	var errs environments.ConfigErrors
 	for instanceType, configuration := range c.Configuration {
 		err := configuration.validate(instanceType, kafkaConfig)
 		if err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errs.ErrorOrNil()
 }
 
 func (c *NodePrewarmingConfig) readFile() error {
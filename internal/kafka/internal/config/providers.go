@@ -9,7 +9,6 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/spf13/pflag"
-	"gopkg.in/yaml.v2"
 
 	errs "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 )
@@ -185,27 +184,35 @@ func NewSupportedProvidersConfig() *ProviderConfig {
 }
 
 var _ environments.ServiceValidator = &ProviderConfig{}
+var _ environments.ValidationDependencies = &ProviderConfig{}
 
+func (c *ProviderConfig) ValidationDependencies(env *environments.Env) []environments.ConfigModule {
+	var dataplaneClusterConfig *DataplaneClusterConfig
+	env.MustResolve(&dataplaneClusterConfig)
+	return []environments.ConfigModule{dataplaneClusterConfig}
+}
+
+// Validate returns the errors of all the providers, prefixed with the path of the providers configuration file
 func (c *ProviderConfig) Validate(env *environments.Env) error {
 
 	var dataplaneClusterConfig *DataplaneClusterConfig
 	env.MustResolve(&dataplaneClusterConfig)
 
+	var errs environments.ConfigErrors
 	providerDefaultCount := 0
 	for _, p := range c.ProvidersConfig.SupportedProviders {
-		if err := p.Validate(dataplaneClusterConfig); err != nil {
-			return err
-		}
+		errs = append(errs, environments.FileErrors(c.ProvidersConfigFile, p.Validate(dataplaneClusterConfig))...)
 		if p.Default {
 			providerDefaultCount++
 		}
 	}
 	if providerDefaultCount != 1 {
-		return fmt.Errorf("expected 1 default provider in provider list, got %d", providerDefaultCount)
+		errs = append(errs, environments.FileErrors(c.ProvidersConfigFile, fmt.Errorf("expected 1 default provider in provider list, got %d", providerDefaultCount))...)
 	}
-	return nil
+	return errs.ErrorOrNil()
 }
 
+// Validate returns an environments.ConfigErrors with the errors of the provider and of all its regions
 func (provider Provider) Validate(dataplaneClusterConfig *DataplaneClusterConfig) error {
 	knownCloudProviders := cloudproviders.KnownCloudProviders()
 	cloudProviderID := cloudproviders.ParseCloudProviderID(provider.Name)
@@ -214,12 +221,14 @@ func (provider Provider) Validate(dataplaneClusterConfig *DataplaneClusterConfig
 		return fmt.Errorf("cloud Provider '%s' is not a recognized Cloud Provider", cloudProviderID)
 	}
 
+	var errs environments.ConfigErrors
+
 	// verify that machine type configuration are there during dynamic scaling mode
 
 	if dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
 		_, err := dataplaneClusterConfig.DefaultComputeMachinesConfig(cloudProviderID)
 		if err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 
@@ -231,13 +240,13 @@ func (provider Provider) Validate(dataplaneClusterConfig *DataplaneClusterConfig
 		}
 
 		if err := r.Validate(dataplaneClusterConfig); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 	if defaultCount != 1 {
-		return fmt.Errorf("expected 1 default region in provider %s, got %d", provider.Name, defaultCount)
+		errs = append(errs, fmt.Errorf("expected 1 default region in provider %s, got %d", provider.Name, defaultCount))
 	}
-	return nil
+	return errs.ErrorOrNil()
 }
 
 func (c *ProviderConfig) AddFlags(fs *pflag.FlagSet) {
@@ -262,11 +271,7 @@ func (c *ProviderConfig) GetInstanceLimit(region string, providerName string, in
 
 // Read the contents of file into the providers config
 func readFileProvidersConfig(file string, val *ProviderConfiguration) error {
-	fileContents, err := shared.ReadFile(file)
-	if err != nil {
-		return err
-	}
-	return yaml.UnmarshalStrict([]byte(fileContents), val)
+	return shared.ReadYamlFileStrict(file, val)
 }
 
 func (c ProviderList) GetDefault() (Provider, error) {
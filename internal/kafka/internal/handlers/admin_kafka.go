@@ -3,7 +3,6 @@ package handlers
 import (
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 
@@ -48,16 +47,19 @@ func NewAdminKafkaHandler(kafkaService services.KafkaService, accountService acc
 }
 
 func (h adminKafkaHandler) Get(w http.ResponseWriter, r *http.Request) {
+	var kafkaRequest *dbapi.KafkaRequest
 	cfg := &handlers.HandlerConfig{
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
-			kafkaRequest, err := h.kafkaService.Get(ctx, id)
+			var err *errors.ServiceError
+			kafkaRequest, err = h.kafkaService.Get(ctx, id)
 			if err != nil {
 				return nil, err
 			}
 			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
 		},
+		Version: kafkaRequestVersion(&kafkaRequest),
 	}
 	handlers.HandleGet(w, r, cfg)
 }
@@ -200,13 +202,19 @@ func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 			updateRequired = update(&kafkaRequest.Status, newStatus) || updateRequired
 
 			if updateRequired {
-				err := h.kafkaService.VerifyAndUpdateKafkaAdmin(ctx, kafkaRequest)
+				update := h.kafkaService.VerifyAndUpdateKafkaAdmin
+				if handlers.HasIfMatch(r) {
+					// the kafka must not have been updated since its version was checked against the If-Match header
+					update = h.kafkaService.VerifyAndUpdateKafkaAdminIfUnmodified
+				}
+				err := update(ctx, kafkaRequest)
 				if err != nil {
 					return nil, err
 				}
 			}
 			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
 		},
+		Version: kafkaRequestVersion(&kafkaRequest),
 	}
 	handlers.Handle(w, r, cfg, http.StatusOK)
 }
@@ -256,42 +264,13 @@ func (h *adminKafkaHandler) validateUpdateKafkaSuspended(kafkaRequest *dbapi.Kaf
 
 func (h *adminKafkaHandler) validateUpdateKafkaCanBeSuspended(kafkaRequest *dbapi.KafkaRequest, kafkaUpdateReq *private.KafkaUpdateRequest) handlers.Validate {
 	return func() *errors.ServiceError {
-		suspendableStates := []string{constants.KafkaRequestStatusReady.String()}
-		isSuspendableState := arrays.Contains(suspendableStates, kafkaRequest.Status)
-		if !isSuspendableState {
-			return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be suspended. Kafka instances can only be suspended in the following states: %s", kafkaRequest.Status, suspendableStates)
-		}
-		return nil
+		return services.ValidateKafkaCanBeSuspended(kafkaRequest)
 	}
 }
 
 func (h *adminKafkaHandler) validateUpdateKafkaCanBeResumed(kafkaRequest *dbapi.KafkaRequest, kafkaUpdateReq *private.KafkaUpdateRequest) handlers.Validate {
 	return func() *errors.ServiceError {
-		resumableStates := []string{constants.KafkaRequestStatusSuspended.String()}
-		isResumableState := arrays.Contains(resumableStates, kafkaRequest.Status)
-		if !isResumableState {
-			return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed. Kafka instances can only be resumed in the following states: %s", kafkaRequest.Status, resumableStates)
-		}
-
-		kafkaRequestHasExpirationSet := kafkaRequest.ExpiresAt.Valid
-		if !kafkaRequestHasExpirationSet {
-			return nil
-		}
-
-		timeNow := time.Now()
-		kafkaBillingModelConfig, err := h.kafkaConfig.GetBillingModelByID(kafkaRequest.InstanceType, kafkaRequest.ActualKafkaBillingModel)
-		if err != nil {
-			return errors.ToServiceError(err)
-		}
-		gracePeriodDays := kafkaBillingModelConfig.GracePeriodDays
-		durationGracePeriodDays := time.Duration(gracePeriodDays*86400) * time.Second
-		startOfGracePeriod := kafkaRequest.ExpiresAt.Time.Add(-durationGracePeriodDays)
-		isWithinOrAfterGracePeriod := timeNow.After(startOfGracePeriod)
-		if isWithinOrAfterGracePeriod {
-			return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed due to the instance is suspended and it is within its grace period: start of grace period: %s ", kafkaRequest.Status, startOfGracePeriod)
-		}
-
-		return nil
+		return services.ValidateKafkaCanBeResumed(kafkaRequest, h.kafkaConfig)
 	}
 }
 
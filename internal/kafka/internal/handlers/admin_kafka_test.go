@@ -21,6 +21,7 @@ import (
 	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	s "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
 	"github.com/onsi/gomega"
@@ -249,9 +250,11 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 		kafkaConfig    *config.KafkaConfig
 	}
 	type args struct {
-		url  string
-		body []byte
+		url     string
+		body    []byte
+		ifMatch string
 	}
+	updatedAt := time.Date(2023, 5, 2, 12, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name            string
 		fields          fields
@@ -450,6 +453,55 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "should return 412 if the kafka is updated concurrently after the If-Match header is checked",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{
+							Meta: api.Meta{
+								ID: "id",
+							},
+							ClusterID: clusterID,
+						}, nil
+					},
+					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+						return true, nil
+					},
+					CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
+						return true, nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return &dbapi.KafkaRequest{
+							Status: constants.KafkaRequestStatusPreparing.String(),
+							Meta: api.Meta{
+								ID:        "id",
+								UpdatedAt: updatedAt,
+							},
+							ClusterID:              "cluster-id",
+							ActualKafkaIBPVersion:  "2.7",
+							DesiredKafkaIBPVersion: "2.8",
+							ActualKafkaVersion:     "2.7",
+							DesiredKafkaVersion:    "2.7",
+							DesiredStrimziVersion:  "2.7",
+							MaxDataRetentionSize:   "100",
+						}, nil
+					},
+					VerifyAndUpdateKafkaAdminIfUnmodifiedFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return errors.PreconditionFailed("kafka %s has been updated since it was read", kafkaRequest.ID)
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			args: args{
+				url:     kafkaByIdUrl,
+				body:    []byte(`{"kafka_ibp_version": "2.7"}`),
+				ifMatch: handlers.ETag(handlers.VersionFromTime(updatedAt)),
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+		},
 		{
 			name: "should successfully upgrade kafka",
 			fields: fields{
@@ -1139,6 +1191,9 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 			g := gomega.NewWithT(t)
 			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{})
 			req, rw := GetHandlerParams("PATCH", tt.args.url, bytes.NewBuffer(tt.args.body), t)
+			if tt.args.ifMatch != "" {
+				req.Header.Set(handlers.IfMatchHeader, tt.args.ifMatch)
+			}
 			h.Update(rw, req)
 			resp := rw.Result()
 			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
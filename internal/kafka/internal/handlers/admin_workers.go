@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/gorilla/mux"
+)
+
+type adminWorkersHandler struct {
+	workerTypes []string
+	stateStore  workers.WorkerStateStore
+	signalBus   signalbus.SignalBus
+}
+
+func NewAdminWorkersHandler(workerList []workers.Worker, stateStore workers.WorkerStateStore, signalBus signalbus.SignalBus) *adminWorkersHandler {
+	workerTypes := []string{}
+	seen := map[string]bool{}
+	for _, w := range workerList {
+		if !seen[w.GetWorkerType()] {
+			seen[w.GetWorkerType()] = true
+			workerTypes = append(workerTypes, w.GetWorkerType())
+		}
+	}
+	sort.Strings(workerTypes)
+	return &adminWorkersHandler{
+		workerTypes: workerTypes,
+		stateStore:  stateStore,
+		signalBus:   signalBus,
+	}
+}
+
+// List returns the status of all the worker types
+func (h adminWorkersHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			statuses, err := h.stateStore.List(h.workerTypes)
+			if err != nil {
+				return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list workers")
+			}
+			workerList := private.WorkerList{
+				Kind:  "WorkerList",
+				Page:  1,
+				Size:  int32(len(statuses)),
+				Total: int32(len(statuses)),
+				Items: []private.Worker{},
+			}
+			now := time.Now()
+			for _, status := range statuses {
+				worker, serviceErr := presenters.PresentWorker(status, now)
+				if serviceErr != nil {
+					return nil, serviceErr
+				}
+				workerList.Items = append(workerList.Items, worker)
+			}
+			return workerList, nil
+		},
+	}
+	handlers.HandleList(w, r, cfg)
+}
+
+// Get returns the status of a worker type
+func (h adminWorkersHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			return h.getWorker(mux.Vars(r)["worker_type"])
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// Pause stops the reconciles of a worker type on all the replicas until it's resumed
+func (h adminWorkersHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	h.setPaused(w, r, true)
+}
+
+// Resume restarts the reconciles of a paused worker type
+func (h adminWorkersHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.setPaused(w, r, false)
+}
+
+func (h adminWorkersHandler) setPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	workerType := mux.Vars(r)["worker_type"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			h.validateWorkerType(workerType),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			if err := h.stateStore.SetPaused(workerType, paused); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to update worker %s", workerType)
+			}
+			if !paused {
+				// don't wait for the next reconcile interval to catch up
+				h.signalBus.Notify("reconcile:" + workerType)
+			}
+			return h.getWorker(workerType)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// Reconcile triggers an immediate reconcile of a worker type by its leader
+func (h adminWorkersHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	workerType := mux.Vars(r)["worker_type"]
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			h.validateWorkerType(workerType),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			paused, err := h.stateStore.IsPaused(workerType)
+			if err != nil {
+				return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to get worker %s", workerType)
+			}
+			if paused {
+				return nil, errors.BadRequest("worker %s is paused and has to be resumed first", workerType)
+			}
+			h.signalBus.Notify("reconcile:" + workerType)
+			return nil, nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusNoContent)
+}
+
+func (h adminWorkersHandler) validateWorkerType(workerType string) handlers.Validate {
+	return func() *errors.ServiceError {
+		for _, t := range h.workerTypes {
+			if t == workerType {
+				return nil
+			}
+		}
+		return errors.NotFound("worker with type %q not found", workerType)
+	}
+}
+
+func (h adminWorkersHandler) getWorker(workerType string) (interface{}, *errors.ServiceError) {
+	if err := h.validateWorkerType(workerType)(); err != nil {
+		return nil, err
+	}
+	statuses, err := h.stateStore.List([]string{workerType})
+	if err != nil || len(statuses) == 0 {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to get worker %s", workerType)
+	}
+	return presenters.PresentWorker(statuses[0], time.Now())
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func newTestWorkerStateStore(paused map[string]bool) *workers.WorkerStateStoreMock {
+	return &workers.WorkerStateStoreMock{
+		IsPausedFunc: func(workerType string) (bool, error) {
+			return paused[workerType], nil
+		},
+		SetPausedFunc: func(workerType string, p bool) error {
+			paused[workerType] = p
+			return nil
+		},
+		ListFunc: func(workerTypes []string) ([]workers.WorkerStatus, error) {
+			expires := time.Now().Add(time.Minute)
+			var statuses []workers.WorkerStatus
+			for _, workerType := range workerTypes {
+				statuses = append(statuses, workers.WorkerStatus{
+					WorkerState: workers.WorkerState{
+						WorkerType: workerType,
+						Paused:     paused[workerType],
+						LastErrors: []byte(`["failed"]`),
+					},
+					Leader:       "leader-id",
+					LeaseExpires: &expires,
+				})
+			}
+			return statuses, nil
+		},
+	}
+}
+
+func newTestWorkers(workerTypes ...string) []workers.Worker {
+	var workerList []workers.Worker
+	for i := range workerTypes {
+		workerType := workerTypes[i]
+		workerList = append(workerList, &workers.WorkerMock{
+			GetWorkerTypeFunc: func() string {
+				return workerType
+			},
+		})
+	}
+	return workerList
+}
+
+func Test_adminWorkersHandler_List(t *testing.T) {
+	g := gomega.NewWithT(t)
+	h := NewAdminWorkersHandler(newTestWorkers("ready_kafka", "cluster", "ready_kafka"),
+		newTestWorkerStateStore(map[string]bool{"cluster": true}), signalbus.NewSignalBus())
+
+	req, rw := GetHandlerParams(http.MethodGet, "/api/kafkas_mgmt/v1/admin/workers", nil, t)
+	h.List(rw, req)
+	g.Expect(rw.Code).To(gomega.Equal(http.StatusOK))
+
+	var workerList private.WorkerList
+	g.Expect(json.Unmarshal(rw.Body.Bytes(), &workerList)).To(gomega.Succeed())
+	g.Expect(workerList.Total).To(gomega.Equal(int32(2)))
+	g.Expect(workerList.Items[0].WorkerType).To(gomega.Equal("cluster"))
+	g.Expect(workerList.Items[0].Paused).To(gomega.BeTrue())
+	g.Expect(workerList.Items[0].Running).To(gomega.BeFalse())
+	g.Expect(workerList.Items[1].WorkerType).To(gomega.Equal("ready_kafka"))
+	g.Expect(workerList.Items[1].Running).To(gomega.BeTrue())
+	g.Expect(workerList.Items[1].Leader).To(gomega.Equal("leader-id"))
+	g.Expect(workerList.Items[1].LastErrors).To(gomega.Equal([]string{"failed"}))
+}
+
+func Test_adminWorkersHandler_PauseAndResume(t *testing.T) {
+	tests := []struct {
+		name           string
+		workerType     string
+		pause          bool
+		wantStatusCode int
+		wantPaused     bool
+		wantSignal     bool
+	}{
+		{
+			name:           "should pause the worker",
+			workerType:     "ready_kafka",
+			pause:          true,
+			wantStatusCode: http.StatusOK,
+			wantPaused:     true,
+		},
+		{
+			name:           "should resume the worker and trigger its reconcile",
+			workerType:     "ready_kafka",
+			wantStatusCode: http.StatusOK,
+			wantSignal:     true,
+		},
+		{
+			name:           "should fail when the worker type is unknown",
+			workerType:     "unknown",
+			pause:          true,
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			bus := signalbus.NewSignalBus()
+			sub := bus.Subscribe("reconcile:" + tt.workerType)
+			defer sub.Close()
+			paused := map[string]bool{"ready_kafka": !tt.pause}
+			h := NewAdminWorkersHandler(newTestWorkers("ready_kafka"), newTestWorkerStateStore(paused), bus)
+
+			req, rw := GetHandlerParams(http.MethodPost, "/api/kafkas_mgmt/v1/admin/workers/{worker_type}/pause", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"worker_type": tt.workerType})
+			if tt.pause {
+				h.Pause(rw, req)
+			} else {
+				h.Resume(rw, req)
+			}
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode), "returned body: '%s'", rw.Body.String())
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var worker private.Worker
+			g.Expect(json.Unmarshal(rw.Body.Bytes(), &worker)).To(gomega.Succeed())
+			g.Expect(worker.Paused).To(gomega.Equal(tt.wantPaused))
+			g.Expect(paused[tt.workerType]).To(gomega.Equal(tt.wantPaused))
+			select {
+			case <-sub.Signal():
+				g.Expect(tt.wantSignal).To(gomega.BeTrue())
+			default:
+				g.Expect(tt.wantSignal).To(gomega.BeFalse())
+			}
+		})
+	}
+}
+
+func Test_adminWorkersHandler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name           string
+		paused         bool
+		wantStatusCode int
+	}{
+		{
+			name:           "should trigger the reconcile of the worker",
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "should fail when the worker is paused",
+			paused:         true,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			bus := signalbus.NewSignalBus()
+			sub := bus.Subscribe("reconcile:ready_kafka")
+			defer sub.Close()
+			h := NewAdminWorkersHandler(newTestWorkers("ready_kafka"),
+				newTestWorkerStateStore(map[string]bool{"ready_kafka": tt.paused}), bus)
+
+			req, rw := GetHandlerParams(http.MethodPost, "/api/kafkas_mgmt/v1/admin/workers/{worker_type}/reconcile", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"worker_type": "ready_kafka"})
+			h.Reconcile(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode), "returned body: '%s'", rw.Body.String())
+
+			select {
+			case <-sub.Signal():
+				g.Expect(tt.wantStatusCode).To(gomega.Equal(http.StatusNoContent))
+			default:
+				g.Expect(tt.wantStatusCode).ToNot(gomega.Equal(http.StatusNoContent))
+			}
+		})
+	}
+}
@@ -1,14 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	config "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 
 	"github.com/gorilla/mux"
 
@@ -17,22 +20,24 @@ import (
 )
 
 type kafkaHandler struct {
-	service        services.KafkaService
-	providerConfig *config.ProviderConfig
-	authService    authorization.Authorization
-	kafkaConfig    *config.KafkaConfig
+	service         services.KafkaService
+	providerConfig  *config.ProviderConfig
+	authService     authorization.Authorization
+	kafkaConfig     *config.KafkaConfig
+	templateService services.KafkaTemplateService
 }
 
 func GetAcceptedOrderByParams() []string {
 	return []string{"bootstrap_server_host", "cloud_provider", "cluster_id", "created_at", "href", "id", "instance_type", "multi_az", "name", "organisation_id", "owner", "reauthentication_enabled", "region", "status", "updated_at", "version"}
 }
 
-func NewKafkaHandler(service services.KafkaService, providerConfig *config.ProviderConfig, authService authorization.Authorization, kafkaConfig *config.KafkaConfig) *kafkaHandler {
+func NewKafkaHandler(service services.KafkaService, providerConfig *config.ProviderConfig, authService authorization.Authorization, kafkaConfig *config.KafkaConfig, templateService services.KafkaTemplateService) *kafkaHandler {
 	return &kafkaHandler{
-		service:        service,
-		providerConfig: providerConfig,
-		authService:    authService,
-		kafkaConfig:    kafkaConfig,
+		service:         service,
+		providerConfig:  providerConfig,
+		authService:     authService,
+		kafkaConfig:     kafkaConfig,
+		templateService: templateService,
 	}
 }
 
@@ -42,35 +47,50 @@ func (h kafkaHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	cfg := &handlers.HandlerConfig{
 		MarshalInto: &kafkaRequestPayload,
-		Validate: []handlers.Validate{
+		Validate: append([]handlers.Validate{
 			handlers.ValidateAsyncEnabled(r, "creating kafka requests"),
-			handlers.ValidateLength(&kafkaRequestPayload.Name, "name", handlers.MinRequiredFieldLength, &MaxKafkaNameLength),
-			ValidKafkaClusterName(&kafkaRequestPayload.Name, "name"),
-			ValidateKafkaClusterNameIsUnique(&kafkaRequestPayload.Name, h.service, r.Context()),
-			ValidateKafkaClaims(ctx, ValidateUsername(), ValidateOrganisationId()),
-			ValidateCloudProvider(ctx, h.service, &kafkaRequestPayload, h.providerConfig, "creating kafka requests"),
-			handlers.ValidateNotEmptyClusterId(kafkaRequestPayload.ClusterId, "cluster id"),
-			ValidateKafkaPlan(ctx, h.service, h.kafkaConfig, &kafkaRequestPayload),
-			validateKafkaBillingModel(ctx, h.service, h.kafkaConfig, &kafkaRequestPayload),
-			ValidateBillingCloudAccountIdAndMarketplace(ctx, h.service, &kafkaRequestPayload),
-		},
+			h.applyKafkaTemplate(ctx, &kafkaRequestPayload),
+		}, h.validateKafkaRequestPayload(ctx, &kafkaRequestPayload)...),
 		Action: func() (interface{}, *errors.ServiceError) {
-			convKafka := presenters.ConvertKafkaRequest(kafkaRequestPayload)
-
-			claims, _ := getClaims(ctx)
-			convKafka.Owner, _ = claims.GetUsername()
-			convKafka.OrganisationId, _ = claims.GetOrgId()
-			convKafka.OwnerAccountId, _ = claims.GetAccountId()
+			return h.registerKafka(ctx, &kafkaRequestPayload)
+		},
+	}
 
-			convKafka.InstanceType, convKafka.SizeId, _ = getInstanceTypeAndSize(ctx, h.service, h.kafkaConfig, &kafkaRequestPayload)
+	// return 202 status accepted
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
 
-			convKafka.CloudProvider, convKafka.Region, _ = getCloudProviderAndRegion(ctx, h.service, &kafkaRequestPayload, h.providerConfig)
+// Clone creates a new kafka request with the settings of a kafka request of the organisation of the user
+func (h kafkaHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	var cloneRequest public.KafkaCloneRequest
+	var kafkaRequestPayload public.KafkaRequestPayload
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.service.Get(ctx, id)
 
-			svcErr := h.service.RegisterKafkaJob(convKafka)
-			if svcErr != nil {
-				return nil, svcErr
-			}
-			return presenters.PresentKafkaRequest(convKafka, h.kafkaConfig)
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &cloneRequest,
+		Validate: append([]handlers.Validate{
+			handlers.ValidateAsyncEnabled(r, "cloning kafka requests"),
+			func() *errors.ServiceError {
+				if kafkaGetError != nil {
+					return kafkaGetError
+				}
+				// kafka requests the organisation has been granted access to can not be cloned
+				orgId, err := getOrgId(ctx)
+				if err != nil {
+					return err
+				}
+				if kafkaRequest.OrganisationId != orgId {
+					return errors.Forbidden("kafka %s can only be cloned by its organisation", id)
+				}
+				// the payload of the new kafka request goes through the same validations as a created one
+				kafkaRequestPayload = presenters.ConvertKafkaCloneRequest(kafkaRequest, cloneRequest)
+				return nil
+			},
+		}, h.validateKafkaRequestPayload(ctx, &kafkaRequestPayload)...),
+		Action: func() (interface{}, *errors.ServiceError) {
+			return h.registerKafka(ctx, &kafkaRequestPayload)
 		},
 	}
 
@@ -78,17 +98,83 @@ func (h kafkaHandler) Create(w http.ResponseWriter, r *http.Request) {
 	handlers.Handle(w, r, cfg, http.StatusAccepted)
 }
 
+// applyKafkaTemplate sets the fields of the kafka request payload that are not set from the kafka template it
+// refers to, if any
+func (h kafkaHandler) applyKafkaTemplate(ctx context.Context, kafkaRequestPayload *public.KafkaRequestPayload) handlers.Validate {
+	return func() *errors.ServiceError {
+		templateID := shared.SafeString(kafkaRequestPayload.TemplateId)
+		if templateID == "" {
+			return nil
+		}
+		orgId, err := getOrgId(ctx)
+		if err != nil {
+			return err
+		}
+		template, err := h.templateService.Get(orgId, templateID)
+		if err != nil {
+			if err.Is404() {
+				return errors.BadRequest("template_id is not valid: kafka template %s not found", templateID)
+			}
+			return err
+		}
+		presenters.ApplyKafkaTemplate(template, kafkaRequestPayload)
+		return nil
+	}
+}
+
+// validateKafkaRequestPayload returns the validations of the payload of a new kafka request
+func (h kafkaHandler) validateKafkaRequestPayload(ctx context.Context, kafkaRequestPayload *public.KafkaRequestPayload) []handlers.Validate {
+	return []handlers.Validate{
+		handlers.ValidateLength(&kafkaRequestPayload.Name, "name", handlers.MinRequiredFieldLength, &MaxKafkaNameLength),
+		ValidKafkaClusterName(&kafkaRequestPayload.Name, "name"),
+		ValidateKafkaClusterNameIsUnique(&kafkaRequestPayload.Name, h.service, ctx),
+		ValidateKafkaClaims(ctx, ValidateUsername(), ValidateOrganisationId()),
+		ValidateCloudProvider(ctx, h.service, kafkaRequestPayload, h.providerConfig, "creating kafka requests"),
+		// the cluster id is only known once the payload has been decoded or built
+		func() *errors.ServiceError {
+			return handlers.ValidateNotEmptyClusterId(kafkaRequestPayload.ClusterId, "cluster id")()
+		},
+		ValidateKafkaPlan(ctx, h.service, h.kafkaConfig, kafkaRequestPayload),
+		validateKafkaBillingModel(ctx, h.service, h.kafkaConfig, kafkaRequestPayload),
+		ValidateBillingCloudAccountIdAndMarketplace(ctx, h.service, kafkaRequestPayload),
+		ValidateKafkaLabels(&kafkaRequestPayload.Labels),
+	}
+}
+
+// registerKafka registers the kafka request of a validated payload for the user
+func (h kafkaHandler) registerKafka(ctx context.Context, kafkaRequestPayload *public.KafkaRequestPayload) (interface{}, *errors.ServiceError) {
+	convKafka := presenters.ConvertKafkaRequest(*kafkaRequestPayload)
+
+	claims, _ := getClaims(ctx)
+	convKafka.Owner, _ = claims.GetUsername()
+	convKafka.OrganisationId, _ = claims.GetOrgId()
+	convKafka.OwnerAccountId, _ = claims.GetAccountId()
+
+	convKafka.InstanceType, convKafka.SizeId, _ = getInstanceTypeAndSize(ctx, h.service, h.kafkaConfig, kafkaRequestPayload)
+
+	convKafka.CloudProvider, convKafka.Region, _ = getCloudProviderAndRegion(ctx, h.service, kafkaRequestPayload, h.providerConfig)
+
+	svcErr := h.service.RegisterKafkaJob(convKafka)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	return presenters.PresentKafkaRequest(convKafka, h.kafkaConfig)
+}
+
 func (h kafkaHandler) Get(w http.ResponseWriter, r *http.Request) {
+	var kafkaRequest *dbapi.KafkaRequest
 	cfg := &handlers.HandlerConfig{
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
-			kafkaRequest, err := h.service.Get(ctx, id)
+			var err *errors.ServiceError
+			kafkaRequest, err = h.service.Get(ctx, id)
 			if err != nil {
 				return nil, err
 			}
 			return presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
 		},
+		Version: kafkaRequestVersion(&kafkaRequest),
 	}
 	handlers.HandleGet(w, r, cfg)
 }
@@ -178,10 +264,22 @@ func (h kafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 				updatedNeeded = true
 			}
 
+			if kafkaUpdateReq.Labels != nil {
+				// the labels are replaced as a whole, an empty map removes them
+				_ = kafkaRequest.SetLabels(*kafkaUpdateReq.Labels)
+				updatedNeeded = true
+			}
+
 			if updatedNeeded {
-				updateErr := h.service.Updates(kafkaRequest, map[string]interface{}{
+				updates := h.service.Updates
+				if handlers.HasIfMatch(r) {
+					// the kafka must not have been updated since its version was checked against the If-Match header
+					updates = h.service.UpdatesIfUnmodified
+				}
+				updateErr := updates(kafkaRequest, map[string]interface{}{
 					"reauthentication_enabled": kafkaRequest.ReauthenticationEnabled,
 					"owner":                    kafkaRequest.Owner,
+					"labels":                   kafkaRequest.Labels,
 				})
 
 				if updateErr != nil {
@@ -191,6 +289,17 @@ func (h kafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 			return presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
 		},
+		Version: kafkaRequestVersion(&kafkaRequest),
 	}
 	handlers.Handle(w, r, cfg, http.StatusOK)
 }
+
+// kafkaRequestVersion returns the version of the kafka request loaded by a handler, it is used as its entity tag
+func kafkaRequestVersion(kafkaRequest **dbapi.KafkaRequest) handlers.ResourceVersion {
+	return func() string {
+		if *kafkaRequest == nil {
+			return ""
+		}
+		return handlers.VersionFromTime((*kafkaRequest).UpdatedAt)
+	}
+}
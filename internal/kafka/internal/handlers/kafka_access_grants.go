@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/gorilla/mux"
+)
+
+type kafkaAccessGrantsHandler struct {
+	kafkaService       services.KafkaService
+	accessGrantService services.KafkaAccessGrantService
+}
+
+func NewKafkaAccessGrantsHandler(kafkaService services.KafkaService, accessGrantService services.KafkaAccessGrantService) *kafkaAccessGrantsHandler {
+	return &kafkaAccessGrantsHandler{
+		kafkaService:       kafkaService,
+		accessGrantService: accessGrantService,
+	}
+}
+
+// Create grants another organisation the access to a kafka request of the organisation of the user
+func (h kafkaAccessGrantsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var grantRequest public.KafkaAccessGrantRequest
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.kafkaService.Get(ctx, id)
+	validateKafkaFound := func() handlers.Validate {
+		return func() *errors.ServiceError {
+			return kafkaGetError
+		}
+	}
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &grantRequest,
+		Validate: []handlers.Validate{
+			handlers.Validation("organisation_id", &grantRequest.OrganisationId, handlers.MinLen(1)),
+			handlers.Validation("access_level", &grantRequest.AccessLevel, handlers.IsOneOf(dbapi.ValidKafkaAccessLevels...)),
+			validateKafkaFound(),
+			validateUserIsKafkaOwnerOrOrgAdmin(ctx, kafkaRequest),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, err := getClaims(ctx)
+			if err != nil {
+				return nil, err
+			}
+			grant := presenters.ConvertKafkaAccessGrantRequest(grantRequest)
+			grant.CreatedBy, _ = claims.GetUsername()
+			if err := h.accessGrantService.Create(kafkaRequest, grant); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaAccessGrant(grant), nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+// List returns the access grants of a kafka request of the organisation of the user
+func (h kafkaAccessGrantsHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			kafkaRequest, err := h.kafkaService.Get(ctx, mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			if err := validateUserIsKafkaOwnerOrOrgAdmin(ctx, kafkaRequest)(); err != nil {
+				return nil, err
+			}
+
+			grants, err := h.accessGrantService.List(kafkaRequest.ID)
+			if err != nil {
+				return nil, err
+			}
+			grantList := public.KafkaAccessGrantList{
+				Kind:  "KafkaAccessGrantList",
+				Page:  1,
+				Size:  int32(len(grants)),
+				Total: int32(len(grants)),
+				Items: []public.KafkaAccessGrant{},
+			}
+			for _, grant := range grants {
+				grantList.Items = append(grantList.Items, presenters.PresentKafkaAccessGrant(grant))
+			}
+			return grantList, nil
+		},
+	}
+	handlers.HandleList(w, r, cfg)
+}
+
+// Delete revokes an access grant of a kafka request of the organisation of the user
+func (h kafkaAccessGrantsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			kafkaRequest, err := h.kafkaService.Get(ctx, mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			if err := validateUserIsKafkaOwnerOrOrgAdmin(ctx, kafkaRequest)(); err != nil {
+				return nil, err
+			}
+			return nil, h.accessGrantService.Revoke(kafkaRequest.ID, mux.Vars(r)["grant_id"])
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_kafkaAccessGrantsHandler_Create(t *testing.T) {
+	ownedKafkaService := &services.KafkaServiceMock{
+		GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+			return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+		},
+	}
+	tests := []struct {
+		name              string
+		kafkaService      services.KafkaService
+		request           public.KafkaAccessGrantRequest
+		wantStatusCode    int
+		wantCreatedGrants int
+	}{
+		{
+			name:              "should grant access to a kafka of the organisation",
+			kafkaService:      ownedKafkaService,
+			request:           public.KafkaAccessGrantRequest{OrganisationId: "partner-org", AccessLevel: "connector_binding"},
+			wantStatusCode:    http.StatusCreated,
+			wantCreatedGrants: 1,
+		},
+		{
+			name:           "should fail when the access level is not valid",
+			kafkaService:   ownedKafkaService,
+			request:        public.KafkaAccessGrantRequest{OrganisationId: "partner-org", AccessLevel: "admin"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should forbid granting access to a kafka another organisation granted access to",
+			kafkaService: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), mocks.With(mocks.ORGANISATION_ID, "owner-org")), nil
+				},
+			},
+			request:        public.KafkaAccessGrantRequest{OrganisationId: "partner-org", AccessLevel: "read_only"},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should fail when the kafka is not found",
+			kafkaService: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return nil, errors.NotFound("not found")
+				},
+			},
+			request:        public.KafkaAccessGrantRequest{OrganisationId: "partner-org", AccessLevel: "read_only"},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			accessGrantService := &services.KafkaAccessGrantServiceMock{
+				CreateFunc: func(kafkaRequest *dbapi.KafkaRequest, grant *dbapi.KafkaAccessGrant) *errors.ServiceError {
+					grant.ID = "grant-id"
+					grant.KafkaID = kafkaRequest.ID
+					return nil
+				},
+			}
+			h := NewKafkaAccessGrantsHandler(tt.kafkaService, accessGrantService)
+			body, err := json.Marshal(tt.request)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams(http.MethodPost, "/api/kafkas_mgmt/v1/kafkas/{id}/access_grants", bytes.NewBuffer(body), t)
+			req = mux.SetURLVars(req.WithContext(ctx), map[string]string{"id": id})
+			h.Create(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode), "returned body: '%s'", rw.Body.String())
+			g.Expect(accessGrantService.CreateCalls()).To(gomega.HaveLen(tt.wantCreatedGrants))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/gorilla/mux"
+)
+
+type kafkaTemplatesHandler struct {
+	templateService services.KafkaTemplateService
+	kafkaService    services.KafkaService
+	providerConfig  *config.ProviderConfig
+	kafkaConfig     *config.KafkaConfig
+}
+
+func NewKafkaTemplatesHandler(templateService services.KafkaTemplateService, kafkaService services.KafkaService, providerConfig *config.ProviderConfig, kafkaConfig *config.KafkaConfig) *kafkaTemplatesHandler {
+	return &kafkaTemplatesHandler{
+		templateService: templateService,
+		kafkaService:    kafkaService,
+		providerConfig:  providerConfig,
+		kafkaConfig:     kafkaConfig,
+	}
+}
+
+// Create creates a kafka template in the organisation of the user
+func (h kafkaTemplatesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var templateRequest public.KafkaTemplateRequest
+	ctx := r.Context()
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &templateRequest,
+		Validate: []handlers.Validate{
+			handlers.ValidateLength(&templateRequest.Name, "name", handlers.MinRequiredFieldLength, &MaxKafkaNameLength),
+			ValidateKafkaClaims(ctx, ValidateUsername(), ValidateOrganisationId()),
+			h.validateKafkaTemplateSettings(ctx, &templateRequest),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, err := getClaims(ctx)
+			if err != nil {
+				return nil, err
+			}
+			template := presenters.ConvertKafkaTemplateRequest(templateRequest)
+			template.OrganisationId, _ = claims.GetOrgId()
+			template.CreatedBy, _ = claims.GetUsername()
+			if err := h.templateService.Create(template); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaTemplate(template), nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+// Get returns a kafka template of the organisation of the user
+func (h kafkaTemplatesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			orgId, err := getOrgId(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			template, err := h.templateService.Get(orgId, mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaTemplate(template), nil
+		},
+	}
+	handlers.HandleGet(w, r, cfg)
+}
+
+// List returns the kafka templates of the organisation of the user
+func (h kafkaTemplatesHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			orgId, err := getOrgId(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			templates, err := h.templateService.List(orgId)
+			if err != nil {
+				return nil, err
+			}
+			templateList := public.KafkaTemplateList{
+				Kind:  "KafkaTemplateList",
+				Page:  1,
+				Size:  int32(len(templates)),
+				Total: int32(len(templates)),
+				Items: []public.KafkaTemplate{},
+			}
+			for _, template := range templates {
+				templateList.Items = append(templateList.Items, presenters.PresentKafkaTemplate(template))
+			}
+			return templateList, nil
+		},
+	}
+	handlers.HandleList(w, r, cfg)
+}
+
+// Delete deletes a kafka template of the organisation of the user, the kafka requests created from it are kept
+func (h kafkaTemplatesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			orgId, err := getOrgId(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			return nil, h.templateService.Delete(orgId, mux.Vars(r)["id"])
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// validateKafkaTemplateSettings validates the settings of the template the same way as those of a kafka request, so
+// that templates can not hold settings the kafka requests created from them would be rejected for
+func (h kafkaTemplatesHandler) validateKafkaTemplateSettings(ctx context.Context, templateRequest *public.KafkaTemplateRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		var kafkaRequestPayload public.KafkaRequestPayload
+		presenters.ApplyKafkaTemplate(presenters.ConvertKafkaTemplateRequest(*templateRequest), &kafkaRequestPayload)
+
+		for _, validate := range []handlers.Validate{
+			ValidateCloudProvider(ctx, h.kafkaService, &kafkaRequestPayload, h.providerConfig, "creating kafka templates"),
+			ValidateKafkaPlan(ctx, h.kafkaService, h.kafkaConfig, &kafkaRequestPayload),
+			validateKafkaBillingModel(ctx, h.kafkaService, h.kafkaConfig, &kafkaRequestPayload),
+			ValidateBillingCloudAccountIdAndMarketplace(ctx, h.kafkaService, &kafkaRequestPayload),
+		} {
+			if err := validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
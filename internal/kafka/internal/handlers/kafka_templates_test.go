@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_kafkaTemplatesHandler_Create(t *testing.T) {
+	kafkaService := &services.KafkaServiceMock{
+		AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+			return types.STANDARD, nil
+		},
+	}
+	tests := []struct {
+		name                 string
+		request              public.KafkaTemplateRequest
+		createErr            *errors.ServiceError
+		wantStatusCode       int
+		wantCreatedTemplates int
+	}{
+		{
+			name:                 "should create a template in the organisation of the user",
+			request:              public.KafkaTemplateRequest{Name: "dev", CloudProvider: "aws", Region: "us-east-1", Plan: "standard.x1"},
+			wantStatusCode:       http.StatusCreated,
+			wantCreatedTemplates: 1,
+		},
+		{
+			name:           "should fail when the name is not set",
+			request:        public.KafkaTemplateRequest{CloudProvider: "aws"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail when the region is not supported",
+			request:        public.KafkaTemplateRequest{Name: "dev", CloudProvider: "aws", Region: "eu-west-1"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail when the plan is not supported",
+			request:        public.KafkaTemplateRequest{Name: "dev", CloudProvider: "aws", Plan: "standard.x9"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:                 "should fail when a template with the same name exists",
+			request:              public.KafkaTemplateRequest{Name: "dev", CloudProvider: "aws", Region: "us-east-1"},
+			createErr:            errors.Conflict("conflict"),
+			wantStatusCode:       http.StatusConflict,
+			wantCreatedTemplates: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			templateService := &services.KafkaTemplateServiceMock{
+				CreateFunc: func(template *dbapi.KafkaTemplate) *errors.ServiceError {
+					template.ID = "template-id"
+					return tt.createErr
+				},
+			}
+			h := NewKafkaTemplatesHandler(templateService, kafkaService, &supportedProviders, &fullKafkaConfig)
+			body, err := json.Marshal(tt.request)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams(http.MethodPost, "/api/kafkas_mgmt/v1/kafka_templates", bytes.NewBuffer(body), t)
+			h.Create(rw, req.WithContext(ctx))
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode), "returned body: '%s'", rw.Body.String())
+			g.Expect(templateService.CreateCalls()).To(gomega.HaveLen(tt.wantCreatedTemplates))
+			if tt.wantCreatedTemplates > 0 {
+				g.Expect(templateService.CreateCalls()[0].Template.OrganisationId).To(gomega.Equal(mocks.DefaultOrganisationId))
+			}
+		})
+	}
+}
+
+func Test_kafkaTemplatesHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		deleteErr      *errors.ServiceError
+		wantStatusCode int
+	}{
+		{
+			name:           "should delete a template of the organisation of the user",
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "should fail when the template is not found in the organisation of the user",
+			deleteErr:      errors.NotFound("not found"),
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			templateService := &services.KafkaTemplateServiceMock{
+				DeleteFunc: func(orgId string, id string) *errors.ServiceError {
+					return tt.deleteErr
+				},
+			}
+			h := NewKafkaTemplatesHandler(templateService, nil, nil, nil)
+			req, rw := GetHandlerParams(http.MethodDelete, "/api/kafkas_mgmt/v1/kafka_templates/{id}", nil, t)
+			req = mux.SetURLVars(req.WithContext(ctx), map[string]string{"id": "template-id"})
+			h.Delete(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode), "returned body: '%s'", rw.Body.String())
+			g.Expect(templateService.DeleteCalls()).To(gomega.HaveLen(1))
+			g.Expect(templateService.DeleteCalls()[0].OrgId).To(gomega.Equal(mocks.DefaultOrganisationId))
+		})
+	}
+}
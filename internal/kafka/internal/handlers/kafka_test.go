@@ -5,6 +5,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
@@ -15,6 +16,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	s "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
 	"github.com/golang-jwt/jwt/v4"
@@ -77,7 +79,7 @@ func Test_KafkaHandler_Get(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("GET", "/{id}", nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": id})
 			h.Get(rw, req)
@@ -145,7 +147,7 @@ func Test_KafkaHandler_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("DELETE", tt.args.url, nil, t)
 			h.Delete(rw, req)
 			resp := rw.Result()
@@ -238,7 +240,7 @@ func Test_KafkaHandler_List(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 			h.List(rw, req)
 			resp := rw.Result()
@@ -257,11 +259,16 @@ func Test_KafkaHandler_Update(t *testing.T) {
 	}
 
 	type args struct {
-		url  string
-		body []byte
-		ctx  context.Context
+		url     string
+		body    []byte
+		ctx     context.Context
+		ifMatch bool
 	}
 
+	kafkaRequest := mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.UpdatedAt = time.Date(2023, 5, 2, 12, 0, 0, 0, time.UTC)
+	})
+
 	tests := []struct {
 		name           string
 		fields         fields
@@ -344,6 +351,48 @@ func Test_KafkaHandler_Update(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "succeeds if the kafka hasn't been updated since the version of the If-Match header",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						k := *kafkaRequest
+						return &k, nil
+					},
+					UpdatesIfUnmodifiedFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+						return nil
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			args: args{
+				body:    []byte(`{"reauthentication_enabled": true}`),
+				ctx:     ctx,
+				ifMatch: true,
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "fails with 412 if the kafka is updated concurrently after the If-Match header is checked",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						k := *kafkaRequest
+						return &k, nil
+					},
+					UpdatesIfUnmodifiedFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+						return errors.PreconditionFailed("kafka %s has been updated since it was read", kafkaRequest.ID)
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			args: args{
+				body:    []byte(`{"reauthentication_enabled": true}`),
+				ctx:     ctx,
+				ifMatch: true,
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -351,9 +400,12 @@ func Test_KafkaHandler_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("PATCH", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 			req = req.WithContext(tt.args.ctx)
+			if tt.args.ifMatch {
+				req.Header.Set(handlers.IfMatchHeader, handlers.ETag(handlers.VersionFromTime(kafkaRequest.UpdatedAt)))
+			}
 			h.Update(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -604,7 +656,7 @@ func Test_KafkaHandler_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("CREATE", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 			req = req.WithContext(tt.args.ctx)
 			h.Create(rw, req)
@@ -614,3 +666,168 @@ func Test_KafkaHandler_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_KafkaHandler_Clone(t *testing.T) {
+	kafkaService := func(kafkaRequest *dbapi.KafkaRequest, getErr *errors.ServiceError, registered *[]*dbapi.KafkaRequest) *services.KafkaServiceMock {
+		return &services.KafkaServiceMock{
+			GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+				return kafkaRequest, getErr
+			},
+			ListFunc: func(ctx context.Context, listArgs *s.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+				return dbapi.KafkaList{}, &api.PagingMeta{}, nil
+			},
+			AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+				return types.STANDARD, nil
+			},
+			RegisterKafkaJobFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+				kafkaRequest.MaxDataRetentionSize = mocksupportedinstancetypes.DefaultMaxDataRetentionSize
+				*registered = append(*registered, kafkaRequest)
+				return nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		kafkaRequest   *dbapi.KafkaRequest
+		getErr         *errors.ServiceError
+		body           string
+		wantStatusCode int
+		wantRegion     string
+	}{
+		{
+			name:           "should clone a kafka of the organisation with its settings",
+			kafkaRequest:   mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()),
+			body:           `{"name": "clone"}`,
+			wantStatusCode: http.StatusAccepted,
+			wantRegion:     mocks.DefaultKafkaRequestRegion,
+		},
+		{
+			name:           "should fail when the region of the clone is not supported",
+			kafkaRequest:   mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()),
+			body:           `{"name": "clone", "region": "eu-west-1"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail when the name of the clone is not valid",
+			kafkaRequest:   mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()),
+			body:           `{"name": "Clone!"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should forbid cloning a kafka another organisation granted access to",
+			kafkaRequest:   mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), mocks.With(mocks.ORGANISATION_ID, "owner-org")),
+			body:           `{"name": "clone"}`,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should fail when the kafka is not found",
+			getErr:         errors.NotFound("not found"),
+			body:           `{"name": "clone"}`,
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			var registered []*dbapi.KafkaRequest
+			h := NewKafkaHandler(kafkaService(tt.kafkaRequest, tt.getErr, &registered), &supportedProviders, nil, &fullKafkaConfig, nil)
+			req, rw := GetHandlerParams(http.MethodPost, "/kafkas/{id}/clone?async=true", bytes.NewBufferString(tt.body), t)
+			req = mux.SetURLVars(req.WithContext(ctx), map[string]string{"id": id})
+			h.Clone(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode), "returned body: '%s'", rw.Body.String())
+			if tt.wantStatusCode != http.StatusAccepted {
+				g.Expect(registered).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(registered).To(gomega.HaveLen(1))
+			g.Expect(registered[0].Name).To(gomega.Equal("clone"))
+			g.Expect(registered[0].Region).To(gomega.Equal(tt.wantRegion))
+			g.Expect(registered[0].SizeId).To(gomega.Equal(tt.kafkaRequest.SizeId))
+		})
+	}
+}
+
+func Test_KafkaHandler_CreateFromTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		templateErr    *errors.ServiceError
+		body           string
+		wantStatusCode int
+		wantReauth     bool
+	}{
+		{
+			name:           "should create a kafka with the settings of the template",
+			body:           `{"name": "name", "template_id": "template-id"}`,
+			wantStatusCode: http.StatusAccepted,
+			wantReauth:     false,
+		},
+		{
+			name:           "should prefer the settings of the request to those of the template",
+			body:           `{"name": "name", "template_id": "template-id", "reauthentication_enabled": true}`,
+			wantStatusCode: http.StatusAccepted,
+			wantReauth:     true,
+		},
+		{
+			name:           "should fail when the template is not found",
+			templateErr:    errors.NotFound("not found"),
+			body:           `{"name": "name", "template_id": "template-id"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			var registered []*dbapi.KafkaRequest
+			kafkaService := &services.KafkaServiceMock{
+				ListFunc: func(ctx context.Context, listArgs *s.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+					return dbapi.KafkaList{}, &api.PagingMeta{}, nil
+				},
+				AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+					return types.STANDARD, nil
+				},
+				RegisterKafkaJobFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+					kafkaRequest.MaxDataRetentionSize = mocksupportedinstancetypes.DefaultMaxDataRetentionSize
+					registered = append(registered, kafkaRequest)
+					return nil
+				},
+			}
+			reauthenticationEnabled := false
+			templateService := &services.KafkaTemplateServiceMock{
+				GetFunc: func(orgId string, id string) (*dbapi.KafkaTemplate, *errors.ServiceError) {
+					if tt.templateErr != nil {
+						return nil, tt.templateErr
+					}
+					return &dbapi.KafkaTemplate{
+						OrganisationId:          orgId,
+						CloudProvider:           mocks.DefaultKafkaRequestProvider,
+						Region:                  mocks.DefaultKafkaRequestRegion,
+						Plan:                    "standard.x1",
+						ReauthenticationEnabled: &reauthenticationEnabled,
+					}, nil
+				},
+			}
+			h := NewKafkaHandler(kafkaService, &supportedProviders, nil, &fullKafkaConfig, templateService)
+			req, rw := GetHandlerParams(http.MethodPost, "/kafkas?async=true", bytes.NewBufferString(tt.body), t)
+			req = req.WithContext(ctx)
+			h.Create(rw, req)
+			g.Expect(rw.Code).To(gomega.Equal(tt.wantStatusCode), "returned body: '%s'", rw.Body.String())
+			g.Expect(templateService.GetCalls()).To(gomega.HaveLen(1))
+			g.Expect(templateService.GetCalls()[0].OrgId).To(gomega.Equal(mocks.DefaultOrganisationId))
+			if tt.wantStatusCode != http.StatusAccepted {
+				g.Expect(registered).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(registered).To(gomega.HaveLen(1))
+			g.Expect(registered[0].Region).To(gomega.Equal(mocks.DefaultKafkaRequestRegion))
+			g.Expect(registered[0].SizeId).To(gomega.Equal("x1"))
+			g.Expect(registered[0].ReauthenticationEnabled).To(gomega.Equal(tt.wantReauth))
+		})
+	}
+}
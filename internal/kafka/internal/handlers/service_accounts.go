@@ -1,14 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
-	"net/url"
-	"strconv"
+	"regexp"
+	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -16,12 +20,16 @@ import (
 )
 
 type serviceAccountsHandler struct {
-	service sso.KeycloakService
+	service        sso.KeycloakService
+	expiryService  services.ServiceAccountExpiryService
+	bindingService services.ServiceAccountBindingService
 }
 
-func NewServiceAccountHandler(service sso.KafkaKeycloakService) *serviceAccountsHandler {
+func NewServiceAccountHandler(service sso.KafkaKeycloakService, expiryService services.ServiceAccountExpiryService, bindingService services.ServiceAccountBindingService) *serviceAccountsHandler {
 	return &serviceAccountsHandler{
-		service: service,
+		service:        service,
+		expiryService:  expiryService,
+		bindingService: bindingService,
 	}
 }
 
@@ -29,24 +37,45 @@ func (s serviceAccountsHandler) ListServiceAccounts(w http.ResponseWriter, r *ht
 	cfg := &handlers.HandlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
-			Page, Size := s.handleParams(r.URL.Query())
-			// required for redhat sso client, returns a bad request stating this field needs to be larger than 0
-			if Size == 0 {
-				Size = s.service.GetConfig().MaxLimitForGetClients
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate(nil); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list service accounts: %s", err.Error())
+			}
+			if listArgs.Page == 0 {
+				listArgs.Page = 1
+			}
+			search, err := parseServiceAccountSearch(listArgs.Search)
+			if err != nil {
+				return nil, err
+			}
+
+			var sa []api.ServiceAccount
+			if search == nil {
+				sa, err = s.service.ListServiceAcc(ctx, (listArgs.Page-1)*listArgs.Size, listArgs.Size)
+			} else {
+				sa, err = s.searchServiceAccounts(r, search, listArgs)
 			}
-			sa, err := s.service.ListServiceAcc(ctx, Page, Size)
 			if err != nil {
 				return nil, err
 			}
 
+			accounts := make([]*api.ServiceAccount, 0, len(sa))
+			for i := range sa {
+				accounts = append(accounts, &sa[i])
+			}
+			if err := s.populate(accounts...); err != nil {
+				return nil, err
+			}
+
 			serviceAccountList := public.ServiceAccountList{
 				Kind:  "ServiceAccountList",
+				Page:  int32(listArgs.Page),
+				Size:  int32(len(accounts)),
 				Items: []public.ServiceAccountListItem{},
 			}
 
-			for i := range sa {
-				account := sa[i]
-				converted := presenters.PresentServiceAccountListItem(&account)
+			for _, account := range accounts {
+				converted := presenters.PresentServiceAccountListItem(account)
 				serviceAccountList.Items = append(serviceAccountList.Items, converted)
 			}
 
@@ -56,16 +85,58 @@ func (s serviceAccountsHandler) ListServiceAccounts(w http.ResponseWriter, r *ht
 	handlers.HandleList(w, r, cfg)
 }
 
-func (s serviceAccountsHandler) handleParams(params url.Values) (int, int) {
-	Page := 0
-	Size := 0
-	if v := params.Get("page"); v != "" {
-		Page, _ = strconv.Atoi(v)
+// getServiceAccountOrgId returns the organisation of the caller, the routes of the service accounts require one
+func getServiceAccountOrgId(ctx context.Context) string {
+	claims, err := getClaims(ctx)
+	if err != nil {
+		return ""
 	}
-	if v := params.Get("size"); v != "" {
-		Size, _ = strconv.Atoi(v)
+	orgId, _ := claims.GetOrgId()
+	return orgId
+}
+
+// populate sets the credentials expiry and the scope of the service accounts, which are kept by the fleet manager
+func (s serviceAccountsHandler) populate(accounts ...*api.ServiceAccount) *errors.ServiceError {
+	if err := s.expiryService.Populate(accounts); err != nil {
+		return err
 	}
-	return Page, Size
+	return s.bindingService.Populate(accounts)
+}
+
+// searchServiceAccounts filters all the service accounts visible to the user, as the SSO providers can't search
+// them, and returns the requested page of the matching ones
+func (s serviceAccountsHandler) searchServiceAccounts(r *http.Request, search serviceAccountSearch, listArgs *coreServices.ListArguments) ([]api.ServiceAccount, *errors.ServiceError) {
+	ctx := r.Context()
+	pageSize := s.service.GetConfig().MaxLimitForGetClients
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	var matches []api.ServiceAccount
+	for first := 0; ; first += pageSize {
+		sa, err := s.service.ListServiceAcc(ctx, first, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range sa {
+			if search.matches(&sa[i]) {
+				matches = append(matches, sa[i])
+			}
+		}
+		if len(sa) < pageSize {
+			break
+		}
+	}
+
+	first := (listArgs.Page - 1) * listArgs.Size
+	if first >= len(matches) {
+		return []api.ServiceAccount{}, nil
+	}
+	last := first + listArgs.Size
+	if last > len(matches) {
+		last = len(matches)
+	}
+	return matches[first:last], nil
 }
 
 func (s serviceAccountsHandler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
@@ -81,16 +152,49 @@ func (s serviceAccountsHandler) CreateServiceAccount(w http.ResponseWriter, r *h
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
 			convSA := presenters.ConvertServiceAccountRequest(serviceAccountRequest)
+			expireAt, err := s.expiryService.GetCredentialsExpiry(convSA.CredentialsExpireAt)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.bindingService.ValidateScope(ctx, convSA.Scope); err != nil {
+				return nil, err
+			}
 			serviceAccount, err := s.service.CreateServiceAccount(convSA, ctx)
 			if err != nil {
 				return nil, err
 			}
+
+			serviceAccount.CredentialsExpireAt = expireAt
+			orgId := getServiceAccountOrgId(ctx)
+			if err := s.expiryService.Create(orgId, serviceAccount); err != nil {
+				// the credentials of the service account would never expire
+				s.removeServiceAccount(ctx, serviceAccount.ID)
+				return nil, err
+			}
+			if !convSA.Scope.IsEmpty() {
+				if err := s.bindingService.Bind(orgId, serviceAccount, convSA.Scope); err != nil {
+					// a scoped service account must not be left behind with an organisation wide access
+					s.removeServiceAccount(ctx, serviceAccount.ID)
+					return nil, err
+				}
+			}
 			return presenters.PresentServiceAccount(serviceAccount), nil
 		},
 	}
 	handlers.Handle(w, r, cfg, http.StatusAccepted)
 }
 
+// removeServiceAccount removes a service account that could not be fully set up. Failures are only logged, the
+// error that caused the removal is the one returned to the caller.
+func (s serviceAccountsHandler) removeServiceAccount(ctx context.Context, id string) {
+	if err := s.service.DeleteServiceAccount(ctx, id); err != nil {
+		logger.Logger.Errorf("failed to remove service account %q: %v", id, err)
+	}
+	if err := s.expiryService.Delete(id); err != nil {
+		logger.Logger.Errorf("failed to remove the credentials expiry of service account %q: %v", id, err)
+	}
+}
+
 func (s serviceAccountsHandler) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
 	cfg := &handlers.HandlerConfig{
@@ -101,6 +205,19 @@ func (s serviceAccountsHandler) DeleteServiceAccount(w http.ResponseWriter, r *h
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
 			err := s.service.DeleteServiceAccount(ctx, id)
+			if err != nil && err.Code != errors.ErrorServiceAccountNotFound {
+				return nil, err
+			}
+			// the service account may have been deleted by a previous request which failed to clean up its bindings
+			// and its credentials expiry, retrying the deletion completes it
+			bindingErr := s.bindingService.Delete(id)
+			expiryErr := s.expiryService.Delete(id)
+			if bindingErr != nil {
+				return nil, bindingErr
+			}
+			if expiryErr != nil {
+				return nil, expiryErr
+			}
 			return nil, err
 		},
 	}
@@ -108,6 +225,59 @@ func (s serviceAccountsHandler) DeleteServiceAccount(w http.ResponseWriter, r *h
 	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
 }
 
+func (s serviceAccountsHandler) UpdateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var serviceAccountUpdateRequest public.ServiceAccountUpdateRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &serviceAccountUpdateRequest,
+		Validate: []handlers.Validate{
+			handlers.ValidateLength(&id, "id", handlers.MinRequiredFieldLength, &handlers.MaxServiceAccountId),
+			handlers.ValidateServiceAccountId(&id, "id"),
+			func() *errors.ServiceError {
+				if serviceAccountUpdateRequest.Name == nil {
+					return nil
+				}
+				if err := handlers.ValidateLength(serviceAccountUpdateRequest.Name, "name", handlers.MinRequiredFieldLength, &handlers.MaxServiceAccountNameLength)(); err != nil {
+					return err
+				}
+				return handlers.ValidateServiceAccountName(serviceAccountUpdateRequest.Name, "name")()
+			},
+			func() *errors.ServiceError {
+				if serviceAccountUpdateRequest.Description == nil {
+					return nil
+				}
+				if err := handlers.ValidateMaxLength(serviceAccountUpdateRequest.Description, "description", &handlers.MaxServiceAccountDescLength)(); err != nil {
+					return err
+				}
+				return handlers.ValidateServiceAccountDesc(serviceAccountUpdateRequest.Description, "description")()
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			scope := presenters.ConvertServiceAccountScope(serviceAccountUpdateRequest.Scope)
+			if err := s.bindingService.ValidateScope(ctx, scope); err != nil {
+				return nil, err
+			}
+			sa, err := s.service.UpdateServiceAccount(ctx, id, serviceAccountUpdateRequest.Name, serviceAccountUpdateRequest.Description)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.populate(sa); err != nil {
+				return nil, err
+			}
+			// the scope is left unchanged when not set
+			if scope != nil {
+				if err := s.bindingService.Bind(getServiceAccountOrgId(ctx), sa, scope); err != nil {
+					return nil, err
+				}
+			}
+			return presenters.PresentServiceAccount(sa), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
 func (s serviceAccountsHandler) ResetServiceAccountCredential(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
 	cfg := &handlers.HandlerConfig{
@@ -121,6 +291,9 @@ func (s serviceAccountsHandler) ResetServiceAccountCredential(w http.ResponseWri
 			if err != nil {
 				return nil, err
 			}
+			if err := s.expiryService.Renew(sa); err != nil {
+				return nil, err
+			}
 			return presenters.PresentServiceAccount(sa), nil
 		},
 	}
@@ -151,6 +324,9 @@ func (s serviceAccountsHandler) GetServiceAccountByClientId(w http.ResponseWrite
 				return nil, err
 			}
 
+			if err := s.populate(sa); err != nil {
+				return nil, err
+			}
 			converted := presenters.PresentServiceAccountListItem(sa)
 			serviceAccountList.Items = append(serviceAccountList.Items, converted)
 			return serviceAccountList, nil
@@ -173,6 +349,9 @@ func (s serviceAccountsHandler) GetServiceAccountById(w http.ResponseWriter, r *
 			if err != nil {
 				return nil, err
 			}
+			if err := s.populate(sa); err != nil {
+				return nil, err
+			}
 			return presenters.PresentServiceAccount(sa), nil
 		},
 	}
@@ -199,3 +378,60 @@ func (s serviceAccountsHandler) GetSsoProviders(w http.ResponseWriter, r *http.R
 
 	handlers.HandleGet(w, r, cfg)
 }
+
+var (
+	serviceAccountSearchConjunctionRegexp = regexp.MustCompile(`(?i)\s+and\s+`)
+	serviceAccountSearchClauseRegexp      = regexp.MustCompile(`(?i)^\s*(name|client_id|created_by)\s*(=|like)\s*'?([^']*)'?\s*$`)
+)
+
+// serviceAccountSearch is a search on the service accounts, made of clauses joined by "and"
+type serviceAccountSearch []serviceAccountSearchClause
+
+type serviceAccountSearchClause struct {
+	field string
+	value *regexp.Regexp
+}
+
+// parseServiceAccountSearch parses a search such as "name like my-app% and created_by = user". It returns nil if
+// the search is empty.
+func parseServiceAccountSearch(search string) (serviceAccountSearch, *errors.ServiceError) {
+	if strings.TrimSpace(search) == "" {
+		return nil, nil
+	}
+
+	var clauses serviceAccountSearch
+	for _, clause := range serviceAccountSearchConjunctionRegexp.Split(search, -1) {
+		parts := serviceAccountSearchClauseRegexp.FindStringSubmatch(clause)
+		if parts == nil {
+			return nil, errors.FailedToParseSearch("unsupported clause %q, expected name, client_id or created_by compared with = or like", clause)
+		}
+
+		pattern := regexp.QuoteMeta(parts[3])
+		if strings.EqualFold(parts[2], "like") {
+			pattern = strings.ReplaceAll(pattern, "%", ".*")
+		}
+		clauses = append(clauses, serviceAccountSearchClause{
+			field: strings.ToLower(parts[1]),
+			value: regexp.MustCompile("^" + pattern + "$"),
+		})
+	}
+	return clauses, nil
+}
+
+func (search serviceAccountSearch) matches(account *api.ServiceAccount) bool {
+	for _, clause := range search {
+		var value string
+		switch clause.field {
+		case "name":
+			value = account.Name
+		case "client_id":
+			value = account.ClientID
+		case "created_by":
+			value = account.CreatedBy
+		}
+		if !clause.value.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
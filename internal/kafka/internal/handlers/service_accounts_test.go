@@ -3,10 +3,14 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -17,11 +21,52 @@ import (
 
 var (
 	createServiceAccountRequest = `{"name": "my-app-sa","description": "service account for my app"}`
+	updateServiceAccountRequest = `{"description": "service account for my other app"}`
 )
 
+func newServiceAccountBindingServiceMock() *services.ServiceAccountBindingServiceMock {
+	return &services.ServiceAccountBindingServiceMock{
+		ValidateScopeFunc: func(ctx context.Context, scope *api.ServiceAccountScope) *errors.ServiceError {
+			return nil
+		},
+		BindFunc: func(orgId string, account *api.ServiceAccount, scope *api.ServiceAccountScope) *errors.ServiceError {
+			account.Scope = scope
+			return nil
+		},
+		PopulateFunc: func(accounts []*api.ServiceAccount) *errors.ServiceError {
+			return nil
+		},
+		DeleteFunc: func(serviceAccountID string) *errors.ServiceError {
+			return nil
+		},
+	}
+}
+
+func newServiceAccountExpiryServiceMock() *services.ServiceAccountExpiryServiceMock {
+	return &services.ServiceAccountExpiryServiceMock{
+		GetCredentialsExpiryFunc: func(requested *time.Time) (*time.Time, *errors.ServiceError) {
+			return requested, nil
+		},
+		CreateFunc: func(orgId string, account *api.ServiceAccount) *errors.ServiceError {
+			return nil
+		},
+		RenewFunc: func(account *api.ServiceAccount) *errors.ServiceError {
+			return nil
+		},
+		PopulateFunc: func(accounts []*api.ServiceAccount) *errors.ServiceError {
+			return nil
+		},
+		DeleteFunc: func(id string) *errors.ServiceError {
+			return nil
+		},
+	}
+}
+
 func TestNewServiceAccountHandler(t *testing.T) {
 	type args struct {
-		service sso.KafkaKeycloakService
+		service        sso.KafkaKeycloakService
+		expiryService  services.ServiceAccountExpiryService
+		bindingService services.ServiceAccountBindingService
 	}
 	tests := []struct {
 		name string
@@ -31,10 +76,14 @@ func TestNewServiceAccountHandler(t *testing.T) {
 		{
 			name: "should return a NewServiceAccountHandler",
 			args: args{
-				service: &sso.KeycloakServiceMock{},
+				service:        &sso.KeycloakServiceMock{},
+				expiryService:  &services.ServiceAccountExpiryServiceMock{},
+				bindingService: &services.ServiceAccountBindingServiceMock{},
 			},
 			want: &serviceAccountsHandler{
-				service: &sso.KeycloakServiceMock{},
+				service:        &sso.KeycloakServiceMock{},
+				expiryService:  &services.ServiceAccountExpiryServiceMock{},
+				bindingService: &services.ServiceAccountBindingServiceMock{},
 			},
 		},
 	}
@@ -44,7 +93,7 @@ func TestNewServiceAccountHandler(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			g.Expect(NewServiceAccountHandler(tt.args.service)).To(gomega.Equal(tt.want))
+			g.Expect(NewServiceAccountHandler(tt.args.service, tt.args.expiryService, tt.args.bindingService)).To(gomega.Equal(tt.want))
 		})
 	}
 }
@@ -103,6 +152,26 @@ func Test_serviceAccountsHandler_ListServiceAccounts(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "should return status code 400 if the search can't be parsed",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{},
+			},
+			args: args{
+				url: "/api/kafkas_mgmt/v1/service_accounts?search=" + url.QueryEscape("owner = my-user"),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return status code 400 if the page size is invalid",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{},
+			},
+			args: args{
+				url: "/api/kafkas_mgmt/v1/service_accounts?size=0",
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -112,7 +181,7 @@ func Test_serviceAccountsHandler_ListServiceAccounts(t *testing.T) {
 			g := gomega.NewWithT(t)
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountExpiryServiceMock(), newServiceAccountBindingServiceMock())
 			h.ListServiceAccounts(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -123,7 +192,7 @@ func Test_serviceAccountsHandler_ListServiceAccounts(t *testing.T) {
 
 func Test_serviceAccountsHandler_CreateServiceAccount(t *testing.T) {
 	type fields struct {
-		service sso.KeycloakService
+		service *sso.KeycloakServiceMock
 	}
 	type args struct {
 		url  string
@@ -133,7 +202,9 @@ func Test_serviceAccountsHandler_CreateServiceAccount(t *testing.T) {
 		name           string
 		fields         fields
 		args           args
+		expiryErr      *errors.ServiceError
 		wantStatusCode int
+		wantDeleted    bool
 	}{
 		{
 			name: "should return status code 202 if the request was accepted successfully",
@@ -161,6 +232,26 @@ func Test_serviceAccountsHandler_CreateServiceAccount(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "should delete the service account if it fails to set the expiry of its credentials",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{
+					CreateServiceAccountFunc: func(serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError) {
+						return &api.ServiceAccount{ID: "sa-id"}, nil
+					},
+					DeleteServiceAccountFunc: func(ctx context.Context, clientId string) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			args: args{
+				url:  "/api/kafkas_mgmt/v1/service_accounts",
+				body: []byte(createServiceAccountRequest),
+			},
+			expiryErr:      errors.GeneralError("failed to create the credentials expiry"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantDeleted:    true,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -170,11 +261,18 @@ func Test_serviceAccountsHandler_CreateServiceAccount(t *testing.T) {
 			g := gomega.NewWithT(t)
 			req, rw := GetHandlerParams("POST", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			expiryService := newServiceAccountExpiryServiceMock()
+			expiryService.CreateFunc = func(orgId string, account *api.ServiceAccount) *errors.ServiceError {
+				return tt.expiryErr
+			}
+			h := NewServiceAccountHandler(tt.fields.service, expiryService, newServiceAccountBindingServiceMock())
 			h.CreateServiceAccount(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
 			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantDeleted {
+				g.Expect(tt.fields.service.DeleteServiceAccountCalls()).To(gomega.HaveLen(1))
+			}
 		})
 	}
 }
@@ -187,10 +285,12 @@ func Test_serviceAccountsHandler_DeleteServiceAccount(t *testing.T) {
 		url string
 	}
 	tests := []struct {
-		name           string
-		fields         fields
-		args           args
-		wantStatusCode int
+		name             string
+		fields           fields
+		args             args
+		bindingDeleteErr *errors.ServiceError
+		wantStatusCode   int
+		wantCleanups     int
 	}{
 		{
 			name: "should return status code 204 if it deletes the service account successfully",
@@ -205,6 +305,7 @@ func Test_serviceAccountsHandler_DeleteServiceAccount(t *testing.T) {
 				url: "/api/kafkas_mgmt/v1/service_accounts/{id}",
 			},
 			wantStatusCode: http.StatusNoContent,
+			wantCleanups:   1,
 		},
 		{
 			name: "should return status code 500 if it fails to delete the service account",
@@ -220,6 +321,37 @@ func Test_serviceAccountsHandler_DeleteServiceAccount(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "should clean up the service account if it was already deleted",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{
+					DeleteServiceAccountFunc: func(ctx context.Context, clientId string) *errors.ServiceError {
+						return errors.New(errors.ErrorServiceAccountNotFound, "service account not found %s", clientId)
+					},
+				},
+			},
+			args: args{
+				url: "/api/kafkas_mgmt/v1/service_accounts/{id}",
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantCleanups:   1,
+		},
+		{
+			name: "should return status code 500 if it fails to clean up the service account",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{
+					DeleteServiceAccountFunc: func(ctx context.Context, clientId string) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			args: args{
+				url: "/api/kafkas_mgmt/v1/service_accounts/{id}",
+			},
+			bindingDeleteErr: errors.GeneralError("failed to delete the bindings"),
+			wantStatusCode:   http.StatusInternalServerError,
+			wantCleanups:     1,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -230,11 +362,19 @@ func Test_serviceAccountsHandler_DeleteServiceAccount(t *testing.T) {
 			req, rw := GetHandlerParams("DELETE", tt.args.url, nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": "b5843c4b-a702-100d-fc77-70e9b20e554f"})
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			bindingService := newServiceAccountBindingServiceMock()
+			bindingService.DeleteFunc = func(serviceAccountID string) *errors.ServiceError {
+				return tt.bindingDeleteErr
+			}
+			expiryService := newServiceAccountExpiryServiceMock()
+			h := NewServiceAccountHandler(tt.fields.service, expiryService, bindingService)
 			h.DeleteServiceAccount(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
 			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			// the clean up is attempted even when one of its steps fails so that retrying the deletion completes it
+			g.Expect(bindingService.DeleteCalls()).To(gomega.HaveLen(tt.wantCleanups))
+			g.Expect(expiryService.DeleteCalls()).To(gomega.HaveLen(tt.wantCleanups))
 		})
 	}
 }
@@ -290,7 +430,7 @@ func Test_serviceAccountsHandler_ResetServiceAccountCredential(t *testing.T) {
 			req, rw := GetHandlerParams("POST", tt.args.url, nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": "b5843c4b-a702-100d-fc77-70e9b20e554f"})
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountExpiryServiceMock(), newServiceAccountBindingServiceMock())
 			h.ResetServiceAccountCredential(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -362,7 +502,7 @@ func Test_serviceAccountsHandler_GetServiceAccountByClientId(t *testing.T) {
 			req.Form = url.Values{}
 			req.Form.Add("client_id", "srvc-acct-7f4f2226-f0cc-7f40-8d74-9b38934d2be0")
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountExpiryServiceMock(), newServiceAccountBindingServiceMock())
 			h.GetServiceAccountByClientId(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -422,7 +562,7 @@ func Test_serviceAccountsHandler_GetServiceAccountById(t *testing.T) {
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": "b5843c4b-a702-100d-fc77-70e9b20e554f"})
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountExpiryServiceMock(), newServiceAccountBindingServiceMock())
 			h.GetServiceAccountById(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -470,7 +610,7 @@ func Test_serviceAccountsHandler_GetSsoProviders(t *testing.T) {
 			g := gomega.NewWithT(t)
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 
-			h := NewServiceAccountHandler(tt.fields.service)
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountExpiryServiceMock(), newServiceAccountBindingServiceMock())
 			h.GetSsoProviders(rw, req)
 			resp := rw.Result()
 			resp.Body.Close()
@@ -478,3 +618,232 @@ func Test_serviceAccountsHandler_GetSsoProviders(t *testing.T) {
 		})
 	}
 }
+
+func Test_serviceAccountsHandler_ListServiceAccounts_Paging(t *testing.T) {
+	accounts := []api.ServiceAccount{
+		{ID: "1", Name: "my-app-1", CreatedBy: "my-user"},
+		{ID: "2", Name: "my-app-2", CreatedBy: "other-user"},
+		{ID: "3", Name: "other-app", CreatedBy: "my-user"},
+		{ID: "4", Name: "my-app-4", CreatedBy: "my-user"},
+	}
+	listServiceAcc := func(ctx context.Context, first, max int) ([]api.ServiceAccount, *errors.ServiceError) {
+		if first >= len(accounts) {
+			return []api.ServiceAccount{}, nil
+		}
+		last := first + max
+		if last > len(accounts) {
+			last = len(accounts)
+		}
+		return accounts[first:last], nil
+	}
+
+	tests := []struct {
+		name    string
+		query   url.Values
+		wantIDs []string
+	}{
+		{
+			name:    "should return the requested page",
+			query:   url.Values{"page": []string{"2"}, "size": []string{"2"}},
+			wantIDs: []string{"3", "4"},
+		},
+		{
+			name:    "should return the service accounts matching the search",
+			query:   url.Values{"search": []string{"name like my-app% and created_by = my-user"}},
+			wantIDs: []string{"1", "4"},
+		},
+		{
+			name:    "should return the requested page of the service accounts matching the search",
+			query:   url.Values{"search": []string{"created_by = my-user"}, "page": []string{"2"}, "size": []string{"2"}},
+			wantIDs: []string{"4"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			req, rw := GetHandlerParams("GET", "/api/kafkas_mgmt/v1/service_accounts?"+tt.query.Encode(), nil, t)
+
+			h := NewServiceAccountHandler(&sso.KeycloakServiceMock{
+				ListServiceAccFunc: listServiceAcc,
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					// a small page size forces the search to go through several pages
+					return &keycloak.KeycloakConfig{MaxLimitForGetClients: 3}
+				},
+			}, newServiceAccountExpiryServiceMock(), newServiceAccountBindingServiceMock())
+			h.ListServiceAccounts(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+			var list public.ServiceAccountList
+			g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+			ids := []string{}
+			for _, item := range list.Items {
+				ids = append(ids, item.Id)
+			}
+			g.Expect(ids).To(gomega.Equal(tt.wantIDs))
+			g.Expect(list.Size).To(gomega.Equal(int32(len(tt.wantIDs))))
+		})
+	}
+}
+
+func Test_serviceAccountsHandler_UpdateServiceAccount(t *testing.T) {
+	type fields struct {
+		service sso.KeycloakService
+	}
+	type args struct {
+		body []byte
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+	}{
+		{
+			name: "should return status code 200 if it updates the service account successfully",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{
+					UpdateServiceAccountFunc: func(ctx context.Context, id string, name, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+						return &api.ServiceAccount{ID: id, Description: *description}, nil
+					},
+				},
+			},
+			args: args{
+				body: []byte(updateServiceAccountRequest),
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "should return status code 400 if the name is invalid",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{},
+			},
+			args: args{
+				body: []byte(`{"name": "my app!"}`),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return status code 403 if the user is not allowed to update the service account",
+			fields: fields{
+				service: &sso.KeycloakServiceMock{
+					UpdateServiceAccountFunc: func(ctx context.Context, id string, name, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+						return nil, errors.Forbidden("failed to update service account")
+					},
+				},
+			},
+			args: args{
+				body: []byte(updateServiceAccountRequest),
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			req, rw := GetHandlerParams("PATCH", "/api/kafkas_mgmt/v1/service_accounts/{id}", bytes.NewBuffer(tt.args.body), t)
+			req = mux.SetURLVars(req, map[string]string{"id": "b5843c4b-a702-100d-fc77-70e9b20e554f"})
+
+			h := NewServiceAccountHandler(tt.fields.service, newServiceAccountExpiryServiceMock(), newServiceAccountBindingServiceMock())
+			h.UpdateServiceAccount(rw, req)
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
+
+func Test_serviceAccountsHandler_CreateServiceAccount_Scope(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		validateErr     *errors.ServiceError
+		bindErr         *errors.ServiceError
+		wantStatusCode  int
+		wantCreateCalls int
+		wantDeleteCalls int
+		wantBound       bool
+	}{
+		{
+			name:            "should bind the service account to the kafkas of the scope",
+			body:            `{"name": "my-app-sa", "scope": {"kafka_ids": ["kafka-id"], "role": "producer"}}`,
+			wantStatusCode:  http.StatusAccepted,
+			wantCreateCalls: 1,
+			wantBound:       true,
+		},
+		{
+			name:            "should not bind an organisation-wide service account",
+			body:            createServiceAccountRequest,
+			wantStatusCode:  http.StatusAccepted,
+			wantCreateCalls: 1,
+		},
+		{
+			name:           "should return status code 400 if the scope is invalid",
+			body:           `{"name": "my-app-sa", "scope": {"kafka_ids": ["unknown-kafka-id"]}}`,
+			validateErr:    errors.BadRequest("scope.kafka_ids is not valid: kafka unknown-kafka-id not found"),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            "should remove the service account if it fails to bind it",
+			body:            `{"name": "my-app-sa", "scope": {"kafka_ids": ["kafka-id"], "role": "producer"}}`,
+			bindErr:         errors.GeneralError("failed to bind service account"),
+			wantStatusCode:  http.StatusInternalServerError,
+			wantCreateCalls: 1,
+			wantDeleteCalls: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			req, rw := GetHandlerParams("POST", "/api/kafkas_mgmt/v1/service_accounts", bytes.NewBufferString(tt.body), t)
+
+			service := &sso.KeycloakServiceMock{
+				CreateServiceAccountFunc: func(serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError) {
+					return &api.ServiceAccount{ID: "sa-id"}, nil
+				},
+				DeleteServiceAccountFunc: func(ctx context.Context, clientId string) *errors.ServiceError {
+					return nil
+				},
+			}
+			bindingService := newServiceAccountBindingServiceMock()
+			bindingService.ValidateScopeFunc = func(ctx context.Context, scope *api.ServiceAccountScope) *errors.ServiceError {
+				return tt.validateErr
+			}
+			if tt.bindErr != nil {
+				bindingService.BindFunc = func(orgId string, account *api.ServiceAccount, scope *api.ServiceAccountScope) *errors.ServiceError {
+					return tt.bindErr
+				}
+			}
+			expiryService := newServiceAccountExpiryServiceMock()
+
+			h := NewServiceAccountHandler(service, expiryService, bindingService)
+			h.CreateServiceAccount(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			// the service account must not be created when its scope is invalid
+			g.Expect(service.CreateServiceAccountCalls()).To(gomega.HaveLen(tt.wantCreateCalls))
+			// a service account failing to be bound must not stay with an organisation wide access
+			g.Expect(service.DeleteServiceAccountCalls()).To(gomega.HaveLen(tt.wantDeleteCalls))
+			g.Expect(expiryService.DeleteCalls()).To(gomega.HaveLen(tt.wantDeleteCalls))
+			if tt.wantBound {
+				g.Expect(bindingService.BindCalls()).To(gomega.HaveLen(1))
+				var account public.ServiceAccount
+				g.Expect(json.NewDecoder(resp.Body).Decode(&account)).To(gomega.Succeed())
+				g.Expect(account.Scope).To(gomega.Equal(&public.ServiceAccountScope{KafkaIds: []string{"kafka-id"}, Role: "producer"}))
+			} else if tt.bindErr == nil {
+				g.Expect(bindingService.BindCalls()).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 var ValidKafkaClusterNameRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
@@ -358,6 +359,12 @@ func ValidateKafkaUserFacingUpdateFields(ctx context.Context, authService author
 			return err
 		}
 
+		if kafkaUpdateReq.Labels != nil {
+			if err := validateKafkaLabels(*kafkaUpdateReq.Labels); err != nil {
+				return err
+			}
+		}
+
 		if kafkaUpdateReq.Owner != nil {
 			validationError := handlers.ValidateMinLength(kafkaUpdateReq.Owner, "owner", 1)()
 			if validationError != nil {
@@ -378,6 +385,33 @@ func ValidateKafkaUserFacingUpdateFields(ctx context.Context, authService author
 	}
 }
 
+// labels could be mapped to k8s labels, check that they are not used to set any reserved domain labels
+var reservedKafkaLabelDomains = []string{"kubernetes.io/", "k8s.io/", "openshift.io/", "bf2.org/"}
+
+// ValidateKafkaLabels returns an error if the given labels are not valid k8s labels, or use a reserved domain
+func ValidateKafkaLabels(labels *map[string]string) handlers.Validate {
+	return func() *errors.ServiceError {
+		return validateKafkaLabels(*labels)
+	}
+}
+
+func validateKafkaLabels(labels map[string]string) *errors.ServiceError {
+	for k, v := range labels {
+		if errs := validation.IsQualifiedName(k); len(errs) != 0 {
+			return errors.BadRequest("invalid label key %s: %s", k, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) != 0 {
+			return errors.BadRequest("invalid label value %s: %s", v, strings.Join(errs, "; "))
+		}
+		for _, d := range reservedKafkaLabelDomains {
+			if strings.Contains(k, d) {
+				return errors.BadRequest("cannot use reserved label %s from domain %s", k, d)
+			}
+		}
+	}
+	return nil
+}
+
 func getClaims(ctx context.Context) (auth.KFMClaims, *errors.ServiceError) {
 	claims, err := auth.GetClaimsFromContext(ctx)
 	if err != nil {
@@ -386,6 +420,16 @@ func getClaims(ctx context.Context) (auth.KFMClaims, *errors.ServiceError) {
 	return auth.KFMClaims(claims), nil
 }
 
+// getOrgId returns the organisation of the user
+func getOrgId(ctx context.Context) (string, *errors.ServiceError) {
+	claims, err := getClaims(ctx)
+	if err != nil {
+		return "", err
+	}
+	orgId, _ := claims.GetOrgId()
+	return orgId, nil
+}
+
 type ValidateKafkaClaimsOptions func(claims *auth.KFMClaims) *errors.ServiceError
 
 func ValidateUsername() ValidateKafkaClaimsOptions {
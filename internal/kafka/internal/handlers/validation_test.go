@@ -1018,6 +1018,50 @@ func TestValidateKafkaUpdateFields(t *testing.T) {
 	}
 }
 
+func TestValidateKafkaLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "should return nil when there are no labels",
+			labels: nil,
+		},
+		{
+			name:   "should return nil when the labels are valid",
+			labels: map[string]string{"cost-centre": "1234", "example.com/env": "dev", "empty": ""},
+		},
+		{
+			name:    "should return an error when a label key is invalid",
+			labels:  map[string]string{"cost centre": "1234"},
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when a label value is invalid",
+			labels:  map[string]string{"env": "dev/test"},
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when a label uses a reserved domain",
+			labels:  map[string]string{"bf2.org/id": "1"},
+			wantErr: true,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			err := ValidateKafkaLabels(&tt.labels)()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(err.Code).To(gomega.Equal(errors.ErrorBadRequest))
+			}
+		})
+	}
+}
+
 func TestValidateMaxDataRetentionSize(t *testing.T) {
 	type args struct {
 		kafkaRequest   *dbapi.KafkaRequest
@@ -0,0 +1,29 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/ratelimit
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addRateLimitBucketsTable() *gormigrate.Migration {
+	type RateLimitBucket struct {
+		ID      string `gorm:"primaryKey"`
+		Tat     int64  `gorm:"not null;index"`
+		Allowed bool   `gorm:"not null"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230419120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&RateLimitBucket{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&RateLimitBucket{})
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/handlers
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addIdempotencyKeysTable() *gormigrate.Migration {
+	type IdempotencyKey struct {
+		ID           string `gorm:"primaryKey"`
+		RequestHash  string `gorm:"not null"`
+		StatusCode   int    `gorm:"not null"`
+		ResponseBody []byte
+		CreatedAt    time.Time `gorm:"not null"`
+		ExpiresAt    time.Time `gorm:"not null;index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230426120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&IdempotencyKey{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&IdempotencyKey{})
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaLabels() *gormigrate.Migration {
+	type KafkaRequest struct {
+		Labels string `gorm:"type:jsonb"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230503120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "labels")
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/workers
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addWorkerShardLeasesTables() *gormigrate.Migration {
+	type WorkerShardLease struct {
+		WorkerType string    `gorm:"primaryKey"`
+		Shard      int       `gorm:"primaryKey;autoIncrement:false"`
+		Owner      string    `gorm:"not null;index"`
+		Expires    time.Time `gorm:"not null"`
+	}
+	type WorkerReplica struct {
+		WorkerType string    `gorm:"primaryKey"`
+		WorkerId   string    `gorm:"primaryKey"`
+		Expires    time.Time `gorm:"not null;index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230510120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&WorkerShardLease{}, &WorkerReplica{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&WorkerShardLease{}, &WorkerReplica{})
+		},
+	}
+}
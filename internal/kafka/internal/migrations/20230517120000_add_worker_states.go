@@ -0,0 +1,35 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/workers
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addWorkerStatesTable() *gormigrate.Migration {
+	type WorkerState struct {
+		WorkerType            string `gorm:"primaryKey"`
+		Paused                bool   `gorm:"not null;default:false"`
+		LastReconcileStart    *time.Time
+		LastReconcileEnd      *time.Time
+		LastReconcileWorkerId string
+		LastErrors            string `gorm:"type:jsonb"`
+		UpdatedAt             time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230517120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&WorkerState{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&WorkerState{})
+		},
+	}
+}
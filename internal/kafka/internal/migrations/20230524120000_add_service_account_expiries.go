@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addServiceAccountExpiriesTable() *gormigrate.Migration {
+	type ServiceAccountExpiry struct {
+		ID                   string `gorm:"primaryKey"`
+		ClientID             string
+		OrganisationId       string    `gorm:"index"`
+		CredentialsIssuedAt  time.Time `gorm:"not null"`
+		CredentialsExpireAt  time.Time `gorm:"not null;index"`
+		CredentialsRevokedAt *time.Time
+		CreatedAt            time.Time
+		UpdatedAt            time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230524120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ServiceAccountExpiry{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ServiceAccountExpiry{})
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaTemplates() *gormigrate.Migration {
+	type KafkaTemplate struct {
+		db.Model
+		Name                    string
+		OrganisationId          string `gorm:"index"`
+		CreatedBy               string
+		CloudProvider           string
+		Region                  string
+		Plan                    string
+		BillingModel            string
+		BillingCloudAccountId   string
+		Marketplace             string
+		ReauthenticationEnabled *bool
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230607120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaTemplate{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&KafkaTemplate{})
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaAccessGrantsUniqueIndex() *gormigrate.Migration {
+	type KafkaAccessGrant struct {
+		KafkaID        string `gorm:"uniqueIndex:uix_kafka_access_grants_kafka_id_organisation_id,where:deleted_at IS NULL"`
+		OrganisationId string `gorm:"uniqueIndex:uix_kafka_access_grants_kafka_id_organisation_id,where:deleted_at IS NULL"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230614120000",
+		Migrate: func(tx *gorm.DB) error {
+			// in case concurrent requests granted the access twice, keep the oldest grant so that the unique index can be created
+			if err := tx.Exec(`UPDATE kafka_access_grants g SET deleted_at = now()
+				WHERE g.deleted_at IS NULL AND EXISTS (SELECT 1 FROM kafka_access_grants o
+					WHERE o.kafka_id = g.kafka_id AND o.organisation_id = g.organisation_id AND o.deleted_at IS NULL
+					AND (o.created_at, o.id) < (g.created_at, g.id))`).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&KafkaAccessGrant{}, "uix_kafka_access_grants_kafka_id_organisation_id")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropIndex(&KafkaAccessGrant{}, "uix_kafka_access_grants_kafka_id_organisation_id")
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaTemplatesUniqueIndex() *gormigrate.Migration {
+	type KafkaTemplate struct {
+		Name           string `gorm:"uniqueIndex:uix_kafka_templates_organisation_id_name,where:deleted_at IS NULL"`
+		OrganisationId string `gorm:"uniqueIndex:uix_kafka_templates_organisation_id_name,where:deleted_at IS NULL"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230614120100",
+		Migrate: func(tx *gorm.DB) error {
+			// in case concurrent requests created a template twice, keep the oldest one so that the unique index can be created
+			if err := tx.Exec(`UPDATE kafka_templates t SET deleted_at = now()
+				WHERE t.deleted_at IS NULL AND EXISTS (SELECT 1 FROM kafka_templates o
+					WHERE o.organisation_id = t.organisation_id AND o.name = t.name AND o.deleted_at IS NULL
+					AND (o.created_at, o.id) < (t.created_at, t.id))`).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&KafkaTemplate{}, "uix_kafka_templates_organisation_id_name")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropIndex(&KafkaTemplate{}, "uix_kafka_templates_organisation_id_name")
+		},
+	}
+}
@@ -100,6 +100,18 @@ var migrations = []*gormigrate.Migration{
 	addKafkaDomainCertificateManagementInfoInKafkaRequestsTable(),
 	addKafkasRoutesTLSCertificateManagerInLeaderLeases(),
 	addDistributedLockTable(),
+	addKafkaAccessGrants(),
+	addRateLimitBucketsTable(),
+	addIdempotencyKeysTable(),
+	addKafkaLabels(),
+	addWorkerShardLeasesTables(),
+	addWorkerStatesTable(),
+	addServiceAccountExpiriesTable(),
+	addServiceAccountExpiryManagerInLeaderLeases(),
+	addServiceAccountBindings(),
+	addKafkaTemplates(),
+	addKafkaAccessGrantsUniqueIndex(),
+	addKafkaTemplatesUniqueIndex(),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
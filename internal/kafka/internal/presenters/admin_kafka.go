@@ -60,6 +60,7 @@ func PresentKafkaRequestAdminEndpoint(kafkaRequest *dbapi.KafkaRequest, accountS
 		QuotaType:              kafkaRequest.QuotaType,
 		Routes:                 GetRoutesFromKafkaRequest(kafkaRequest),
 		RoutesCreated:          kafkaRequest.RoutesCreated,
+		Labels:                 GetLabelsFromKafkaRequest(kafkaRequest),
 		ClusterId:              kafkaRequest.ClusterID,
 		InstanceType:           kafkaRequest.InstanceType,
 		Namespace:              kafkaRequest.Namespace,
@@ -38,6 +38,11 @@ func ConvertKafkaRequest(kafkaRequestPayload public.KafkaRequestPayload, dbKafka
 		kafka.ReauthenticationEnabled = true // true by default
 	}
 
+	if kafkaRequestPayload.Labels != nil {
+		// labels are a map of strings, they can always be marshalled
+		_ = kafka.SetLabels(kafkaRequestPayload.Labels)
+	}
+
 	// enterprise kafkas should be assigned to specified cluster, if its ID is provided
 	if !shared.StringEmpty(kafkaRequestPayload.ClusterId) {
 		kafka.ClusterID = *kafkaRequestPayload.ClusterId
@@ -124,6 +129,7 @@ func PresentKafkaRequest(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 		PromotionStatus:                       kafkaRequest.PromotionStatus.String(),
 		PromotionDetails:                      kafkaRequest.PromotionDetails,
 		ClusterId:                             getClusterID(kafkaRequest),
+		Labels:                                GetLabelsFromKafkaRequest(kafkaRequest),
 	}, nil
 }
 
@@ -152,3 +158,13 @@ func getClusterID(kafkaRequest *dbapi.KafkaRequest) *string {
 	}
 	return nil
 }
+
+// GetLabelsFromKafkaRequest returns the labels of the kafka request, or nil if they can't be read
+func GetLabelsFromKafkaRequest(kafkaRequest *dbapi.KafkaRequest) map[string]string {
+	labels, err := kafkaRequest.GetLabels()
+	if err != nil {
+		logger.Logger.Errorf("unable to read the labels of kafka request %q: %v", kafkaRequest.ID, err)
+		return nil
+	}
+	return labels
+}
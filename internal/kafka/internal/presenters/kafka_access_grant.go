@@ -0,0 +1,31 @@
+package presenters
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+)
+
+// KindKafkaAccessGrant is a string identifier for the type dbapi.KafkaAccessGrant
+const KindKafkaAccessGrant = "KafkaAccessGrant"
+
+func ConvertKafkaAccessGrantRequest(request public.KafkaAccessGrantRequest) *dbapi.KafkaAccessGrant {
+	return &dbapi.KafkaAccessGrant{
+		OrganisationId: request.OrganisationId,
+		AccessLevel:    dbapi.KafkaAccessLevel(request.AccessLevel),
+	}
+}
+
+func PresentKafkaAccessGrant(grant *dbapi.KafkaAccessGrant) public.KafkaAccessGrant {
+	return public.KafkaAccessGrant{
+		Id:             grant.ID,
+		Kind:           KindKafkaAccessGrant,
+		Href:           fmt.Sprintf("%s/kafkas/%s/access_grants/%s", BasePath, grant.KafkaID, grant.ID),
+		KafkaId:        grant.KafkaID,
+		OrganisationId: grant.OrganisationId,
+		AccessLevel:    grant.AccessLevel.String(),
+		CreatedBy:      grant.CreatedBy,
+		CreatedAt:      grant.CreatedAt,
+	}
+}
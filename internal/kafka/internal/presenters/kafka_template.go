@@ -0,0 +1,124 @@
+package presenters
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+// KindKafkaTemplate is a string identifier for the type dbapi.KafkaTemplate
+const KindKafkaTemplate = "KafkaTemplate"
+
+func ConvertKafkaTemplateRequest(request public.KafkaTemplateRequest) *dbapi.KafkaTemplate {
+	return &dbapi.KafkaTemplate{
+		Name:                    request.Name,
+		CloudProvider:           request.CloudProvider,
+		Region:                  request.Region,
+		Plan:                    request.Plan,
+		BillingModel:            request.BillingModel,
+		BillingCloudAccountId:   request.BillingCloudAccountId,
+		Marketplace:             request.Marketplace,
+		ReauthenticationEnabled: request.ReauthenticationEnabled,
+	}
+}
+
+func PresentKafkaTemplate(template *dbapi.KafkaTemplate) public.KafkaTemplate {
+	return public.KafkaTemplate{
+		Id:                      template.ID,
+		Kind:                    KindKafkaTemplate,
+		Href:                    fmt.Sprintf("%s/kafka_templates/%s", BasePath, template.ID),
+		Name:                    template.Name,
+		CloudProvider:           template.CloudProvider,
+		Region:                  template.Region,
+		Plan:                    template.Plan,
+		BillingModel:            template.BillingModel,
+		BillingCloudAccountId:   template.BillingCloudAccountId,
+		Marketplace:             template.Marketplace,
+		ReauthenticationEnabled: template.ReauthenticationEnabled,
+		CreatedBy:               template.CreatedBy,
+		CreatedAt:               template.CreatedAt,
+	}
+}
+
+// ApplyKafkaTemplate sets the fields of the kafka request payload that are not set from the settings of the template
+func ApplyKafkaTemplate(template *dbapi.KafkaTemplate, kafkaRequestPayload *public.KafkaRequestPayload) {
+	if kafkaRequestPayload.CloudProvider == "" {
+		kafkaRequestPayload.CloudProvider = template.CloudProvider
+		// the region of the template only makes sense with its cloud provider
+		if kafkaRequestPayload.Region == "" {
+			kafkaRequestPayload.Region = template.Region
+		}
+	} else if kafkaRequestPayload.Region == "" && shared.StringEqualsIgnoreCase(kafkaRequestPayload.CloudProvider, template.CloudProvider) {
+		kafkaRequestPayload.Region = template.Region
+	}
+	if kafkaRequestPayload.Plan == "" {
+		kafkaRequestPayload.Plan = template.Plan
+	}
+	if kafkaRequestPayload.ReauthenticationEnabled == nil && template.ReauthenticationEnabled != nil {
+		reauthenticationEnabled := *template.ReauthenticationEnabled
+		kafkaRequestPayload.ReauthenticationEnabled = &reauthenticationEnabled
+	}
+	if shared.StringEmpty(kafkaRequestPayload.BillingModel) && template.BillingModel != "" {
+		kafkaRequestPayload.BillingModel = &template.BillingModel
+	}
+	// the marketplace is tied to the billing cloud account, they are taken from the template together
+	if shared.StringEmpty(kafkaRequestPayload.BillingCloudAccountId) && shared.StringEmpty(kafkaRequestPayload.Marketplace) {
+		if template.BillingCloudAccountId != "" {
+			kafkaRequestPayload.BillingCloudAccountId = &template.BillingCloudAccountId
+		}
+		if template.Marketplace != "" {
+			kafkaRequestPayload.Marketplace = &template.Marketplace
+		}
+	}
+}
+
+// ConvertKafkaCloneRequest returns the payload of the kafka request created by cloning the given kafka request. The
+// settings of the cloned kafka request are used for the fields that are not set in the clone request.
+func ConvertKafkaCloneRequest(kafkaRequest *dbapi.KafkaRequest, cloneRequest public.KafkaCloneRequest) public.KafkaRequestPayload {
+	reauthenticationEnabled := kafkaRequest.ReauthenticationEnabled
+	kafkaRequestPayload := public.KafkaRequestPayload{
+		Name:                    cloneRequest.Name,
+		CloudProvider:           kafkaRequest.CloudProvider,
+		Region:                  kafkaRequest.Region,
+		Plan:                    fmt.Sprintf("%s.%s", kafkaRequest.InstanceType, kafkaRequest.SizeId),
+		ReauthenticationEnabled: &reauthenticationEnabled,
+		ClusterId:               cloneRequest.ClusterId,
+	}
+
+	if cloneRequest.CloudProvider != "" && !shared.StringEqualsIgnoreCase(cloneRequest.CloudProvider, kafkaRequest.CloudProvider) {
+		// the region of the cloned kafka request does not exist in another cloud provider
+		kafkaRequestPayload.CloudProvider = cloneRequest.CloudProvider
+		kafkaRequestPayload.Region = ""
+	}
+	if cloneRequest.Region != "" {
+		kafkaRequestPayload.Region = cloneRequest.Region
+	}
+	if cloneRequest.Plan != "" {
+		kafkaRequestPayload.Plan = cloneRequest.Plan
+	}
+
+	if kafkaRequest.DesiredKafkaBillingModel != "" {
+		billingModel := kafkaRequest.DesiredKafkaBillingModel
+		kafkaRequestPayload.BillingModel = &billingModel
+	}
+	if kafkaRequest.BillingCloudAccountId != "" {
+		billingCloudAccountId := kafkaRequest.BillingCloudAccountId
+		kafkaRequestPayload.BillingCloudAccountId = &billingCloudAccountId
+	}
+	if kafkaRequest.Marketplace != "" {
+		marketplace := kafkaRequest.Marketplace
+		kafkaRequestPayload.Marketplace = &marketplace
+	}
+	// enterprise kafkas are cloned in the same data plane cluster unless another one is given
+	if shared.StringEmpty(kafkaRequestPayload.ClusterId) && kafkaRequest.DesiredBillingModelIsEnterprise() && kafkaRequest.ClusterID != "" {
+		clusterID := kafkaRequest.ClusterID
+		kafkaRequestPayload.ClusterId = &clusterID
+	}
+	if labels, err := kafkaRequest.GetLabels(); err == nil && len(labels) > 0 {
+		kafkaRequestPayload.Labels = labels
+	}
+
+	return kafkaRequestPayload
+}
@@ -0,0 +1,175 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/onsi/gomega"
+)
+
+func TestApplyKafkaTemplate(t *testing.T) {
+	reauthDisabled := false
+	reauthEnabled := true
+	otherMarketplace := "rhm"
+	template := &dbapi.KafkaTemplate{
+		CloudProvider:           "aws",
+		Region:                  "us-east-1",
+		Plan:                    "standard.x1",
+		BillingModel:            "marketplace",
+		BillingCloudAccountId:   "123456",
+		Marketplace:             "aws",
+		ReauthenticationEnabled: &reauthDisabled,
+	}
+
+	tests := []struct {
+		name    string
+		payload public.KafkaRequestPayload
+		want    public.KafkaRequestPayload
+	}{
+		{
+			name:    "should set the unset fields from the template",
+			payload: public.KafkaRequestPayload{Name: "name"},
+			want: public.KafkaRequestPayload{
+				Name:                    "name",
+				CloudProvider:           "aws",
+				Region:                  "us-east-1",
+				Plan:                    "standard.x1",
+				BillingModel:            &template.BillingModel,
+				BillingCloudAccountId:   &template.BillingCloudAccountId,
+				Marketplace:             &template.Marketplace,
+				ReauthenticationEnabled: &reauthDisabled,
+			},
+		},
+		{
+			name: "should keep the fields set in the request",
+			payload: public.KafkaRequestPayload{
+				Name:                    "name",
+				Region:                  "eu-west-1",
+				ReauthenticationEnabled: &reauthEnabled,
+				Marketplace:             &otherMarketplace,
+			},
+			want: public.KafkaRequestPayload{
+				Name:                    "name",
+				CloudProvider:           "aws",
+				Region:                  "eu-west-1",
+				Plan:                    "standard.x1",
+				BillingModel:            &template.BillingModel,
+				Marketplace:             &otherMarketplace,
+				ReauthenticationEnabled: &reauthEnabled,
+			},
+		},
+		{
+			name:    "should not use the region of the template with another cloud provider",
+			payload: public.KafkaRequestPayload{Name: "name", CloudProvider: "gcp"},
+			want: public.KafkaRequestPayload{
+				Name:                    "name",
+				CloudProvider:           "gcp",
+				Plan:                    "standard.x1",
+				BillingModel:            &template.BillingModel,
+				BillingCloudAccountId:   &template.BillingCloudAccountId,
+				Marketplace:             &template.Marketplace,
+				ReauthenticationEnabled: &reauthDisabled,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			payload := tt.payload
+			ApplyKafkaTemplate(template, &payload)
+			g.Expect(payload).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestConvertKafkaCloneRequest(t *testing.T) {
+	reauthEnabled := true
+	enterpriseBillingModel := constants.BillingModelEnterprise.String()
+	clusterID := mocks.DefaultClusterID
+	otherClusterID := "other-cluster"
+	labels := map[string]string{"env": "dev"}
+
+	tests := []struct {
+		name         string
+		kafkaRequest *dbapi.KafkaRequest
+		cloneRequest public.KafkaCloneRequest
+		want         public.KafkaRequestPayload
+	}{
+		{
+			name: "should use the settings of the cloned kafka",
+			kafkaRequest: mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(k *dbapi.KafkaRequest) {
+				k.ReauthenticationEnabled = true
+				_ = k.SetLabels(labels)
+			}),
+			cloneRequest: public.KafkaCloneRequest{Name: "clone"},
+			want: public.KafkaRequestPayload{
+				Name:                    "clone",
+				CloudProvider:           mocks.DefaultKafkaRequestProvider,
+				Region:                  mocks.DefaultKafkaRequestRegion,
+				Plan:                    "standard.x1",
+				ReauthenticationEnabled: &reauthEnabled,
+				Labels:                  labels,
+			},
+		},
+		{
+			name: "should use the default region of another cloud provider",
+			kafkaRequest: mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(k *dbapi.KafkaRequest) {
+				k.ReauthenticationEnabled = true
+			}),
+			cloneRequest: public.KafkaCloneRequest{Name: "clone", CloudProvider: "gcp", Plan: "standard.x2"},
+			want: public.KafkaRequestPayload{
+				Name:                    "clone",
+				CloudProvider:           "gcp",
+				Plan:                    "standard.x2",
+				ReauthenticationEnabled: &reauthEnabled,
+			},
+		},
+		{
+			name: "should clone an enterprise kafka in the same cluster",
+			kafkaRequest: mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(k *dbapi.KafkaRequest) {
+				k.ReauthenticationEnabled = true
+				k.DesiredKafkaBillingModel = enterpriseBillingModel
+			}),
+			cloneRequest: public.KafkaCloneRequest{Name: "clone"},
+			want: public.KafkaRequestPayload{
+				Name:                    "clone",
+				CloudProvider:           mocks.DefaultKafkaRequestProvider,
+				Region:                  mocks.DefaultKafkaRequestRegion,
+				Plan:                    "standard.x1",
+				ReauthenticationEnabled: &reauthEnabled,
+				BillingModel:            &enterpriseBillingModel,
+				ClusterId:               &clusterID,
+			},
+		},
+		{
+			name: "should clone an enterprise kafka in the given cluster",
+			kafkaRequest: mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(k *dbapi.KafkaRequest) {
+				k.ReauthenticationEnabled = true
+				k.DesiredKafkaBillingModel = enterpriseBillingModel
+			}),
+			cloneRequest: public.KafkaCloneRequest{Name: "clone", ClusterId: &otherClusterID},
+			want: public.KafkaRequestPayload{
+				Name:                    "clone",
+				CloudProvider:           mocks.DefaultKafkaRequestProvider,
+				Region:                  mocks.DefaultKafkaRequestRegion,
+				Plan:                    "standard.x1",
+				ReauthenticationEnabled: &reauthEnabled,
+				BillingModel:            &enterpriseBillingModel,
+				ClusterId:               &otherClusterID,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(ConvertKafkaCloneRequest(tt.kafkaRequest, tt.cloneRequest)).To(gomega.Equal(tt.want))
+		})
+	}
+}
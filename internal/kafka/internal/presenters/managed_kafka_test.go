@@ -128,3 +128,15 @@ func TestGetServiceAccounts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetServiceAccountScopes(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect(getServiceAccountScopes(nil)).To(gomega.BeNil())
+	g.Expect(getServiceAccountScopes(&v1.ServiceAccountScopesSpec{
+		Bindings:         []v1.ServiceAccountBinding{{Principal: "bound", Role: "producer"}},
+		DeniedPrincipals: []string{"denied"},
+	})).To(gomega.Equal(&private.ManagedKafkaAllOfSpecServiceAccountScopes{
+		Bindings:         []private.ManagedKafkaAllOfSpecServiceAccountScopesBindings{{Principal: "bound", Role: "producer"}},
+		DeniedPrincipals: []string{"denied"},
+	}))
+}
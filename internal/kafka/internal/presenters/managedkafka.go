@@ -53,9 +53,10 @@ func PresentManagedKafka(from *v1.ManagedKafka) private.ManagedKafka {
 				KafkaIbp: from.Spec.Versions.KafkaIBP,
 				Strimzi:  from.Spec.Versions.Strimzi,
 			},
-			Deleted:         from.Spec.Deleted,
-			Owners:          from.Spec.Owners,
-			ServiceAccounts: getServiceAccounts(from.Spec.ServiceAccounts),
+			Deleted:              from.Spec.Deleted,
+			Owners:               from.Spec.Owners,
+			ServiceAccounts:      getServiceAccounts(from.Spec.ServiceAccounts),
+			ServiceAccountScopes: getServiceAccountScopes(from.Spec.ServiceAccountScopes),
 		},
 	}
 
@@ -92,3 +93,20 @@ func getServiceAccounts(from []v1.ServiceAccount) []private.ManagedKafkaAllOfSpe
 	}
 	return accounts
 }
+
+func getServiceAccountScopes(from *v1.ServiceAccountScopesSpec) *private.ManagedKafkaAllOfSpecServiceAccountScopes {
+	if from == nil {
+		return nil
+	}
+	scopes := &private.ManagedKafkaAllOfSpecServiceAccountScopes{
+		Bindings:         []private.ManagedKafkaAllOfSpecServiceAccountScopesBindings{},
+		DeniedPrincipals: from.DeniedPrincipals,
+	}
+	for _, binding := range from.Bindings {
+		scopes.Bindings = append(scopes.Bindings, private.ManagedKafkaAllOfSpecServiceAccountScopesBindings{
+			Principal: binding.Principal,
+			Role:      binding.Role,
+		})
+	}
+	return scopes
+}
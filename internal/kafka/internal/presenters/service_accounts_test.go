@@ -2,6 +2,7 @@ package presenters
 
 import (
 	"testing"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/service_accounts"
@@ -125,3 +126,19 @@ func TestPresentSsoProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestPresentServiceAccount_CredentialsExpiry(t *testing.T) {
+	g := gomega.NewWithT(t)
+	expireAt := time.Now().Add(24 * time.Hour)
+	account := mocks.BuildApiServiceAccount(nil)
+	account.CredentialsExpireAt = &expireAt
+	account.CredentialsRotationDue = true
+
+	presented := PresentServiceAccount(account)
+	g.Expect(presented.CredentialsExpireAt).To(gomega.Equal(&expireAt))
+	g.Expect(presented.CredentialsRotationDue).To(gomega.BeTrue())
+
+	listItem := PresentServiceAccountListItem(account)
+	g.Expect(listItem.CredentialsExpireAt).To(gomega.Equal(&expireAt))
+	g.Expect(listItem.CredentialsRotationDue).To(gomega.BeTrue())
+}
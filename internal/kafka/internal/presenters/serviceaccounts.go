@@ -7,39 +7,69 @@ import (
 
 func ConvertServiceAccountRequest(account public.ServiceAccountRequest) *api.ServiceAccountRequest {
 	return &api.ServiceAccountRequest{
-		Name:        account.Name,
-		Description: account.Description,
+		Name:                account.Name,
+		Description:         account.Description,
+		CredentialsExpireAt: account.CredentialsExpireAt,
+		Scope:               ConvertServiceAccountScope(account.Scope),
+	}
+}
+
+func ConvertServiceAccountScope(scope *public.ServiceAccountScope) *api.ServiceAccountScope {
+	if scope == nil {
+		return nil
+	}
+	return &api.ServiceAccountScope{
+		KafkaIDs:            scope.KafkaIds,
+		ConnectorClusterIDs: scope.ConnectorClusterIds,
+		Role:                scope.Role,
 	}
 }
 
 func PresentServiceAccount(account *api.ServiceAccount) *public.ServiceAccount {
 	reference := PresentReference(account.ID, account)
 	return &public.ServiceAccount{
-		ClientId:        account.ClientID,
-		ClientSecret:    account.ClientSecret,
-		Name:            account.Name,
-		Description:     account.Description,
-		DeprecatedOwner: account.CreatedBy,
-		CreatedAt:       account.CreatedAt,
-		CreatedBy:       account.CreatedBy,
-		Id:              reference.Id,
-		Kind:            reference.Kind,
-		Href:            reference.Href,
+		ClientId:               account.ClientID,
+		ClientSecret:           account.ClientSecret,
+		Name:                   account.Name,
+		Description:            account.Description,
+		DeprecatedOwner:        account.CreatedBy,
+		CreatedAt:              account.CreatedAt,
+		CreatedBy:              account.CreatedBy,
+		CredentialsExpireAt:    account.CredentialsExpireAt,
+		CredentialsRotationDue: account.CredentialsRotationDue,
+		Scope:                  PresentServiceAccountScope(account.Scope),
+		Id:                     reference.Id,
+		Kind:                   reference.Kind,
+		Href:                   reference.Href,
 	}
 }
 
 func PresentServiceAccountListItem(account *api.ServiceAccount) public.ServiceAccountListItem {
 	ref := PresentReference(account.ID, account)
 	return public.ServiceAccountListItem{
-		Id:              ref.Id,
-		Kind:            ref.Kind,
-		Href:            ref.Href,
-		ClientId:        account.ClientID,
-		Name:            account.Name,
-		DeprecatedOwner: account.CreatedBy,
-		Description:     account.Description,
-		CreatedAt:       account.CreatedAt,
-		CreatedBy:       account.CreatedBy,
+		Id:                     ref.Id,
+		Kind:                   ref.Kind,
+		Href:                   ref.Href,
+		ClientId:               account.ClientID,
+		Name:                   account.Name,
+		DeprecatedOwner:        account.CreatedBy,
+		Description:            account.Description,
+		CreatedAt:              account.CreatedAt,
+		CreatedBy:              account.CreatedBy,
+		CredentialsExpireAt:    account.CredentialsExpireAt,
+		CredentialsRotationDue: account.CredentialsRotationDue,
+		Scope:                  PresentServiceAccountScope(account.Scope),
+	}
+}
+
+func PresentServiceAccountScope(scope *api.ServiceAccountScope) *public.ServiceAccountScope {
+	if scope == nil {
+		return nil
+	}
+	return &public.ServiceAccountScope{
+		KafkaIds:            scope.KafkaIDs,
+		ConnectorClusterIds: scope.ConnectorClusterIDs,
+		Role:                scope.Role,
 	}
 }
 
@@ -0,0 +1,34 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+)
+
+func PresentWorker(status workers.WorkerStatus, now time.Time) (private.Worker, *errors.ServiceError) {
+	lastErrors, err := status.GetLastErrors()
+	if err != nil {
+		return private.Worker{}, errors.NewWithCause(errors.ErrorGeneral, err, "unable to present worker %s", status.WorkerType)
+	}
+	worker := private.Worker{
+		WorkerType:            status.WorkerType,
+		Leader:                status.Leader,
+		Running:               status.IsRunning(now),
+		Paused:                status.Paused,
+		LastReconcileInstance: status.LastReconcileWorkerId,
+		LastErrors:            lastErrors,
+	}
+	if status.LeaseExpires != nil {
+		worker.LeaseExpires = *status.LeaseExpires
+	}
+	if status.LastReconcileStart != nil {
+		worker.LastReconcileStart = *status.LastReconcileStart
+	}
+	if status.LastReconcileEnd != nil {
+		worker.LastReconcileEnd = *status.LastReconcileEnd
+	}
+	return worker, nil
+}
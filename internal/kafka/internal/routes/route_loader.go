@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/ratelimit"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
@@ -28,7 +29,9 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	coreHandlers "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 
 	"github.com/goava/di"
 	gorillaHandlers "github.com/gorilla/handlers"
@@ -59,10 +62,19 @@ type options struct {
 	SupportedKafkaInstanceTypes               services.SupportedKafkaInstanceTypesService
 	AccessControlListMiddleware               *acl.AccessControlListMiddleware
 	AccessControlListConfig                   *acl.AccessControlListConfig
+	RateLimitMiddleware                       *ratelimit.RateLimitMiddleware
+	IdempotencyMiddleware                     *coreHandlers.IdempotencyMiddleware
 	EnterpriseClustersAccessControlMiddleware *internalAcl.EnterpriseClustersAccessControlMiddleware
 	AdminRoleAuthZConfig                      *auth.AdminRoleAuthZConfig
 	KasFleetshardOperatorAddon                services.KasFleetshardOperatorAddon
 	KafkaTLSCertificateManagementService      kafkatlscertmgmt.KafkaTLSCertificateManagementService
+	KafkaAccessGrantService                   services.KafkaAccessGrantService
+	ServiceAccountExpiryService               services.ServiceAccountExpiryService
+	ServiceAccountBindingService              services.ServiceAccountBindingService
+	KafkaTemplateService                      services.KafkaTemplateService
+	Workers                                   []workers.Worker
+	WorkerStateStore                          workers.WorkerStateStore
+	SignalBus                                 signalbus.SignalBus
 }
 
 func NewRouteLoader(s options) environments.RouteLoader {
@@ -85,16 +97,21 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		return pkgerrors.Wrapf(err, "can't load OpenAPI specification")
 	}
 
-	kafkaHandler := handlers.NewKafkaHandler(s.Kafka, s.ProviderConfig, s.AuthService, s.KafkaConfig)
+	kafkaHandler := handlers.NewKafkaHandler(s.Kafka, s.ProviderConfig, s.AuthService, s.KafkaConfig, s.KafkaTemplateService)
 	kafkaPromoteValidatorFactory := handlers.NewDefaultKafkaPromoteValidatorFactory(s.KafkaConfig)
 	kafkaPromoteHandler := handlers.NewKafkaPromoteHandler(s.Kafka, s.KafkaConfig, kafkaPromoteValidatorFactory)
+	kafkaAccessGrantsHandler := handlers.NewKafkaAccessGrantsHandler(s.Kafka, s.KafkaAccessGrantService)
+	kafkaTemplatesHandler := handlers.NewKafkaTemplatesHandler(s.KafkaTemplateService, s.Kafka, s.ProviderConfig, s.KafkaConfig)
 	cloudProvidersHandler := handlers.NewCloudProviderHandler(s.CloudProviders, s.ProviderConfig, s.Kafka, s.ClusterPlacementStrategy, s.KafkaConfig)
 	errorsHandler := coreHandlers.NewErrorsHandler()
-	serviceAccountsHandler := handlers.NewServiceAccountHandler(s.Keycloak)
+	serviceAccountsHandler := handlers.NewServiceAccountHandler(s.Keycloak, s.ServiceAccountExpiryService, s.ServiceAccountBindingService)
 	metricsHandler := handlers.NewMetricsHandler(s.Observatorium)
 	supportedKafkaInstanceTypesHandler := handlers.NewSupportedKafkaInstanceTypesHandler(s.SupportedKafkaInstanceTypes)
 
 	authorizeMiddleware := s.AccessControlListMiddleware.Authorize
+	rateLimitMiddleware := s.RateLimitMiddleware.RateLimit
+	idempotent := s.IdempotencyMiddleware.Idempotent
+	idempotentWithSecrets := s.IdempotencyMiddleware.IdempotentWithSecrets
 	requireOrgID := auth.NewRequireOrgIDMiddleware().RequireOrgID(errors.ErrorUnauthenticated)
 	requireIssuer := auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.ServerConfig.TokenIssuerURL}, errors.ErrorUnauthenticated)
 	requireTermsAcceptance := auth.NewRequireTermsAcceptanceMiddleware().RequireTermsAcceptance(s.ServerConfig.EnableTermsAcceptance, s.AMSClient, errors.ErrorTermsNotAccepted)
@@ -139,12 +156,17 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	apiV1KafkasRouter.Use(requireIssuer)
 	apiV1KafkasRouter.Use(requireOrgID)
 	apiV1KafkasRouter.Use(authorizeMiddleware)
+	apiV1KafkasRouter.Use(rateLimitMiddleware)
 
 	apiV1KafkasCreateRouter := apiV1KafkasRouter.NewRoute().Subrouter()
 	apiV1KafkasCreateRouter.HandleFunc("", kafkaHandler.Create).
 		Name(logger.NewLogEvent("create-kafka", "create a kafka instance").ToString()).
 		Methods(http.MethodPost)
+	apiV1KafkasCreateRouter.HandleFunc("/{id}/clone", kafkaHandler.Clone).
+		Name(logger.NewLogEvent("clone-kafka", "clone a kafka instance").ToString()).
+		Methods(http.MethodPost)
 	apiV1KafkasCreateRouter.Use(requireTermsAcceptance)
+	apiV1KafkasCreateRouter.Use(idempotent)
 
 	// /kafkas/{id}/promote
 	apiV1KafkasPromoteRouter := apiV1KafkasRouter.PathPrefix("/{id}/promote").Subrouter()
@@ -152,6 +174,41 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		Name(logger.NewLogEvent("promote-kafka", "promote a kafka instance").ToString()).
 		Methods(http.MethodPost)
 
+	// /kafkas/{id}/access_grants
+	apiV1KafkasAccessGrantsRouter := apiV1KafkasRouter.PathPrefix("/{id}/access_grants").Subrouter()
+	apiV1KafkasAccessGrantsRouter.HandleFunc("", kafkaAccessGrantsHandler.List).
+		Name(logger.NewLogEvent("list-kafka-access-grants", "list the access grants of a kafka instance").ToString()).
+		Methods(http.MethodGet)
+	apiV1KafkasAccessGrantsRouter.HandleFunc("", kafkaAccessGrantsHandler.Create).
+		Name(logger.NewLogEvent("create-kafka-access-grant", "grant access to a kafka instance").ToString()).
+		Methods(http.MethodPost)
+	apiV1KafkasAccessGrantsRouter.HandleFunc("/{grant_id}", kafkaAccessGrantsHandler.Delete).
+		Name(logger.NewLogEvent("delete-kafka-access-grant", "revoke access to a kafka instance").ToString()).
+		Methods(http.MethodDelete)
+
+	//  /kafka_templates
+	v1Collections = append(v1Collections, api.CollectionMetadata{
+		ID:   "kafka_templates",
+		Kind: "KafkaTemplateList",
+	})
+	apiV1KafkaTemplatesRouter := apiV1Router.PathPrefix("/kafka_templates").Subrouter()
+	apiV1KafkaTemplatesRouter.HandleFunc("", kafkaTemplatesHandler.List).
+		Name(logger.NewLogEvent("list-kafka-templates", "list the kafka templates").ToString()).
+		Methods(http.MethodGet)
+	apiV1KafkaTemplatesRouter.HandleFunc("", kafkaTemplatesHandler.Create).
+		Name(logger.NewLogEvent("create-kafka-template", "create a kafka template").ToString()).
+		Methods(http.MethodPost)
+	apiV1KafkaTemplatesRouter.HandleFunc("/{id}", kafkaTemplatesHandler.Get).
+		Name(logger.NewLogEvent("get-kafka-template", "get a kafka template").ToString()).
+		Methods(http.MethodGet)
+	apiV1KafkaTemplatesRouter.HandleFunc("/{id}", kafkaTemplatesHandler.Delete).
+		Name(logger.NewLogEvent("delete-kafka-template", "delete a kafka template").ToString()).
+		Methods(http.MethodDelete)
+	apiV1KafkaTemplatesRouter.Use(requireIssuer)
+	apiV1KafkaTemplatesRouter.Use(requireOrgID)
+	apiV1KafkaTemplatesRouter.Use(authorizeMiddleware)
+	apiV1KafkaTemplatesRouter.Use(rateLimitMiddleware)
+
 	//  /kafkas/{id}/metrics
 	apiV1MetricsRouter := apiV1KafkasRouter.PathPrefix("/{id}/metrics").Subrouter()
 	apiV1MetricsRouter.HandleFunc("/query_range", metricsHandler.GetMetricsByRangeQuery).
@@ -188,12 +245,15 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	apiV1ServiceAccountsRouter.HandleFunc("", serviceAccountsHandler.ListServiceAccounts).
 		Name(logger.NewLogEvent("list-service-accounts", "lists all service accounts").ToString()).
 		Methods(http.MethodGet)
-	apiV1ServiceAccountsRouter.HandleFunc("", serviceAccountsHandler.CreateServiceAccount).
+	apiV1ServiceAccountsRouter.Handle("", idempotentWithSecrets(http.HandlerFunc(serviceAccountsHandler.CreateServiceAccount))).
 		Name(logger.NewLogEvent("create-service-accounts", "create a service accounts").ToString()).
 		Methods(http.MethodPost)
 	apiV1ServiceAccountsRouter.HandleFunc("/{id}", serviceAccountsHandler.DeleteServiceAccount).
 		Name(logger.NewLogEvent("delete-service-accounts", "delete a service accounts").ToString()).
 		Methods(http.MethodDelete)
+	apiV1ServiceAccountsRouter.HandleFunc("/{id}", serviceAccountsHandler.UpdateServiceAccount).
+		Name(logger.NewLogEvent("update-service-accounts", "update a service account").ToString()).
+		Methods(http.MethodPatch)
 	apiV1ServiceAccountsRouter.HandleFunc("/{id}/reset_credentials", serviceAccountsHandler.ResetServiceAccountCredential).
 		Name(logger.NewLogEvent("reset-service-accounts", "reset a service accounts").ToString()).
 		Methods(http.MethodPost)
@@ -204,6 +264,7 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	apiV1ServiceAccountsRouter.Use(requireIssuer)
 	apiV1ServiceAccountsRouter.Use(requireOrgID)
 	apiV1ServiceAccountsRouter.Use(authorizeMiddleware)
+	apiV1ServiceAccountsRouter.Use(rateLimitMiddleware)
 
 	//  /cloud_providers
 	v1Collections = append(v1Collections, api.CollectionMetadata{
@@ -226,6 +287,7 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	apiV1SupportedKafkaInstanceTypesRouter.Use(requireIssuer)
 	apiV1SupportedKafkaInstanceTypesRouter.Use(requireOrgID)
 	apiV1SupportedKafkaInstanceTypesRouter.Use(authorizeMiddleware)
+	apiV1SupportedKafkaInstanceTypesRouter.Use(rateLimitMiddleware)
 
 	// /api/kafkas_mgmt/v1/clusters/
 	v1Collections = append(v1Collections, api.CollectionMetadata{
@@ -300,6 +362,24 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		Name(logger.NewLogEvent("admin-kafka-tls-certificate-revocation", "[admin] revoke the TLS certificate of a kafka by id").ToString()).
 		Methods(http.MethodPost)
 
+	// /api/kafkas_mgmt/v1/admin/workers
+	adminWorkersHandler := handlers.NewAdminWorkersHandler(s.Workers, s.WorkerStateStore, s.SignalBus)
+	adminRouter.HandleFunc("/workers", adminWorkersHandler.List).
+		Name(logger.NewLogEvent("admin-list-workers", "[admin] list all workers").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/workers/{worker_type}", adminWorkersHandler.Get).
+		Name(logger.NewLogEvent("admin-get-worker", "[admin] get worker by type").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/workers/{worker_type}/pause", adminWorkersHandler.Pause).
+		Name(logger.NewLogEvent("admin-pause-worker", "[admin] pause worker by type").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/workers/{worker_type}/resume", adminWorkersHandler.Resume).
+		Name(logger.NewLogEvent("admin-resume-worker", "[admin] resume worker by type").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/workers/{worker_type}/reconcile", adminWorkersHandler.Reconcile).
+		Name(logger.NewLogEvent("admin-reconcile-worker", "[admin] trigger the reconcile of a worker by type").ToString()).
+		Methods(http.MethodPost)
+
 	// /api/kafkas_mgmt/v1
 	v1Metadata := api.VersionMetadata{
 		ID:          "v1",
@@ -10,9 +10,11 @@ import (
 
 	coreErrors "errors"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
@@ -34,10 +36,10 @@ import (
 	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
@@ -73,11 +75,20 @@ const (
 
 const CanaryServiceAccountPrefix = "canary"
 
+const (
+	CNameRecordStatusInSync  = "INSYNC"
+	CNameRecordStatusPending = "PENDING"
+)
+
 type CNameRecordStatus struct {
 	Id     *string
 	Status *string
 }
 
+// KafkaStatusSignal is the name of the signals notified with the ID of a kafka request as payload when its status
+// changes, see workers.TargetedWorker
+const KafkaStatusSignal = "kafka"
+
 //go:generate moq -out kafkaservice_moq.go . KafkaService
 type KafkaService interface {
 	// PrepareKafkaRequest sets any required information (i.e. bootstrap server host, sso client id and secret)
@@ -98,6 +109,8 @@ type KafkaService interface {
 	ListAll() (dbapi.KafkaList, *errors.ServiceError)
 	ListKafkasToBePromoted() ([]*dbapi.KafkaRequest, *errors.ServiceError)
 	GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError)
+	// GetManagedKafkaByID returns the ManagedKafka CR generated for the kafka with the given id, whatever its status
+	GetManagedKafkaByID(id string) (*managedkafka.ManagedKafka, *errors.ServiceError)
 	// GenerateReservedManagedKafkasByClusterID returns a list of reserved managed
 	// kafkas for a given clusterID. The number of generated reserved managed
 	// kafkas in the cluster is the sum of the specified number of reserved
@@ -121,7 +134,10 @@ type KafkaService interface {
 	// Use this only when you want to update the multiple columns that may contain zero-fields, otherwise use the `KafkaService.Update()` method.
 	// See https://gorm.io/docs/update.html#Updates-multiple-columns for more info
 	Updates(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError
-	ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *errors.ServiceError)
+	// UpdatesIfUnmodified is like Updates but the update is only applied if the kafka hasn't been updated since the given
+	// kafka request was read, i.e. its updated_at column is unchanged. It returns a precondition failed error otherwise.
+	UpdatesIfUnmodified(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError
+	ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*CNameRecordStatus, *errors.ServiceError)
 	GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error)
 	AssignInstanceType(owner string, organisationID string) (types.KafkaInstanceType, *errors.ServiceError)
 	RegisterKafkaDeprovisionJob(ctx context.Context, id string) *errors.ServiceError
@@ -131,6 +147,9 @@ type KafkaService interface {
 	CountByStatus(status []constants.KafkaStatus) ([]KafkaStatusCount, error)
 	ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *errors.ServiceError)
 	VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// VerifyAndUpdateKafkaAdminIfUnmodified is like VerifyAndUpdateKafkaAdmin but the update is only applied if the kafka
+	// hasn't been updated since the given kafka request was read. It returns a precondition failed error otherwise.
+	VerifyAndUpdateKafkaAdminIfUnmodified(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
 	ListComponentVersions() ([]KafkaComponentVersions, error)
 	HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest) (bool, *errors.ServiceError)
 	// GetAvailableSizesInRegion returns a list of ids of the Kafka instance sizes that can still be created according to the specified criteria
@@ -159,20 +178,22 @@ type kafkaService struct {
 	awsConfig                            *config.AWSConfig
 	quotaServiceFactory                  QuotaServiceFactory
 	mu                                   sync.Mutex
-	awsClientFactory                     aws.ClientFactory
+	dnsConfig                            *config.DNSConfig
+	dnsProviderFactory                   dns.ProviderFactory
 	authService                          authorization.Authorization
 	dataplaneClusterConfig               *config.DataplaneClusterConfig
 	providerConfig                       *config.ProviderConfig
 	clusterPlacementStrategy             ClusterPlacementStrategy
 	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+	signalBus                            signalbus.SignalBus
 }
 
 func NewKafkaService(
 	connectionFactory *db.ConnectionFactory, clusterService ClusterService, keycloakService sso.KafkaKeycloakService,
 	kafkaConfig *config.KafkaConfig, dataplaneClusterConfig *config.DataplaneClusterConfig, awsConfig *config.AWSConfig,
-	quotaServiceFactory QuotaServiceFactory, awsClientFactory aws.ClientFactory, authorizationService authorization.Authorization,
+	dnsConfig *config.DNSConfig, quotaServiceFactory QuotaServiceFactory, dnsProviderFactory dns.ProviderFactory, authorizationService authorization.Authorization,
 	providerConfig *config.ProviderConfig, clusterPlacementStrategy ClusterPlacementStrategy,
-	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService) *kafkaService {
+	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService, signalBus signalbus.SignalBus) *kafkaService {
 	return &kafkaService{
 		connectionFactory:                    connectionFactory,
 		clusterService:                       clusterService,
@@ -180,12 +201,14 @@ func NewKafkaService(
 		kafkaConfig:                          kafkaConfig,
 		awsConfig:                            awsConfig,
 		quotaServiceFactory:                  quotaServiceFactory,
-		awsClientFactory:                     awsClientFactory,
+		dnsConfig:                            dnsConfig,
+		dnsProviderFactory:                   dnsProviderFactory,
 		authService:                          authorizationService,
 		dataplaneClusterConfig:               dataplaneClusterConfig,
 		providerConfig:                       providerConfig,
 		clusterPlacementStrategy:             clusterPlacementStrategy,
 		kafkaTLSCertificateManagementService: kafkaTLSCertificateManagementService,
+		signalBus:                            signalBus,
 	}
 }
 
@@ -481,6 +504,7 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 	}
 
 	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusAccepted, kafkaRequest.ID, kafkaRequest.ClusterID, time.Since(kafkaRequest.CreatedAt))
+	k.notifyStatusChange(kafkaRequest.ID)
 
 	return nil
 }
@@ -638,9 +662,10 @@ func (k *kafkaService) Get(ctx context.Context, id string) (*dbapi.KafkaRequest,
 		orgId, _ := claims.GetOrgId()
 		filterByOrganisationId := auth.GetFilterByOrganisationFromContext(ctx)
 
-		// filter by organisationId if a user is part of an organisation and is not allowed as a service account
+		// filter by organisationId if a user is part of an organisation and is not allowed as a service account.
+		// Kafka requests other organisations granted the organisation access to are included
 		if filterByOrganisationId {
-			dbConn = dbConn.Where("organisation_id = ?", orgId)
+			dbConn = dbConn.Where("organisation_id = ? OR id IN ("+grantedKafkaIDsQuery+")", orgId, orgId)
 		} else {
 			dbConn = dbConn.Where("owner = ?", user)
 		}
@@ -883,8 +908,9 @@ func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArgument
 
 		// filter by organisationId if a user is part of an organisation and is not allowed as a service account
 		if filterByOrganisationId {
-			// filter kafka requests by organisation_id since the user is allowed to see all kafka requests of my id
-			dbConn = dbConn.Where("organisation_id = ?", orgId)
+			// filter kafka requests by organisation_id since the user is allowed to see all kafka requests of my id,
+			// and the kafka requests other organisations granted the organisation access to
+			dbConn = dbConn.Where("organisation_id = ? OR id IN ("+grantedKafkaIDsQuery+")", orgId, orgId)
 		} else {
 			// filter kafka requests by owner as we are dealing with service accounts which may not have an org id
 			dbConn = dbConn.Where("owner = ?", user)
@@ -893,7 +919,7 @@ func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArgument
 
 	// Apply search query
 	if len(listArgs.Search) > 0 {
-		searchDbQuery, err := coreServices.NewQueryParser().Parse(listArgs.Search)
+		searchDbQuery, err := coreServices.NewQueryParserWithLabels("labels").Parse(listArgs.Search)
 		if err != nil {
 			return kafkaRequestList, pagingMeta, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list kafka requests: %s", err.Error())
 		}
@@ -938,39 +964,83 @@ func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string) ([]managedka
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka requests")
 	}
 
-	enableKafkaExternalCertificate := k.kafkaTLSCertificateManagementService.IsKafkaExternalCertificateEnabled()
-
 	var res []managedkafka.ManagedKafka
 	// convert kafka requests to managed kafka
 	for _, kafkaRequest := range kafkaRequestList {
-		var getCertificateErr error
-		var certificate kafkatlscertmgmt.Certificate
+		mk, err := k.buildManagedKafkaCRWithCertificate(kafkaRequest)
+		if err != nil {
+			return nil, err
+		}
 
-		if enableKafkaExternalCertificate { // only fetch certs when Kafka external certificates is enabled
-			certRequest := kafkatlscertmgmt.GetCertificateRequest{
-				TLSCertRef: kafkaRequest.KafkasRoutesBaseDomainTLSCrtRef,
-				TLSKeyRef:  kafkaRequest.KafkasRoutesBaseDomainTLSKeyRef,
-			}
+		res = append(res, *mk)
+	}
 
-			certificate, getCertificateErr = k.kafkaTLSCertificateManagementService.GetCertificate(context.Background(), certRequest)
-			if getCertificateErr != nil {
-				logger.Logger.V(10).Infof("failed to find TLS certificate for kafka with id %q in the data plane cluster with id %q. The corresponding ManagedKafkaCR will be paused for reconciliation", kafkaRequest.ID, clusterID)
-			}
-		}
+	return res, nil
+}
 
-		mk, err := buildManagedKafkaCR(kafkaRequest, k.kafkaConfig, k.keycloakService, certificate, enableKafkaExternalCertificate)
-		if err != nil {
-			return nil, err
+func (k *kafkaService) GetManagedKafkaByID(id string) (*managedkafka.ManagedKafka, *errors.ServiceError) {
+	kafkaRequest, err := k.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.buildManagedKafkaCRWithCertificate(kafkaRequest)
+}
+
+// buildManagedKafkaCRWithCertificate builds the ManagedKafka CR of a kafka request with its TLS certificate when
+// Kafka external certificates are enabled. The CR is paused for reconciliation when the certificate can't be found.
+func (k *kafkaService) buildManagedKafkaCRWithCertificate(kafkaRequest *dbapi.KafkaRequest) (*managedkafka.ManagedKafka, *errors.ServiceError) {
+	enableKafkaExternalCertificate := k.kafkaTLSCertificateManagementService.IsKafkaExternalCertificateEnabled()
+
+	var getCertificateErr error
+	var certificate kafkatlscertmgmt.Certificate
+
+	if enableKafkaExternalCertificate { // only fetch certs when Kafka external certificates is enabled
+		certRequest := kafkatlscertmgmt.GetCertificateRequest{
+			TLSCertRef: kafkaRequest.KafkasRoutesBaseDomainTLSCrtRef,
+			TLSKeyRef:  kafkaRequest.KafkasRoutesBaseDomainTLSKeyRef,
 		}
 
-		if getCertificateErr != nil { // indicate that the ManagedKafkaCR can be paused for reconciliation in the database
-			mk.Annotations[managedkafka.ManagedKafkaBf2PauseReconciliationAnnotationKey] = "true"
+		certificate, getCertificateErr = k.kafkaTLSCertificateManagementService.GetCertificate(context.Background(), certRequest)
+		if getCertificateErr != nil {
+			logger.Logger.V(10).Infof("failed to find TLS certificate for kafka with id %q in the data plane cluster with id %q. The corresponding ManagedKafkaCR will be paused for reconciliation", kafkaRequest.ID, kafkaRequest.ClusterID)
 		}
+	}
 
-		res = append(res, *mk)
+	serviceAccountBindings, err := k.listKafkaServiceAccountBindings(kafkaRequest)
+	if err != nil {
+		return nil, err
 	}
 
-	return res, nil
+	mk, err := buildManagedKafkaCR(kafkaRequest, k.kafkaConfig, k.keycloakService, certificate, enableKafkaExternalCertificate, serviceAccountBindings)
+	if err != nil {
+		return nil, err
+	}
+
+	if getCertificateErr != nil { // indicate that the ManagedKafkaCR can be paused for reconciliation in the database
+		mk.Annotations[managedkafka.ManagedKafkaBf2PauseReconciliationAnnotationKey] = "true"
+	}
+
+	return mk, nil
+}
+
+// listKafkaServiceAccountBindings lists the bindings of all the scoped service accounts of the organisation owning the
+// kafka request and of the organisations granted access to it, whatever the resources they are bound to: a service
+// account only bound to connector clusters or to the instances of another organisation must be denied on the Kafka
+// instance too. They are only needed when the authentication is enabled on the Kafka instances.
+func (k *kafkaService) listKafkaServiceAccountBindings(kafkaRequest *dbapi.KafkaRequest) (dbapi.ServiceAccountBindingList, *errors.ServiceError) {
+	if kafkaRequest.OrganisationId == "" || !k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
+		return nil, nil
+	}
+
+	var bindings dbapi.ServiceAccountBindingList
+	if err := k.connectionFactory.New().
+		Where("organisation_id = ? OR organisation_id IN ("+granteeOrganisationIDsQuery+")", kafkaRequest.OrganisationId, kafkaRequest.ID).
+		Order("created_at").
+		Find(&bindings).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the service account bindings of kafka %s", kafkaRequest.ID)
+	}
+	return bindings, nil
 }
 
 func (k *kafkaService) GenerateReservedManagedKafkasByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
@@ -1019,27 +1089,45 @@ func (k *kafkaService) GenerateReservedManagedKafkasByClusterID(clusterID string
 }
 
 func (k *kafkaService) Update(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
-	dbConn := k.connectionFactory.New().
-		Model(kafkaRequest).
-		Where("status not IN (?)", kafkaDeletionStatuses) // ignore updates of kafka under deletion
-
-	if err := dbConn.Updates(kafkaRequest).Error; err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
+	update := func(dbConn *gorm.DB) *errors.ServiceError {
+		if err := dbConn.Model(kafkaRequest).
+			Where("status not IN (?)", kafkaDeletionStatuses). // ignore updates of kafka under deletion
+			Updates(kafkaRequest).Error; err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
+		}
+		return nil
 	}
-
-	return nil
+	if kafkaRequest.Status == "" {
+		return update(k.connectionFactory.New())
+	}
+	return k.updateAndNotify(kafkaRequest, update)
 }
 
 func (k *kafkaService) Updates(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
-	dbConn := k.connectionFactory.New().
-		Model(kafkaRequest).
-		Where("status not IN (?)", kafkaDeletionStatuses) // ignore updates of kafka under deletion
-
-	if err := dbConn.Updates(fields).Error; err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
+	update := func(dbConn *gorm.DB) *errors.ServiceError {
+		if err := dbConn.Model(kafkaRequest).
+			Where("status not IN (?)", kafkaDeletionStatuses). // ignore updates of kafka under deletion
+			Updates(fields).Error; err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
+		}
+		return nil
 	}
+	if _, ok := fields["status"]; !ok {
+		return update(k.connectionFactory.New())
+	}
+	return k.updateAndNotify(kafkaRequest, update)
+}
 
-	return nil
+func (k *kafkaService) UpdatesIfUnmodified(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
+	update := func(dbConn *gorm.DB) *errors.ServiceError {
+		return updatesIfUnmodified(dbConn.Model(kafkaRequest).
+			Where("status not IN (?)", kafkaDeletionStatuses), // ignore updates of kafka under deletion
+			kafkaRequest, fields)
+	}
+	if _, ok := fields["status"]; !ok {
+		return update(k.connectionFactory.New())
+	}
+	return k.updateAndNotify(kafkaRequest, update)
 }
 
 func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
@@ -1047,22 +1135,74 @@ func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaReque
 		return errors.New(errors.ErrorUnauthenticated, "user not authenticated")
 	}
 
-	// only updated specified columns to avoid changing other columns e.g Status
-	updatableFields := map[string]interface{}{
+	return k.updateAndNotify(kafkaRequest, func(dbConn *gorm.DB) *errors.ServiceError {
+		if err := dbConn.Model(kafkaRequest).Updates(adminUpdatableFields(kafkaRequest)).Error; err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
+		}
+		return nil
+	})
+}
+
+func (k *kafkaService) VerifyAndUpdateKafkaAdminIfUnmodified(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	if !auth.GetIsAdminFromContext(ctx) {
+		return errors.New(errors.ErrorUnauthenticated, "user not authenticated")
+	}
+
+	return k.updateAndNotify(kafkaRequest, func(dbConn *gorm.DB) *errors.ServiceError {
+		return updatesIfUnmodified(dbConn.Model(kafkaRequest), kafkaRequest, adminUpdatableFields(kafkaRequest))
+	})
+}
+
+// updateAndNotify applies an update writing the status of the kafka request, and notifies the workers only if the
+// stored status changed. The kafka request is locked until the update is committed, so that the status it is compared
+// with can't be changed concurrently
+func (k *kafkaService) updateAndNotify(kafkaRequest *dbapi.KafkaRequest, update func(dbConn *gorm.DB) *errors.ServiceError) *errors.ServiceError {
+	var previous, current dbapi.KafkaRequest
+	var serr *errors.ServiceError
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+		if err := dbConn.Clauses(clause.Locking{Strength: "UPDATE"}).Select("status").
+			Where("id = ?", kafkaRequest.ID).Find(&previous).Error; err != nil {
+			return err
+		}
+		if serr = update(dbConn); serr != nil {
+			return serr
+		}
+		return dbConn.Select("status").Where("id = ?", kafkaRequest.ID).Find(&current).Error
+	}); err != nil {
+		if serr != nil {
+			return serr
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
+	}
+	if current.Status != previous.Status {
+		k.notifyStatusChange(kafkaRequest.ID)
+	}
+
+	return nil
+}
+
+// adminUpdatableFields returns the columns of the kafka that can be updated through the admin API. Only these columns
+// are updated to avoid changing the other ones e.g Status
+func adminUpdatableFields(kafkaRequest *dbapi.KafkaRequest) map[string]interface{} {
+	return map[string]interface{}{
 		"max_data_retention_size":   kafkaRequest.MaxDataRetentionSize,
 		"desired_strimzi_version":   kafkaRequest.DesiredStrimziVersion,
 		"desired_kafka_version":     kafkaRequest.DesiredKafkaVersion,
 		"desired_kafka_ibp_version": kafkaRequest.DesiredKafkaIBPVersion,
 		"status":                    kafkaRequest.Status,
 	}
+}
 
-	dbConn := k.connectionFactory.New().
-		Model(kafkaRequest)
-
-	if err := dbConn.Updates(updatableFields).Error; err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
+// updatesIfUnmodified updates the given fields of the kafka in the same statement that checks its updated_at column
+// still has the value it had when the kafka request was read, so that a concurrent update can't be overwritten
+func updatesIfUnmodified(dbConn *gorm.DB, kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
+	result := dbConn.Where("updated_at = ?", kafkaRequest.UpdatedAt).Updates(fields)
+	if result.Error != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to update kafka")
+	}
+	if result.RowsAffected == 0 {
+		return errors.PreconditionFailed("kafka %s has been updated since it was read", kafkaRequest.ID)
 	}
-
 	return nil
 }
 
@@ -1086,66 +1226,143 @@ func (k *kafkaService) UpdateStatus(id string, status constants.KafkaStatus) (bo
 	if err := dbConn.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: id}}).Update("status", status).Error; err != nil {
 		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka status")
 	}
+	k.notifyStatusChange(id)
 
 	return true, nil
 }
 
-func (k *kafkaService) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *errors.ServiceError) {
-	routes, err := kafkaRequest.GetRoutes()
-	if routes == nil || err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")
+// ValidateKafkaCanBeSuspended checks that a kafka can be suspended, only ready kafkas can be
+func ValidateKafkaCanBeSuspended(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	suspendableStates := []string{constants.KafkaRequestStatusReady.String()}
+	isSuspendableState := arrays.Contains(suspendableStates, kafkaRequest.Status)
+	if !isSuspendableState {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be suspended. Kafka instances can only be suspended in the following states: %s", kafkaRequest.Status, suspendableStates)
 	}
+	return nil
+}
 
-	domainRecordBatch := buildKafkaClusterCNAMESRecordBatch(routes, action)
+// ValidateKafkaCanBeResumed checks that a kafka can be resumed, only suspended kafkas that are not within the grace
+// period before their expiration can be
+func ValidateKafkaCanBeResumed(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig) *errors.ServiceError {
+	resumableStates := []string{constants.KafkaRequestStatusSuspended.String()}
+	isResumableState := arrays.Contains(resumableStates, kafkaRequest.Status)
+	if !isResumableState {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed. Kafka instances can only be resumed in the following states: %s", kafkaRequest.Status, resumableStates)
+	}
 
-	awsConfig := aws.Config{
-		AccessKeyID:     k.awsConfig.Route53.AccessKey,
-		SecretAccessKey: k.awsConfig.Route53.SecretAccessKey,
+	kafkaRequestHasExpirationSet := kafkaRequest.ExpiresAt.Valid
+	if !kafkaRequestHasExpirationSet {
+		return nil
 	}
 
-	route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
+	timeNow := time.Now()
+	kafkaBillingModelConfig, err := kafkaConfig.GetBillingModelByID(kafkaRequest.InstanceType, kafkaRequest.ActualKafkaBillingModel)
 	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")
+		return errors.ToServiceError(err)
+	}
+	gracePeriodDays := kafkaBillingModelConfig.GracePeriodDays
+	durationGracePeriodDays := time.Duration(gracePeriodDays*86400) * time.Second
+	startOfGracePeriod := kafkaRequest.ExpiresAt.Time.Add(-durationGracePeriodDays)
+	isWithinOrAfterGracePeriod := timeNow.After(startOfGracePeriod)
+	if isWithinOrAfterGracePeriod {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed due to the instance is suspended and it is within its grace period: start of grace period: %s ", kafkaRequest.Status, startOfGracePeriod)
+	}
+
+	return nil
+}
+
+// notifyStatusChange notifies the workers that the status of the kafka request changed, so that they reconcile it
+// without waiting for their next periodic reconcile
+func (k *kafkaService) notifyStatusChange(id string) {
+	if k.signalBus != nil && id != "" {
+		k.signalBus.NotifyPayload(KafkaStatusSignal, id)
+	}
+}
+
+func (k *kafkaService) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*CNameRecordStatus, *errors.ServiceError) {
+	routes, err := kafkaRequest.GetRoutes()
+	if routes == nil || err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")
 	}
 
-	awsClient, err := k.awsClientFactory.NewClient(awsConfig, route53Region)
+	dnsProvider, err := k.getDNSProvider(kafkaRequest)
 	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create aws client")
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create dns provider")
 	}
 
-	changeRecordsOutput, err := awsClient.ChangeResourceRecordSets(k.kafkaConfig.KafkaDomainName, domainRecordBatch)
+	changeStatus, err := dnsProvider.ChangeRecords(k.kafkaConfig.KafkaDomainName, buildKafkaClusterCNAMERecordChanges(routes, action))
 	if err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create domain record sets")
 	}
 
-	return changeRecordsOutput, nil
+	return toCNameRecordStatus(changeStatus), nil
 }
 
 func (k *kafkaService) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
-	awsConfig := aws.Config{
-		AccessKeyID:     k.awsConfig.Route53.AccessKey,
-		SecretAccessKey: k.awsConfig.Route53.SecretAccessKey,
+	dnsProvider, err := k.getDNSProvider(kafkaRequest)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create dns provider")
 	}
 
-	route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
+	changeStatus, err := dnsProvider.GetChange(kafkaRequest.RoutesCreationId)
 	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to get status of dns change request with ID %q", kafkaRequest.RoutesCreationId)
 	}
 
-	awsClient, err := k.awsClientFactory.NewClient(awsConfig, route53Region)
-	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create aws client")
+	return toCNameRecordStatus(changeStatus), nil
+}
+
+// getDNSProvider returns the DNS provider configured for the routes base domain or the cloud provider of the kafka request
+func (k *kafkaService) getDNSProvider(kafkaRequest *dbapi.KafkaRequest) (dns.Provider, error) {
+	domain := kafkaRequest.KafkasRoutesBaseDomainName
+	if domain == "" {
+		domain = k.kafkaConfig.KafkaDomainName
 	}
 
-	changeOutput, err := awsClient.GetChange(kafkaRequest.RoutesCreationId)
-	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to get status of Route53 change batch request with ID %q", kafkaRequest.RoutesCreationId)
+	providerConfig := dns.ProviderConfig{
+		Type: k.dnsConfig.GetProviderType(kafkaRequest.CloudProvider, domain),
+	}
+	switch providerConfig.Type {
+	case dns.ProviderTypeRoute53:
+		route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")
+		}
+		providerConfig.Route53 = dns.Route53Config{
+			Credentials: aws.Config{
+				AccessKeyID:     k.awsConfig.Route53.AccessKey,
+				SecretAccessKey: k.awsConfig.Route53.SecretAccessKey,
+			},
+			Region: route53Region,
+		}
+	case dns.ProviderTypeCloudDNS:
+		providerConfig.CloudDNS = dns.CloudDNSConfig{
+			Credentials: k.dnsConfig.CloudDNS.Credentials,
+			ProjectID:   k.dnsConfig.CloudDNS.ProjectID,
+			ManagedZone: k.dnsConfig.CloudDNS.ManagedZone,
+		}
+	case dns.ProviderTypeRFC2136:
+		providerConfig.RFC2136 = dns.RFC2136Config{
+			Server:        k.dnsConfig.RFC2136.Server,
+			Zone:          k.dnsConfig.RFC2136.Zone,
+			TSIGKeyName:   k.dnsConfig.RFC2136.TSIGKeyName,
+			TSIGSecret:    k.dnsConfig.RFC2136.TSIGSecret,
+			TSIGAlgorithm: k.dnsConfig.RFC2136.TSIGAlgorithm,
+		}
 	}
 
+	return k.dnsProviderFactory.NewProvider(providerConfig)
+}
+
+func toCNameRecordStatus(changeStatus *dns.ChangeStatus) *CNameRecordStatus {
+	status := CNameRecordStatusPending
+	if changeStatus.InSync {
+		status = CNameRecordStatusInSync
+	}
 	return &CNameRecordStatus{
-		Id:     changeOutput.ChangeInfo.Id,
-		Status: changeOutput.ChangeInfo.Status,
-	}, nil
+		Id:     &changeStatus.Id,
+		Status: &status,
+	}
 }
 
 type KafkaStatusCount struct {
@@ -1211,7 +1428,8 @@ func (k *kafkaService) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest,
 
 func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig, keycloakService sso.KeycloakService,
 	certificates kafkatlscertmgmt.Certificate,
-	enableKafkaExternalCertificate bool) (*managedkafka.ManagedKafka, *errors.ServiceError) {
+	enableKafkaExternalCertificate bool,
+	serviceAccountBindings dbapi.ServiceAccountBindingList) (*managedkafka.ManagedKafka, *errors.ServiceError) {
 	k, err := kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.SizeId)
 	if err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka request")
@@ -1291,6 +1509,7 @@ func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 			Password:  kafkaRequest.CanaryServiceAccountClientSecret,
 		})
 		managedKafkaCR.Spec.ServiceAccounts = serviceAccounts
+		managedKafkaCR.Spec.ServiceAccountScopes = buildServiceAccountScopes(kafkaRequest, serviceAccountBindings)
 	}
 
 	if enableKafkaExternalCertificate {
@@ -1367,6 +1586,37 @@ func (k *kafkaService) buildReservedManagedKafkaCR(kafkaID, instanceType, stream
 	return managedKafkaCR, nil
 }
 
+// buildServiceAccountScopes builds the service account scopes of a Kafka instance from the bindings of the scoped
+// service accounts of its organisation and of the organisations granted access to it. The service accounts bound to the
+// instance are granted their role, all the others are denied, whether they are bound to other Kafka instances or to
+// connector clusters only.
+func buildServiceAccountScopes(kafkaRequest *dbapi.KafkaRequest, serviceAccountBindings dbapi.ServiceAccountBindingList) *managedkafka.ServiceAccountScopesSpec {
+	if len(serviceAccountBindings) == 0 {
+		return nil
+	}
+
+	scopes := &managedkafka.ServiceAccountScopesSpec{
+		Bindings:         []managedkafka.ServiceAccountBinding{},
+		DeniedPrincipals: []string{},
+	}
+	bound := map[string]bool{}
+	for _, binding := range serviceAccountBindings {
+		if binding.ResourceType == dbapi.ServiceAccountBindingResourceTypeKafka && binding.ResourceID == kafkaRequest.ID && !bound[binding.ClientID] {
+			bound[binding.ClientID] = true
+			scopes.Bindings = append(scopes.Bindings, managedkafka.ServiceAccountBinding{
+				Principal: binding.ClientID,
+				Role:      binding.Role.String(),
+			})
+		}
+	}
+	for _, binding := range serviceAccountBindings {
+		if !bound[binding.ClientID] && !arrays.Contains(scopes.DeniedPrincipals, binding.ClientID) {
+			scopes.DeniedPrincipals = append(scopes.DeniedPrincipals, binding.ClientID)
+		}
+	}
+	return scopes
+}
+
 func buildKafkaOwner(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig) []string {
 	if kafkaConfig.EnableKafkaOwnerConfig {
 		return append([]string{kafkaRequest.Owner}, kafkaConfig.KafkaOwnerList...)
@@ -1376,39 +1626,21 @@ func buildKafkaOwner(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.Kafka
 	}
 }
 
-func buildKafkaClusterCNAMESRecordBatch(routes []dbapi.DataPlaneKafkaRoute, action KafkaRoutesAction) *route53.ChangeBatch {
-	var changes []*route53.Change
+func buildKafkaClusterCNAMERecordChanges(routes []dbapi.DataPlaneKafkaRoute, action KafkaRoutesAction) []dns.Change {
+	var changes []dns.Change
 	for _, r := range routes {
-		c := buildResourceRecordChange(r.Domain, r.Router, action)
-		changes = append(changes, c)
-	}
-	recordChangeBatch := &route53.ChangeBatch{
-		Changes: changes,
-	}
-
-	return recordChangeBatch
-}
-
-func buildResourceRecordChange(recordName string, clusterIngress string, action KafkaRoutesAction) *route53.Change {
-	recordType := "CNAME"
-	recordTTL := int64(300)
-
-	actionStr := action.String()
-	resourceRecordChange := &route53.Change{
-		Action: &actionStr,
-		ResourceRecordSet: &route53.ResourceRecordSet{
-			Name: &recordName,
-			Type: &recordType,
-			TTL:  &recordTTL,
-			ResourceRecords: []*route53.ResourceRecord{
-				{
-					Value: &clusterIngress,
-				},
+		changes = append(changes, dns.Change{
+			Action: dns.ChangeAction(action.String()),
+			Record: dns.Record{
+				Name:  r.Domain,
+				Type:  dns.RecordTypeCNAME,
+				TTL:   dns.DefaultTTL,
+				Value: r.Router,
 			},
-		},
+		})
 	}
 
-	return resourceRecordChange
+	return changes
 }
 
 func (k *kafkaService) AssignBootstrapServerHost(kafkaRequest *dbapi.KafkaRequest) error {
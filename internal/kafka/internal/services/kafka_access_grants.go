@@ -0,0 +1,123 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
+)
+
+// grantedKafkaIDsQuery selects the IDs of the Kafka instances an organisation has been granted access to
+const grantedKafkaIDsQuery = "SELECT kafka_id FROM kafka_access_grants WHERE organisation_id = ? AND deleted_at IS NULL"
+
+// granteeOrganisationIDsQuery selects the IDs of the organisations granted access to a Kafka instance
+const granteeOrganisationIDsQuery = "SELECT organisation_id FROM kafka_access_grants WHERE kafka_id = ? AND deleted_at IS NULL"
+
+var _ KafkaAccessGrantService = &kafkaAccessGrantService{}
+var _ kafkaaccess.ConnectorBindingValidator = &kafkaAccessGrantService{}
+var _ kafkaaccess.KafkaLocator = &kafkaAccessGrantService{}
+
+//go:generate moq -out kafka_access_grants_moq.go . KafkaAccessGrantService
+type KafkaAccessGrantService interface {
+	// Create grants the access to a Kafka instance to the organisation of the grant, an organisation has at most one
+	// active grant per Kafka instance
+	Create(kafkaRequest *dbapi.KafkaRequest, grant *dbapi.KafkaAccessGrant) *errors.ServiceError
+	List(kafkaID string) (dbapi.KafkaAccessGrantList, *errors.ServiceError)
+	Revoke(kafkaID string, grantID string) *errors.ServiceError
+	ValidateConnectorBinding(kafkaID string, orgID string) *errors.ServiceError
+}
+
+type kafkaAccessGrantService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewKafkaAccessGrantService(connectionFactory *db.ConnectionFactory) *kafkaAccessGrantService {
+	return &kafkaAccessGrantService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (k *kafkaAccessGrantService) Create(kafkaRequest *dbapi.KafkaRequest, grant *dbapi.KafkaAccessGrant) *errors.ServiceError {
+	if grant.OrganisationId == kafkaRequest.OrganisationId {
+		return errors.BadRequest("organisation %s already owns kafka %s", grant.OrganisationId, kafkaRequest.ID)
+	}
+
+	dbConn := k.connectionFactory.New()
+	var count int64
+	if err := dbConn.Model(&dbapi.KafkaAccessGrant{}).
+		Where("kafka_id = ? AND organisation_id = ?", kafkaRequest.ID, grant.OrganisationId).
+		Count(&count).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to check existing access grants of kafka %s", kafkaRequest.ID)
+	}
+	if count > 0 {
+		return errors.Conflict("organisation %s has already been granted access to kafka %s", grant.OrganisationId, kafkaRequest.ID)
+	}
+
+	grant.ID = api.NewID()
+	grant.KafkaID = kafkaRequest.ID
+	if err := dbConn.Create(grant).Error; err != nil {
+		// the unique index rejects concurrent requests granting the same access
+		if serr := services.HandleCreateError("KafkaAccessGrant", err); !serr.IsConflict() {
+			return serr
+		}
+		return errors.Conflict("organisation %s has already been granted access to kafka %s", grant.OrganisationId, kafkaRequest.ID)
+	}
+	return nil
+}
+
+func (k *kafkaAccessGrantService) List(kafkaID string) (dbapi.KafkaAccessGrantList, *errors.ServiceError) {
+	var grants dbapi.KafkaAccessGrantList
+	if err := k.connectionFactory.New().
+		Where("kafka_id = ?", kafkaID).
+		Order("created_at").
+		Find(&grants).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list access grants of kafka %s", kafkaID)
+	}
+	return grants, nil
+}
+
+func (k *kafkaAccessGrantService) Revoke(kafkaID string, grantID string) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+	var grant dbapi.KafkaAccessGrant
+	if err := dbConn.Where("id = ? AND kafka_id = ?", grantID, kafkaID).First(&grant).Error; err != nil {
+		return services.HandleGetError("KafkaAccessGrant", "id", grantID, err)
+	}
+	if err := dbConn.Delete(&grant).Error; err != nil {
+		return services.HandleDeleteError("KafkaAccessGrant", "id", grantID, err)
+	}
+	return nil
+}
+
+// ValidateConnectorBinding checks that the organisation owns the Kafka instance or has been granted the access to
+// attach connectors to it. Kafka instances the organisation has no access to are reported as not found.
+func (k *kafkaAccessGrantService) ValidateConnectorBinding(kafkaID string, orgID string) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+	var kafkaRequest dbapi.KafkaRequest
+	if err := dbConn.Where("id = ?", kafkaID).First(&kafkaRequest).Error; err != nil {
+		return services.HandleGetError("KafkaResource", "id", kafkaID, err)
+	}
+	if orgID != "" && kafkaRequest.OrganisationId == orgID {
+		return nil
+	}
+
+	var grant dbapi.KafkaAccessGrant
+	if err := dbConn.Where("kafka_id = ? AND organisation_id = ?", kafkaID, orgID).First(&grant).Error; err != nil {
+		return services.HandleGetError("KafkaResource", "id", kafkaID, err)
+	}
+	if !grant.AllowsConnectorBinding() {
+		return errors.Forbidden("organisation %s is not allowed to attach connectors to kafka %s", orgID, kafkaID)
+	}
+	return nil
+}
+
+// GetKafkaLocation returns the cloud provider and region of a Kafka instance
+func (k *kafkaAccessGrantService) GetKafkaLocation(kafkaID string) (string, string, *errors.ServiceError) {
+	var kafkaRequest dbapi.KafkaRequest
+	if err := k.connectionFactory.New().Select("cloud_provider", "region").
+		Where("id = ?", kafkaID).First(&kafkaRequest).Error; err != nil {
+		return "", "", services.HandleGetError("KafkaResource", "id", kafkaID, err)
+	}
+	return kafkaRequest.CloudProvider, kafkaRequest.Region, nil
+}
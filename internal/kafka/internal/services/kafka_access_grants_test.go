@@ -0,0 +1,221 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+const (
+	testOwnerOrgID   = "owner-org"
+	testGranteeOrgID = "grantee-org"
+	testGrantID      = "test-grant"
+)
+
+func Test_kafkaAccessGrantService_Create(t *testing.T) {
+	kafkaRequest := &dbapi.KafkaRequest{
+		Meta:           api.Meta{ID: testID},
+		OrganisationId: testOwnerOrgID,
+	}
+
+	tests := []struct {
+		name     string
+		grant    *dbapi.KafkaAccessGrant
+		setupFn  func()
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name:     "should fail when granting access to the owning organisation",
+			grant:    &dbapi.KafkaAccessGrant{OrganisationId: testOwnerOrgID, AccessLevel: dbapi.KafkaAccessLevelReadOnly},
+			wantCode: errors.ErrorBadRequest,
+		},
+		{
+			name:  "should fail when the organisation has already been granted access",
+			grant: &dbapi.KafkaAccessGrant{OrganisationId: testGranteeOrgID, AccessLevel: dbapi.KafkaAccessLevelReadOnly},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_access_grants" WHERE (kafka_id = $1 AND organisation_id = $2)`).
+					WithArgs(testID, testGranteeOrgID).
+					WithReply([]map[string]interface{}{{"count": 1}})
+			},
+			wantCode: errors.ErrorConflict,
+		},
+		{
+			name:  "should fail when a concurrent request granted the access",
+			grant: &dbapi.KafkaAccessGrant{OrganisationId: testGranteeOrgID, AccessLevel: dbapi.KafkaAccessLevelReadOnly},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_access_grants"`).
+					WithReply([]map[string]interface{}{{"count": 0}})
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_access_grants"`).
+					WithError(fmt.Errorf(`ERROR: duplicate key value violates unique constraint "uix_kafka_access_grants_kafka_id_organisation_id" (SQLSTATE 23505)`))
+			},
+			wantCode: errors.ErrorConflict,
+		},
+		{
+			name:  "should fail when the grant can't be stored",
+			grant: &dbapi.KafkaAccessGrant{OrganisationId: testGranteeOrgID, AccessLevel: dbapi.KafkaAccessLevelReadOnly},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_access_grants"`).
+					WithReply([]map[string]interface{}{{"count": 0}})
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_access_grants"`).WithExecException()
+			},
+			wantCode: errors.ErrorGeneral,
+		},
+		{
+			name:  "should create the grant",
+			grant: &dbapi.KafkaAccessGrant{OrganisationId: testGranteeOrgID, AccessLevel: dbapi.KafkaAccessLevelConnectorBinding},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_access_grants"`).
+					WithReply([]map[string]interface{}{{"count": 0}})
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_access_grants"`)
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := NewKafkaAccessGrantService(db.NewMockConnectionFactory(nil))
+			err := k.Create(kafkaRequest, tt.grant)
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(tt.grant.ID).ToNot(gomega.BeEmpty())
+			g.Expect(tt.grant.KafkaID).To(gomega.Equal(testID))
+		})
+	}
+}
+
+func Test_kafkaAccessGrantService_Revoke(t *testing.T) {
+	tests := []struct {
+		name     string
+		setupFn  func()
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name:     "should fail when the grant does not exist",
+			wantCode: errors.ErrorNotFound,
+		},
+		{
+			name: "should soft delete the grant",
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_access_grants" WHERE (id = $1 AND kafka_id = $2)`).
+					WithArgs(testGrantID, testID).
+					WithReply([]map[string]interface{}{{"id": testGrantID, "kafka_id": testID}})
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_access_grants" SET "deleted_at"=`)
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := NewKafkaAccessGrantService(db.NewMockConnectionFactory(nil))
+			err := k.Revoke(testID, testGrantID)
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+		})
+	}
+}
+
+func Test_kafkaAccessGrantService_ValidateConnectorBinding(t *testing.T) {
+	mockKafka := func() {
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+			WithArgs(testID).
+			WithReply([]map[string]interface{}{{"id": testID, "organisation_id": testOwnerOrgID}})
+	}
+	mockGrant := func(accessLevel dbapi.KafkaAccessLevel) {
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT * FROM "kafka_access_grants" WHERE (kafka_id = $1 AND organisation_id = $2)`).
+			WithArgs(testID, testGranteeOrgID).
+			WithReply([]map[string]interface{}{{"id": testGrantID, "kafka_id": testID, "organisation_id": testGranteeOrgID, "access_level": accessLevel.String()}})
+	}
+
+	tests := []struct {
+		name     string
+		orgID    string
+		setupFn  func()
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name:     "should fail when the kafka does not exist",
+			orgID:    testOwnerOrgID,
+			wantCode: errors.ErrorNotFound,
+		},
+		{
+			name:    "should allow the owning organisation",
+			orgID:   testOwnerOrgID,
+			setupFn: mockKafka,
+		},
+		{
+			name:  "should allow an organisation granted connector binding access",
+			orgID: testGranteeOrgID,
+			setupFn: func() {
+				mockKafka()
+				mockGrant(dbapi.KafkaAccessLevelConnectorBinding)
+			},
+		},
+		{
+			name:  "should forbid an organisation granted read only access",
+			orgID: testGranteeOrgID,
+			setupFn: func() {
+				mockKafka()
+				mockGrant(dbapi.KafkaAccessLevelReadOnly)
+			},
+			wantCode: errors.ErrorForbidden,
+		},
+		{
+			name:     "should report the kafka as not found to an organisation without access",
+			orgID:    testGranteeOrgID,
+			setupFn:  mockKafka,
+			wantCode: errors.ErrorNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := NewKafkaAccessGrantService(db.NewMockConnectionFactory(nil))
+			err := k.ValidateConnectorBinding(testID, tt.orgID)
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+)
+
+var _ KafkaTemplateService = &kafkaTemplateService{}
+
+// KafkaTemplateService keeps the Kafka templates of the organisations. Templates are only visible to the
+// organisation they belong to.
+//
+//go:generate moq -out kafka_templates_moq.go . KafkaTemplateService
+type KafkaTemplateService interface {
+	// Create creates a template, the name of the templates is unique within an organisation
+	Create(template *dbapi.KafkaTemplate) *errors.ServiceError
+	Get(orgId string, id string) (*dbapi.KafkaTemplate, *errors.ServiceError)
+	List(orgId string) (dbapi.KafkaTemplateList, *errors.ServiceError)
+	Delete(orgId string, id string) *errors.ServiceError
+}
+
+type kafkaTemplateService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewKafkaTemplateService(connectionFactory *db.ConnectionFactory) *kafkaTemplateService {
+	return &kafkaTemplateService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (k *kafkaTemplateService) Create(template *dbapi.KafkaTemplate) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+	var count int64
+	if err := dbConn.Model(&dbapi.KafkaTemplate{}).
+		Where("organisation_id = ? AND name = ?", template.OrganisationId, template.Name).
+		Count(&count).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to check existing kafka templates of organisation %s", template.OrganisationId)
+	}
+	if count > 0 {
+		return errors.Conflict("kafka template %s already exists in organisation %s", template.Name, template.OrganisationId)
+	}
+
+	template.ID = api.NewID()
+	if err := dbConn.Create(template).Error; err != nil {
+		// the unique index rejects concurrent requests creating a template with the same name
+		if serr := services.HandleCreateError("KafkaTemplate", err); !serr.IsConflict() {
+			return serr
+		}
+		return errors.Conflict("kafka template %s already exists in organisation %s", template.Name, template.OrganisationId)
+	}
+	return nil
+}
+
+func (k *kafkaTemplateService) Get(orgId string, id string) (*dbapi.KafkaTemplate, *errors.ServiceError) {
+	var template dbapi.KafkaTemplate
+	if err := k.connectionFactory.New().Where("id = ? AND organisation_id = ?", id, orgId).First(&template).Error; err != nil {
+		return nil, services.HandleGetError("KafkaTemplate", "id", id, err)
+	}
+	return &template, nil
+}
+
+func (k *kafkaTemplateService) List(orgId string) (dbapi.KafkaTemplateList, *errors.ServiceError) {
+	var templates dbapi.KafkaTemplateList
+	if err := k.connectionFactory.New().
+		Where("organisation_id = ?", orgId).
+		Order("name").
+		Find(&templates).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka templates of organisation %s", orgId)
+	}
+	return templates, nil
+}
+
+func (k *kafkaTemplateService) Delete(orgId string, id string) *errors.ServiceError {
+	template, err := k.Get(orgId, id)
+	if err != nil {
+		return err
+	}
+	if err := k.connectionFactory.New().Delete(template).Error; err != nil {
+		return services.HandleDeleteError("KafkaTemplate", "id", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+const (
+	testTemplateOrgID = "template-org"
+	testTemplateID    = "test-template"
+)
+
+func Test_kafkaTemplateService_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		setupFn  func()
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name: "should fail when a template with the same name exists in the organisation",
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_templates" WHERE (organisation_id = $1 AND name = $2)`).
+					WithArgs(testTemplateOrgID, "dev").
+					WithReply([]map[string]interface{}{{"count": 1}})
+			},
+			wantCode: errors.ErrorConflict,
+		},
+		{
+			name: "should fail when a template with the same name is created concurrently",
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_templates"`).
+					WithReply([]map[string]interface{}{{"count": 0}})
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_templates"`).
+					WithError(fmt.Errorf(`ERROR: duplicate key value violates unique constraint "uix_kafka_templates_organisation_id_name" (SQLSTATE 23505)`))
+			},
+			wantCode: errors.ErrorConflict,
+		},
+		{
+			name: "should fail when the template can't be stored",
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_templates"`).
+					WithReply([]map[string]interface{}{{"count": 0}})
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_templates"`).WithExecException()
+			},
+			wantCode: errors.ErrorGeneral,
+		},
+		{
+			name: "should create the template",
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_templates"`).
+					WithReply([]map[string]interface{}{{"count": 0}})
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_templates"`)
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			template := &dbapi.KafkaTemplate{Name: "dev", OrganisationId: testTemplateOrgID}
+			k := NewKafkaTemplateService(db.NewMockConnectionFactory(nil))
+			err := k.Create(template)
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(template.ID).ToNot(gomega.BeEmpty())
+		})
+	}
+}
+
+func Test_kafkaTemplateService_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		setupFn  func()
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name:     "should fail when the template does not exist in the organisation",
+			wantCode: errors.ErrorNotFound,
+		},
+		{
+			name: "should soft delete the template",
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_templates" WHERE (id = $1 AND organisation_id = $2)`).
+					WithArgs(testTemplateID, testTemplateOrgID).
+					WithReply([]map[string]interface{}{{"id": testTemplateID, "organisation_id": testTemplateOrgID}})
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_templates" SET "deleted_at"=`)
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := NewKafkaTemplateService(db.NewMockConnectionFactory(nil))
+			err := k.Delete(testTemplateOrgID, testTemplateID)
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+		})
+	}
+}
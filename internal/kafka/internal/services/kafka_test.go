@@ -25,11 +25,13 @@ import (
 	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 	"github.com/onsi/gomega"
 	goerrors "github.com/pkg/errors"
@@ -273,6 +275,16 @@ func Test_kafkaService_Get(t *testing.T) {
 	ctx := context.TODO()
 	authenticatedCtx := auth.SetTokenInContext(ctx, jwt)
 
+	orgAccount, err := authHelper.NewAccount(testUser, "", "", "13640203")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	orgJwt, err := authHelper.CreateJWTWithClaims(orgAccount, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	orgCtx := auth.SetTokenInContext(auth.SetFilterByOrganisationContext(ctx, true), orgJwt)
+
 	// we define tests as list of structs that contain inputs and expected outputs
 	// this means we can execute the same logic on each test struct, and makes adding new tests simple as we only need
 	// to provide a new struct to the list instead of defining an entirely new test
@@ -336,6 +348,25 @@ func Test_kafkaService_Get(t *testing.T) {
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
+		{
+			name: "successful output when filtering by organisation, including the kafkas the organisation was granted access to",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: orgCtx,
+				id:  testID,
+			},
+			want: buildKafkaRequest(nil),
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1 AND (organisation_id = $2 OR id IN (SELECT kafka_id FROM kafka_access_grants WHERE organisation_id = $3 AND deleted_at IS NULL))`).
+					WithArgs(testID, "13640203", "13640203").
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
 	}
 	// we loop through each test case defined in the list above and start a new test invocation, using the testing
 	// t.Run function
@@ -506,6 +537,7 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr: false,
@@ -542,6 +574,7 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr: true,
@@ -579,6 +612,7 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr:                 false,
@@ -620,6 +654,7 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr: true,
@@ -660,6 +695,7 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr: true,
@@ -759,6 +795,7 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 			},
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			wantErr: true,
@@ -2813,6 +2850,103 @@ func Test_kafkaService_Updates(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_Updates_NotifyStatusChange(t *testing.T) {
+	tests := []struct {
+		name           string
+		previousStatus string
+		currentStatus  string
+		wantNotified   bool
+	}{
+		{
+			name:           "should notify the workers when the status changed",
+			previousStatus: constants.KafkaRequestStatusProvisioning.String(),
+			currentStatus:  constants.KafkaRequestStatusReady.String(),
+			wantNotified:   true,
+		},
+		{
+			name:           "should not notify the workers when the status is unchanged",
+			previousStatus: constants.KafkaRequestStatusReady.String(),
+			currentStatus:  constants.KafkaRequestStatusReady.String(),
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			// the status read before the update locks the kafka until the update is committed
+			mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests" WHERE id = $1 AND "kafka_requests"."deleted_at" IS NULL FOR UPDATE`).
+				WithReply([]map[string]interface{}{{"status": tt.previousStatus}})
+			mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests"`).
+				WithReply([]map[string]interface{}{{"status": tt.currentStatus}})
+			updateMock := mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests"`).WithRowsNum(1)
+			mocket.Catcher.NewMock().WithQueryException().WithExecException()
+
+			signalBus := signalbus.NewSignalBus()
+			sub := signalBus.Subscribe(KafkaStatusSignal)
+			defer sub.Close()
+			k := kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				signalBus:         signalBus,
+			}
+			err := k.Updates(buildKafkaRequest(nil), map[string]interface{}{
+				"status": tt.currentStatus,
+			})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(updateMock.Triggered).To(gomega.BeTrue())
+			g.Expect(sub.IsSignaled()).To(gomega.Equal(tt.wantNotified))
+		})
+	}
+}
+
+func Test_kafkaService_UpdatesIfUnmodified(t *testing.T) {
+	updatedAt := time.Date(2023, 5, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErrCode  errors.ServiceErrorCode
+	}{
+		{
+			name:         "should update the kafka when it hasn't been updated since it was read",
+			rowsAffected: 1,
+		},
+		{
+			name:         "should return a precondition failed error when the kafka has been updated concurrently",
+			rowsAffected: 0,
+			wantErrCode:  errors.ErrorPreconditionFailed,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			// the update and the check of the version are made in the same statement
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`UPDATE "kafka_requests" SET "owner"=$1,"updated_at"=$2 WHERE status not IN ($3,$4) AND updated_at = $5`).
+				WithRowsNum(tt.rowsAffected)
+			mocket.Catcher.NewMock().WithQueryException().WithExecException()
+
+			k := kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.UpdatedAt = updatedAt
+			})
+			err := k.UpdatesIfUnmodified(kafkaRequest, map[string]interface{}{
+				"owner": "new-owner",
+			})
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+		})
+	}
+}
+
 func Test_kafkaService_DeprovisionKafkaForUsers(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -3082,7 +3216,9 @@ func Test_KafkaService_CountByStatus(t *testing.T) {
 
 func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
 	type fields struct {
-		awsClient aws.AWSClient
+		awsClient   aws.AWSClient
+		dnsProvider dns.Provider
+		dnsConfig   *config.DNSConfig
 	}
 
 	type args struct {
@@ -3158,6 +3294,35 @@ func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
 				action: KafkaRoutesActionDelete,
 			},
 		},
+		{
+			name: "should create CNAMEs with the DNS provider of the cloud provider",
+			fields: fields{
+				dnsProvider: &dns.ProviderMock{
+					ChangeRecordsFunc: func(domain string, changes []dns.Change) (*dns.ChangeStatus, error) {
+						if len(changes) != 1 || changes[0].Action != dns.ChangeActionCreate || changes[0].Record.Value != "test-kafka-id.rhcloud.com" {
+							return nil, goerrors.Errorf("unexpected record changes %v", changes)
+						}
+						return &dns.ChangeStatus{Id: "zone/1"}, nil
+					},
+				},
+				dnsConfig: &config.DNSConfig{
+					DefaultProvider:          dns.ProviderTypeRoute53.String(),
+					ProvidersByCloudProvider: map[string]string{cloudproviders.GCP.String(): dns.ProviderTypeCloudDNS.String()},
+				},
+			},
+			args: args{
+				kafkaRequest: &dbapi.KafkaRequest{
+					Meta: api.Meta{
+						ID: "test-kafka-id",
+					},
+					Name:          "test-kafka-cname",
+					Routes:        []byte("[{\"domain\": \"test-kafka-id.example.com\", \"router\": \"test-kafka-id.rhcloud.com\"}]"),
+					Region:        testKafkaRequestRegion,
+					CloudProvider: cloudproviders.GCP.String(),
+				},
+				action: KafkaRoutesActionCreate,
+			},
+		},
 		{
 			name: "should return error if it fails to get routes",
 			fields: fields{
@@ -3198,9 +3363,19 @@ func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
 			awsConfig.Route53.AccessKey = "test-route-53-key"
 			awsConfig.Route53.SecretAccessKey = "test-route-53-secret-key"
 
+			var dnsProviderFactory dns.ProviderFactory = dns.NewDefaultProviderFactory(aws.NewMockClientFactory(tt.fields.awsClient))
+			if tt.fields.dnsProvider != nil {
+				dnsProviderFactory = dns.NewMockProviderFactory(tt.fields.dnsProvider)
+			}
+			dnsConfig := tt.fields.dnsConfig
+			if dnsConfig == nil {
+				dnsConfig = config.NewDNSConfig()
+			}
+
 			kafkaService := &kafkaService{
-				awsClientFactory: aws.NewMockClientFactory(tt.fields.awsClient),
-				awsConfig:        awsConfig,
+				dnsProviderFactory: dnsProviderFactory,
+				dnsConfig:          dnsConfig,
+				awsConfig:          awsConfig,
 				kafkaConfig: &config.KafkaConfig{
 					KafkaDomainName: "rhcloud.com",
 				},
@@ -3656,7 +3831,7 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
 				return &keycloak.KeycloakRealmConfig{}
 			},
-		}, kafkatlscertmgmt.Certificate{}, false)
+		}, kafkatlscertmgmt.Certificate{}, false, nil)
 
 	managedkafkaCRWithCert, _ := buildManagedKafkaCR(
 		&dbapi.KafkaRequest{
@@ -3677,7 +3852,7 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
 				return &keycloak.KeycloakRealmConfig{}
 			},
-		}, kafkatlscertmgmt.Certificate{TLSCert: "crt-cert", TLSKey: "key-cert"}, true)
+		}, kafkatlscertmgmt.Certificate{TLSCert: "crt-cert", TLSKey: "key-cert"}, true, nil)
 
 	managedkafkaCRWithPausedReconciliation, _ := buildManagedKafkaCR(
 		&dbapi.KafkaRequest{
@@ -3698,7 +3873,7 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
 				return &keycloak.KeycloakRealmConfig{}
 			},
-		}, kafkatlscertmgmt.Certificate{}, true)
+		}, kafkatlscertmgmt.Certificate{}, true, nil)
 
 	managedkafkaCRWithPausedReconciliation.Annotations[managedkafka.ManagedKafkaBf2PauseReconciliationAnnotationKey] = "true"
 
@@ -3865,6 +4040,84 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_GetManagedKafkaByID(t *testing.T) {
+	kafkaRequest := &dbapi.KafkaRequest{
+		Meta: api.Meta{
+			ID: testID,
+		},
+		ClusterID:    testClusterID,
+		InstanceType: "developer",
+		SizeId:       "x1",
+		Status:       constants.KafkaRequestStatusSuspended.String(),
+	}
+	keycloakService := &sso.KeycloakServiceMock{
+		GetConfigFunc: func() *keycloak.KeycloakConfig {
+			return &keycloak.KeycloakConfig{
+				EnableAuthenticationOnKafka: true,
+			}
+		},
+		GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+			return &keycloak.KeycloakRealmConfig{}
+		},
+	}
+	kafkaConfig := &config.KafkaConfig{
+		EnableKafkaCNAMERegistration: true,
+		SupportedInstanceTypes:       &kafkaSupportedInstanceTypesConfig,
+	}
+	managedKafkaCR, _ := buildManagedKafkaCR(kafkaRequest, kafkaConfig, keycloakService, kafkatlscertmgmt.Certificate{}, false, nil)
+
+	tests := []struct {
+		name    string
+		id      string
+		want    *managedkafka.ManagedKafka
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "should return the managed kafka of a kafka whatever its status",
+			id:   testID,
+			want: managedKafkaCR,
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				query := fmt.Sprintf(`SELECT * FROM "%s" WHERE id = $1`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequest(kafkaRequest)
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:    "should return an error when the kafka is not found",
+			id:      testID,
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(nil)
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		tt.setupFn()
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService:   keycloakService,
+				kafkaConfig:       kafkaConfig,
+				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+					IsKafkaExternalCertificateEnabledFunc: func() bool {
+						return false
+					},
+				},
+			}
+			got, err := k.GetManagedKafkaByID(tt.id)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_kafkaService_GenerateReservedManagedKafkasByClusterID(t *testing.T) {
 	type fields struct {
 		connectionFactory      *db.ConnectionFactory
@@ -4395,6 +4648,7 @@ func Test_kafkaService_VerifyAndUpdateKafkaAdmin(t *testing.T) {
 			setupFunc: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`).
 					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+				mocket.Catcher.NewMock().WithQuery(`SELECT "status" FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
@@ -4448,12 +4702,13 @@ func Test_kafkaService_VerifyAndUpdateKafkaAdmin(t *testing.T) {
 
 func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 	type fields struct {
-		awsConfig        *config.AWSConfig
-		awsClientFactory aws.ClientFactory
+		awsConfig          *config.AWSConfig
+		dnsProviderFactory dns.ProviderFactory
 	}
 
 	CNAME_Id := "CNAME_Id"
-	CNAME_Status := "CNAME_Status"
+	CNAME_Status := route53.ChangeStatusInsync
+	inSync := CNameRecordStatusInSync
 
 	awsConfig := &config.AWSConfig{}
 	awsConfig.Route53.AccessKey = "Route53AccessKey"
@@ -4473,7 +4728,7 @@ func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 			name: "should get the CNAME record Status",
 			fields: fields{
 				awsConfig: awsConfig,
-				awsClientFactory: aws.NewMockClientFactory(&aws.AWSClientMock{
+				dnsProviderFactory: dns.NewDefaultProviderFactory(aws.NewMockClientFactory(&aws.AWSClientMock{
 					GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
 						return &route53.GetChangeOutput{
 							ChangeInfo: &route53.ChangeInfo{
@@ -4482,7 +4737,7 @@ func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 							},
 						}, nil
 					},
-				}),
+				})),
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
@@ -4492,7 +4747,7 @@ func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 			},
 			want: &CNameRecordStatus{
 				Id:     &CNAME_Id,
-				Status: &CNAME_Status,
+				Status: &inSync,
 			},
 			wantErr: false,
 		},
@@ -4500,11 +4755,11 @@ func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 			name: "should return error when it fails to get CNAME status",
 			fields: fields{
 				awsConfig: awsConfig,
-				awsClientFactory: aws.NewMockClientFactory(&aws.AWSClientMock{
+				dnsProviderFactory: dns.NewDefaultProviderFactory(aws.NewMockClientFactory(&aws.AWSClientMock{
 					GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
 						return nil, errors.GeneralError("unable to CNAME record status")
 					},
-				}),
+				})),
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
@@ -4520,8 +4775,10 @@ func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
 			k := &kafkaService{
-				awsConfig:        tt.fields.awsConfig,
-				awsClientFactory: tt.fields.awsClientFactory,
+				awsConfig:          tt.fields.awsConfig,
+				kafkaConfig:        &config.KafkaConfig{},
+				dnsConfig:          config.NewDNSConfig(),
+				dnsProviderFactory: tt.fields.dnsProviderFactory,
 			}
 			got, err := k.GetCNAMERecordStatus(tt.args.kafkaRequest)
 			g.Expect(got).To(gomega.Equal(tt.want))
@@ -4538,13 +4795,16 @@ func Test_NewKafkaService(t *testing.T) {
 		kafkaConfig                          *config.KafkaConfig
 		dataplaneClusterConfig               *config.DataplaneClusterConfig
 		awsConfig                            *config.AWSConfig
+		dnsConfig                            *config.DNSConfig
 		quotaServiceFactory                  QuotaServiceFactory
-		awsClientFactory                     aws.ClientFactory
+		dnsProviderFactory                   dns.ProviderFactory
 		authorizationService                 authorization.Authorization
 		providerConfig                       *config.ProviderConfig
 		clusterPlacementStrategy             ClusterPlacementStrategy
 		kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+		signalBus                            signalbus.SignalBus
 	}
+	signalBus := signalbus.NewSignalBus()
 	tests := []struct {
 		name string
 		args args
@@ -4559,11 +4819,13 @@ func Test_NewKafkaService(t *testing.T) {
 				kafkaConfig:                          &config.KafkaConfig{},
 				dataplaneClusterConfig:               &config.DataplaneClusterConfig{},
 				awsConfig:                            &config.AWSConfig{},
+				dnsConfig:                            &config.DNSConfig{},
 				quotaServiceFactory:                  &QuotaServiceFactoryMock{},
-				awsClientFactory:                     &aws.MockClientFactory{},
+				dnsProviderFactory:                   &dns.MockProviderFactory{},
 				providerConfig:                       &config.ProviderConfig{},
 				clusterPlacementStrategy:             &ClusterPlacementStrategyMock{},
 				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{},
+				signalBus:                            signalBus,
 			},
 			want: &kafkaService{
 				connectionFactory:                    &db.ConnectionFactory{},
@@ -4572,11 +4834,13 @@ func Test_NewKafkaService(t *testing.T) {
 				kafkaConfig:                          &config.KafkaConfig{},
 				dataplaneClusterConfig:               &config.DataplaneClusterConfig{},
 				awsConfig:                            &config.AWSConfig{},
+				dnsConfig:                            &config.DNSConfig{},
 				quotaServiceFactory:                  &QuotaServiceFactoryMock{},
-				awsClientFactory:                     &aws.MockClientFactory{},
+				dnsProviderFactory:                   &dns.MockProviderFactory{},
 				providerConfig:                       &config.ProviderConfig{},
 				clusterPlacementStrategy:             &ClusterPlacementStrategyMock{},
 				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{},
+				signalBus:                            signalBus,
 			},
 		},
 	}
@@ -4591,12 +4855,14 @@ func Test_NewKafkaService(t *testing.T) {
 			tt.args.kafkaConfig,
 			tt.args.dataplaneClusterConfig,
 			tt.args.awsConfig,
+			tt.args.dnsConfig,
 			tt.args.quotaServiceFactory,
-			tt.args.awsClientFactory,
+			tt.args.dnsProviderFactory,
 			tt.args.authorizationService,
 			tt.args.providerConfig,
 			tt.args.clusterPlacementStrategy,
-			tt.args.kafkaTLSCertificateManagementService)).To(gomega.Equal(tt.want))
+			tt.args.kafkaTLSCertificateManagementService,
+			tt.args.signalBus)).To(gomega.Equal(tt.want))
 	}
 }
 
@@ -5125,3 +5391,91 @@ func Test_kafkaService_ManagedKafkasRoutesTLSCertificate(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildServiceAccountScopes(t *testing.T) {
+	kafkaRequest := &dbapi.KafkaRequest{Meta: api.Meta{ID: testID}}
+	binding := func(clientID string, kafkaID string, role dbapi.ServiceAccountRole) *dbapi.ServiceAccountBinding {
+		return &dbapi.ServiceAccountBinding{
+			ClientID:     clientID,
+			ResourceType: dbapi.ServiceAccountBindingResourceTypeKafka,
+			ResourceID:   kafkaID,
+			Role:         role,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		bindings dbapi.ServiceAccountBindingList
+		want     *managedkafka.ServiceAccountScopesSpec
+	}{
+		{
+			name: "should not set the scopes when no service account is scoped",
+		},
+		{
+			name: "should grant their role to the bound service accounts and deny the others",
+			bindings: dbapi.ServiceAccountBindingList{
+				binding("bound", testID, dbapi.ServiceAccountRoleProducer),
+				binding("bound", "other-kafka", dbapi.ServiceAccountRoleProducer),
+				binding("bound-elsewhere", "other-kafka", dbapi.ServiceAccountRoleAdmin),
+				binding("bound-elsewhere", "another-kafka", dbapi.ServiceAccountRoleAdmin),
+			},
+			want: &managedkafka.ServiceAccountScopesSpec{
+				Bindings:         []managedkafka.ServiceAccountBinding{{Principal: "bound", Role: "producer"}},
+				DeniedPrincipals: []string{"bound-elsewhere"},
+			},
+		},
+		{
+			name: "should deny the service accounts only bound to connector clusters",
+			bindings: dbapi.ServiceAccountBindingList{
+				{
+					ClientID:     "connector-only",
+					ResourceType: dbapi.ServiceAccountBindingResourceTypeConnectorCluster,
+					ResourceID:   testID,
+					Role:         dbapi.ServiceAccountRoleConsumer,
+				},
+			},
+			want: &managedkafka.ServiceAccountScopesSpec{
+				Bindings:         []managedkafka.ServiceAccountBinding{},
+				DeniedPrincipals: []string{"connector-only"},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(buildServiceAccountScopes(kafkaRequest, tt.bindings)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_listKafkaServiceAccountBindings(t *testing.T) {
+	g := gomega.NewWithT(t)
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		keycloakService: &sso.KeycloakServiceMock{
+			GetConfigFunc: func() *keycloak.KeycloakConfig {
+				return &keycloak.KeycloakConfig{EnableAuthenticationOnKafka: true}
+			},
+		},
+	}
+
+	mocket.Catcher.Reset()
+	mock := mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "service_account_bindings" WHERE (organisation_id = $1 OR organisation_id IN (SELECT organisation_id FROM kafka_access_grants WHERE kafka_id = $2 AND deleted_at IS NULL))`).
+		WithArgs("org-id", testID).
+		WithReply([]map[string]interface{}{
+			{"client_id": "owner-org-sa", "resource_type": "kafka", "resource_id": testID, "role": "producer"},
+			{"client_id": "grantee-org-sa", "resource_type": "kafka", "resource_id": "grantee-kafka", "role": "admin"},
+		})
+
+	bindings, err := k.listKafkaServiceAccountBindings(&dbapi.KafkaRequest{Meta: api.Meta{ID: testID}, OrganisationId: "org-id"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mock.Triggered).To(gomega.BeTrue())
+	// the service accounts of the organisations granted access to the instance are denied by default too
+	g.Expect(buildServiceAccountScopes(&dbapi.KafkaRequest{Meta: api.Meta{ID: testID}}, bindings)).To(gomega.Equal(&managedkafka.ServiceAccountScopesSpec{
+		Bindings:         []managedkafka.ServiceAccountBinding{{Principal: "owner-org-sa", Role: "producer"}},
+		DeniedPrincipals: []string{"grantee-org-sa"},
+	}))
+}
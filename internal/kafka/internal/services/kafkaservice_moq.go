@@ -5,7 +5,6 @@ package services
 
 import (
 	"context"
-	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	kafkaTypes "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
@@ -32,7 +31,7 @@ var _ KafkaService = &KafkaServiceMock{}
 //			AssignInstanceTypeFunc: func(owner string, organisationID string) (kafkaTypes.KafkaInstanceType, *serviceError.ServiceError) {
 //				panic("mock out the AssignInstanceType method")
 //			},
-//			ChangeKafkaCNAMErecordsFunc: func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *serviceError.ServiceError) {
+//			ChangeKafkaCNAMErecordsFunc: func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*CNameRecordStatus, *serviceError.ServiceError) {
 //				panic("mock out the ChangeKafkaCNAMErecords method")
 //			},
 //			CountByStatusFunc: func(status []constants.KafkaStatus) ([]KafkaStatusCount, error) {
@@ -65,6 +64,9 @@ var _ KafkaService = &KafkaServiceMock{}
 //			GetManagedKafkaByClusterIDFunc: func(clusterID string) ([]managedkafka.ManagedKafka, *serviceError.ServiceError) {
 //				panic("mock out the GetManagedKafkaByClusterID method")
 //			},
+//			GetManagedKafkaByIDFunc: func(id string) (*managedkafka.ManagedKafka, *serviceError.ServiceError) {
+//				panic("mock out the GetManagedKafkaByID method")
+//			},
 //			HasAvailableCapacityInRegionFunc: func(kafkaRequest *dbapi.KafkaRequest) (bool, *serviceError.ServiceError) {
 //				panic("mock out the HasAvailableCapacityInRegion method")
 //			},
@@ -110,12 +112,18 @@ var _ KafkaService = &KafkaServiceMock{}
 //			UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *serviceError.ServiceError {
 //				panic("mock out the Updates method")
 //			},
+//			UpdatesIfUnmodifiedFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *serviceError.ServiceError {
+//				panic("mock out the UpdatesIfUnmodified method")
+//			},
 //			ValidateBillingAccountFunc: func(externalId string, instanceType kafkaTypes.KafkaInstanceType, kafkaBillingModelID string, billingCloudAccountId string, marketplace *string) *serviceError.ServiceError {
 //				panic("mock out the ValidateBillingAccount method")
 //			},
 //			VerifyAndUpdateKafkaAdminFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *serviceError.ServiceError {
 //				panic("mock out the VerifyAndUpdateKafkaAdmin method")
 //			},
+//			VerifyAndUpdateKafkaAdminIfUnmodifiedFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *serviceError.ServiceError {
+//				panic("mock out the VerifyAndUpdateKafkaAdminIfUnmodified method")
+//			},
 //		}
 //
 //		// use mockedKafkaService in code that requires KafkaService
@@ -130,7 +138,7 @@ type KafkaServiceMock struct {
 	AssignInstanceTypeFunc func(owner string, organisationID string) (kafkaTypes.KafkaInstanceType, *serviceError.ServiceError)
 
 	// ChangeKafkaCNAMErecordsFunc mocks the ChangeKafkaCNAMErecords method.
-	ChangeKafkaCNAMErecordsFunc func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *serviceError.ServiceError)
+	ChangeKafkaCNAMErecordsFunc func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*CNameRecordStatus, *serviceError.ServiceError)
 
 	// CountByStatusFunc mocks the CountByStatus method.
 	CountByStatusFunc func(status []constants.KafkaStatus) ([]KafkaStatusCount, error)
@@ -162,6 +170,9 @@ type KafkaServiceMock struct {
 	// GetManagedKafkaByClusterIDFunc mocks the GetManagedKafkaByClusterID method.
 	GetManagedKafkaByClusterIDFunc func(clusterID string) ([]managedkafka.ManagedKafka, *serviceError.ServiceError)
 
+	// GetManagedKafkaByIDFunc mocks the GetManagedKafkaByID method.
+	GetManagedKafkaByIDFunc func(id string) (*managedkafka.ManagedKafka, *serviceError.ServiceError)
+
 	// HasAvailableCapacityInRegionFunc mocks the HasAvailableCapacityInRegion method.
 	HasAvailableCapacityInRegionFunc func(kafkaRequest *dbapi.KafkaRequest) (bool, *serviceError.ServiceError)
 
@@ -207,12 +218,18 @@ type KafkaServiceMock struct {
 	// UpdatesFunc mocks the Updates method.
 	UpdatesFunc func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *serviceError.ServiceError
 
+	// UpdatesIfUnmodifiedFunc mocks the UpdatesIfUnmodified method.
+	UpdatesIfUnmodifiedFunc func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *serviceError.ServiceError
+
 	// ValidateBillingAccountFunc mocks the ValidateBillingAccount method.
 	ValidateBillingAccountFunc func(externalId string, instanceType kafkaTypes.KafkaInstanceType, kafkaBillingModelID string, billingCloudAccountId string, marketplace *string) *serviceError.ServiceError
 
 	// VerifyAndUpdateKafkaAdminFunc mocks the VerifyAndUpdateKafkaAdmin method.
 	VerifyAndUpdateKafkaAdminFunc func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *serviceError.ServiceError
 
+	// VerifyAndUpdateKafkaAdminIfUnmodifiedFunc mocks the VerifyAndUpdateKafkaAdminIfUnmodified method.
+	VerifyAndUpdateKafkaAdminIfUnmodifiedFunc func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *serviceError.ServiceError
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// AssignBootstrapServerHost holds details about calls to the AssignBootstrapServerHost method.
@@ -284,6 +301,11 @@ type KafkaServiceMock struct {
 			// ClusterID is the clusterID argument value.
 			ClusterID string
 		}
+		// GetManagedKafkaByID holds details about calls to the GetManagedKafkaByID method.
+		GetManagedKafkaByID []struct {
+			// ID is the id argument value.
+			ID string
+		}
 		// HasAvailableCapacityInRegion holds details about calls to the HasAvailableCapacityInRegion method.
 		HasAvailableCapacityInRegion []struct {
 			// KafkaRequest is the kafkaRequest argument value.
@@ -359,6 +381,13 @@ type KafkaServiceMock struct {
 			// Values is the values argument value.
 			Values map[string]interface{}
 		}
+		// UpdatesIfUnmodified holds details about calls to the UpdatesIfUnmodified method.
+		UpdatesIfUnmodified []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+			// Values is the values argument value.
+			Values map[string]interface{}
+		}
 		// ValidateBillingAccount holds details about calls to the ValidateBillingAccount method.
 		ValidateBillingAccount []struct {
 			// ExternalId is the externalId argument value.
@@ -379,6 +408,13 @@ type KafkaServiceMock struct {
 			// KafkaRequest is the kafkaRequest argument value.
 			KafkaRequest *dbapi.KafkaRequest
 		}
+		// VerifyAndUpdateKafkaAdminIfUnmodified holds details about calls to the VerifyAndUpdateKafkaAdminIfUnmodified method.
+		VerifyAndUpdateKafkaAdminIfUnmodified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
 	}
 	lockAssignBootstrapServerHost                sync.RWMutex
 	lockAssignInstanceType                       sync.RWMutex
@@ -393,6 +429,7 @@ type KafkaServiceMock struct {
 	lockGetByID                                  sync.RWMutex
 	lockGetCNAMERecordStatus                     sync.RWMutex
 	lockGetManagedKafkaByClusterID               sync.RWMutex
+	lockGetManagedKafkaByID                      sync.RWMutex
 	lockHasAvailableCapacityInRegion             sync.RWMutex
 	lockIsQuotaEntitlementActive                 sync.RWMutex
 	lockList                                     sync.RWMutex
@@ -408,8 +445,10 @@ type KafkaServiceMock struct {
 	lockUpdate                                   sync.RWMutex
 	lockUpdateStatus                             sync.RWMutex
 	lockUpdates                                  sync.RWMutex
+	lockUpdatesIfUnmodified                      sync.RWMutex
 	lockValidateBillingAccount                   sync.RWMutex
 	lockVerifyAndUpdateKafkaAdmin                sync.RWMutex
+	lockVerifyAndUpdateKafkaAdminIfUnmodified    sync.RWMutex
 }
 
 // AssignBootstrapServerHost calls AssignBootstrapServerHostFunc.
@@ -481,7 +520,7 @@ func (mock *KafkaServiceMock) AssignInstanceTypeCalls() []struct {
 }
 
 // ChangeKafkaCNAMErecords calls ChangeKafkaCNAMErecordsFunc.
-func (mock *KafkaServiceMock) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *serviceError.ServiceError) {
+func (mock *KafkaServiceMock) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*CNameRecordStatus, *serviceError.ServiceError) {
 	if mock.ChangeKafkaCNAMErecordsFunc == nil {
 		panic("KafkaServiceMock.ChangeKafkaCNAMErecordsFunc: method is nil but KafkaService.ChangeKafkaCNAMErecords was just called")
 	}
@@ -835,6 +874,38 @@ func (mock *KafkaServiceMock) GetManagedKafkaByClusterIDCalls() []struct {
 	return calls
 }
 
+// GetManagedKafkaByID calls GetManagedKafkaByIDFunc.
+func (mock *KafkaServiceMock) GetManagedKafkaByID(id string) (*managedkafka.ManagedKafka, *serviceError.ServiceError) {
+	if mock.GetManagedKafkaByIDFunc == nil {
+		panic("KafkaServiceMock.GetManagedKafkaByIDFunc: method is nil but KafkaService.GetManagedKafkaByID was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockGetManagedKafkaByID.Lock()
+	mock.calls.GetManagedKafkaByID = append(mock.calls.GetManagedKafkaByID, callInfo)
+	mock.lockGetManagedKafkaByID.Unlock()
+	return mock.GetManagedKafkaByIDFunc(id)
+}
+
+// GetManagedKafkaByIDCalls gets all the calls that were made to GetManagedKafkaByID.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetManagedKafkaByIDCalls())
+func (mock *KafkaServiceMock) GetManagedKafkaByIDCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockGetManagedKafkaByID.RLock()
+	calls = mock.calls.GetManagedKafkaByID
+	mock.lockGetManagedKafkaByID.RUnlock()
+	return calls
+}
+
 // HasAvailableCapacityInRegion calls HasAvailableCapacityInRegionFunc.
 func (mock *KafkaServiceMock) HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest) (bool, *serviceError.ServiceError) {
 	if mock.HasAvailableCapacityInRegionFunc == nil {
@@ -1311,6 +1382,42 @@ func (mock *KafkaServiceMock) UpdatesCalls() []struct {
 	return calls
 }
 
+// UpdatesIfUnmodified calls UpdatesIfUnmodifiedFunc.
+func (mock *KafkaServiceMock) UpdatesIfUnmodified(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *serviceError.ServiceError {
+	if mock.UpdatesIfUnmodifiedFunc == nil {
+		panic("KafkaServiceMock.UpdatesIfUnmodifiedFunc: method is nil but KafkaService.UpdatesIfUnmodified was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+		Values       map[string]interface{}
+	}{
+		KafkaRequest: kafkaRequest,
+		Values:       values,
+	}
+	mock.lockUpdatesIfUnmodified.Lock()
+	mock.calls.UpdatesIfUnmodified = append(mock.calls.UpdatesIfUnmodified, callInfo)
+	mock.lockUpdatesIfUnmodified.Unlock()
+	return mock.UpdatesIfUnmodifiedFunc(kafkaRequest, values)
+}
+
+// UpdatesIfUnmodifiedCalls gets all the calls that were made to UpdatesIfUnmodified.
+// Check the length with:
+//
+//	len(mockedKafkaService.UpdatesIfUnmodifiedCalls())
+func (mock *KafkaServiceMock) UpdatesIfUnmodifiedCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+	Values       map[string]interface{}
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+		Values       map[string]interface{}
+	}
+	mock.lockUpdatesIfUnmodified.RLock()
+	calls = mock.calls.UpdatesIfUnmodified
+	mock.lockUpdatesIfUnmodified.RUnlock()
+	return calls
+}
+
 // ValidateBillingAccount calls ValidateBillingAccountFunc.
 func (mock *KafkaServiceMock) ValidateBillingAccount(externalId string, instanceType kafkaTypes.KafkaInstanceType, kafkaBillingModelID string, billingCloudAccountId string, marketplace *string) *serviceError.ServiceError {
 	if mock.ValidateBillingAccountFunc == nil {
@@ -1394,3 +1501,39 @@ func (mock *KafkaServiceMock) VerifyAndUpdateKafkaAdminCalls() []struct {
 	mock.lockVerifyAndUpdateKafkaAdmin.RUnlock()
 	return calls
 }
+
+// VerifyAndUpdateKafkaAdminIfUnmodified calls VerifyAndUpdateKafkaAdminIfUnmodifiedFunc.
+func (mock *KafkaServiceMock) VerifyAndUpdateKafkaAdminIfUnmodified(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *serviceError.ServiceError {
+	if mock.VerifyAndUpdateKafkaAdminIfUnmodifiedFunc == nil {
+		panic("KafkaServiceMock.VerifyAndUpdateKafkaAdminIfUnmodifiedFunc: method is nil but KafkaService.VerifyAndUpdateKafkaAdminIfUnmodified was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		Ctx:          ctx,
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockVerifyAndUpdateKafkaAdminIfUnmodified.Lock()
+	mock.calls.VerifyAndUpdateKafkaAdminIfUnmodified = append(mock.calls.VerifyAndUpdateKafkaAdminIfUnmodified, callInfo)
+	mock.lockVerifyAndUpdateKafkaAdminIfUnmodified.Unlock()
+	return mock.VerifyAndUpdateKafkaAdminIfUnmodifiedFunc(ctx, kafkaRequest)
+}
+
+// VerifyAndUpdateKafkaAdminIfUnmodifiedCalls gets all the calls that were made to VerifyAndUpdateKafkaAdminIfUnmodified.
+// Check the length with:
+//
+//	len(mockedKafkaService.VerifyAndUpdateKafkaAdminIfUnmodifiedCalls())
+func (mock *KafkaServiceMock) VerifyAndUpdateKafkaAdminIfUnmodifiedCalls() []struct {
+	Ctx          context.Context
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		Ctx          context.Context
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockVerifyAndUpdateKafkaAdminIfUnmodified.RLock()
+	calls = mock.calls.VerifyAndUpdateKafkaAdminIfUnmodified
+	mock.lockVerifyAndUpdateKafkaAdminIfUnmodified.RUnlock()
+	return calls
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"gorm.io/gorm"
+)
+
+var _ ServiceAccountBindingService = &serviceAccountBindingService{}
+var _ kafkaaccess.ServiceAccountScopeValidator = &serviceAccountBindingService{}
+
+// ServiceAccountBindingService keeps the bindings of the service accounts scoped to some Kafka instances and
+// connector clusters. The bindings are enforced by the data plane, through the ManagedKafka CRs, and by the connector
+// module.
+//
+//go:generate moq -out service_account_bindings_moq.go . ServiceAccountBindingService
+type ServiceAccountBindingService interface {
+	// ValidateScope checks that the Kafka instances of the scope are owned by the organisation of the caller and sets
+	// the default role of the scope if none is set. The connector clusters are only checked by the connector module.
+	ValidateScope(ctx context.Context, scope *api.ServiceAccountScope) *errors.ServiceError
+	// Bind replaces the bindings of the service account by the ones of the scope, an empty scope makes the service
+	// account organisation-wide
+	Bind(orgId string, account *api.ServiceAccount, scope *api.ServiceAccountScope) *errors.ServiceError
+	// Populate sets the scope of the given service accounts
+	Populate(accounts []*api.ServiceAccount) *errors.ServiceError
+	Delete(serviceAccountID string) *errors.ServiceError
+	ValidateServiceAccountScope(clientID string, kafkaID string, connectorClusterID string) *errors.ServiceError
+}
+
+type serviceAccountBindingService struct {
+	connectionFactory *db.ConnectionFactory
+	kafkaService      KafkaService
+}
+
+func NewServiceAccountBindingService(connectionFactory *db.ConnectionFactory, kafkaService KafkaService) *serviceAccountBindingService {
+	return &serviceAccountBindingService{
+		connectionFactory: connectionFactory,
+		kafkaService:      kafkaService,
+	}
+}
+
+func (s *serviceAccountBindingService) ValidateScope(ctx context.Context, scope *api.ServiceAccountScope) *errors.ServiceError {
+	if scope.IsEmpty() {
+		return nil
+	}
+
+	if scope.Role == "" {
+		scope.Role = dbapi.DefaultServiceAccountRole.String()
+	}
+	if !arrays.Contains(dbapi.ValidServiceAccountRoles, scope.Role) {
+		return errors.BadRequest("scope.role %q is not valid, valid roles are %v", scope.Role, dbapi.ValidServiceAccountRoles)
+	}
+
+	var orgId string
+	if claims, err := auth.GetClaimsFromContext(ctx); err == nil {
+		orgId, _ = claims.GetOrgId()
+	}
+	for _, kafkaID := range scope.KafkaIDs {
+		// only the Kafka instances visible to the caller can be bound
+		kafka, err := s.kafkaService.Get(ctx, kafkaID)
+		if err != nil {
+			if err.Is404() {
+				return errors.BadRequest("scope.kafka_ids is not valid: kafka %s not found", kafkaID)
+			}
+			return err
+		}
+		// the Kafka instances other organisations granted access to are visible too, but the bindings are only
+		// enforced on the instances of the organisation of the service account
+		if kafka.OrganisationId != orgId {
+			return errors.BadRequest("scope.kafka_ids is not valid: kafka %s is not owned by the organisation", kafkaID)
+		}
+	}
+	for _, clusterID := range scope.ConnectorClusterIDs {
+		if clusterID == "" {
+			return errors.BadRequest("scope.connector_cluster_ids must not contain empty ids")
+		}
+	}
+	return nil
+}
+
+func (s *serviceAccountBindingService) Bind(orgId string, account *api.ServiceAccount, scope *api.ServiceAccountScope) *errors.ServiceError {
+	var bindings dbapi.ServiceAccountBindingList
+	if !scope.IsEmpty() {
+		role := dbapi.ServiceAccountRole(scope.Role)
+		newBinding := func(resourceType dbapi.ServiceAccountBindingResourceType, resourceID string) *dbapi.ServiceAccountBinding {
+			return &dbapi.ServiceAccountBinding{
+				Meta:             api.Meta{ID: api.NewID()},
+				ServiceAccountID: account.ID,
+				ClientID:         account.ClientID,
+				OrganisationId:   orgId,
+				ResourceType:     resourceType,
+				ResourceID:       resourceID,
+				Role:             role,
+			}
+		}
+		for _, kafkaID := range distinct(scope.KafkaIDs) {
+			bindings = append(bindings, newBinding(dbapi.ServiceAccountBindingResourceTypeKafka, kafkaID))
+		}
+		for _, clusterID := range distinct(scope.ConnectorClusterIDs) {
+			bindings = append(bindings, newBinding(dbapi.ServiceAccountBindingResourceTypeConnectorCluster, clusterID))
+		}
+	}
+
+	if err := s.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("service_account_id = ?", account.ID).Delete(&dbapi.ServiceAccountBinding{}).Error; err != nil {
+			return err
+		}
+		if len(bindings) == 0 {
+			return nil
+		}
+		return tx.Create(&bindings).Error
+	}); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to bind service account %s", account.ID)
+	}
+
+	account.Scope = bindings.Scope()[account.ID]
+	return nil
+}
+
+func (s *serviceAccountBindingService) Populate(accounts []*api.ServiceAccount) *errors.ServiceError {
+	if len(accounts) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, account.ID)
+	}
+
+	var bindings dbapi.ServiceAccountBindingList
+	if err := s.connectionFactory.New().Where("service_account_id IN ?", ids).Order("created_at").Find(&bindings).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the bindings of service accounts")
+	}
+	scopes := bindings.Scope()
+	for _, account := range accounts {
+		account.Scope = scopes[account.ID]
+	}
+	return nil
+}
+
+func (s *serviceAccountBindingService) Delete(serviceAccountID string) *errors.ServiceError {
+	if err := s.connectionFactory.New().Unscoped().Where("service_account_id = ?", serviceAccountID).Delete(&dbapi.ServiceAccountBinding{}).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete the bindings of service account %s", serviceAccountID)
+	}
+	return nil
+}
+
+// ValidateServiceAccountScope checks that a service account can be used with a Kafka instance and a connector cluster.
+// Organisation-wide service accounts can be used with any of them, the scoped ones only with those they are bound to.
+// Empty ids are not checked.
+func (s *serviceAccountBindingService) ValidateServiceAccountScope(clientID string, kafkaID string, connectorClusterID string) *errors.ServiceError {
+	var bindings dbapi.ServiceAccountBindingList
+	if err := s.connectionFactory.New().Where("client_id = ?", clientID).Find(&bindings).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the bindings of service account %s", clientID)
+	}
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	isBound := func(resourceType dbapi.ServiceAccountBindingResourceType, resourceID string) bool {
+		for _, binding := range bindings {
+			if binding.ResourceType == resourceType && binding.ResourceID == resourceID {
+				return true
+			}
+		}
+		return false
+	}
+	if kafkaID != "" && !isBound(dbapi.ServiceAccountBindingResourceTypeKafka, kafkaID) {
+		return errors.Forbidden("service account %s is not bound to kafka %s", clientID, kafkaID)
+	}
+	if connectorClusterID != "" && !isBound(dbapi.ServiceAccountBindingResourceTypeConnectorCluster, connectorClusterID) {
+		return errors.Forbidden("service account %s is not bound to connector cluster %s", clientID, connectorClusterID)
+	}
+	return nil
+}
+
+// distinct returns the values without duplicates, in their original order
+func distinct(values []string) []string {
+	var res []string
+	for _, value := range values {
+		if !arrays.Contains(res, value) {
+			res = append(res, value)
+		}
+	}
+	return res
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_serviceAccountBindingService_ValidateScope(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "13640203")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	ctx := auth.SetTokenInContext(context.Background(), jwt)
+
+	kafkaService := &KafkaServiceMock{
+		GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+			switch id {
+			case "kafka-id":
+				return &dbapi.KafkaRequest{Meta: api.Meta{ID: id}, OrganisationId: "13640203"}, nil
+			case "granted-kafka-id":
+				return &dbapi.KafkaRequest{Meta: api.Meta{ID: id}, OrganisationId: "other-org"}, nil
+			case "unknown-kafka-id":
+				return nil, errors.NotFound("KafkaResource with id='%s' not found", id)
+			default:
+				return nil, errors.GeneralError("unexpected error")
+			}
+		},
+	}
+
+	tests := []struct {
+		name     string
+		scope    *api.ServiceAccountScope
+		wantRole string
+		wantCode errors.ServiceErrorCode
+	}{
+		{
+			name: "should accept an organisation-wide service account",
+		},
+		{
+			name:     "should set the default role",
+			scope:    &api.ServiceAccountScope{KafkaIDs: []string{"kafka-id"}},
+			wantRole: "consumer",
+		},
+		{
+			name:     "should accept the bindings to connector clusters only",
+			scope:    &api.ServiceAccountScope{ConnectorClusterIDs: []string{"cluster-id"}, Role: "producer"},
+			wantRole: "producer",
+		},
+		{
+			name:     "should reject an unknown role",
+			scope:    &api.ServiceAccountScope{KafkaIDs: []string{"kafka-id"}, Role: "owner"},
+			wantCode: errors.ErrorBadRequest,
+		},
+		{
+			name:     "should reject the kafkas the caller can't access",
+			scope:    &api.ServiceAccountScope{KafkaIDs: []string{"kafka-id", "unknown-kafka-id"}},
+			wantCode: errors.ErrorBadRequest,
+		},
+		{
+			name:     "should reject the kafkas of other organisations the caller has been granted access to",
+			scope:    &api.ServiceAccountScope{KafkaIDs: []string{"kafka-id", "granted-kafka-id"}},
+			wantCode: errors.ErrorBadRequest,
+		},
+		{
+			name:     "should return the errors getting the kafkas",
+			scope:    &api.ServiceAccountScope{KafkaIDs: []string{"failing-kafka-id"}},
+			wantCode: errors.ErrorGeneral,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			s := NewServiceAccountBindingService(db.NewMockConnectionFactory(nil), kafkaService)
+			err := s.ValidateScope(ctx, tt.scope)
+			if tt.wantCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			if tt.scope != nil {
+				g.Expect(tt.scope.Role).To(gomega.Equal(tt.wantRole))
+			}
+		})
+	}
+}
+
+func Test_serviceAccountBindingService_Bind(t *testing.T) {
+	tests := []struct {
+		name       string
+		scope      *api.ServiceAccountScope
+		wantInsert bool
+		wantScope  *api.ServiceAccountScope
+	}{
+		{
+			name:       "should replace the bindings of the service account",
+			scope:      &api.ServiceAccountScope{KafkaIDs: []string{"kafka-id", "kafka-id"}, ConnectorClusterIDs: []string{"cluster-id"}, Role: "admin"},
+			wantInsert: true,
+			wantScope:  &api.ServiceAccountScope{KafkaIDs: []string{"kafka-id"}, ConnectorClusterIDs: []string{"cluster-id"}, Role: "admin"},
+		},
+		{
+			name:  "should make the service account organisation-wide with an empty scope",
+			scope: &api.ServiceAccountScope{},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			deleteMock := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "service_account_bindings" WHERE service_account_id = $1`).WithArgs(testID)
+			insertMock := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "service_account_bindings"`)
+
+			s := NewServiceAccountBindingService(db.NewMockConnectionFactory(nil), &KafkaServiceMock{})
+			account := &api.ServiceAccount{ID: testID, ClientID: "client-id"}
+			g.Expect(s.Bind("org-id", account, tt.scope)).To(gomega.BeNil())
+			g.Expect(deleteMock.Triggered).To(gomega.BeTrue())
+			g.Expect(insertMock.Triggered).To(gomega.Equal(tt.wantInsert))
+			g.Expect(account.Scope).To(gomega.Equal(tt.wantScope))
+		})
+	}
+}
+
+func Test_serviceAccountBindingService_Populate(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "service_account_bindings" WHERE service_account_id IN`).
+		WithReply([]map[string]interface{}{
+			{"service_account_id": testID, "resource_type": "kafka", "resource_id": "kafka-id", "role": "producer"},
+			{"service_account_id": testID, "resource_type": "connector_cluster", "resource_id": "cluster-id", "role": "producer"},
+		})
+
+	s := NewServiceAccountBindingService(db.NewMockConnectionFactory(nil), &KafkaServiceMock{})
+	scoped := &api.ServiceAccount{ID: testID}
+	organisationWide := &api.ServiceAccount{ID: "organisation-wide"}
+	g.Expect(s.Populate([]*api.ServiceAccount{scoped, organisationWide})).To(gomega.BeNil())
+	g.Expect(scoped.Scope).To(gomega.Equal(&api.ServiceAccountScope{
+		KafkaIDs:            []string{"kafka-id"},
+		ConnectorClusterIDs: []string{"cluster-id"},
+		Role:                "producer",
+	}))
+	g.Expect(organisationWide.Scope).To(gomega.BeNil())
+}
+
+func Test_serviceAccountBindingService_ValidateServiceAccountScope(t *testing.T) {
+	tests := []struct {
+		name               string
+		bindings           []map[string]interface{}
+		kafkaID            string
+		connectorClusterID string
+		wantForbidden      bool
+	}{
+		{
+			name:               "should accept an organisation-wide service account",
+			kafkaID:            "kafka-id",
+			connectorClusterID: "cluster-id",
+		},
+		{
+			name: "should accept the kafka and the connector cluster the service account is bound to",
+			bindings: []map[string]interface{}{
+				{"resource_type": "kafka", "resource_id": "kafka-id"},
+				{"resource_type": "connector_cluster", "resource_id": "cluster-id"},
+			},
+			kafkaID:            "kafka-id",
+			connectorClusterID: "cluster-id",
+		},
+		{
+			name: "should reject a kafka the service account is not bound to",
+			bindings: []map[string]interface{}{
+				{"resource_type": "kafka", "resource_id": "kafka-id"},
+			},
+			kafkaID:       "other-kafka-id",
+			wantForbidden: true,
+		},
+		{
+			name: "should reject a connector cluster the service account is not bound to",
+			bindings: []map[string]interface{}{
+				{"resource_type": "kafka", "resource_id": "kafka-id"},
+			},
+			kafkaID:            "kafka-id",
+			connectorClusterID: "cluster-id",
+			wantForbidden:      true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "service_account_bindings" WHERE client_id = $1`).
+				WithArgs("client-id").
+				WithReply(tt.bindings)
+
+			s := NewServiceAccountBindingService(db.NewMockConnectionFactory(nil), &KafkaServiceMock{})
+			err := s.ValidateServiceAccountScope("client-id", tt.kafkaID, tt.connectorClusterID)
+			if tt.wantForbidden {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.IsForbidden()).To(gomega.BeTrue())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+		})
+	}
+}
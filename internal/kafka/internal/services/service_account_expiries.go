@@ -0,0 +1,201 @@
+package services
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/golang/glog"
+)
+
+var _ ServiceAccountExpiryService = &serviceAccountExpiryService{}
+
+// ServiceAccountExpiryService keeps the expiry of the credentials of the service accounts. The SSO providers have no
+// notion of expiry, so the credentials that have expired are revoked by regenerating their secret. This isn't
+// possible with the redhat_sso provider, whose service accounts can only be managed with the token of their users,
+// so the credentials don't expire with it.
+//
+//go:generate moq -out service_account_expiries_moq.go . ServiceAccountExpiryService
+type ServiceAccountExpiryService interface {
+	// GetCredentialsExpiry validates the requested expiry of the credentials of a new service account, or returns the
+	// default one when none is requested. It returns nil if the credentials never expire.
+	GetCredentialsExpiry(requested *time.Time) (*time.Time, *errors.ServiceError)
+	// Create stores the expiry of the credentials of a new service account, if they expire
+	Create(orgId string, account *api.ServiceAccount) *errors.ServiceError
+	// Renew postpones the expiry of the credentials of a service account after they have been reset, by the
+	// lifetime of the previous credentials
+	Renew(account *api.ServiceAccount) *errors.ServiceError
+	// Populate sets the expiry of the credentials of the given service accounts
+	Populate(accounts []*api.ServiceAccount) *errors.ServiceError
+	Delete(id string) *errors.ServiceError
+	// RevokeExpiredCredentials revokes the expired credentials in the SSO provider
+	RevokeExpiredCredentials() []error
+}
+
+type serviceAccountExpiryService struct {
+	connectionFactory *db.ConnectionFactory
+	keycloakService   sso.KafkaKeycloakService
+	keycloakConfig    *keycloak.KeycloakConfig
+}
+
+func NewServiceAccountExpiryService(connectionFactory *db.ConnectionFactory, keycloakService sso.KafkaKeycloakService, keycloakConfig *keycloak.KeycloakConfig) ServiceAccountExpiryService {
+	return &serviceAccountExpiryService{
+		connectionFactory: connectionFactory,
+		keycloakService:   keycloakService,
+		keycloakConfig:    keycloakConfig,
+	}
+}
+
+func (s *serviceAccountExpiryService) GetCredentialsExpiry(requested *time.Time) (*time.Time, *errors.ServiceError) {
+	if s.keycloakConfig.SelectSSOProvider == keycloak.REDHAT_SSO {
+		if requested != nil {
+			return nil, errors.BadRequest("credentials_expire_at is not supported with the %s provider", keycloak.REDHAT_SSO)
+		}
+		return nil, nil
+	}
+
+	now := time.Now()
+	lifetime := s.keycloakConfig.ServiceAccountCredentialsLifetime
+	if requested == nil {
+		if lifetime == 0 {
+			return nil, nil
+		}
+		expireAt := now.Add(lifetime)
+		return &expireAt, nil
+	}
+
+	if !requested.After(now) {
+		return nil, errors.BadRequest("credentials_expire_at must be in the future")
+	}
+	if lifetime > 0 && requested.After(now.Add(lifetime)) {
+		return nil, errors.BadRequest("credentials_expire_at must not be more than %s in the future", lifetime)
+	}
+	return requested, nil
+}
+
+func (s *serviceAccountExpiryService) Create(orgId string, account *api.ServiceAccount) *errors.ServiceError {
+	if account.CredentialsExpireAt == nil {
+		return nil
+	}
+
+	expiry := &dbapi.ServiceAccountExpiry{
+		ID:                  account.ID,
+		ClientID:            account.ClientID,
+		OrganisationId:      orgId,
+		CredentialsIssuedAt: time.Now(),
+		CredentialsExpireAt: *account.CredentialsExpireAt,
+	}
+	if err := s.connectionFactory.New().Create(expiry).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to store the credentials expiry of service account %s", account.ID)
+	}
+	account.CredentialsRotationDue = s.isRotationDue(expiry)
+	return nil
+}
+
+func (s *serviceAccountExpiryService) Renew(account *api.ServiceAccount) *errors.ServiceError {
+	dbConn := s.connectionFactory.New()
+	var expiries []*dbapi.ServiceAccountExpiry
+	if err := dbConn.Where("id = ?", account.ID).Find(&expiries).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the credentials expiry of service account %s", account.ID)
+	}
+	if len(expiries) == 0 {
+		return nil
+	}
+
+	expiry := expiries[0]
+	now := time.Now()
+	expireAt := now.Add(expiry.Lifetime())
+	if err := dbConn.Model(expiry).Updates(map[string]interface{}{
+		"credentials_issued_at":  now,
+		"credentials_expire_at":  expireAt,
+		"credentials_revoked_at": nil,
+	}).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to renew the credentials expiry of service account %s", account.ID)
+	}
+	expiry.CredentialsExpireAt = expireAt
+	expiry.CredentialsRevokedAt = nil
+	s.populate(account, expiry)
+	return nil
+}
+
+func (s *serviceAccountExpiryService) Populate(accounts []*api.ServiceAccount) *errors.ServiceError {
+	if len(accounts) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, account.ID)
+	}
+
+	var expiries []*dbapi.ServiceAccountExpiry
+	if err := s.connectionFactory.New().Where("id IN ?", ids).Find(&expiries).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the credentials expiry of service accounts")
+	}
+	expiriesByID := make(map[string]*dbapi.ServiceAccountExpiry, len(expiries))
+	for _, expiry := range expiries {
+		expiriesByID[expiry.ID] = expiry
+	}
+	for _, account := range accounts {
+		if expiry, ok := expiriesByID[account.ID]; ok {
+			s.populate(account, expiry)
+		}
+	}
+	return nil
+}
+
+func (s *serviceAccountExpiryService) Delete(id string) *errors.ServiceError {
+	if err := s.connectionFactory.New().Where("id = ?", id).Delete(&dbapi.ServiceAccountExpiry{}).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete the credentials expiry of service account %s", id)
+	}
+	return nil
+}
+
+func (s *serviceAccountExpiryService) RevokeExpiredCredentials() []error {
+	dbConn := s.connectionFactory.New()
+	var expiries []*dbapi.ServiceAccountExpiry
+	if err := dbConn.Where("credentials_expire_at <= ? AND credentials_revoked_at IS NULL", time.Now()).Find(&expiries).Error; err != nil {
+		return []error{errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the expired service account credentials")}
+	}
+
+	var errs []error
+	for _, expiry := range expiries {
+		if err := s.keycloakService.RevokeServiceAccountCredentialsInternal(expiry.ID); err != nil {
+			if err.Code == errors.ErrorServiceAccountNotFound {
+				// the service account may have been deleted outside of the fleet manager, its expiry is only deleted
+				// once its client is confirmed to be gone
+				existErr := s.keycloakService.IsKafkaClientExist(expiry.ClientID)
+				if existErr == nil || existErr.Code != errors.ErrorNotFound {
+					errs = append(errs, err)
+					continue
+				}
+				if deleteErr := s.Delete(expiry.ID); deleteErr != nil {
+					errs = append(errs, deleteErr)
+				}
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+		if err := dbConn.Model(expiry).Update("credentials_revoked_at", time.Now()).Error; err != nil {
+			errs = append(errs, errors.NewWithCause(errors.ErrorGeneral, err, "failed to record the revocation of the credentials of service account %s", expiry.ID))
+			continue
+		}
+		glog.Infof("revoked the expired credentials of service account %s of organisation %s", expiry.ClientID, expiry.OrganisationId)
+	}
+	return errs
+}
+
+func (s *serviceAccountExpiryService) populate(account *api.ServiceAccount, expiry *dbapi.ServiceAccountExpiry) {
+	expireAt := expiry.CredentialsExpireAt
+	account.CredentialsExpireAt = &expireAt
+	account.CredentialsRotationDue = s.isRotationDue(expiry)
+}
+
+// isRotationDue returns true when the credentials expire within the rotation reminder period, or have expired
+func (s *serviceAccountExpiryService) isRotationDue(expiry *dbapi.ServiceAccountExpiry) bool {
+	return time.Until(expiry.CredentialsExpireAt) <= s.keycloakConfig.ServiceAccountRotationReminder
+}
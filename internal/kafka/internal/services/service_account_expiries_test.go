@@ -0,0 +1,212 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_serviceAccountExpiryService_GetCredentialsExpiry(t *testing.T) {
+	now := time.Now()
+	inOneDay := now.Add(24 * time.Hour)
+	inOneYear := now.Add(365 * 24 * time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		provider   string
+		lifetime   time.Duration
+		requested  *time.Time
+		wantExpiry bool
+		wantErr    bool
+	}{
+		{
+			name: "should not expire when no lifetime is configured nor requested",
+		},
+		{
+			name:     "should not expire with the redhat_sso provider, which can't revoke the credentials",
+			provider: keycloak.REDHAT_SSO,
+		},
+		{
+			name:      "should fail when an expiry is requested with the redhat_sso provider",
+			provider:  keycloak.REDHAT_SSO,
+			requested: &inOneDay,
+			wantErr:   true,
+		},
+		{
+			name:       "should expire after the configured lifetime",
+			lifetime:   30 * 24 * time.Hour,
+			wantExpiry: true,
+		},
+		{
+			name:       "should expire at the requested time",
+			requested:  &inOneDay,
+			wantExpiry: true,
+		},
+		{
+			name:      "should fail when the requested time is in the past",
+			requested: &yesterday,
+			wantErr:   true,
+		},
+		{
+			name:      "should fail when the requested time is after the configured lifetime",
+			lifetime:  30 * 24 * time.Hour,
+			requested: &inOneYear,
+			wantErr:   true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := keycloak.NewKeycloakConfig()
+			config.ServiceAccountCredentialsLifetime = tt.lifetime
+			if tt.provider != "" {
+				config.SelectSSOProvider = tt.provider
+			}
+			s := NewServiceAccountExpiryService(db.NewMockConnectionFactory(nil), &sso.KeycloakServiceMock{}, config)
+
+			got, err := s.GetCredentialsExpiry(tt.requested)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(errors.ErrorBadRequest))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			if !tt.wantExpiry {
+				g.Expect(got).To(gomega.BeNil())
+				return
+			}
+			g.Expect(got).ToNot(gomega.BeNil())
+			if tt.requested != nil {
+				g.Expect(*got).To(gomega.Equal(*tt.requested))
+			} else {
+				g.Expect(*got).To(gomega.BeTemporally("~", now.Add(tt.lifetime), time.Minute))
+			}
+		})
+	}
+}
+
+func Test_serviceAccountExpiryService_Renew(t *testing.T) {
+	g := gomega.NewWithT(t)
+	issuedAt := time.Now().Add(-20 * 24 * time.Hour)
+	expireAt := issuedAt.Add(30 * 24 * time.Hour)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "service_account_expiries" WHERE id = $1`).
+		WithArgs(testID).
+		WithReply([]map[string]interface{}{{
+			"id":                    testID,
+			"credentials_issued_at": issuedAt,
+			"credentials_expire_at": expireAt,
+		}})
+	updateMock := mocket.Catcher.NewMock().WithQuery(`UPDATE "service_account_expiries"`)
+
+	s := NewServiceAccountExpiryService(db.NewMockConnectionFactory(nil), &sso.KeycloakServiceMock{}, keycloak.NewKeycloakConfig())
+	account := &api.ServiceAccount{ID: testID}
+	g.Expect(s.Renew(account)).To(gomega.BeNil())
+	g.Expect(updateMock.Triggered).To(gomega.BeTrue())
+	// the new credentials have the same lifetime as the previous ones
+	g.Expect(*account.CredentialsExpireAt).To(gomega.BeTemporally("~", time.Now().Add(30*24*time.Hour), time.Minute))
+	g.Expect(account.CredentialsRotationDue).To(gomega.BeFalse())
+}
+
+func Test_serviceAccountExpiryService_Populate(t *testing.T) {
+	g := gomega.NewWithT(t)
+	expireAt := time.Now().Add(24 * time.Hour)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "service_account_expiries" WHERE id IN`).
+		WithReply([]map[string]interface{}{{
+			"id":                    testID,
+			"credentials_expire_at": expireAt,
+		}})
+
+	s := NewServiceAccountExpiryService(db.NewMockConnectionFactory(nil), &sso.KeycloakServiceMock{}, keycloak.NewKeycloakConfig())
+	expiring := &api.ServiceAccount{ID: testID}
+	neverExpiring := &api.ServiceAccount{ID: "never-expiring"}
+	g.Expect(s.Populate([]*api.ServiceAccount{expiring, neverExpiring})).To(gomega.BeNil())
+	g.Expect(*expiring.CredentialsExpireAt).To(gomega.BeTemporally("==", expireAt))
+	// the credentials expiring within the rotation reminder period are due for rotation
+	g.Expect(expiring.CredentialsRotationDue).To(gomega.BeTrue())
+	g.Expect(neverExpiring.CredentialsExpireAt).To(gomega.BeNil())
+	g.Expect(neverExpiring.CredentialsRotationDue).To(gomega.BeFalse())
+}
+
+func Test_serviceAccountExpiryService_RevokeExpiredCredentials(t *testing.T) {
+	tests := []struct {
+		name           string
+		revokeErr      *errors.ServiceError
+		clientExistErr *errors.ServiceError
+		wantErr        bool
+		wantUpdate     bool
+		wantDeleteRow  bool
+	}{
+		{
+			name:       "should revoke the expired credentials",
+			wantUpdate: true,
+		},
+		{
+			name:           "should forget the service accounts that don't exist anymore",
+			revokeErr:      errors.New(errors.ErrorServiceAccountNotFound, "service account not found"),
+			clientExistErr: errors.New(errors.ErrorNotFound, "sso client not found"),
+			wantDeleteRow:  true,
+		},
+		{
+			name:      "should keep the expiry when the service account can't be found but its client still exists",
+			revokeErr: errors.New(errors.ErrorServiceAccountNotFound, "service account not found"),
+			wantErr:   true,
+		},
+		{
+			name:           "should keep the expiry when the existence of the client can't be checked",
+			revokeErr:      errors.New(errors.ErrorServiceAccountNotFound, "service account not found"),
+			clientExistErr: errors.New(errors.ErrorFailedToGetSSOClient, "failed to get sso client"),
+			wantErr:        true,
+		},
+		{
+			name:      "should return the errors of the revocation",
+			revokeErr: errors.GeneralError("failed to revoke"),
+			wantErr:   true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "service_account_expiries" WHERE credentials_expire_at <= $1 AND credentials_revoked_at IS NULL`).
+				WithReply([]map[string]interface{}{{"id": testID}})
+			updateMock := mocket.Catcher.NewMock().WithQuery(`UPDATE "service_account_expiries" SET "credentials_revoked_at"`)
+			deleteMock := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "service_account_expiries"`)
+
+			keycloakService := &sso.KeycloakServiceMock{
+				RevokeServiceAccountCredentialsInternalFunc: func(id string) *errors.ServiceError {
+					return tt.revokeErr
+				},
+				IsKafkaClientExistFunc: func(clientId string) *errors.ServiceError {
+					return tt.clientExistErr
+				},
+			}
+			s := NewServiceAccountExpiryService(db.NewMockConnectionFactory(nil), keycloakService, keycloak.NewKeycloakConfig())
+
+			errs := s.RevokeExpiredCredentials()
+			g.Expect(len(errs) > 0).To(gomega.Equal(tt.wantErr))
+			g.Expect(keycloakService.RevokeServiceAccountCredentialsInternalCalls()).To(gomega.HaveLen(1))
+			g.Expect(keycloakService.RevokeServiceAccountCredentialsInternalCalls()[0].ID).To(gomega.Equal(testID))
+			g.Expect(updateMock.Triggered).To(gomega.Equal(tt.wantUpdate))
+			g.Expect(deleteMock.Triggered).To(gomega.Equal(tt.wantDeleteRow))
+		})
+	}
+}
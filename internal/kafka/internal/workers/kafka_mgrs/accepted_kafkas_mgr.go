@@ -65,6 +65,21 @@ func (k *AcceptedKafkaManager) Reconcile() []error {
 		glog.Infof("accepted kafkas count = %d", len(acceptedKafkas))
 	}
 
+	return append(encounteredErrors, k.reconcileAcceptedKafkas(acceptedKafkas)...)
+}
+
+func (k *AcceptedKafkaManager) GetTargetSignals() []string {
+	return kafkaTargetSignals
+}
+
+// ReconcileTargets reconciles the kafka requests with the given ids that are accepted
+func (k *AcceptedKafkaManager) ReconcileTargets(ids []string) []error {
+	acceptedKafkas, encounteredErrors := getTargetKafkas(k.kafkaService, ids, constants.KafkaRequestStatusAccepted)
+	return append(encounteredErrors, k.reconcileAcceptedKafkas(acceptedKafkas)...)
+}
+
+func (k *AcceptedKafkaManager) reconcileAcceptedKafkas(acceptedKafkas []*dbapi.KafkaRequest) []error {
+	var encounteredErrors []error
 	for _, kafka := range acceptedKafkas {
 		glog.V(10).Infof("accepted kafka id = %s", kafka.ID)
 		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusAccepted, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
@@ -73,7 +88,6 @@ func (k *AcceptedKafkaManager) Reconcile() []error {
 			continue
 		}
 	}
-
 	return encounteredErrors
 }
 
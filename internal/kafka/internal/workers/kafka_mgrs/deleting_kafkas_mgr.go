@@ -28,6 +28,7 @@ func NewDeletingKafkaManager(kafkaService services.KafkaService, keycloakConfig
 		BaseWorker: workers.BaseWorker{
 			Id:         uuid.New().String(),
 			WorkerType: "deleting_kafka",
+			Sharded:    true,
 			Reconciler: reconciler,
 		},
 		kafkaService:        kafkaService,
@@ -81,14 +82,38 @@ func (k *DeletingKafkaManager) Reconcile() []error {
 
 	glog.Infof("An additional of kafkas count = %d which are marked for removal before being provisioned will also be deleted", len(deletingKafkas)-originalTotalKafkaInDeleting)
 
+	return append(encounteredErrors, k.reconcileDeletingKafkaList(deletingKafkas)...)
+}
+
+func (k *DeletingKafkaManager) GetTargetSignals() []string {
+	return kafkaTargetSignals
+}
+
+// ReconcileTargets reconciles the kafka requests with the given ids that are deleting, or deprovisioning without
+// having been provisioned
+func (k *DeletingKafkaManager) ReconcileTargets(ids []string) []error {
+	kafkas, encounteredErrors := getTargetKafkas(k.kafkaService, ids, constants.KafkaRequestStatusDeleting, constants.KafkaRequestStatusDeprovision)
+	var deletingKafkas []*dbapi.KafkaRequest
+	for _, kafka := range kafkas {
+		if kafka.Status == constants.KafkaRequestStatusDeleting.String() || kafka.BootstrapServerHost == "" {
+			deletingKafkas = append(deletingKafkas, kafka)
+		}
+	}
+	return append(encounteredErrors, k.reconcileDeletingKafkaList(deletingKafkas)...)
+}
+
+func (k *DeletingKafkaManager) reconcileDeletingKafkaList(deletingKafkas []*dbapi.KafkaRequest) []error {
+	var encounteredErrors []error
 	for _, kafka := range deletingKafkas {
+		if !k.InShard(kafka.ID) {
+			continue
+		}
 		glog.V(10).Infof("deleting kafka id = %s", kafka.ID)
 		if err := k.reconcileDeletingKafkas(kafka); err != nil {
 			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to reconcile deleting kafka request %s", kafka.ID))
 			continue
 		}
 	}
-
 	return encounteredErrors
 }
 
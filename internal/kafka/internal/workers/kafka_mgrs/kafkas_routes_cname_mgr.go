@@ -60,15 +60,15 @@ func (k *KafkaRoutesCNAMEManager) Reconcile() []error {
 					continue
 				}
 
-				kafka.RoutesCreationId = *changeOutput.ChangeInfo.Id
-				kafka.RoutesCreated = *changeOutput.ChangeInfo.Status == "INSYNC"
+				kafka.RoutesCreationId = *changeOutput.Id
+				kafka.RoutesCreated = *changeOutput.Status == services.CNameRecordStatusInSync
 			} else {
 				recordStatus, err := k.kafkaService.GetCNAMERecordStatus(kafka)
 				if err != nil {
 					errs = append(errs, err)
 					continue
 				}
-				kafka.RoutesCreated = *recordStatus.Status == "INSYNC"
+				kafka.RoutesCreated = *recordStatus.Status == services.CNameRecordStatusInSync
 			}
 		} else {
 			glog.Infof("external certificate is disabled, skip CNAME creation for Kafka %s", kafka.ID)
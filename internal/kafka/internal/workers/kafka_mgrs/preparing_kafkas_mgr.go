@@ -29,6 +29,7 @@ func NewPreparingKafkaManager(kafkaService services.KafkaService, reconciler wor
 		BaseWorker: workers.BaseWorker{
 			Id:         uuid.New().String(),
 			WorkerType: "preparing_kafka",
+			Sharded:    true,
 			Reconciler: reconciler,
 		},
 		kafkaService: kafkaService,
@@ -57,7 +58,25 @@ func (k *PreparingKafkaManager) Reconcile() []error {
 		glog.Infof("preparing kafkas count = %d", len(preparingKafkas))
 	}
 
+	return append(encounteredErrors, k.reconcilePreparingKafkas(preparingKafkas)...)
+}
+
+func (k *PreparingKafkaManager) GetTargetSignals() []string {
+	return kafkaTargetSignals
+}
+
+// ReconcileTargets reconciles the kafka requests with the given ids that are preparing
+func (k *PreparingKafkaManager) ReconcileTargets(ids []string) []error {
+	preparingKafkas, encounteredErrors := getTargetKafkas(k.kafkaService, ids, constants.KafkaRequestStatusPreparing)
+	return append(encounteredErrors, k.reconcilePreparingKafkas(preparingKafkas)...)
+}
+
+func (k *PreparingKafkaManager) reconcilePreparingKafkas(preparingKafkas []*dbapi.KafkaRequest) []error {
+	var encounteredErrors []error
 	for _, kafka := range preparingKafkas {
+		if !k.InShard(kafka.ID) {
+			continue
+		}
 		glog.V(10).Infof("preparing kafka id = %s", kafka.ID)
 		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusPreparing, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
 		if err := k.reconcilePreparingKafka(kafka); err != nil {
@@ -66,7 +85,6 @@ func (k *PreparingKafkaManager) Reconcile() []error {
 		}
 
 	}
-
 	return encounteredErrors
 }
 
@@ -30,6 +30,7 @@ func NewReadyKafkaManager(kafkaService services.KafkaService, keycloakService ss
 		BaseWorker: workers.BaseWorker{
 			Id:         uuid.New().String(),
 			WorkerType: "ready_kafka",
+			Sharded:    true,
 			Reconciler: reconciler,
 		},
 		kafkaService:    kafkaService,
@@ -63,14 +64,34 @@ func (k *ReadyKafkaManager) Reconcile() []error {
 		glog.Infof("ready kafkas count = %d", len(readyKafkas))
 	}
 
+	return append(encounteredErrors, k.reconcileReadyKafkas(readyKafkas)...)
+}
+
+func (k *ReadyKafkaManager) GetTargetSignals() []string {
+	return kafkaTargetSignals
+}
+
+// ReconcileTargets reconciles the kafka requests with the given ids that are ready
+func (k *ReadyKafkaManager) ReconcileTargets(ids []string) []error {
+	if !k.keycloakConfig.EnableAuthenticationOnKafka {
+		return nil
+	}
+	readyKafkas, encounteredErrors := getTargetKafkas(k.kafkaService, ids, constants.KafkaRequestStatusReady)
+	return append(encounteredErrors, k.reconcileReadyKafkas(readyKafkas)...)
+}
+
+func (k *ReadyKafkaManager) reconcileReadyKafkas(readyKafkas []*dbapi.KafkaRequest) []error {
+	var encounteredErrors []error
 	for _, kafka := range readyKafkas {
+		if !k.InShard(kafka.ID) {
+			continue
+		}
 		glog.V(10).Infof("ready kafka id = %s", kafka.ID)
 
 		if err := k.reconcileCanaryServiceAccount(kafka); err != nil {
 			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to create ready kafka canary service account: %q", kafka.ID))
 		}
 	}
-
 	return encounteredErrors
 }
 
@@ -0,0 +1,41 @@
+package kafka_mgrs
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/pkg/errors"
+)
+
+// kafkaTargetSignals are the signals notifying the kafka managers of the kafka requests whose status changed
+var kafkaTargetSignals = []string{services.KafkaStatusSignal}
+
+// getTargetKafkas returns the kafka requests with the given ids that are in one of the given statuses.
+// The kafka requests that don't exist anymore are skipped.
+func getTargetKafkas(kafkaService services.KafkaService, ids []string, statuses ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, []error) {
+	var kafkas []*dbapi.KafkaRequest
+	var errs []error
+	for _, id := range ids {
+		kafka, err := kafkaService.GetByID(id)
+		if err != nil {
+			if err.Is404() {
+				continue
+			}
+			errs = append(errs, errors.Wrapf(err, "failed to get kafka %s", id))
+			continue
+		}
+		for _, status := range statuses {
+			if kafka.Status == status.String() {
+				kafkas = append(kafkas, kafka)
+				break
+			}
+		}
+	}
+	return kafkas, errs
+}
+
+var _ workers.TargetedWorker = &AcceptedKafkaManager{}
+var _ workers.TargetedWorker = &PreparingKafkaManager{}
+var _ workers.TargetedWorker = &ReadyKafkaManager{}
+var _ workers.TargetedWorker = &DeletingKafkaManager{}
@@ -0,0 +1,101 @@
+package kafka_mgrs
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func Test_getTargetKafkas(t *testing.T) {
+	kafkas := map[string]*dbapi.KafkaRequest{
+		"preparing": mockKafkas.BuildKafkaRequest(
+			mockKafkas.With(mockKafkas.ID, "preparing"),
+			mockKafkas.With(mockKafkas.STATUS, constants.KafkaRequestStatusPreparing.String()),
+		),
+		"ready": mockKafkas.BuildKafkaRequest(
+			mockKafkas.With(mockKafkas.ID, "ready"),
+			mockKafkas.With(mockKafkas.STATUS, constants.KafkaRequestStatusReady.String()),
+		),
+	}
+	kafkaService := &services.KafkaServiceMock{
+		GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+			if id == "failing" {
+				return nil, errors.GeneralError("failed to get kafka")
+			}
+			if kafka, ok := kafkas[id]; ok {
+				return kafka, nil
+			}
+			return nil, errors.NotFound("kafka %s not found", id)
+		},
+	}
+
+	tests := []struct {
+		name       string
+		ids        []string
+		statuses   []constants.KafkaStatus
+		wantIds    []string
+		wantErrors int
+	}{
+		{
+			name:     "should return the kafkas in the given statuses",
+			ids:      []string{"preparing", "ready"},
+			statuses: []constants.KafkaStatus{constants.KafkaRequestStatusPreparing},
+			wantIds:  []string{"preparing"},
+		},
+		{
+			name:     "should skip the kafkas that don't exist anymore",
+			ids:      []string{"deleted", "ready"},
+			statuses: []constants.KafkaStatus{constants.KafkaRequestStatusPreparing, constants.KafkaRequestStatusReady},
+			wantIds:  []string{"ready"},
+		},
+		{
+			name:       "should return the errors of the kafkas that can't be retrieved",
+			ids:        []string{"failing", "preparing"},
+			statuses:   []constants.KafkaStatus{constants.KafkaRequestStatusPreparing},
+			wantIds:    []string{"preparing"},
+			wantErrors: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, errs := getTargetKafkas(kafkaService, tt.ids, tt.statuses...)
+			g.Expect(errs).To(gomega.HaveLen(tt.wantErrors))
+			var gotIds []string
+			for _, kafka := range got {
+				gotIds = append(gotIds, kafka.ID)
+			}
+			g.Expect(gotIds).To(gomega.Equal(tt.wantIds))
+		})
+	}
+}
+
+func TestPreparingKafkaManager_ReconcileTargets(t *testing.T) {
+	g := gomega.NewWithT(t)
+	kafkaService := &services.KafkaServiceMock{
+		GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+			return mockKafkas.BuildKafkaRequest(
+				mockKafkas.With(mockKafkas.ID, id),
+				mockKafkas.With(mockKafkas.STATUS, constants.KafkaRequestStatusPreparing.String()),
+			), nil
+		},
+		PrepareKafkaRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+			return nil
+		},
+		UpdateFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+			return nil
+		},
+	}
+	k := &PreparingKafkaManager{kafkaService: kafkaService}
+
+	g.Expect(k.GetTargetSignals()).To(gomega.Equal([]string{services.KafkaStatusSignal}))
+	g.Expect(k.ReconcileTargets([]string{"1", "2"})).To(gomega.BeEmpty())
+	g.Expect(kafkaService.PrepareKafkaRequestCalls()).To(gomega.HaveLen(2))
+}
@@ -0,0 +1,41 @@
+package service_account_mgrs
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+)
+
+// ServiceAccountExpiryManager represents a manager that periodically revokes the expired service account credentials.
+type ServiceAccountExpiryManager struct {
+	workers.BaseWorker
+	serviceAccountExpiryService services.ServiceAccountExpiryService
+}
+
+// NewServiceAccountExpiryManager creates a new manager to revoke the expired service account credentials.
+func NewServiceAccountExpiryManager(serviceAccountExpiryService services.ServiceAccountExpiryService, reconciler workers.Reconciler) *ServiceAccountExpiryManager {
+	return &ServiceAccountExpiryManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "service_account_expiry",
+			Reconciler: reconciler,
+		},
+		serviceAccountExpiryService: serviceAccountExpiryService,
+	}
+}
+
+// Start initializes the manager to revoke the expired service account credentials.
+func (m *ServiceAccountExpiryManager) Start() {
+	m.StartWorker(m)
+}
+
+// Stop causes the process for revoking the expired service account credentials to stop.
+func (m *ServiceAccountExpiryManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *ServiceAccountExpiryManager) Reconcile() []error {
+	glog.Infoln("revoking expired service account credentials")
+	return m.serviceAccountExpiryService.RevokeExpiredCredentials()
+}
@@ -0,0 +1,53 @@
+package service_account_mgrs
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func TestServiceAccountExpiryManager_Reconcile(t *testing.T) {
+	type fields struct {
+		serviceAccountExpiryService services.ServiceAccountExpiryService
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "should succeed when the expired credentials are revoked",
+			fields: fields{
+				serviceAccountExpiryService: &services.ServiceAccountExpiryServiceMock{
+					RevokeExpiredCredentialsFunc: func() []error {
+						return nil
+					},
+				},
+			},
+		},
+		{
+			name: "should return the errors of the revocation",
+			fields: fields{
+				serviceAccountExpiryService: &services.ServiceAccountExpiryServiceMock{
+					RevokeExpiredCredentialsFunc: func() []error {
+						return []error{errors.New("failed to revoke")}
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			m := NewServiceAccountExpiryManager(tt.fields.serviceAccountExpiryService, workers.Reconciler{})
+			g.Expect(len(m.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
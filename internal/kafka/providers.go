@@ -3,6 +3,8 @@ package kafka
 import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/acl"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters"
+	cmdadmin "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cmd/admin"
+	cmdsimulator "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cmd/simulator"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/handlers"
@@ -15,12 +17,14 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/cluster_mgrs"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/promotion"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/service_account_mgrs"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 
 	observatoriumClient "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/observatorium"
 	environments2 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/providers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/kafkaaccess"
 	"github.com/goava/di"
 )
 
@@ -43,6 +47,7 @@ func ConfigProviders() di.Option {
 		// Configuration for the Kafka service...
 		di.Provide(config.NewAWSConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(config.NewGCPConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
+		di.Provide(config.NewDNSConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 
 		di.Provide(config.NewSupportedProvidersConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 		di.Provide(observatoriumClient.NewObservabilityConfigurationConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
@@ -55,6 +60,8 @@ func ConfigProviders() di.Option {
 		// Additional CLI subcommands
 		di.Provide(environments2.Func(ServiceProviders)),
 		di.Provide(migrations.New),
+		di.Provide(cmdadmin.NewAdminCommand),
+		di.Provide(cmdsimulator.NewAgentSimulatorCommand),
 
 		metrics.ConfigProviders(),
 	)
@@ -64,6 +71,9 @@ func ServiceProviders() di.Option {
 	return di.Options(
 		di.Provide(services.NewClusterService),
 		di.Provide(services.NewKafkaService, di.As(new(services.KafkaService))),
+		di.Provide(services.NewKafkaAccessGrantService, di.As(new(services.KafkaAccessGrantService)), di.As(new(kafkaaccess.ConnectorBindingValidator)),
+			di.As(new(kafkaaccess.KafkaLocator))),
+		di.Provide(services.NewKafkaTemplateService, di.As(new(services.KafkaTemplateService))),
 		di.Provide(services.NewCloudProvidersService),
 		di.Provide(services.NewSupportedKafkaInstanceTypesService),
 		di.Provide(services.NewObservatoriumService),
@@ -71,6 +81,8 @@ func ServiceProviders() di.Option {
 		di.Provide(services.NewClusterPlacementStrategy),
 		di.Provide(services.NewDataPlaneClusterService, di.As(new(services.DataPlaneClusterService))),
 		di.Provide(services.NewDataPlaneKafkaService, di.As(new(services.DataPlaneKafkaService))),
+		di.Provide(services.NewServiceAccountExpiryService),
+		di.Provide(services.NewServiceAccountBindingService, di.As(new(services.ServiceAccountBindingService)), di.As(new(kafkaaccess.ServiceAccountScopeValidator))),
 		di.Provide(handlers.NewAuthenticationBuilder),
 		di.Provide(clusters.NewDefaultProviderFactory, di.As(new(clusters.ProviderFactory))),
 		di.Provide(routes.NewRouteLoader),
@@ -89,6 +101,7 @@ func ServiceProviders() di.Option {
 		di.Provide(kafka_mgrs.NewKafkaCNAMEManager, di.As(new(workers.Worker))),
 		di.Provide(promotion.NewPromotionKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewKafkasRoutesTLSCertificateManager, di.As(new(workers.Worker))),
+		di.Provide(service_account_mgrs.NewServiceAccountExpiryManager, di.As(new(workers.Worker))),
 		di.Provide(acl.NewEnterpriseClustersAccessControlMiddleware),
 		di.Provide(kafkatlscertmgmt.NewKafkaTLSCertificateManagementService),
 	)
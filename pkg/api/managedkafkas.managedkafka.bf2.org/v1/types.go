@@ -77,14 +77,29 @@ type ServiceAccount struct {
 	Password  string `json:"password"`
 }
 
+// ServiceAccountBinding grants a role on the Kafka instance to a service account scoped to it
+type ServiceAccountBinding struct {
+	Principal string `json:"principal"`
+	Role      string `json:"role"`
+}
+
+// ServiceAccountScopesSpec lists the service accounts of the owning organisation that are scoped to some Kafka
+// instances. The bound principals are only granted their role, and the denied ones, which are scoped to other
+// instances only, must be denied any access.
+type ServiceAccountScopesSpec struct {
+	Bindings         []ServiceAccountBinding `json:"bindings"`
+	DeniedPrincipals []string                `json:"deniedPrincipals"`
+}
+
 type ManagedKafkaSpec struct {
-	Capacity        Capacity         `json:"capacity"`
-	OAuth           OAuthSpec        `json:"oauth"`
-	Endpoint        EndpointSpec     `json:"endpoint"`
-	Versions        VersionsSpec     `json:"versions"`
-	Deleted         bool             `json:"deleted"`
-	Owners          []string         `json:"owners"`
-	ServiceAccounts []ServiceAccount `json:"service_accounts"`
+	Capacity             Capacity                  `json:"capacity"`
+	OAuth                OAuthSpec                 `json:"oauth"`
+	Endpoint             EndpointSpec              `json:"endpoint"`
+	Versions             VersionsSpec              `json:"versions"`
+	Deleted              bool                      `json:"deleted"`
+	Owners               []string                  `json:"owners"`
+	ServiceAccounts      []ServiceAccount          `json:"service_accounts"`
+	ServiceAccountScopes *ServiceAccountScopesSpec `json:"serviceAccountScopes,omitempty"`
 }
 
 type ManagedKafka struct {
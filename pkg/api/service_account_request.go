@@ -1,6 +1,13 @@
 package api
 
+import "time"
+
 type ServiceAccountRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
+	// CredentialsExpireAt is the requested expiry of the credentials, the configured lifetime applies when nil
+	CredentialsExpireAt *time.Time `json:"credentials_expire_at,omitempty"`
+	// Scope restricts the service account to some Kafka instances and connector clusters, it is organisation-wide
+	// when nil
+	Scope *ServiceAccountScope `json:"scope,omitempty"`
 }
@@ -10,4 +10,25 @@ type ServiceAccount struct {
 	CreatedBy    string    `json:"owner,omitempty"`
 	Description  string    `json:"description,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
+	// CredentialsExpireAt is nil when the credentials never expire
+	CredentialsExpireAt *time.Time `json:"credentials_expire_at,omitempty"`
+	// CredentialsRotationDue is true when the credentials expire soon or have expired
+	CredentialsRotationDue bool `json:"credentials_rotation_due,omitempty"`
+	// Scope is nil when the service account is organisation-wide
+	Scope *ServiceAccountScope `json:"scope,omitempty"`
+}
+
+// ServiceAccountScope binds a service account to the Kafka instances and connector clusters it can be used with.
+// The service account is denied access to any other Kafka instance or connector cluster.
+type ServiceAccountScope struct {
+	KafkaIDs            []string `json:"kafka_ids,omitempty"`
+	ConnectorClusterIDs []string `json:"connector_cluster_ids,omitempty"`
+	// Role is the role of the service account on the Kafka instances it is bound to
+	Role string `json:"role,omitempty"`
+}
+
+// IsEmpty returns true when the scope binds the service account to nothing, i.e. the service account is
+// organisation-wide
+func (s *ServiceAccountScope) IsEmpty() bool {
+	return s == nil || (len(s.KafkaIDs) == 0 && len(s.ConnectorClusterIDs) == 0)
 }
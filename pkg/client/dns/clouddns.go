@@ -0,0 +1,276 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	defaultCloudDNSEndpoint = "https://dns.googleapis.com/dns/v1/"
+	cloudDNSScope           = "https://www.googleapis.com/auth/ndev.clouddns.readwrite"
+	cloudDNSTimeout         = 30 * time.Second
+)
+
+// CloudDNSConfig contains the GCP settings for Cloud DNS
+type CloudDNSConfig struct {
+	// Credentials is a GCP service account key in JSON format
+	Credentials []byte
+	// ProjectID defaults to the project of the service account
+	ProjectID string
+	// ManagedZone is the name of the managed zone, it defaults to the public managed zone of the domain
+	ManagedZone string
+	// Endpoint overrides the Cloud DNS API endpoint
+	Endpoint string
+}
+
+type serviceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	ProjectID    string `json:"project_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+var _ Provider = &cloudDNSProvider{}
+
+type cloudDNSProvider struct {
+	client      *http.Client
+	endpoint    string
+	projectID   string
+	managedZone string
+}
+
+// cloudDNSRecordSet is a Cloud DNS ResourceRecordSet
+type cloudDNSRecordSet struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     int64    `json:"ttl"`
+	Rrdatas []string `json:"rrdatas"`
+}
+
+// cloudDNSChange is a Cloud DNS Change
+type cloudDNSChange struct {
+	Id        string              `json:"id,omitempty"`
+	Status    string              `json:"status,omitempty"`
+	Additions []cloudDNSRecordSet `json:"additions,omitempty"`
+	Deletions []cloudDNSRecordSet `json:"deletions,omitempty"`
+}
+
+type cloudDNSStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *cloudDNSStatusError) Error() string {
+	return fmt.Sprintf("cloud dns request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+func NewCloudDNSProvider(config CloudDNSConfig) (Provider, error) {
+	var key serviceAccountKey
+	if err := json.Unmarshal(config.Credentials, &key); err != nil {
+		return nil, fmt.Errorf("invalid GCP service account key: %v", err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("invalid GCP service account key: client_email and private_key are required")
+	}
+	if config.ProjectID == "" {
+		config.ProjectID = key.ProjectID
+	}
+
+	jwtConfig := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{cloudDNSScope},
+		TokenURL:     key.TokenURI,
+	}
+	client := jwtConfig.Client(context.Background())
+	client.Timeout = cloudDNSTimeout
+	return newCloudDNSProvider(config, client)
+}
+
+func newCloudDNSProvider(config CloudDNSConfig, client *http.Client) (*cloudDNSProvider, error) {
+	if config.ProjectID == "" {
+		return nil, fmt.Errorf("a GCP project id is required for cloud dns")
+	}
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = defaultCloudDNSEndpoint
+	}
+	if !strings.HasSuffix(endpoint, "/") {
+		endpoint += "/"
+	}
+	return &cloudDNSProvider{
+		client:      client,
+		endpoint:    endpoint,
+		projectID:   config.ProjectID,
+		managedZone: config.ManagedZone,
+	}, nil
+}
+
+func (p *cloudDNSProvider) ChangeRecords(domain string, changes []Change) (*ChangeStatus, error) {
+	zone, err := p.getManagedZone(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var change cloudDNSChange
+	for _, c := range changes {
+		recordSet := cloudDNSRecordSet{
+			Name:    fqdn(c.Record.Name),
+			Type:    c.Record.Type,
+			TTL:     c.Record.TTL,
+			Rrdatas: []string{recordValue(c.Record)},
+		}
+		switch c.Action {
+		case ChangeActionCreate:
+			change.Additions = append(change.Additions, recordSet)
+		case ChangeActionDelete:
+			change.Deletions = append(change.Deletions, recordSet)
+		default:
+			return nil, fmt.Errorf("unknown DNS change action %q", c.Action)
+		}
+	}
+
+	var result cloudDNSChange
+	err = p.do(http.MethodPost, p.zonePath(zone)+"/changes", &change, &result)
+	if statusErr, ok := err.(*cloudDNSStatusError); ok {
+		switch {
+		case statusErr.StatusCode == http.StatusConflict && len(change.Additions) > 0:
+			// some of the record sets already exist, they are replaced in a single change like route53 upserts them
+			change, err = p.replaceExistingRecordSets(zone, change)
+			if err != nil {
+				return nil, err
+			}
+			if len(change.Additions) == 0 && len(change.Deletions) == 0 {
+				return &ChangeStatus{InSync: true}, nil
+			}
+			err = p.do(http.MethodPost, p.zonePath(zone)+"/changes", &change, &result)
+		case statusErr.StatusCode == http.StatusNotFound && len(change.Additions) == 0:
+			// records already deleted
+			return &ChangeStatus{InSync: true}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ChangeStatus{
+		Id:     zone + "/" + result.Id,
+		InSync: result.Status == "done",
+	}, nil
+}
+
+// replaceExistingRecordSets returns the given change with the deletion of the existing record sets its additions
+// conflict with, Cloud DNS only deletes a record set that exactly matches the existing one. The additions of the
+// record sets that already exist as they are added are removed from the change.
+func (p *cloudDNSProvider) replaceExistingRecordSets(zone string, change cloudDNSChange) (cloudDNSChange, error) {
+	replacement := cloudDNSChange{Deletions: change.Deletions}
+	for _, addition := range change.Additions {
+		var result struct {
+			Rrsets []cloudDNSRecordSet `json:"rrsets"`
+		}
+		path := fmt.Sprintf("%s/rrsets?name=%s&type=%s", p.zonePath(zone), url.QueryEscape(addition.Name), url.QueryEscape(addition.Type))
+		if err := p.do(http.MethodGet, path, nil, &result); err != nil {
+			return cloudDNSChange{}, err
+		}
+		if len(result.Rrsets) == 1 && reflect.DeepEqual(result.Rrsets[0], addition) {
+			continue
+		}
+		replacement.Deletions = append(replacement.Deletions, result.Rrsets...)
+		replacement.Additions = append(replacement.Additions, addition)
+	}
+	return replacement, nil
+}
+
+func (p *cloudDNSProvider) GetChange(id string) (*ChangeStatus, error) {
+	zone, changeId, found := strings.Cut(id, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid cloud dns change id %q", id)
+	}
+	var result cloudDNSChange
+	if err := p.do(http.MethodGet, p.zonePath(zone)+"/changes/"+url.PathEscape(changeId), nil, &result); err != nil {
+		return nil, err
+	}
+	return &ChangeStatus{
+		Id:     id,
+		InSync: result.Status == "done",
+	}, nil
+}
+
+// getManagedZone returns the configured managed zone, or the public managed zone for the domain
+func (p *cloudDNSProvider) getManagedZone(domain string) (string, error) {
+	if p.managedZone != "" {
+		return p.managedZone, nil
+	}
+	var result struct {
+		ManagedZones []struct {
+			Name       string `json:"name"`
+			Visibility string `json:"visibility"`
+		} `json:"managedZones"`
+	}
+	path := fmt.Sprintf("projects/%s/managedZones?dnsName=%s", url.PathEscape(p.projectID), url.QueryEscape(fqdn(domain)))
+	if err := p.do(http.MethodGet, path, nil, &result); err != nil {
+		return "", err
+	}
+	for _, zone := range result.ManagedZones {
+		if zone.Visibility == "" || zone.Visibility == "public" {
+			return zone.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no cloud dns managed zone found for %s in project %s", domain, p.projectID)
+}
+
+func (p *cloudDNSProvider) zonePath(zone string) string {
+	return fmt.Sprintf("projects/%s/managedZones/%s", url.PathEscape(p.projectID), url.PathEscape(zone))
+}
+
+func (p *cloudDNSProvider) do(method string, path string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequest(method, p.endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	buf, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		message := strings.TrimSpace(string(buf))
+		if json.Unmarshal(buf, &apiErr) == nil && apiErr.Error.Message != "" {
+			message = apiErr.Error.Message
+		}
+		return &cloudDNSStatusError{StatusCode: resp.StatusCode, Message: message}
+	}
+	return json.Unmarshal(buf, result)
+}
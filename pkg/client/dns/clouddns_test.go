@@ -0,0 +1,147 @@
+package dns
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_cloudDNSProvider_ChangeRecords(t *testing.T) {
+	changes := []Change{
+		{
+			Action: ChangeActionCreate,
+			Record: Record{Name: "admin-server-kafka.example.com", Type: RecordTypeCNAME, TTL: DefaultTTL, Value: "router.cluster.example.com"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		managedZone string
+		handler     http.HandlerFunc
+		want        *ChangeStatus
+		wantErr     bool
+	}{
+		{
+			name:        "should create a change in the configured managed zone",
+			managedZone: "kafka-zone",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/projects/test-project/managedZones/kafka-zone/changes" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				var change cloudDNSChange
+				if err := json.NewDecoder(r.Body).Decode(&change); err != nil || len(change.Additions) != 1 ||
+					change.Additions[0].Rrdatas[0] != "router.cluster.example.com." {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_, _ = w.Write([]byte(`{"id": "1", "status": "pending"}`))
+			},
+			want: &ChangeStatus{Id: "kafka-zone/1", InSync: false},
+		},
+		{
+			name: "should look up the public managed zone of the domain",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/projects/test-project/managedZones" &&
+					r.URL.Query().Get("dnsName") == "example.com.":
+					_, _ = w.Write([]byte(`{"managedZones": [{"name": "private-zone", "visibility": "private"}, {"name": "public-zone", "visibility": "public"}]}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/projects/test-project/managedZones/public-zone/changes":
+					_, _ = w.Write([]byte(`{"id": "2", "status": "done"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			},
+			want: &ChangeStatus{Id: "public-zone/2", InSync: true},
+		},
+		{
+			name:        "should be in sync when the records already exist",
+			managedZone: "kafka-zone",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost:
+					w.WriteHeader(http.StatusConflict)
+					_, _ = w.Write([]byte(`{"error": {"message": "already exists"}}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/projects/test-project/managedZones/kafka-zone/rrsets" &&
+					r.URL.Query().Get("name") == "admin-server-kafka.example.com." && r.URL.Query().Get("type") == "CNAME":
+					_, _ = w.Write([]byte(`{"rrsets": [{"name": "admin-server-kafka.example.com.", "type": "CNAME", "ttl": 300, "rrdatas": ["router.cluster.example.com."]}]}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			},
+			want: &ChangeStatus{InSync: true},
+		},
+		{
+			name:        "should replace the existing records when they differ",
+			managedZone: "kafka-zone",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				existing := cloudDNSRecordSet{Name: "admin-server-kafka.example.com.", Type: "CNAME", TTL: 300, Rrdatas: []string{"old-router.cluster.example.com."}}
+				switch r.Method {
+				case http.MethodGet:
+					_ = json.NewEncoder(w).Encode(map[string][]cloudDNSRecordSet{"rrsets": {existing}})
+				case http.MethodPost:
+					var change cloudDNSChange
+					if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					if len(change.Deletions) == 0 {
+						w.WriteHeader(http.StatusConflict)
+						return
+					}
+					if !reflect.DeepEqual(change.Deletions, []cloudDNSRecordSet{existing}) || len(change.Additions) != 1 ||
+						change.Additions[0].Rrdatas[0] != "router.cluster.example.com." {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					_, _ = w.Write([]byte(`{"id": "3", "status": "pending"}`))
+				}
+			},
+			want: &ChangeStatus{Id: "kafka-zone/3", InSync: false},
+		},
+		{
+			name:        "should return an error when the existing records cannot be read",
+			managedZone: "kafka-zone",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "should return an error when the change fails",
+			managedZone: "kafka-zone",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			provider, err := newCloudDNSProvider(CloudDNSConfig{
+				ProjectID:   "test-project",
+				ManagedZone: tt.managedZone,
+				Endpoint:    server.URL,
+			}, server.Client())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			got, err := provider.ChangeRecords("example.com", changes)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+)
+
+type ProviderType string
+
+func (t ProviderType) String() string {
+	return string(t)
+}
+
+const (
+	// ProviderTypeRoute53 manages records in AWS Route53 hosted zones
+	ProviderTypeRoute53 ProviderType = "route53"
+	// ProviderTypeCloudDNS manages records in GCP Cloud DNS managed zones
+	ProviderTypeCloudDNS ProviderType = "clouddns"
+	// ProviderTypeRFC2136 manages records with dynamic DNS updates, e.g. in BIND or CoreDNS zones
+	ProviderTypeRFC2136 ProviderType = "rfc2136"
+)
+
+var ValidProviderTypes = []string{ProviderTypeRoute53.String(), ProviderTypeCloudDNS.String(), ProviderTypeRFC2136.String()}
+
+type ChangeAction string
+
+func (a ChangeAction) String() string {
+	return string(a)
+}
+
+const (
+	ChangeActionCreate ChangeAction = "CREATE"
+	ChangeActionDelete ChangeAction = "DELETE"
+)
+
+const (
+	RecordTypeCNAME = "CNAME"
+	DefaultTTL      = int64(300)
+)
+
+// Record is a DNS resource record with a single value
+type Record struct {
+	Name  string
+	Type  string
+	TTL   int64
+	Value string
+}
+
+// Change creates or deletes a record
+type Change struct {
+	Action ChangeAction
+	Record Record
+}
+
+// ChangeStatus is the status of changes applied by a provider, changes may not be visible in all name servers
+// until they are in sync
+type ChangeStatus struct {
+	// Id identifies the changes for GetChange, it's empty if there is nothing to wait for
+	Id     string
+	InSync bool
+}
+
+//go:generate moq -out provider_moq.go . Provider
+type Provider interface {
+	// ChangeRecords applies changes to records in the zone of the given domain.
+	// Creating a record that already exists or deleting a record that doesn't exist is not an error.
+	ChangeRecords(domain string, changes []Change) (*ChangeStatus, error)
+	// GetChange returns the status of changes applied by ChangeRecords
+	GetChange(id string) (*ChangeStatus, error)
+}
+
+// ProviderConfig contains the settings of a DNS provider
+type ProviderConfig struct {
+	Type     ProviderType
+	Route53  Route53Config
+	CloudDNS CloudDNSConfig
+	RFC2136  RFC2136Config
+}
+
+// Route53Config contains the AWS settings for Route53
+type Route53Config struct {
+	Credentials aws.Config
+	Region      string
+}
+
+type ProviderFactory interface {
+	NewProvider(config ProviderConfig) (Provider, error)
+}
+
+type DefaultProviderFactory struct {
+	awsClientFactory aws.ClientFactory
+}
+
+func NewDefaultProviderFactory(awsClientFactory aws.ClientFactory) *DefaultProviderFactory {
+	return &DefaultProviderFactory{
+		awsClientFactory: awsClientFactory,
+	}
+}
+
+func (f *DefaultProviderFactory) NewProvider(config ProviderConfig) (Provider, error) {
+	switch config.Type {
+	case ProviderTypeRoute53:
+		client, err := f.awsClientFactory.NewClient(config.Route53.Credentials, config.Route53.Region)
+		if err != nil {
+			return nil, err
+		}
+		return NewRoute53Provider(client), nil
+	case ProviderTypeCloudDNS:
+		return NewCloudDNSProvider(config.CloudDNS)
+	case ProviderTypeRFC2136:
+		return NewRFC2136Provider(config.RFC2136)
+	default:
+		return nil, fmt.Errorf("unknown DNS provider %q", config.Type)
+	}
+}
+
+type MockProviderFactory struct {
+	mock Provider
+}
+
+func (m *MockProviderFactory) NewProvider(config ProviderConfig) (Provider, error) {
+	return m.mock, nil
+}
+
+func NewMockProviderFactory(provider Provider) *MockProviderFactory {
+	return &MockProviderFactory{
+		mock: provider,
+	}
+}
+
+// recordValue returns the value of a record in zone file format, i.e. with fully qualified domain names
+func recordValue(record Record) string {
+	if record.Type == RecordTypeCNAME {
+		return fqdn(record.Value)
+	}
+	return record.Value
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
@@ -0,0 +1,104 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+const (
+	DefaultTSIGAlgorithm = miekgdns.HmacSHA256
+	rfc2136Timeout       = 10 * time.Second
+)
+
+// RFC2136Config contains the settings for dynamic DNS updates
+type RFC2136Config struct {
+	// Server is the host:port of the primary name server of the zone
+	Server string
+	// Zone is the zone to update, it defaults to the domain
+	Zone string
+	// TSIGKeyName is the name of the key used to sign updates, updates are not signed if it's empty
+	TSIGKeyName string
+	// TSIGSecret is the base64 encoded TSIG key
+	TSIGSecret string
+	// TSIGAlgorithm defaults to hmac-sha256
+	TSIGAlgorithm string
+}
+
+var _ Provider = &rfc2136Provider{}
+
+type rfc2136Provider struct {
+	config RFC2136Config
+	client *miekgdns.Client
+}
+
+func NewRFC2136Provider(config RFC2136Config) (Provider, error) {
+	if config.Server == "" {
+		return nil, fmt.Errorf("a name server is required for rfc2136 dns updates")
+	}
+	client := &miekgdns.Client{
+		Timeout: rfc2136Timeout,
+	}
+	if config.TSIGKeyName != "" {
+		if config.TSIGSecret == "" {
+			return nil, fmt.Errorf("a TSIG secret is required for TSIG key %s", config.TSIGKeyName)
+		}
+		if config.TSIGAlgorithm == "" {
+			config.TSIGAlgorithm = DefaultTSIGAlgorithm
+		}
+		config.TSIGKeyName = miekgdns.Fqdn(strings.ToLower(config.TSIGKeyName))
+		config.TSIGAlgorithm = miekgdns.Fqdn(strings.ToLower(config.TSIGAlgorithm))
+		client.TsigSecret = map[string]string{config.TSIGKeyName: config.TSIGSecret}
+	}
+	return &rfc2136Provider{
+		config: config,
+		client: client,
+	}, nil
+}
+
+func (p *rfc2136Provider) ChangeRecords(domain string, changes []Change) (*ChangeStatus, error) {
+	zone := p.config.Zone
+	if zone == "" {
+		zone = domain
+	}
+	zone = miekgdns.Fqdn(zone)
+
+	msg := new(miekgdns.Msg)
+	msg.SetUpdate(zone)
+	for _, c := range changes {
+		rr, err := miekgdns.NewRR(fmt.Sprintf("%s %d IN %s %s",
+			miekgdns.Fqdn(c.Record.Name), c.Record.TTL, c.Record.Type, recordValue(c.Record)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNS record %s: %v", c.Record.Name, err)
+		}
+		switch c.Action {
+		case ChangeActionCreate:
+			// replace any existing record, a name can only have one CNAME record
+			msg.RemoveRRset([]miekgdns.RR{rr})
+			msg.Insert([]miekgdns.RR{rr})
+		case ChangeActionDelete:
+			msg.Remove([]miekgdns.RR{rr})
+		default:
+			return nil, fmt.Errorf("unknown DNS change action %q", c.Action)
+		}
+	}
+	if p.config.TSIGKeyName != "" {
+		msg.SetTsig(p.config.TSIGKeyName, p.config.TSIGAlgorithm, 300, time.Now().Unix())
+	}
+
+	resp, _, err := p.client.Exchange(msg, p.config.Server)
+	if err != nil {
+		return nil, fmt.Errorf("dns update of zone %s failed: %v", zone, err)
+	}
+	if resp.Rcode != miekgdns.RcodeSuccess {
+		return nil, fmt.Errorf("dns update of zone %s failed: %s", zone, miekgdns.RcodeToString[resp.Rcode])
+	}
+	// the primary name server has applied the update when it responds
+	return &ChangeStatus{InSync: true}, nil
+}
+
+func (p *rfc2136Provider) GetChange(id string) (*ChangeStatus, error) {
+	return &ChangeStatus{Id: id, InSync: true}, nil
+}
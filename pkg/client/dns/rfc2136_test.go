@@ -0,0 +1,152 @@
+package dns
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+	"github.com/onsi/gomega"
+)
+
+const (
+	testTSIGKeyName = "kas-fleet-manager."
+	testTSIGSecret  = "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3JldA=="
+)
+
+// testUpdateServer is a name server that records the dynamic updates it receives
+type testUpdateServer struct {
+	mu      sync.Mutex
+	updates []*miekgdns.Msg
+	rcode   int
+}
+
+func (s *testUpdateServer) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
+	resp := new(miekgdns.Msg)
+	resp.SetReply(r)
+	switch {
+	case r.IsTsig() == nil:
+		resp.Rcode = miekgdns.RcodeRefused
+	case w.TsigStatus() != nil:
+		resp.Rcode = miekgdns.RcodeNotAuth
+	default:
+		s.mu.Lock()
+		s.updates = append(s.updates, r)
+		s.mu.Unlock()
+		resp.Rcode = s.rcode
+	}
+	if tsig := r.IsTsig(); tsig != nil {
+		resp.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+	}
+	_ = w.WriteMsg(resp)
+}
+
+func startTestUpdateServer(t *testing.T, handler *testUpdateServer) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	started := make(chan struct{})
+	server := &miekgdns.Server{
+		PacketConn:        conn,
+		Handler:           handler,
+		TsigSecret:        map[string]string{testTSIGKeyName: testTSIGSecret},
+		NotifyStartedFunc: func() { close(started) },
+		// the default accept func only accepts queries and notifies
+		MsgAcceptFunc: func(dh miekgdns.Header) miekgdns.MsgAcceptAction { return miekgdns.MsgAccept },
+	}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	<-started
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+	return conn.LocalAddr().String()
+}
+
+func Test_rfc2136Provider_ChangeRecords(t *testing.T) {
+	changes := []Change{
+		{
+			Action: ChangeActionCreate,
+			Record: Record{Name: "admin-server-kafka.example.com", Type: RecordTypeCNAME, TTL: DefaultTTL, Value: "router.cluster.example.com"},
+		},
+		{
+			Action: ChangeActionDelete,
+			Record: Record{Name: "broker-0-kafka.example.com", Type: RecordTypeCNAME, TTL: DefaultTTL, Value: "router.cluster.example.com"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		config      RFC2136Config
+		rcode       int
+		wantErr     bool
+		wantUpdates int
+	}{
+		{
+			name: "should sign and send updates to the zone of the domain",
+			config: RFC2136Config{
+				TSIGKeyName: "kas-fleet-manager",
+				TSIGSecret:  testTSIGSecret,
+			},
+			rcode:       miekgdns.RcodeSuccess,
+			wantUpdates: 1,
+		},
+		{
+			name: "should return an error when the update is rejected",
+			config: RFC2136Config{
+				TSIGKeyName: "kas-fleet-manager",
+				TSIGSecret:  testTSIGSecret,
+			},
+			rcode:       miekgdns.RcodeServerFailure,
+			wantErr:     true,
+			wantUpdates: 1,
+		},
+		{
+			name: "should return an error when the TSIG key is wrong",
+			config: RFC2136Config{
+				TSIGKeyName: "kas-fleet-manager",
+				TSIGSecret:  "d3Jvbmctc2VjcmV0",
+			},
+			rcode:   miekgdns.RcodeSuccess,
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when updates are not signed",
+			config:  RFC2136Config{},
+			rcode:   miekgdns.RcodeSuccess,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			handler := &testUpdateServer{rcode: tt.rcode}
+			tt.config.Server = startTestUpdateServer(t, handler)
+
+			provider, err := NewRFC2136Provider(tt.config)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			status, err := provider.ChangeRecords("example.com", changes)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(handler.updates).To(gomega.HaveLen(tt.wantUpdates))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(status.InSync).To(gomega.BeTrue())
+
+			update := handler.updates[0]
+			g.Expect(update.Question[0].Name).To(gomega.Equal("example.com."))
+			// remove and insert the created record, remove the deleted record
+			g.Expect(update.Ns).To(gomega.HaveLen(3))
+			g.Expect(update.Ns[0].Header().Class).To(gomega.Equal(uint16(miekgdns.ClassANY)))
+			g.Expect(update.Ns[1].(*miekgdns.CNAME).Target).To(gomega.Equal("router.cluster.example.com."))
+			g.Expect(update.Ns[2].Header().Name).To(gomega.Equal("broker-0-kafka.example.com."))
+			g.Expect(update.Ns[2].Header().Class).To(gomega.Equal(uint16(miekgdns.ClassNONE)))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package dns
+
+import (
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+)
+
+var _ Provider = &route53Provider{}
+
+type route53Provider struct {
+	client aws.AWSClient
+}
+
+func NewRoute53Provider(client aws.AWSClient) Provider {
+	return &route53Provider{
+		client: client,
+	}
+}
+
+func (p *route53Provider) ChangeRecords(domain string, changes []Change) (*ChangeStatus, error) {
+	batch := &route53.ChangeBatch{}
+	for _, c := range changes {
+		action := c.Action.String()
+		record := c.Record
+		batch.Changes = append(batch.Changes, &route53.Change{
+			Action: &action,
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name: &record.Name,
+				Type: &record.Type,
+				TTL:  &record.TTL,
+				ResourceRecords: []*route53.ResourceRecord{
+					{
+						Value: &record.Value,
+					},
+				},
+			},
+		})
+	}
+
+	output, err := p.client.ChangeResourceRecordSets(domain, batch)
+	if err != nil {
+		return nil, err
+	}
+	if output == nil {
+		// records already created or deleted
+		return &ChangeStatus{InSync: true}, nil
+	}
+	return toChangeStatus(output.ChangeInfo), nil
+}
+
+func (p *route53Provider) GetChange(id string) (*ChangeStatus, error) {
+	output, err := p.client.GetChange(id)
+	if err != nil {
+		return nil, err
+	}
+	return toChangeStatus(output.ChangeInfo), nil
+}
+
+func toChangeStatus(info *route53.ChangeInfo) *ChangeStatus {
+	status := &ChangeStatus{}
+	if info.Id != nil {
+		status.Id = *info.Id
+	}
+	status.InSync = info.Status != nil && *info.Status == route53.ChangeStatusInsync
+	return status
+}
@@ -34,6 +34,7 @@ type KcClient interface {
 	GetToken() (string, error)
 	GetCachedToken(tokenKey string) (string, error)
 	DeleteClient(internalClientID string, accessToken string) error
+	UpdateClient(client gocloak.Client, accessToken string) error
 	GetClientSecret(internalClientId string, accessToken string) (string, error)
 	GetClient(clientId string, accessToken string) (*gocloak.Client, error)
 	IsClientExist(clientId string, accessToken string) (string, error)
@@ -217,6 +218,10 @@ func (kc *kcClient) DeleteClient(internalClientID string, accessToken string) er
 	return kc.kcClient.DeleteClient(kc.ctx, accessToken, kc.realmConfig.Realm, internalClientID)
 }
 
+func (kc *kcClient) UpdateClient(client gocloak.Client, accessToken string) error {
+	return kc.kcClient.UpdateClient(kc.ctx, accessToken, kc.realmConfig.Realm, client)
+}
+
 func (kc *kcClient) getClient(clientId string, accessToken string) ([]*gocloak.Client, error) {
 	params := gocloak.GetClientsParams{
 		ClientID: &clientId,
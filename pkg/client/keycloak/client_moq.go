@@ -78,6 +78,9 @@ var _ KcClient = &KcClientMock{}
 //			RegenerateClientSecretFunc: func(accessToken string, id string) (*gocloak.CredentialRepresentation, error) {
 //				panic("mock out the RegenerateClientSecret method")
 //			},
+//			UpdateClientFunc: func(client gocloak.Client, accessToken string) error {
+//				panic("mock out the UpdateClient method")
+//			},
 //			UpdateServiceAccountUserFunc: func(accessToken string, serviceAccountUser gocloak.User) error {
 //				panic("mock out the UpdateServiceAccountUser method")
 //			},
@@ -151,6 +154,9 @@ type KcClientMock struct {
 	// RegenerateClientSecretFunc mocks the RegenerateClientSecret method.
 	RegenerateClientSecretFunc func(accessToken string, id string) (*gocloak.CredentialRepresentation, error)
 
+	// UpdateClientFunc mocks the UpdateClient method.
+	UpdateClientFunc func(client gocloak.Client, accessToken string) error
+
 	// UpdateServiceAccountUserFunc mocks the UpdateServiceAccountUser method.
 	UpdateServiceAccountUserFunc func(accessToken string, serviceAccountUser gocloak.User) error
 
@@ -287,6 +293,13 @@ type KcClientMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// UpdateClient holds details about calls to the UpdateClient method.
+		UpdateClient []struct {
+			// Client is the client argument value.
+			Client gocloak.Client
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+		}
 		// UpdateServiceAccountUser holds details about calls to the UpdateServiceAccountUser method.
 		UpdateServiceAccountUser []struct {
 			// AccessToken is the accessToken argument value.
@@ -324,6 +337,7 @@ type KcClientMock struct {
 	lockIsOwner                    sync.RWMutex
 	lockIsSameOrg                  sync.RWMutex
 	lockRegenerateClientSecret     sync.RWMutex
+	lockUpdateClient               sync.RWMutex
 	lockUpdateServiceAccountUser   sync.RWMutex
 	lockUserHasRealmRole           sync.RWMutex
 }
@@ -1021,6 +1035,42 @@ func (mock *KcClientMock) RegenerateClientSecretCalls() []struct {
 	return calls
 }
 
+// UpdateClient calls UpdateClientFunc.
+func (mock *KcClientMock) UpdateClient(client gocloak.Client, accessToken string) error {
+	if mock.UpdateClientFunc == nil {
+		panic("KcClientMock.UpdateClientFunc: method is nil but KcClient.UpdateClient was just called")
+	}
+	callInfo := struct {
+		Client      gocloak.Client
+		AccessToken string
+	}{
+		Client:      client,
+		AccessToken: accessToken,
+	}
+	mock.lockUpdateClient.Lock()
+	mock.calls.UpdateClient = append(mock.calls.UpdateClient, callInfo)
+	mock.lockUpdateClient.Unlock()
+	return mock.UpdateClientFunc(client, accessToken)
+}
+
+// UpdateClientCalls gets all the calls that were made to UpdateClient.
+// Check the length with:
+//
+//	len(mockedKcClient.UpdateClientCalls())
+func (mock *KcClientMock) UpdateClientCalls() []struct {
+	Client      gocloak.Client
+	AccessToken string
+} {
+	var calls []struct {
+		Client      gocloak.Client
+		AccessToken string
+	}
+	mock.lockUpdateClient.RLock()
+	calls = mock.calls.UpdateClient
+	mock.lockUpdateClient.RUnlock()
+	return calls
+}
+
 // UpdateServiceAccountUser calls UpdateServiceAccountUserFunc.
 func (mock *KcClientMock) UpdateServiceAccountUser(accessToken string, serviceAccountUser gocloak.User) error {
 	if mock.UpdateServiceAccountUserFunc == nil {
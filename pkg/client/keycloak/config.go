@@ -3,6 +3,7 @@ package keycloak
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
@@ -40,6 +41,14 @@ type KeycloakConfig struct {
 	SSOSpecialManagementOrgID                  string               `json:"-"`
 	ServiceAccounttLimitCheckSkipOrgIdListFile string               `json:"-"`
 	ServiceAccounttLimitCheckSkipOrgIdList     []string             `json:"-"`
+	// ServiceAccountLimits overrides MaxAllowedServiceAccounts for the organisations it lists, whatever the SSO provider
+	ServiceAccountLimitsFile string         `json:"-"`
+	ServiceAccountLimits     map[string]int `json:"-"`
+	// ServiceAccountCredentialsLifetime is the default lifetime of the credentials of the service accounts, the
+	// credentials never expire by default
+	ServiceAccountCredentialsLifetime time.Duration `json:"service_account_credentials_lifetime"`
+	// ServiceAccountRotationReminder is how long before their expiry the credentials are reported as due for rotation
+	ServiceAccountRotationReminder time.Duration `json:"service_account_rotation_reminder"`
 }
 
 type KeycloakRealmConfig struct {
@@ -115,6 +124,9 @@ func NewKeycloakConfig() *KeycloakConfig {
 		SelectSSOProvider:                          MAS_SSO,
 		SSOSpecialManagementOrgID:                  SSO_SPEICAL_MGMT_ORG_ID_STAGE,
 		ServiceAccounttLimitCheckSkipOrgIdListFile: "config/service-account-limits-check-skip-org-id-list.yaml",
+		ServiceAccountLimitsFile:                   "config/service-account-limits.yaml",
+		ServiceAccountLimits:                       map[string]int{},
+		ServiceAccountRotationReminder:             7 * 24 * time.Hour,
 	}
 	return kc
 }
@@ -141,6 +153,9 @@ func (kc *KeycloakConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&kc.SsoBaseUrl, "redhat-sso-base-url", kc.SsoBaseUrl, "The base URL of the mas-sso, integration by default")
 	fs.StringVar(&kc.SSOSpecialManagementOrgID, "sso-special-management-org-id", SSO_SPEICAL_MGMT_ORG_ID_STAGE, "The Special Management Organization ID used for creating internal Service accounts")
 	fs.StringVar(&kc.ServiceAccounttLimitCheckSkipOrgIdListFile, "service-account-limits-check-skip-org-id-list-file", kc.ServiceAccounttLimitCheckSkipOrgIdListFile, "File containing a list of Org IDs for which service account limits check will be skipped")
+	fs.StringVar(&kc.ServiceAccountLimitsFile, "service-account-limits-file", kc.ServiceAccountLimitsFile, "File containing the max allowed number of service accounts of the organisations whose limit differs from max-allowed-service-accounts")
+	fs.DurationVar(&kc.ServiceAccountCredentialsLifetime, "service-account-credentials-lifetime", kc.ServiceAccountCredentialsLifetime, "Default lifetime of the credentials of the service accounts, the credentials never expire when set to 0")
+	fs.DurationVar(&kc.ServiceAccountRotationReminder, "service-account-rotation-reminder", kc.ServiceAccountRotationReminder, "How long before their expiry the credentials of the service accounts are reported as due for rotation")
 	fs.StringVar(&kc.SelectSSOProvider, "sso-provider-type", kc.SelectSSOProvider, "Option to choose between sso providers i.e, mas_sso or redhat_sso, mas_sso by default")
 	fs.StringVar(&kc.AdminAPISSORealm.BaseURL, "admin-api-sso-base-url", kc.AdminAPISSORealm.BaseURL, "Base url of admin api sso realm, 'https://auth.redhat.com' by default")
 	fs.StringVar(&kc.AdminAPISSORealm.APIEndpointURI, "admin-api-sso-endpoint-uri", kc.AdminAPISSORealm.APIEndpointURI, "API Endpoint URI of admin api sso realm, '/auth/realms/EmployeeIDP' by default")
@@ -151,9 +166,29 @@ func (kc *KeycloakConfig) Validate(env *environments.Env) error {
 	if kc.SelectSSOProvider != REDHAT_SSO && kc.SelectSSOProvider != MAS_SSO {
 		return fmt.Errorf("invalid sso provider selected must be `mas_sso` or `redhat_sso`")
 	}
+	if kc.ServiceAccountCredentialsLifetime < 0 {
+		return fmt.Errorf("service account credentials lifetime must not be negative")
+	}
+	if kc.ServiceAccountCredentialsLifetime > 0 && kc.SelectSSOProvider == REDHAT_SSO {
+		// the service accounts of users can only be managed with the token of the users, so the fleet manager can't revoke their credentials
+		return fmt.Errorf("service account credentials lifetime is not supported with the `redhat_sso` provider")
+	}
+	for orgId, limit := range kc.ServiceAccountLimits {
+		if limit < 0 {
+			return fmt.Errorf("service account limit of organisation %s must not be negative", orgId)
+		}
+	}
 	return nil
 }
 
+// GetMaxAllowedServiceAccounts returns the max number of service accounts the given organisation can create
+func (kc *KeycloakConfig) GetMaxAllowedServiceAccounts(orgId string) int {
+	if limit, ok := kc.ServiceAccountLimits[orgId]; ok {
+		return limit
+	}
+	return kc.MaxAllowedServiceAccounts
+}
+
 func (kc *KeycloakConfig) ReadFiles() error {
 	err := shared.ReadFileValueString(kc.KafkaRealm.ClientIDFile, &kc.KafkaRealm.ClientID)
 	if err != nil {
@@ -206,6 +241,16 @@ func (kc *KeycloakConfig) ReadFiles() error {
 		}
 	}
 
+	//Read the per organisation service account limits yaml file
+	err = shared.ReadYamlFile(kc.ServiceAccountLimitsFile, &kc.ServiceAccountLimits)
+	if err != nil {
+		if os.IsNotExist(err) {
+			glog.V(10).Infof("Specified service account limits file '%s' does not exist. Proceeding as if no organisation had a specific limit", kc.ServiceAccountLimitsFile)
+		} else {
+			return err
+		}
+	}
+
 	kc.KafkaRealm.setDefaultURIs(kc.BaseURL)
 	kc.OSDClusterIDPRealm.setDefaultURIs(kc.BaseURL)
 	kc.RedhatSSORealm.setDefaultURIs(kc.SsoBaseUrl)
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/spf13/cobra"
+)
+
+// config sub-command handles the inspection of the kas-fleet-manager configuration
+func NewConfigCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the kas-fleet-manager configuration",
+		Long:  "Inspect the Kafka Service Fleet Manager configuration",
+	}
+	cmd.AddCommand(
+		NewValidateCommand(env),
+	)
+	return cmd
+}
+
+func NewValidateCommand(env *environments.Env) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the kas-fleet-manager configuration",
+		Long: "Load and validate the configuration files and flags of the selected environment, as the serve command does, " +
+			"without connecting to the database or any other service. All the errors found are reported and the command " +
+			"exits with a non zero status when the configuration is not valid",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			errs := env.ValidateConfiguration()
+			for _, err := range errs {
+				fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+			}
+			if len(errs) > 0 {
+				fmt.Fprintf(os.Stderr, "configuration of the %s environment is not valid: %d error(s) found\n", env.Name, len(errs))
+				os.Exit(1)
+			}
+			fmt.Printf("configuration of the %s environment is valid\n", env.Name)
+		},
+	}
+}
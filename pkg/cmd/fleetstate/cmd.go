@@ -0,0 +1,58 @@
+package fleetstate
+
+import (
+	"os"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/fleetstate"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+const (
+	fileFlag           = "file"
+	secretsKeyFileFlag = "secrets-key-file"
+)
+
+// fleet-state sub-command handles the export and import of the fleet state
+func NewFleetStateCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet-state",
+		Short: "Export and import the fleet state",
+		Long: "Export the logical state of the fleet, the database rows and optionally the secret values, to an archive " +
+			"and import it into an empty database of the same schema version",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			err := env.CreateServices()
+			if err != nil {
+				glog.Fatalf("Unable to initialize environment: %s", err.Error())
+			}
+		},
+	}
+	cmd.AddCommand(
+		NewExportCommand(env),
+		NewImportCommand(env),
+	)
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command, fileUsage string, secretsKeyFileUsage string) {
+	cmd.Flags().StringP(fileFlag, "f", "", fileUsage)
+	cmd.Flags().String(secretsKeyFileFlag, "", secretsKeyFileUsage)
+	_ = cmd.MarkFlagRequired(fileFlag)
+}
+
+// readSecretsKey returns the key derived from the secrets key file, or nil when no file is given
+func readSecretsKey(cmd *cobra.Command) []byte {
+	keyFile, _ := cmd.Flags().GetString(secretsKeyFileFlag)
+	if keyFile == "" {
+		return nil
+	}
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		glog.Fatalf("Unable to read secrets key file: %s", err.Error())
+	}
+	if len(content) == 0 {
+		glog.Fatalf("Secrets key file %s is empty", keyFile)
+	}
+	return fleetstate.NewSecretsKey(content)
+}
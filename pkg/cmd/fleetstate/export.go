@@ -0,0 +1,45 @@
+package fleetstate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/fleetstate"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+func NewExportCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the fleet state to an archive",
+		Long: "Export the fleet state to a gzipped tar archive. The secret values are included, encrypted with a key " +
+			"derived from the content of the secrets key file, only when --secrets-key-file is given",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			file, _ := cmd.Flags().GetString(fileFlag)
+			secretsKey := readSecretsKey(cmd)
+			env.MustInvoke(func(fleetStateService fleetstate.FleetStateService) {
+				archive, err := fleetStateService.Export(secretsKey)
+				if err != nil {
+					glog.Fatalf("Unable to export the fleet state: %s", err.Error())
+				}
+				f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+				if err != nil {
+					glog.Fatalf("Unable to create archive: %s", err.Error())
+				}
+				defer f.Close()
+				if err := archive.Write(f); err != nil {
+					glog.Fatalf("Unable to export the fleet state: %s", err.Error())
+				}
+				for _, table := range archive.Manifest.Tables {
+					fmt.Printf("%s: %d rows\n", table.Name, table.Rows)
+				}
+				fmt.Printf("fleet state exported to %s with %d secrets\n", file, len(archive.Secrets))
+			})
+		},
+	}
+	addFlags(cmd, "Path of the archive to create", "Path of the file whose content is used to encrypt the secret values")
+	return cmd
+}
@@ -0,0 +1,43 @@
+package fleetstate
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/fleetstate"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+func NewImportCommand(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import the fleet state from an archive",
+		Long: "Import the fleet state from an archive created by the export command. The database must be migrated to the " +
+			"schema version of the archive and must not have any fleet state yet, the import is done in a single transaction",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			file, _ := cmd.Flags().GetString(fileFlag)
+			secretsKey := readSecretsKey(cmd)
+			f, err := os.Open(file)
+			if err != nil {
+				glog.Fatalf("Unable to open archive: %s", err.Error())
+			}
+			defer f.Close()
+			archive, err := fleetstate.ReadArchive(f)
+			if err != nil {
+				glog.Fatalf("Unable to import the fleet state: %s", err.Error())
+			}
+			env.MustInvoke(func(fleetStateService fleetstate.FleetStateService) {
+				if err := fleetStateService.Import(archive, secretsKey); err != nil {
+					glog.Fatalf("Unable to import the fleet state: %s", err.Error())
+				}
+				fmt.Printf("fleet state exported at %s imported from %s\n", archive.Manifest.CreatedAt.Format(time.RFC3339), file)
+			})
+		},
+	}
+	addFlags(cmd, "Path of the archive to import", "Path of the secrets key file used for the export")
+	return cmd
+}
@@ -1,6 +1,8 @@
 package migrate
 
 import (
+	"fmt"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
@@ -8,25 +10,83 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 )
 
+const (
+	toFlag     = "to"
+	dryRunFlag = "dry-run"
+)
+
 // migrate sub-command handles running migrations
 func NewMigrateCommand(env *environments.Env) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "migrate",
 		Short: "Run kas-fleet-manager data migrations",
-		Long:  "Run Kafka Service Fleet Manager data migrations",
+		Long: "Run Kafka Service Fleet Manager data migrations. With --to only the migrations up to the given one are applied, " +
+			"with --dry-run the SQL of the pending migrations is printed instead of being applied",
 		Run: func(cmd *cobra.Command, args []string) {
+			to, _ := cmd.Flags().GetString(toFlag)
+			dryRun, _ := cmd.Flags().GetBool(dryRunFlag)
 			env.MustInvoke(func(migrations []*db.Migration) {
+				migrations = filterMigrations(migrations, to)
+				if dryRun {
+					for _, migration := range migrations {
+						dryRuns, err := migration.DryRunMigrateTo(to)
+						printDryRuns(migration, dryRuns, err)
+					}
+					return
+				}
 				glog.Infoln("Migration starting")
 				for _, migration := range migrations {
-					migration.Migrate()
+					if to == "" {
+						migration.Migrate()
+					} else {
+						migration.MigrateTo(to)
+					}
 					glog.Infof("Database has %d %s applied", migration.CountMigrationsApplied(), migration.GormOptions.TableName)
 				}
 			})
 		},
 	}
+	cmd.Flags().String(toFlag, "", "ID of the last migration to apply, all the pending migrations are applied by default")
+	cmd.Flags().Bool(dryRunFlag, false, "Print the SQL executed by the pending migrations, in a transaction that is rolled back, without applying them")
 	cmd.AddCommand(
+		NewStatus(env),
+		NewRollback(env),
 		NewRollbackAll(env),
 		NewRollbackLast(env),
 	)
 	return cmd
 }
+
+// filterMigrations returns the migrations that contain the migration with the given ID, or all the migrations
+// when the ID is empty
+func filterMigrations(migrations []*db.Migration, migrationID string) []*db.Migration {
+	if migrationID == "" {
+		return migrations
+	}
+	var filtered []*db.Migration
+	for _, migration := range migrations {
+		if migration.HasMigration(migrationID) {
+			filtered = append(filtered, migration)
+		}
+	}
+	if len(filtered) == 0 {
+		glog.Fatalf("Unknown migration %q", migrationID)
+	}
+	return filtered
+}
+
+func printDryRuns(migration *db.Migration, dryRuns []db.MigrationDryRun, err error) {
+	if len(dryRuns) == 0 && err == nil {
+		fmt.Printf("-- %s: nothing to do\n", migration.GormOptions.TableName)
+	}
+	for _, dryRun := range dryRuns {
+		fmt.Printf("-- %s: %s\n", migration.GormOptions.TableName, dryRun.ID)
+		for _, sql := range dryRun.SQL {
+			fmt.Printf("%s;\n", sql)
+		}
+		fmt.Println()
+	}
+	if err != nil {
+		glog.Fatalf("Dry run of %s failed: %v", migration.GormOptions.TableName, err)
+	}
+}
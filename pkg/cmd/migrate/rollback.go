@@ -0,0 +1,39 @@
+package migrate
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+func NewRollback(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "rollback the migrations applied after the given one",
+		Long:  "rollback the migrations applied after the migration given with --to, the given migration is not rolled back",
+		Run: func(cmd *cobra.Command, args []string) {
+			to, _ := cmd.Flags().GetString(toFlag)
+			dryRun, _ := cmd.Flags().GetBool(dryRunFlag)
+			env.MustInvoke(func(migrations []*db.Migration) {
+				migrations = filterMigrations(migrations, to)
+				if dryRun {
+					for _, migration := range migrations {
+						dryRuns, err := migration.DryRunRollbackTo(to)
+						printDryRuns(migration, dryRuns, err)
+					}
+					return
+				}
+				glog.Infof("Rolling back the migrations applied after %s", to)
+				for _, migration := range migrations {
+					migration.RollbackTo(to)
+					glog.Infof("Database has %d %s applied", migration.CountMigrationsApplied(), migration.GormOptions.TableName)
+				}
+			})
+		},
+	}
+	cmd.Flags().String(toFlag, "", "ID of the migration to roll back to, it is not rolled back")
+	cmd.Flags().Bool(dryRunFlag, false, "Print the SQL executed by the rollback, in a transaction that is rolled back, without applying it")
+	_ = cmd.MarkFlagRequired(toFlag)
+	return cmd
+}
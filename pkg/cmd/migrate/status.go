@@ -0,0 +1,65 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func NewStatus(env *environments.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "show the applied and pending migrations",
+		Long:  "show the applied and pending migrations of every migrations table, and the applied migrations that are unknown to this version",
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			if output != "table" && output != "json" {
+				glog.Fatalf("Invalid output format %q, one of [table json] is expected", output)
+			}
+			env.MustInvoke(func(migrations []*db.Migration) {
+				status := map[string][]db.MigrationStatus{}
+				table := tablewriter.NewWriter(os.Stdout)
+				table.SetHeader([]string{"Table", "ID", "Status"})
+				table.SetAutoMergeCellsByColumnIndex([]int{0})
+				for _, migration := range migrations {
+					tableStatus, err := migration.Status()
+					if err != nil {
+						glog.Fatalf("Unable to get the status of %s: %v", migration.GormOptions.TableName, err)
+					}
+					status[migration.GormOptions.TableName] = tableStatus
+					for _, s := range tableStatus {
+						table.Append([]string{migration.GormOptions.TableName, s.ID, statusString(s)})
+					}
+				}
+				if output == "json" {
+					data, err := json.MarshalIndent(status, "", "  ")
+					if err != nil {
+						glog.Fatalf("Unable to print the status: %v", err)
+					}
+					fmt.Println(string(data))
+					return
+				}
+				table.Render()
+			})
+		},
+	}
+	cmd.Flags().StringP("output", "o", "table", "Output format, one of: [table json]")
+	return cmd
+}
+
+func statusString(s db.MigrationStatus) string {
+	switch {
+	case s.Unknown:
+		return "unknown"
+	case s.Applied:
+		return "applied"
+	default:
+		return "pending"
+	}
+}
@@ -1,20 +1,39 @@
 package db
 
 import (
+	"context"
 	"fmt"
 	"runtime"
+	"sort"
 	"time"
 
 	"github.com/go-gormigrate/gormigrate/v2"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 type Migration struct {
 	DbFactory   *ConnectionFactory
 	Gormigrate  *gormigrate.Gormigrate
 	GormOptions *gormigrate.Options
+	Migrations  []*gormigrate.Migration
+}
+
+// MigrationStatus tells if a migration has been applied to the database
+type MigrationStatus struct {
+	ID      string `json:"id"`
+	Applied bool   `json:"applied"`
+	// Unknown is true for the migrations applied to the database that are not in the list of migrations,
+	// e.g. after a downgrade
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// MigrationDryRun holds the SQL statements executed by a migration during a dry run
+type MigrationDryRun struct {
+	ID  string   `json:"id"`
+	SQL []string `json:"sql"`
 }
 
 func NewMigration(dbConfig *DatabaseConfig, gormOptions *gormigrate.Options, migrations []*gormigrate.Migration) (*Migration, func(), error) {
@@ -28,6 +47,7 @@ func NewMigration(dbConfig *DatabaseConfig, gormOptions *gormigrate.Options, mig
 		DbFactory:   dbFactory,
 		GormOptions: gormOptions,
 		Gormigrate:  gormigrate.New(dbFactory.New(), gormOptions, migrations),
+		Migrations:  migrations,
 	}, cleanup, nil
 }
 
@@ -104,6 +124,136 @@ func (m *Migration) CountMigrationsApplied() int {
 	return count
 }
 
+// HasMigration returns true if migrationID is in the list of migrations
+func (m *Migration) HasMigration(migrationID string) bool {
+	for _, migration := range m.Migrations {
+		if migration.ID == migrationID {
+			return true
+		}
+	}
+	return false
+}
+
+// Status returns the status of all the migrations, in the order they are applied, followed by the
+// unknown migrations found in the database
+func (m *Migration) Status() ([]MigrationStatus, error) {
+	applied, err := m.appliedMigrationIDs(m.DbFactory.New())
+	if err != nil {
+		return nil, err
+	}
+
+	var status []MigrationStatus
+	for _, migration := range m.Migrations {
+		status = append(status, MigrationStatus{ID: migration.ID, Applied: applied[migration.ID]})
+		delete(applied, migration.ID)
+	}
+	var unknown []string
+	for id := range applied {
+		unknown = append(unknown, id)
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		status = append(status, MigrationStatus{ID: id, Applied: true, Unknown: true})
+	}
+	return status, nil
+}
+
+func (m *Migration) appliedMigrationIDs(db *gorm.DB) (map[string]bool, error) {
+	applied := map[string]bool{}
+	if !db.Migrator().HasTable(m.GormOptions.TableName) {
+		return applied, nil
+	}
+	var ids []string
+	sql := fmt.Sprintf("SELECT %s FROM %s", m.GormOptions.IDColumnName, m.GormOptions.TableName)
+	if err := db.Raw(sql).Scan(&ids).Error; err != nil {
+		return nil, errors.Wrap(err, "could not get the applied migrations")
+	}
+	for _, id := range ids {
+		applied[id] = true
+	}
+	return applied, nil
+}
+
+// DryRunMigrateTo applies the pending migrations up to the migration that matches migrationID, or all of them
+// when migrationID is empty, in a transaction that is rolled back. It returns the SQL executed by each migration.
+func (m *Migration) DryRunMigrateTo(migrationID string) ([]MigrationDryRun, error) {
+	return m.dryRun(func(g *gormigrate.Gormigrate) error {
+		if migrationID == "" {
+			return g.Migrate()
+		}
+		return g.MigrateTo(migrationID)
+	})
+}
+
+// DryRunRollbackTo rolls back the migrations applied after the migration that matches migrationID in a
+// transaction that is rolled back. It returns the SQL executed by each migration.
+func (m *Migration) DryRunRollbackTo(migrationID string) ([]MigrationDryRun, error) {
+	return m.dryRun(func(g *gormigrate.Gormigrate) error {
+		return g.RollbackTo(migrationID)
+	})
+}
+
+// dryRun runs the migrations with a copy of the migrations that records the SQL statements they execute,
+// all of it in a transaction that is always rolled back
+func (m *Migration) dryRun(run func(g *gormigrate.Gormigrate) error) ([]MigrationDryRun, error) {
+	recorder := &sqlRecorder{}
+	tx := m.DbFactory.New().Session(&gorm.Session{Logger: recorder}).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	defer tx.Rollback()
+
+	var dryRuns []MigrationDryRun
+	record := func(id string, f func(*gorm.DB) error) func(*gorm.DB) error {
+		return func(tx *gorm.DB) error {
+			recorder.statements = nil
+			err := f(tx)
+			dryRuns = append(dryRuns, MigrationDryRun{ID: id, SQL: recorder.statements})
+			return err
+		}
+	}
+	migrations := make([]*gormigrate.Migration, len(m.Migrations))
+	for i := range m.Migrations {
+		migration := *m.Migrations[i]
+		migration.Migrate = record(migration.ID, migration.Migrate)
+		// a nil rollback tells gormigrate that the migration can't be rolled back
+		if migration.Rollback != nil {
+			migration.Rollback = record(migration.ID, migration.Rollback)
+		}
+		migrations[i] = &migration
+	}
+
+	options := *m.GormOptions
+	// the dry run transaction is already open
+	options.UseTransaction = false
+	if err := run(gormigrate.New(tx, &options, migrations)); err != nil {
+		return dryRuns, err
+	}
+	return dryRuns, nil
+}
+
+// sqlRecorder is a gorm logger that records the SQL statements executed
+type sqlRecorder struct {
+	statements []string
+}
+
+var _ logger.Interface = &sqlRecorder{}
+
+func (r *sqlRecorder) LogMode(level logger.LogLevel) logger.Interface {
+	return r
+}
+
+func (r *sqlRecorder) Info(ctx context.Context, msg string, data ...interface{}) {}
+
+func (r *sqlRecorder) Warn(ctx context.Context, msg string, data ...interface{}) {}
+
+func (r *sqlRecorder) Error(ctx context.Context, msg string, data ...interface{}) {}
+
+func (r *sqlRecorder) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
 // Model represents the base model struct. All entities will have this struct embedded.
 type Model struct {
 	ID        string `gorm:"primary_key"`
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+	"gorm.io/gorm"
+)
+
+func newTestMigration() *Migration {
+	return &Migration{
+		DbFactory: NewMockConnectionFactory(nil),
+		GormOptions: &gormigrate.Options{
+			TableName:    "migrations",
+			IDColumnName: "id",
+			IDColumnSize: 255,
+		},
+		Migrations: []*gormigrate.Migration{
+			CreateMigrationFromActions("1", ExecAction("UPDATE first SET id = 1", "UPDATE first SET id = 0")),
+			CreateMigrationFromActions("2", ExecAction("UPDATE second SET id = 1", "UPDATE second SET id = 0")),
+			CreateMigrationFromActions("3", ExecAction("UPDATE third SET id = 1", "UPDATE third SET id = 0")),
+		},
+	}
+}
+
+func mockMigrationsTable(exists bool, ids ...string) {
+	count := 0
+	if exists {
+		count = 1
+	}
+	mocket.Catcher.NewMock().WithQuery("information_schema.tables").WithReply([]map[string]interface{}{{"count": count}})
+	var reply []map[string]interface{}
+	for _, id := range ids {
+		reply = append(reply, map[string]interface{}{"id": id})
+	}
+	mocket.Catcher.NewMock().WithQuery("SELECT id FROM migrations").WithReply(reply)
+}
+
+func Test_Migration_HasMigration(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newTestMigration()
+	g.Expect(m.HasMigration("2")).To(gomega.BeTrue())
+	g.Expect(m.HasMigration("4")).To(gomega.BeFalse())
+}
+
+func Test_Migration_Status(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupFn    func()
+		wantStatus []MigrationStatus
+	}{
+		{
+			name: "should return all migrations as pending when the migrations table does not exist",
+			setupFn: func() {
+				mockMigrationsTable(false)
+			},
+			wantStatus: []MigrationStatus{
+				{ID: "1"},
+				{ID: "2"},
+				{ID: "3"},
+			},
+		},
+		{
+			name: "should return applied, pending and unknown migrations",
+			setupFn: func() {
+				mockMigrationsTable(true, "1", "2", "0")
+			},
+			wantStatus: []MigrationStatus{
+				{ID: "1", Applied: true},
+				{ID: "2", Applied: true},
+				{ID: "3"},
+				{ID: "0", Applied: true, Unknown: true},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			m := newTestMigration()
+			mocket.Catcher.Reset()
+			tt.setupFn()
+			status, err := m.Status()
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(status).To(gomega.Equal(tt.wantStatus))
+		})
+	}
+}
+
+func Test_Migration_DryRunMigrateTo(t *testing.T) {
+	g := gomega.NewWithT(t)
+	m := newTestMigration()
+	var executed []string
+	m.Migrations = append(m.Migrations, &gormigrate.Migration{
+		ID: "4",
+		Migrate: func(tx *gorm.DB) error {
+			executed = append(executed, "4")
+			return tx.Exec("UPDATE fourth SET id = 1").Error
+		},
+	})
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery("information_schema.tables").WithReply([]map[string]interface{}{{"count": 1}})
+	mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "migrations" WHERE id = $1`).WithArgs("1").WithReply([]map[string]interface{}{{"count": 1}})
+
+	dryRuns, err := m.DryRunMigrateTo("3")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(dryRuns).To(gomega.HaveLen(2))
+	g.Expect(dryRuns[0].ID).To(gomega.Equal("2"))
+	g.Expect(dryRuns[0].SQL).To(gomega.Equal([]string{"UPDATE second SET id = 1"}))
+	g.Expect(dryRuns[1].ID).To(gomega.Equal("3"))
+	g.Expect(dryRuns[1].SQL).To(gomega.Equal([]string{"UPDATE third SET id = 1"}))
+	g.Expect(executed).To(gomega.BeEmpty())
+}
@@ -186,6 +186,75 @@ func (env *Env) CreateServices() error {
 	return nil
 }
 
+// ValidateConfiguration loads and validates the configuration without creating the Env.ServiceContainer, so
+// no database or network connections are opened. Unlike CreateServices it does not stop at the first failure and
+// returns all the errors found.
+//
+// The function will look for many inject types in dependency injection container and called them in this order:
+// 1) All ConfigModule.ReadFiles functions - to load file system based configuration.
+// 2) The EnvLoader.ModifyConfiguration function - to allow named environment to apply configuration changes
+// 3) All ServiceValidator.Validate functions found in the Env.ConfigContainer, except the ones of the modules
+// whose files, or whose ValidationDependencies files, could not be read.
+//
+// The ConfigErrors returned by a module are reported as separate errors.
+func (env *Env) ValidateConfiguration() []error {
+	var errs []error
+
+	modules := []ConfigModule{}
+	if err := env.ConfigContainer.Resolve(&modules); err != nil && !goerrors.Is(err, di.ErrTypeNotExists) {
+		return []error{err}
+	}
+	failedModules := map[interface{}]bool{}
+	for i := range modules {
+		if err := modules[i].ReadFiles(); err != nil {
+			for _, e := range SplitConfigErrors(err) {
+				errs = append(errs, errors.Errorf("%T: unable to read configuration files: %s", modules[i], e))
+			}
+			failedModules[modules[i]] = true
+		}
+	}
+
+	var namedEnv EnvLoader
+	if err := env.ConfigContainer.Resolve(&namedEnv, di.Tags{"env": env.Name}); err != nil {
+		return append(errs, errors.Errorf("unsupported environment %q", env.Name))
+	}
+	if err := namedEnv.ModifyConfiguration(env); err != nil {
+		return append(errs, err)
+	}
+
+	var validators []ServiceValidator
+	if err := env.ConfigContainer.Resolve(&validators); err != nil {
+		if !errors.Is(err, di.ErrTypeNotExists) {
+			errs = append(errs, err)
+		}
+		return errs
+	}
+	for _, validator := range validators {
+		if failedModules[validator] || hasFailedDependency(env, validator, failedModules) {
+			continue
+		}
+		for _, e := range SplitConfigErrors(validator.Validate(env)) {
+			errs = append(errs, errors.Errorf("%T: %s", validator, e))
+		}
+	}
+
+	return errs
+}
+
+// hasFailedDependency returns true when the validator depends on the configuration of a module whose files could not be read
+func hasFailedDependency(env *Env, validator ServiceValidator, failedModules map[interface{}]bool) bool {
+	dependent, ok := validator.(ValidationDependencies)
+	if !ok {
+		return false
+	}
+	for _, dependency := range dependent.ValidationDependencies(env) {
+		if failedModules[dependency] {
+			return true
+		}
+	}
+	return false
+}
+
 func (env *Env) MustInvoke(invocation di.Invocation, options ...di.InvokeOption) {
 	container := env.ServiceContainer
 	containerName := "service container"
@@ -0,0 +1,164 @@
+package environments
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/goava/di"
+	"github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+type configModuleMock struct {
+	readFilesErr error
+	validateErr  error
+	validated    bool
+}
+
+func (m *configModuleMock) AddFlags(fs *pflag.FlagSet) {}
+
+func (m *configModuleMock) ReadFiles() error {
+	return m.readFilesErr
+}
+
+func (m *configModuleMock) Validate(env *Env) error {
+	m.validated = true
+	return m.validateErr
+}
+
+// the dependency injection container needs a different type for each module
+type otherConfigModuleMock struct {
+	configModuleMock
+	dependsOnModule bool
+}
+
+func (m *otherConfigModuleMock) ValidationDependencies(env *Env) []ConfigModule {
+	if !m.dependsOnModule {
+		return nil
+	}
+	var module *configModuleMock
+	env.MustResolve(&module)
+	return []ConfigModule{module}
+}
+
+func Test_Env_ValidateConfiguration(t *testing.T) {
+	type fields struct {
+		module      *configModuleMock
+		otherModule *otherConfigModuleMock
+		envName     string
+	}
+
+	tests := []struct {
+		name                string
+		fields              fields
+		wantErrs            []string
+		wantModuleValidated bool
+	}{
+		{
+			name: "should return no error when the configuration is valid",
+			fields: fields{
+				module:      &configModuleMock{},
+				otherModule: &otherConfigModuleMock{},
+				envName:     TestingEnv,
+			},
+			wantModuleValidated: true,
+		},
+		{
+			name: "should return the errors of all the modules",
+			fields: fields{
+				module: &configModuleMock{validateErr: fmt.Errorf("invalid value")},
+				otherModule: &otherConfigModuleMock{
+					configModuleMock: configModuleMock{validateErr: fmt.Errorf("other invalid value")},
+				},
+				envName: TestingEnv,
+			},
+			wantErrs: []string{
+				"*environments.configModuleMock: invalid value",
+				"*environments.otherConfigModuleMock: other invalid value",
+			},
+			wantModuleValidated: true,
+		},
+		{
+			name: "should return each of the errors of a module",
+			fields: fields{
+				module: &configModuleMock{
+					readFilesErr: ConfigErrors{fmt.Errorf("unknown field"), fmt.Errorf("invalid type")},
+				},
+				otherModule: &otherConfigModuleMock{
+					configModuleMock: configModuleMock{
+						validateErr: ConfigErrors{fmt.Errorf("invalid value"), fmt.Errorf("other invalid value")},
+					},
+				},
+				envName: TestingEnv,
+			},
+			wantErrs: []string{
+				"*environments.configModuleMock: unable to read configuration files: unknown field",
+				"*environments.configModuleMock: unable to read configuration files: invalid type",
+				"*environments.otherConfigModuleMock: invalid value",
+				"*environments.otherConfigModuleMock: other invalid value",
+			},
+			wantModuleValidated: false,
+		},
+		{
+			name: "should not validate the modules whose files could not be read",
+			fields: fields{
+				module: &configModuleMock{readFilesErr: fmt.Errorf("file.yaml: yaml: line 3: did not find expected key")},
+				otherModule: &otherConfigModuleMock{
+					configModuleMock: configModuleMock{validateErr: fmt.Errorf("other invalid value")},
+				},
+				envName: TestingEnv,
+			},
+			wantErrs: []string{
+				"*environments.configModuleMock: unable to read configuration files: file.yaml: yaml: line 3: did not find expected key",
+				"*environments.otherConfigModuleMock: other invalid value",
+			},
+			wantModuleValidated: false,
+		},
+		{
+			name: "should not validate the modules whose dependencies files could not be read",
+			fields: fields{
+				module: &configModuleMock{readFilesErr: fmt.Errorf("file.yaml: yaml: line 3: did not find expected key")},
+				otherModule: &otherConfigModuleMock{
+					configModuleMock: configModuleMock{validateErr: fmt.Errorf("other invalid value")},
+					dependsOnModule:  true,
+				},
+				envName: TestingEnv,
+			},
+			wantErrs: []string{
+				"*environments.configModuleMock: unable to read configuration files: file.yaml: yaml: line 3: did not find expected key",
+			},
+			wantModuleValidated: false,
+		},
+		{
+			name: "should return an error when the environment is not supported",
+			fields: fields{
+				module:      &configModuleMock{},
+				otherModule: &otherConfigModuleMock{},
+				envName:     "unknown",
+			},
+			wantErrs:            []string{`unsupported environment "unknown"`},
+			wantModuleValidated: false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			env, err := New(tt.fields.envName,
+				di.ProvideValue(tt.fields.module, di.As(new(ConfigModule)), di.As(new(ServiceValidator))),
+				di.ProvideValue(tt.fields.otherModule, di.As(new(ConfigModule)), di.As(new(ServiceValidator))),
+				di.Provide(func() EnvLoader { return SimpleEnvLoader{} }, di.Tags{"env": TestingEnv}),
+			)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			var errs []string
+			for _, e := range env.ValidateConfiguration() {
+				errs = append(errs, e.Error())
+			}
+			g.Expect(errs).To(gomega.ConsistOf(tt.wantErrs))
+			g.Expect(tt.fields.module.validated).To(gomega.Equal(tt.wantModuleValidated))
+			g.Expect(env.ServiceContainer).To(gomega.BeNil())
+		})
+	}
+}
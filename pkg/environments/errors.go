@@ -0,0 +1,50 @@
+package environments
+
+import (
+	goerrors "errors"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/pkg/errors"
+)
+
+// ConfigErrors can be returned by the ConfigModule.ReadFiles and ServiceValidator.Validate functions to report all
+// the errors found in the configuration instead of only the first one. ValidateConfiguration reports each of them.
+type ConfigErrors []error
+
+func (e ConfigErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ErrorOrNil returns nil when no error was found, so that an empty ConfigErrors is never returned as an error
+func (e ConfigErrors) ErrorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// SplitConfigErrors returns the errors held by err when it is a ConfigErrors, or err itself otherwise
+func SplitConfigErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	var configErrs ConfigErrors
+	if goerrors.As(err, &configErrs) {
+		return configErrs
+	}
+	return []error{err}
+}
+
+// FileErrors returns the errors of err, prefixed with the path of the configuration file they were found in
+func FileErrors(filename string, err error) ConfigErrors {
+	var fileErrs ConfigErrors
+	for _, e := range SplitConfigErrors(err) {
+		fileErrs = append(fileErrs, errors.Errorf("%s: %s", shared.BuildFullFilePath(filename), e))
+	}
+	return fileErrs
+}
@@ -16,6 +16,12 @@ type ServiceValidator interface {
 	Validate(env *Env) error
 }
 
+// ValidationDependencies is implemented by the ServiceValidator values whose validation uses the configuration of
+// other modules, so that ValidateConfiguration doesn't validate them when any of those modules failed to read its files
+type ValidationDependencies interface {
+	ValidationDependencies(env *Env) []ConfigModule
+}
+
 // BootService are services that get started on application boot.
 type BootService interface {
 	Start()
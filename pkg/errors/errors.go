@@ -208,6 +208,10 @@ const (
 	ErrorInvalidDnsName       ServiceErrorCode = 47
 	ErrorInvalidDnsNameReason string           = "Dns name is invalid"
 
+	// The resource has changed since the version given in the If-Match header
+	ErrorPreconditionFailed       ServiceErrorCode = 48
+	ErrorPreconditionFailedReason string           = "Precondition failed"
+
 	// Too Many requests error. Used by rate limiting
 	ErrorTooManyRequests       ServiceErrorCode = 429
 	ErrorTooManyRequestsReason string           = "Too many requests"
@@ -319,6 +323,7 @@ func Errors() ServiceErrors {
 		ServiceError{ErrorInvalidClusterId, ErrorInvalidClusterIdReason, http.StatusBadRequest, nil, false},
 		ServiceError{ErrorInvalidExternalClusterId, ErrorInvalidExternalClusterIdReason, http.StatusBadRequest, nil, false},
 		ServiceError{ErrorInvalidDnsName, ErrorInvalidDnsNameReason, http.StatusBadRequest, nil, false},
+		ServiceError{ErrorPreconditionFailed, ErrorPreconditionFailedReason, http.StatusPreconditionFailed, nil, false},
 	}
 }
 
@@ -640,6 +645,10 @@ func Conflict(reason string, values ...interface{}) *ServiceError {
 	return New(ErrorConflict, reason, values...)
 }
 
+func PreconditionFailed(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorPreconditionFailed, reason, values...)
+}
+
 func Validation(reason string, values ...interface{}) *ServiceError {
 	return New(ErrorValidation, reason, values...)
 }
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+const (
+	ETagHeader        = "ETag"
+	IfMatchHeader     = "If-Match"
+	IfNoneMatchHeader = "If-None-Match"
+)
+
+// ResourceVersion returns the current version of the resource a request applies to, or an empty string if the
+// resource can't be found. The version changes every time the resource is updated.
+type ResourceVersion func() string
+
+// VersionFromTime returns the version of a resource from the time it was last updated, e.g. api.Meta UpdatedAt.
+// The time is rounded to microseconds, the precision of the timestamps stored in the database.
+func VersionFromTime(updatedAt time.Time) string {
+	if updatedAt.IsZero() {
+		return ""
+	}
+	return strconv.FormatInt(updatedAt.Round(time.Microsecond).UnixMicro(), 10)
+}
+
+// VersionFromInt returns the version of a resource that has a version column, e.g. connectors
+func VersionFromInt(version int64) string {
+	if version == 0 {
+		return ""
+	}
+	return strconv.FormatInt(version, 10)
+}
+
+// ETag returns the strong entity tag of the given resource version
+func ETag(version string) string {
+	return `"` + version + `"`
+}
+
+// checkIfMatch returns a precondition failed error if the request has an If-Match header that doesn't match the
+// current version of the resource. Entity tags are compared with the strong comparison.
+func checkIfMatch(r *http.Request, cfg *HandlerConfig) *errors.ServiceError {
+	ifMatch := r.Header.Get(IfMatchHeader)
+	if cfg.Version == nil || ifMatch == "" {
+		return nil
+	}
+	version := cfg.Version()
+	if version == "" {
+		// the resource doesn't exist, the action reports it
+		return nil
+	}
+	if !etagListContains(ifMatch, ETag(version), false) {
+		return errors.PreconditionFailed("the resource has changed, its current entity tag is %s", ETag(version))
+	}
+	return nil
+}
+
+// HasIfMatch returns true if the request has an If-Match header. The update made for such a request must be
+// conditional on the version that was checked, so that a concurrent update made in between isn't overwritten.
+func HasIfMatch(r *http.Request) bool {
+	return r.Header.Get(IfMatchHeader) != ""
+}
+
+// setETag sets the ETag header of the response from the version of the resource. It returns the entity tag that was set.
+func setETag(w http.ResponseWriter, cfg *HandlerConfig) string {
+	if cfg.Version == nil {
+		return ""
+	}
+	version := cfg.Version()
+	if version == "" {
+		return ""
+	}
+	etag := ETag(version)
+	w.Header().Set(ETagHeader, etag)
+	return etag
+}
+
+// ifNoneMatch returns true if the request has an If-None-Match header that matches the given entity tag.
+// Entity tags are compared with the weak comparison.
+func ifNoneMatch(r *http.Request, etag string) bool {
+	ifNoneMatch := r.Header.Get(IfNoneMatchHeader)
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	return etagListContains(ifNoneMatch, etag, true)
+}
+
+// etagListContains returns true if the given list of entity tags of a conditional request header contains the given entity tag
+func etagListContains(list string, etag string, weak bool) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func Test_VersionFromTime(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect(VersionFromTime(time.Time{})).To(gomega.BeEmpty())
+	updatedAt := time.Date(2023, 5, 2, 12, 0, 0, 1500, time.UTC)
+	g.Expect(VersionFromTime(updatedAt)).To(gomega.Equal(VersionFromTime(updatedAt.Round(time.Microsecond))))
+	g.Expect(VersionFromTime(updatedAt)).ToNot(gomega.Equal(VersionFromTime(updatedAt.Add(time.Microsecond))))
+}
+
+func Test_etagListContains(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		weak bool
+		want bool
+	}{
+		{name: "should match any entity tag", list: "*", want: true},
+		{name: "should match the entity tag", list: `"1"`, want: true},
+		{name: "should match the entity tag in a list", list: `"2", "1"`, want: true},
+		{name: "should not match a different entity tag", list: `"2"`, want: false},
+		{name: "should not match a weak entity tag with the strong comparison", list: `W/"1"`, want: false},
+		{name: "should match a weak entity tag with the weak comparison", list: `W/"1"`, weak: true, want: true},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(etagListContains(tt.list, `"1"`, tt.weak)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_Handle_IfMatch(t *testing.T) {
+	tests := []struct {
+		name             string
+		ifMatch          string
+		version          string
+		wantStatus       int
+		wantETag         string
+		wantActionCalled bool
+	}{
+		{
+			name:             "should update the resource when there is no If-Match header",
+			version:          "1",
+			wantStatus:       http.StatusOK,
+			wantETag:         `"1"`,
+			wantActionCalled: true,
+		},
+		{
+			name:             "should update the resource when the If-Match header matches its version",
+			ifMatch:          `"1"`,
+			version:          "1",
+			wantStatus:       http.StatusOK,
+			wantETag:         `"1"`,
+			wantActionCalled: true,
+		},
+		{
+			name:       "should return 412 when the If-Match header doesn't match the version of the resource",
+			ifMatch:    `"0"`,
+			version:    "1",
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:             "should let the action report a resource that doesn't exist",
+			ifMatch:          `"0"`,
+			wantStatus:       http.StatusOK,
+			wantActionCalled: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			actionCalled := false
+			cfg := &HandlerConfig{
+				Action: func() (interface{}, *errors.ServiceError) {
+					actionCalled = true
+					return map[string]string{"id": "1"}, nil
+				},
+				Version: func() string {
+					return tt.version
+				},
+			}
+			req := httptest.NewRequest(http.MethodPatch, "http://example.com/api/kafkas_mgmt/v1/kafkas/1", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set(IfMatchHeader, tt.ifMatch)
+			}
+			recorder := httptest.NewRecorder()
+			Handle(recorder, req, cfg, http.StatusOK)
+
+			g.Expect(recorder.Code).To(gomega.Equal(tt.wantStatus))
+			g.Expect(recorder.Header().Get(ETagHeader)).To(gomega.Equal(tt.wantETag))
+			g.Expect(actionCalled).To(gomega.Equal(tt.wantActionCalled))
+		})
+	}
+}
+
+func Test_HandleGet_IfNoneMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{
+			name:       "should return the resource when there is no If-None-Match header",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "should return 304 when the If-None-Match header matches the version of the resource",
+			ifNoneMatch: `W/"1"`,
+			wantStatus:  http.StatusNotModified,
+		},
+		{
+			name:        "should return the resource when the If-None-Match header doesn't match its version",
+			ifNoneMatch: `"0"`,
+			wantStatus:  http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			cfg := &HandlerConfig{
+				Action: func() (interface{}, *errors.ServiceError) {
+					return map[string]string{"id": "1"}, nil
+				},
+				Version: func() string {
+					return "1"
+				},
+			}
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/api/kafkas_mgmt/v1/kafkas/1", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set(IfNoneMatchHeader, tt.ifNoneMatch)
+			}
+			recorder := httptest.NewRecorder()
+			HandleGet(recorder, req, cfg)
+
+			g.Expect(recorder.Code).To(gomega.Equal(tt.wantStatus))
+			g.Expect(recorder.Header().Get(ETagHeader)).To(gomega.Equal(`"1"`))
+			if tt.wantStatus == http.StatusNotModified {
+				g.Expect(recorder.Body.Len()).To(gomega.BeZero())
+			}
+		})
+	}
+}
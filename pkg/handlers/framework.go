@@ -21,11 +21,14 @@ import (
 //	Validate is a list of Validation function that run in order, returning fast on the first error.
 //	Action is the specific logic a handler must take (e.g, find an object, save an object)
 //	ErrorHandler is the way errors are returned to the client
+//	Version is the optional version of the resource. When it is set, the ETag header is set from it, updates honour the
+//	If-Match header, and gets honour the If-None-Match header.
 type HandlerConfig struct {
 	MarshalInto  interface{}
 	Validate     []Validate
 	Action       HttpAction
 	ErrorHandler ErrorHandlerFunc
+	Version      ResourceVersion
 }
 
 type EventStream struct {
@@ -86,12 +89,18 @@ func Handle(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStat
 		}
 	}
 
+	if err := checkIfMatch(r, cfg); err != nil {
+		errorHandler(r, w, cfg, err)
+		return
+	}
+
 	result, serviceErr := cfg.Action()
 
 	switch {
 	case serviceErr != nil:
 		errorHandler(r, w, cfg, serviceErr)
 	default:
+		setETag(w, cfg)
 		shared.WriteJSONResponse(w, httpStatus, result)
 		success(r)
 	}
@@ -138,7 +147,11 @@ func HandleGet(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig) {
 	result, serviceErr := cfg.Action()
 	switch {
 	case serviceErr == nil:
-		shared.WriteJSONResponse(w, http.StatusOK, result)
+		if etag := setETag(w, cfg); ifNoneMatch(r, etag) {
+			w.WriteHeader(http.StatusNotModified)
+		} else {
+			shared.WriteJSONResponse(w, http.StatusOK, result)
+		}
 		success(r)
 	default:
 		errorHandler(r, w, cfg, serviceErr)
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type IdempotencyConfig struct {
+	// KeyTTL is how long the response of a request is replayed to the requests with the same Idempotency-Key
+	KeyTTL time.Duration
+	// PendingTimeout is how long a request in progress keeps its Idempotency-Key locked, after which a retry can
+	// take the key over, e.g. when the replica handling the request crashed
+	PendingTimeout time.Duration
+}
+
+func NewIdempotencyConfig() *IdempotencyConfig {
+	return &IdempotencyConfig{
+		KeyTTL:         24 * time.Hour,
+		PendingTimeout: 5 * time.Minute,
+	}
+}
+
+func (c *IdempotencyConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.KeyTTL, "idempotency-key-ttl", c.KeyTTL, "How long the response of a create request is replayed to the retries with the same Idempotency-Key header")
+	fs.DurationVar(&c.PendingTimeout, "idempotency-key-pending-timeout", c.PendingTimeout, "How long a create request in progress locks its Idempotency-Key before a retry can take it over")
+}
+
+func (c *IdempotencyConfig) ReadFiles() error {
+	return nil
+}
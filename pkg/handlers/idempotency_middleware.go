@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+const (
+	// IdempotencyKeyHeader is the header used by clients to make a create request idempotent
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on the responses replayed from a previous request with the same Idempotency-Key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+type IdempotencyMiddleware struct {
+	store IdempotencyKeyStore
+	now   func() time.Time
+}
+
+func NewIdempotencyMiddleware(store IdempotencyKeyStore) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Idempotent honours the Idempotency-Key header of the requests. The response of the first request made with a key is
+// stored, scoped to the organisation and the user of the request, and replayed to the following requests with the same key.
+// A request made with a key that is used by a request still in progress gets a 409 Conflict response, and a request
+// made with a key that was used for a different request gets a 400 Bad Request response.
+// Server errors are not stored, so that the request can be retried with the same key.
+func (m *IdempotencyMiddleware) Idempotent(next http.Handler) http.Handler {
+	return m.idempotent(next, true)
+}
+
+// IdempotentWithSecrets honours the Idempotency-Key header of the requests whose response contains credentials, e.g.
+// the secret of a service account. Their response is never stored: a request made with the key of a completed request
+// gets a 409 Conflict response instead of the replay of the response.
+func (m *IdempotencyMiddleware) IdempotentWithSecrets(next http.Handler) http.Handler {
+	return m.idempotent(next, false)
+}
+
+func (m *IdempotencyMiddleware) idempotent(next http.Handler, replay bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			shared.HandleError(r, w, errors.BadRequest("%s header must not be longer than %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+			return
+		}
+
+		claims, err := auth.GetClaimsFromContext(r.Context())
+		if err != nil {
+			shared.HandleError(r, w, errors.NewWithCause(errors.ErrorUnauthenticated, err, ""))
+			return
+		}
+		orgId, _ := claims.GetOrgId()
+		username, _ := claims.GetUsername()
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			shared.HandleError(r, w, errors.MalformedRequest("unable to read request body: %s", err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		key := hash(orgId, username, idempotencyKey)
+		requestHash := hash(r.Method, r.URL.Path, string(body))
+
+		record, err := m.store.Acquire(key, requestHash, m.now())
+		if err != nil {
+			shared.HandleError(r, w, errors.GeneralError("unable to apply %s header: %v", IdempotencyKeyHeader, err))
+			return
+		}
+
+		if record != nil {
+			switch {
+			case record.RequestHash != requestHash:
+				shared.HandleError(r, w, errors.BadRequest("%s '%s' has already been used for a different request", IdempotencyKeyHeader, idempotencyKey))
+			case record.InProgress():
+				shared.HandleError(r, w, errors.Conflict("a request with %s '%s' is already in progress", IdempotencyKeyHeader, idempotencyKey))
+			case !replay:
+				shared.HandleError(r, w, errors.Conflict("a request with %s '%s' has already been completed, its response contains credentials and is not replayed", IdempotencyKeyHeader, idempotencyKey))
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Vary", "Authorization")
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(record.StatusCode)
+				_, _ = w.Write(record.ResponseBody)
+			}
+			return
+		}
+
+		recorder := &idempotencyResponseRecorder{wrapped: w}
+		next.ServeHTTP(recorder, r)
+
+		if recorder.code == 0 {
+			recorder.code = http.StatusOK
+		}
+
+		ulog := logger.NewUHCLogger(r.Context())
+		if recorder.code >= http.StatusInternalServerError {
+			if err := m.store.Release(key); err != nil {
+				ulog.Errorf("%v", err)
+			}
+			return
+		}
+		var responseBody []byte
+		if replay {
+			responseBody = recorder.body.Bytes()
+		}
+		if err := m.store.Complete(key, recorder.code, responseBody); err != nil {
+			ulog.Errorf("%v", err)
+		}
+	})
+}
+
+func hash(values ...string) string {
+	h := sha256.New()
+	for _, value := range values {
+		_, _ = h.Write([]byte(value))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyResponseRecorder is an extension of the HTTP response writer that keeps a copy of the response,
+// so that it can be stored once it is sent to the client.
+type idempotencyResponseRecorder struct {
+	wrapped http.ResponseWriter
+	code    int
+	body    bytes.Buffer
+}
+
+func (w *idempotencyResponseRecorder) Header() http.Header {
+	return w.wrapped.Header()
+}
+
+func (w *idempotencyResponseRecorder) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.wrapped.Write(b)
+}
+
+func (w *idempotencyResponseRecorder) WriteHeader(code int) {
+	w.code = code
+	w.wrapped.WriteHeader(code)
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+)
+
+func TestIdempotencyMiddleware_Idempotent(t *testing.T) {
+	const body = `{"name":"test"}`
+	requestHash := hash(http.MethodPost, "/api/kafkas_mgmt/v1/kafkas", body)
+
+	tests := []struct {
+		name             string
+		idempotencyKey   string
+		withSecrets      bool
+		store            *IdempotencyKeyStoreMock
+		nextStatus       int
+		wantStatus       int
+		wantBody         string
+		wantNextCalled   bool
+		wantReplayed     bool
+		wantCompleted    bool
+		wantStoredBody   string
+		wantReleased     bool
+		wantAcquireCalls int
+	}{
+		{
+			name:           "should handle the request when there is no idempotency key",
+			store:          &IdempotencyKeyStoreMock{},
+			nextStatus:     http.StatusAccepted,
+			wantStatus:     http.StatusAccepted,
+			wantBody:       `{"id":"1"}`,
+			wantNextCalled: true,
+		},
+		{
+			name:           "should return 400 when the idempotency key is too long",
+			idempotencyKey: strings.Repeat("k", maxIdempotencyKeyLength+1),
+			store:          &IdempotencyKeyStoreMock{},
+			wantStatus:     http.StatusBadRequest,
+		},
+		{
+			name:           "should handle the request and store the response when the key is acquired",
+			idempotencyKey: "key",
+			store: &IdempotencyKeyStoreMock{
+				AcquireFunc: func(key string, requestHash string, now time.Time) (*IdempotencyRecord, error) {
+					return nil, nil
+				},
+				CompleteFunc: func(key string, statusCode int, responseBody []byte) error {
+					return nil
+				},
+			},
+			nextStatus:       http.StatusAccepted,
+			wantStatus:       http.StatusAccepted,
+			wantBody:         `{"id":"1"}`,
+			wantNextCalled:   true,
+			wantCompleted:    true,
+			wantStoredBody:   `{"id":"1"}`,
+			wantAcquireCalls: 1,
+		},
+		{
+			name:           "should not store the response of a request with secrets",
+			idempotencyKey: "key",
+			withSecrets:    true,
+			store: &IdempotencyKeyStoreMock{
+				AcquireFunc: func(key string, requestHash string, now time.Time) (*IdempotencyRecord, error) {
+					return nil, nil
+				},
+				CompleteFunc: func(key string, statusCode int, responseBody []byte) error {
+					return nil
+				},
+			},
+			nextStatus:       http.StatusAccepted,
+			wantStatus:       http.StatusAccepted,
+			wantBody:         `{"id":"1"}`,
+			wantNextCalled:   true,
+			wantCompleted:    true,
+			wantAcquireCalls: 1,
+		},
+		{
+			name:           "should return 409 instead of replaying the response of a request with secrets",
+			idempotencyKey: "key",
+			withSecrets:    true,
+			store: &IdempotencyKeyStoreMock{
+				AcquireFunc: func(key string, hash string, now time.Time) (*IdempotencyRecord, error) {
+					return &IdempotencyRecord{RequestHash: requestHash, StatusCode: http.StatusAccepted}, nil
+				},
+			},
+			wantStatus:       http.StatusConflict,
+			wantAcquireCalls: 1,
+		},
+		{
+			name:           "should release the key when the request fails with a server error",
+			idempotencyKey: "key",
+			store: &IdempotencyKeyStoreMock{
+				AcquireFunc: func(key string, requestHash string, now time.Time) (*IdempotencyRecord, error) {
+					return nil, nil
+				},
+				ReleaseFunc: func(key string) error {
+					return nil
+				},
+			},
+			nextStatus:       http.StatusInternalServerError,
+			wantStatus:       http.StatusInternalServerError,
+			wantBody:         `{"id":"1"}`,
+			wantNextCalled:   true,
+			wantReleased:     true,
+			wantAcquireCalls: 1,
+		},
+		{
+			name:           "should replay the stored response when the key was used for the same request",
+			idempotencyKey: "key",
+			store: &IdempotencyKeyStoreMock{
+				AcquireFunc: func(key string, hash string, now time.Time) (*IdempotencyRecord, error) {
+					return &IdempotencyRecord{RequestHash: requestHash, StatusCode: http.StatusAccepted, ResponseBody: []byte(`{"id":"stored"}`)}, nil
+				},
+			},
+			wantStatus:       http.StatusAccepted,
+			wantBody:         `{"id":"stored"}`,
+			wantReplayed:     true,
+			wantAcquireCalls: 1,
+		},
+		{
+			name:           "should return 409 when a request with the same key is in progress",
+			idempotencyKey: "key",
+			store: &IdempotencyKeyStoreMock{
+				AcquireFunc: func(key string, hash string, now time.Time) (*IdempotencyRecord, error) {
+					return &IdempotencyRecord{RequestHash: requestHash}, nil
+				},
+			},
+			wantStatus:       http.StatusConflict,
+			wantAcquireCalls: 1,
+		},
+		{
+			name:           "should return 400 when the key was used for a different request",
+			idempotencyKey: "key",
+			store: &IdempotencyKeyStoreMock{
+				AcquireFunc: func(key string, hash string, now time.Time) (*IdempotencyRecord, error) {
+					return &IdempotencyRecord{RequestHash: "other", StatusCode: http.StatusAccepted}, nil
+				},
+			},
+			wantStatus:       http.StatusBadRequest,
+			wantAcquireCalls: 1,
+		},
+		{
+			name:           "should return 500 when the key can't be acquired",
+			idempotencyKey: "key",
+			store: &IdempotencyKeyStoreMock{
+				AcquireFunc: func(key string, hash string, now time.Time) (*IdempotencyRecord, error) {
+					return nil, fmt.Errorf("connection refused")
+				},
+			},
+			wantStatus:       http.StatusInternalServerError,
+			wantAcquireCalls: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				requestBody, err := io.ReadAll(r.Body)
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(string(requestBody)).To(gomega.Equal(body))
+				w.WriteHeader(tt.nextStatus)
+				_, _ = w.Write([]byte(`{"id":"1"}`))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "http://example.com/api/kafkas_mgmt/v1/kafkas", strings.NewReader(body))
+			if tt.idempotencyKey != "" {
+				req.Header.Set(IdempotencyKeyHeader, tt.idempotencyKey)
+			}
+			req = req.WithContext(auth.SetTokenInContext(req.Context(), &jwt.Token{Claims: jwt.MapClaims{"org_id": "123", "username": "test-user"}}))
+			recorder := httptest.NewRecorder()
+			middleware := NewIdempotencyMiddleware(tt.store)
+			if tt.withSecrets {
+				middleware.IdempotentWithSecrets(next).ServeHTTP(recorder, req)
+			} else {
+				middleware.Idempotent(next).ServeHTTP(recorder, req)
+			}
+
+			resp := recorder.Result()
+			respBody, err := io.ReadAll(resp.Body)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			resp.Body.Close()
+
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatus))
+			if tt.wantBody != "" {
+				g.Expect(string(respBody)).To(gomega.Equal(tt.wantBody))
+			}
+			g.Expect(nextCalled).To(gomega.Equal(tt.wantNextCalled))
+			g.Expect(resp.Header.Get(IdempotentReplayedHeader) == "true").To(gomega.Equal(tt.wantReplayed))
+			g.Expect(tt.store.AcquireCalls()).To(gomega.HaveLen(tt.wantAcquireCalls))
+			for _, call := range tt.store.AcquireCalls() {
+				g.Expect(call.Key).To(gomega.Equal(hash("123", "test-user", tt.idempotencyKey)))
+				g.Expect(call.RequestHash).To(gomega.Equal(requestHash))
+			}
+			g.Expect(len(tt.store.CompleteCalls()) == 1).To(gomega.Equal(tt.wantCompleted))
+			if tt.wantCompleted {
+				g.Expect(tt.store.CompleteCalls()[0].StatusCode).To(gomega.Equal(tt.nextStatus))
+				g.Expect(string(tt.store.CompleteCalls()[0].ResponseBody)).To(gomega.Equal(tt.wantStoredBody))
+			}
+			g.Expect(len(tt.store.ReleaseCalls()) == 1).To(gomega.Equal(tt.wantReleased))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// IdempotencyRecord is the state of a request made with an Idempotency-Key
+type IdempotencyRecord struct {
+	RequestHash string
+	// StatusCode is 0 while the request is in progress
+	StatusCode   int
+	ResponseBody []byte
+}
+
+// InProgress returns true if the response of the request has not been stored yet
+func (r *IdempotencyRecord) InProgress() bool {
+	return r.StatusCode == 0
+}
+
+//go:generate moq -out idempotency_store_moq.go . IdempotencyKeyStore
+type IdempotencyKeyStore interface {
+	// Acquire locks the given key for a new request. It returns nil if the key was acquired, or the record of the
+	// request that already uses the key otherwise.
+	Acquire(key string, requestHash string, now time.Time) (*IdempotencyRecord, error)
+	// Complete stores the response of the request that acquired the given key
+	Complete(key string, statusCode int, responseBody []byte) error
+	// Release releases the given key without storing a response, so that the request can be retried
+	Release(key string) error
+}
+
+// acquireIdempotencyKeyQuery inserts the key, or takes it over if it has expired or if the request that locked it
+// has timed out. No row is returned if the key is used by another request.
+const acquireIdempotencyKeyQuery = `INSERT INTO idempotency_keys (id, request_hash, status_code, response_body, created_at, expires_at)
+VALUES (@key, @hash, 0, NULL, @now, @expires)
+ON CONFLICT (id) DO UPDATE SET
+	request_hash = excluded.request_hash,
+	status_code = 0,
+	response_body = NULL,
+	created_at = excluded.created_at,
+	expires_at = excluded.expires_at
+WHERE idempotency_keys.expires_at < @now OR (idempotency_keys.status_code = 0 AND idempotency_keys.created_at < @pendingDeadline)
+RETURNING id`
+
+// expiredIdempotencyKeysSweepInterval is how often the expired keys are removed from the database
+const expiredIdempotencyKeysSweepInterval = 10 * time.Minute
+
+var _ IdempotencyKeyStore = &idempotencyKeyStore{}
+
+type idempotencyKeyStore struct {
+	config            *IdempotencyConfig
+	connectionFactory *db.ConnectionFactory
+	mutex             sync.Mutex
+	lastSweep         time.Time
+}
+
+// NewIdempotencyKeyStore returns a store that keeps the keys in the `idempotency_keys` table. The keys are stored
+// outside of the transaction of the request, so that they are visible to concurrent retries.
+func NewIdempotencyKeyStore(config *IdempotencyConfig, connectionFactory *db.ConnectionFactory) IdempotencyKeyStore {
+	return &idempotencyKeyStore{
+		config:            config,
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (s *idempotencyKeyStore) Acquire(key string, requestHash string, now time.Time) (*IdempotencyRecord, error) {
+	s.sweep(now)
+
+	dbConn := s.connectionFactory.New()
+	var acquired []string
+	err := dbConn.Raw(acquireIdempotencyKeyQuery, map[string]interface{}{
+		"key":             key,
+		"hash":            requestHash,
+		"now":             now,
+		"expires":         now.Add(s.config.KeyTTL),
+		"pendingDeadline": now.Add(-s.config.PendingTimeout),
+	}).Scan(&acquired).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to acquire idempotency key")
+	}
+	if len(acquired) > 0 {
+		return nil, nil
+	}
+
+	var record IdempotencyRecord
+	err = dbConn.Table("idempotency_keys").
+		Select("request_hash", "status_code", "response_body").
+		Where("id = ?", key).
+		Take(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// the key was removed in the meantime, so it is reported as in progress and can be retried
+			return &IdempotencyRecord{RequestHash: requestHash}, nil
+		}
+		return nil, errors.Wrap(err, "unable to get idempotency key")
+	}
+	return &record, nil
+}
+
+func (s *idempotencyKeyStore) Complete(key string, statusCode int, responseBody []byte) error {
+	err := s.connectionFactory.New().Table("idempotency_keys").
+		Where("id = ?", key).
+		Updates(map[string]interface{}{"status_code": statusCode, "response_body": responseBody}).Error
+	if err != nil {
+		return errors.Wrap(err, "unable to store idempotency key response")
+	}
+	return nil
+}
+
+func (s *idempotencyKeyStore) Release(key string) error {
+	if err := s.connectionFactory.New().Exec("DELETE FROM idempotency_keys WHERE id = ?", key).Error; err != nil {
+		return errors.Wrap(err, "unable to release idempotency key")
+	}
+	return nil
+}
+
+// sweep removes the expired keys, at most once per sweep interval on each replica
+func (s *idempotencyKeyStore) sweep(now time.Time) {
+	s.mutex.Lock()
+	if now.Sub(s.lastSweep) <= expiredIdempotencyKeysSweepInterval {
+		s.mutex.Unlock()
+		return
+	}
+	s.lastSweep = now
+	s.mutex.Unlock()
+
+	if err := s.connectionFactory.New().Exec("DELETE FROM idempotency_keys WHERE expires_at < ?", now).Error; err != nil {
+		logger.Logger.Errorf("unable to remove expired idempotency keys: %v", err)
+	}
+}
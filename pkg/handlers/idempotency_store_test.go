@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_idempotencyKeyStore_Acquire(t *testing.T) {
+	now := time.Date(2023, 4, 26, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setupFn func()
+		want    *IdempotencyRecord
+		wantErr bool
+	}{
+		{
+			name: "should return no record when the key is acquired",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO idempotency_keys`).
+					WithReply([]map[string]interface{}{{"id": "key"}})
+			},
+			want: nil,
+		},
+		{
+			name: "should return the record of the request that uses the key",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO idempotency_keys`).WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithQuery(`SELECT "request_hash","status_code","response_body" FROM "idempotency_keys" WHERE id = $1`).
+					WithArgs("key").
+					WithReply([]map[string]interface{}{{"request_hash": "hash", "status_code": 202, "response_body": []byte(`{"id":"1"}`)}})
+			},
+			want: &IdempotencyRecord{RequestHash: "hash", StatusCode: 202, ResponseBody: []byte(`{"id":"1"}`)},
+		},
+		{
+			name: "should return an error when the key can't be inserted",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO idempotency_keys`).WithQueryException()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			store := NewIdempotencyKeyStore(NewIdempotencyConfig(), db.NewMockConnectionFactory(nil))
+			// skip the removal of the expired keys
+			store.(*idempotencyKeyStore).lastSweep = now
+			record, err := store.Acquire("key", "hash", now)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(record).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -1,10 +1,20 @@
 package handlers
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/xeipuuv/gojsonschema"
 )
 
+// JsonSchemaViolation describes a value in a document that doesn't conform to a JSON schema
+type JsonSchemaViolation struct {
+	// Pointer is a JSON pointer (RFC 6901) to the value in the document
+	Pointer string
+	Message string
+}
+
 func ValidateJsonSchema(schemaName string, schemaLoader gojsonschema.JSONLoader, documentName string, documentLoader gojsonschema.JSONLoader) *errors.ServiceError {
 	schema, err := gojsonschema.NewSchema(schemaLoader)
 	if err != nil {
@@ -21,3 +31,45 @@ func ValidateJsonSchema(schemaName string, schemaLoader gojsonschema.JSONLoader,
 	}
 	return nil
 }
+
+// GetJsonSchemaViolations returns all the values in a document that don't conform to a JSON schema
+func GetJsonSchemaViolations(schemaName string, schemaLoader gojsonschema.JSONLoader, documentName string, documentLoader gojsonschema.JSONLoader) ([]JsonSchemaViolation, *errors.ServiceError) {
+	schema, err := gojsonschema.NewSchema(schemaLoader)
+	if err != nil {
+		return nil, errors.BadRequest("invalid %s: %v", schemaName, err)
+	}
+
+	r, err := schema.Validate(documentLoader)
+	if err != nil {
+		return nil, errors.BadRequest("invalid %s: %v", documentName, err)
+	}
+	violations := make([]JsonSchemaViolation, 0, len(r.Errors()))
+	for _, e := range r.Errors() {
+		violations = append(violations, JsonSchemaViolation{
+			Pointer: jsonSchemaErrorPointer(e),
+			Message: e.Description(),
+		})
+	}
+	return violations, nil
+}
+
+// jsonSchemaErrorPointer returns a JSON pointer to the value that caused a validation error,
+// for missing required properties it points to the missing property
+func jsonSchemaErrorPointer(e gojsonschema.ResultError) string {
+	const separator = "\x00"
+	tokens := strings.Split(e.Context().String(separator), separator)
+	// first token is always the document root
+	tokens = tokens[1:]
+	if e.Type() == "required" {
+		if property, ok := e.Details()["property"]; ok {
+			tokens = append(tokens, fmt.Sprint(property))
+		}
+	}
+
+	var pointer strings.Builder
+	for _, token := range tokens {
+		pointer.WriteString("/")
+		pointer.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return pointer.String()
+}
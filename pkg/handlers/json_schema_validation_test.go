@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/onsi/gomega"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func Test_GetJsonSchemaViolations(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"topic", "config"},
+		"properties": map[string]interface{}{
+			"topic": map[string]interface{}{"type": "string"},
+			"config": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"a/b"},
+				"properties": map[string]interface{}{
+					"a/b":     map[string]interface{}{"type": "string"},
+					"retries": map[string]interface{}{"type": "integer"},
+				},
+			},
+			"brokers": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		document map[string]interface{}
+		want     []string
+	}{
+		{
+			name: "valid document",
+			document: map[string]interface{}{
+				"topic":  "t",
+				"config": map[string]interface{}{"a/b": "c"},
+			},
+			want: []string{},
+		},
+		{
+			name: "all violations with pointers",
+			document: map[string]interface{}{
+				"config":  map[string]interface{}{"retries": "many"},
+				"brokers": []interface{}{"b1", 2},
+			},
+			want: []string{"/topic", "/config/a~1b", "/config/retries", "/brokers/1"},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			violations, err := handlers.GetJsonSchemaViolations("schema", gojsonschema.NewGoLoader(schema),
+				"document", gojsonschema.NewGoLoader(tt.document))
+			g.Expect(err).To(gomega.BeNil())
+			pointers := make([]string, 0, len(violations))
+			for _, v := range violations {
+				g.Expect(v.Message).ToNot(gomega.BeEmpty())
+				pointers = append(pointers, v.Pointer)
+			}
+			g.Expect(pointers).To(gomega.ConsistOf(tt.want))
+		})
+	}
+}
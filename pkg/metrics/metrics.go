@@ -55,6 +55,14 @@ const (
 
 	LeaderWorker = "leader_worker"
 
+	// WorkerShardOwner - metric name to indicate if the current process owns a shard of a sharded worker
+	WorkerShardOwner = "worker_shard_owner"
+	// WorkerShardReconciledCount - metric name for the number of rows reconciled in a shard of a sharded worker
+	WorkerShardReconciledCount = "worker_shard_reconciled_count"
+	// WorkerShardRebalanceCount - metric name for the number of times the shards owned by the current process changed
+	WorkerShardRebalanceCount = "worker_shard_rebalance_count"
+	labelShard                = "shard"
+
 	// ObservatoriumRequestCount - metric name for the number of observatorium requests sent
 	ObservatoriumRequestCount = "observatorium_request_count"
 	// ObservatoriumRequestDuration - metric name for observatorium request duration in seconds
@@ -165,6 +173,11 @@ var ReconcilerMetricsLabels = []string{
 	labelWorkerType,
 }
 
+var workerShardMetricsLabels = []string{
+	labelWorkerType,
+	labelShard,
+}
+
 var observatoriumRequestMetricsLabels = []string{
 	LabelStatusCode,
 	LabelMethod,
@@ -638,6 +651,60 @@ func SetLeaderWorkerMetric(workerType string, leader bool) {
 	leaderWorkerMetric.With(labels).Set(float64(val))
 }
 
+var workerShardOwnerMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: KasFleetManager,
+		Name:      WorkerShardOwner,
+		Help:      "metrics to indicate if the current process owns a shard of a sharded worker",
+	}, workerShardMetricsLabels)
+
+var workerShardReconciledCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: KasFleetManager,
+		Name:      WorkerShardReconciledCount,
+		Help:      "number of rows reconciled in a shard of a sharded worker by the current process",
+	}, workerShardMetricsLabels)
+
+var workerShardRebalanceCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: KasFleetManager,
+		Name:      WorkerShardRebalanceCount,
+		Help:      "number of times the shards of a sharded worker owned by the current process changed",
+	}, ReconcilerMetricsLabels)
+
+// SetWorkerShardOwnerMetric sets the metric value to 1 for each shard of the given count owned by the worker, and to 0 for the other shards
+func SetWorkerShardOwnerMetric(workerType string, shardCount int, owned []int) {
+	isOwned := make(map[int]bool, len(owned))
+	for _, shard := range owned {
+		isOwned[shard] = true
+	}
+	for shard := 0; shard < shardCount; shard++ {
+		val := 0
+		if isOwned[shard] {
+			val = 1
+		}
+		workerShardOwnerMetric.With(prometheus.Labels{
+			labelWorkerType: workerType,
+			labelShard:      strconv.Itoa(shard),
+		}).Set(float64(val))
+	}
+}
+
+// IncreaseWorkerShardReconciledCount increases the number of rows reconciled in the given shard
+func IncreaseWorkerShardReconciledCount(workerType string, shard int) {
+	workerShardReconciledCountMetric.With(prometheus.Labels{
+		labelWorkerType: workerType,
+		labelShard:      strconv.Itoa(shard),
+	}).Inc()
+}
+
+// IncreaseWorkerShardRebalanceCount increases the number of times the shards owned by the worker changed
+func IncreaseWorkerShardRebalanceCount(workerType string) {
+	workerShardRebalanceCountMetric.With(prometheus.Labels{
+		labelWorkerType: workerType,
+	}).Inc()
+}
+
 // #### Metrics for Reconcilers - End ####
 
 // #### Metrics for Observatorium ####
@@ -839,6 +906,9 @@ func init() {
 	prometheus.MustRegister(reconcilerFailureCountMetric)
 	prometheus.MustRegister(reconcilerErrorsCountMetric)
 	prometheus.MustRegister(leaderWorkerMetric)
+	prometheus.MustRegister(workerShardOwnerMetric)
+	prometheus.MustRegister(workerShardReconciledCountMetric)
+	prometheus.MustRegister(workerShardRebalanceCountMetric)
 
 	// metrics for observatorium
 	prometheus.MustRegister(observatoriumRequestCountMetric)
@@ -911,6 +981,9 @@ func Reset() {
 	reconcilerFailureCountMetric.Reset()
 	reconcilerErrorsCountMetric.Reset()
 	leaderWorkerMetric.Reset()
+	workerShardOwnerMetric.Reset()
+	workerShardReconciledCountMetric.Reset()
+	workerShardRebalanceCountMetric.Reset()
 
 	ResetMetricsForObservatorium()
 
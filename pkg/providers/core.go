@@ -4,18 +4,23 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/acl"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/observatorium"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
+	cmdconfig "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/config"
+	cmdfleetstate "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/fleetstate"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/migrate"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/cmd/serve"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/ratelimit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/fleetstate"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sentry"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
@@ -36,6 +41,8 @@ func CoreConfigProviders() di.Option {
 		di.Provide(ocm.NewOCMConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(keycloak.NewKeycloakConfig, di.As(new(environments.ConfigModule)), di.As(new(environments.ServiceValidator))),
 		di.Provide(acl.NewAccessControlListConfig, di.As(new(environments.ConfigModule))),
+		di.Provide(ratelimit.NewRateLimitConfig, di.As(new(environments.ConfigModule))),
+		di.Provide(handlers.NewIdempotencyConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(server.NewMetricsConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(workers.NewReconcilerConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(auth.NewContextConfig, di.As(new(environments.ConfigModule))),
@@ -44,12 +51,15 @@ func CoreConfigProviders() di.Option {
 		// Add common CLI sub commands
 		di.Provide(serve.NewServeCommand),
 		di.Provide(migrate.NewMigrateCommand),
+		di.Provide(cmdconfig.NewConfigCommand),
+		di.Provide(cmdfleetstate.NewFleetStateCommand),
 
 		// Add other core config providers..
 		sentry.ConfigProviders(),
 		signalbus.ConfigProviders(),
 		authorization.ConfigProviders(),
 		account.ConfigProviders(),
+		fleetstate.ConfigProviders(),
 
 		di.Provide(environments.Func(ServiceProviders)),
 	)
@@ -79,9 +89,15 @@ func ServiceProviders() di.Option {
 		}),
 
 		di.Provide(aws.NewDefaultClientFactory, di.As(new(aws.ClientFactory))),
+		di.Provide(dns.NewDefaultProviderFactory, di.As(new(dns.ProviderFactory))),
 
 		di.Provide(acl.NewAccessControlListMiddleware),
+		di.Provide(ratelimit.NewStore),
+		di.Provide(ratelimit.NewRateLimitMiddleware),
+		di.Provide(handlers.NewIdempotencyKeyStore),
+		di.Provide(handlers.NewIdempotencyMiddleware),
 		di.Provide(handlers.NewErrorsHandler),
+		di.Provide(workers.NewWorkerStateStore),
 		di.Provide(func(c *keycloak.KeycloakConfig) sso.KafkaKeycloakService {
 			return sso.NewKeycloakServiceBuilder().
 				ForKFM().
@@ -4,7 +4,6 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/spf13/pflag"
-	"gopkg.in/yaml.v2"
 	"os"
 )
 
@@ -54,10 +53,5 @@ func (c *QuotaManagementListConfig) GetAllowedAccountByUsernameAndOrgId(username
 
 // Read the contents of file into the quota list config
 func readQuotaManagementListConfigFile(file string, val *RegisteredUsersListConfiguration) error {
-	fileContents, err := shared.ReadFile(file)
-	if err != nil {
-		return err
-	}
-
-	return yaml.UnmarshalStrict([]byte(fileContents), val)
+	return shared.ReadYamlFileStrict(file, val)
 }
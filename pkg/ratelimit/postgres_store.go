@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+// takeQuery takes a request from a bucket of the `rate_limit_buckets` table in a single statement, so that
+// concurrent requests on different replicas are serialised by the row lock. The TAT is stored in unix nanoseconds.
+const takeQuery = `INSERT INTO rate_limit_buckets (id, tat, allowed) VALUES (@key, @now + @interval, true)
+ON CONFLICT (id) DO UPDATE SET
+	allowed = GREATEST(rate_limit_buckets.tat, @now) + @interval - @now <= @burst,
+	tat = CASE WHEN GREATEST(rate_limit_buckets.tat, @now) + @interval - @now <= @burst
+		THEN GREATEST(rate_limit_buckets.tat, @now) + @interval
+		ELSE GREATEST(rate_limit_buckets.tat, @now) END
+RETURNING tat, allowed`
+
+const refundQuery = "UPDATE rate_limit_buckets SET tat = tat - ? WHERE id = ?"
+
+const sweepQuery = "DELETE FROM rate_limit_buckets WHERE tat < ?"
+
+var _ Store = &postgresStore{}
+
+type postgresStore struct {
+	connectionFactory *db.ConnectionFactory
+	mutex             sync.Mutex
+	lastSweep         time.Time
+}
+
+// NewPostgresStore returns a store that keeps the buckets in the database. The buckets are shared by all the replicas of the service.
+func NewPostgresStore(connectionFactory *db.ConnectionFactory) Store {
+	return &postgresStore{
+		connectionFactory: connectionFactory,
+	}
+}
+
+type bucketRow struct {
+	Tat     int64
+	Allowed bool
+}
+
+func (s *postgresStore) Take(key string, limit Limit, now time.Time) (Result, error) {
+	s.sweep(now)
+
+	var row bucketRow
+	err := s.connectionFactory.New().Raw(takeQuery, map[string]interface{}{
+		"key":      key,
+		"now":      now.UnixNano(),
+		"interval": limit.interval().Nanoseconds(),
+		"burst":    limit.burstWindow().Nanoseconds(),
+	}).Scan(&row).Error
+	if err != nil {
+		return Result{}, errors.Wrapf(err, "unable to take request from rate limit bucket %q", key)
+	}
+
+	return limit.result(time.Unix(0, row.Tat), row.Allowed, now), nil
+}
+
+func (s *postgresStore) Refund(key string, limit Limit) error {
+	if err := s.connectionFactory.New().Exec(refundQuery, limit.interval().Nanoseconds(), key).Error; err != nil {
+		return errors.Wrapf(err, "unable to refund request to rate limit bucket %q", key)
+	}
+	return nil
+}
+
+// sweep removes the buckets that are full again, at most once per sweep interval on each replica
+func (s *postgresStore) sweep(now time.Time) {
+	s.mutex.Lock()
+	if now.Sub(s.lastSweep) <= staleBucketsSweepInterval {
+		s.mutex.Unlock()
+		return
+	}
+	s.lastSweep = now
+	s.mutex.Unlock()
+
+	if err := s.connectionFactory.New().Exec(sweepQuery, now.UnixNano()).Error; err != nil {
+		logger.Logger.Errorf("unable to remove stale rate limit buckets: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_postgresStore_Take(t *testing.T) {
+	now := time.Date(2023, 4, 19, 12, 0, 0, 0, time.UTC)
+	limit := Limit{RequestsPerMinute: 60, Burst: 3}
+
+	tests := []struct {
+		name    string
+		setupFn func()
+		want    Result
+		wantErr bool
+	}{
+		{
+			name: "should return the state of the bucket updated in the database",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`INSERT INTO rate_limit_buckets`).
+					WithReply([]map[string]interface{}{{"tat": now.Add(2 * time.Second).UnixNano(), "allowed": true}})
+			},
+			want: Result{Allowed: true, Limit: 3, Remaining: 1, ResetAfter: 2 * time.Second},
+		},
+		{
+			name: "should return the retry after time when the request is not allowed",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`INSERT INTO rate_limit_buckets`).
+					WithReply([]map[string]interface{}{{"tat": now.Add(3 * time.Second).UnixNano(), "allowed": false}})
+			},
+			want: Result{Allowed: false, Limit: 3, Remaining: 0, ResetAfter: 3 * time.Second, RetryAfter: time.Second},
+		},
+		{
+			name: "should return an error when the bucket can't be updated",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`INSERT INTO rate_limit_buckets`).WithQueryException()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			store := NewPostgresStore(db.NewMockConnectionFactory(nil))
+			result, err := store.Take("organisation:123:create", limit, now)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(result).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func Test_postgresStore_Refund(t *testing.T) {
+	limit := Limit{RequestsPerMinute: 60, Burst: 3}
+
+	tests := []struct {
+		name    string
+		setupFn func()
+		wantErr bool
+	}{
+		{
+			name: "should give the request back to the bucket in the database",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE rate_limit_buckets SET tat = tat - $1 WHERE id = $2`).
+					WithArgs(time.Second.Nanoseconds(), "user:123:create").WithRowsNum(1)
+				mocket.Catcher.NewMock().WithExecException()
+			},
+		},
+		{
+			name: "should return an error when the bucket can't be updated",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE rate_limit_buckets`).WithExecException()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			store := NewPostgresStore(db.NewMockConnectionFactory(nil))
+			err := store.Refund("user:123:create", limit)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// RouteClass groups the requests that share the same limits
+type RouteClass string
+
+const (
+	// RouteClassRead is the class of the requests that only read resources
+	RouteClassRead RouteClass = "read"
+	// RouteClassCreate is the class of the requests that create or change resources
+	RouteClassCreate RouteClass = "create"
+)
+
+// RouteClassFor returns the route class of a request with the given HTTP method
+func RouteClassFor(method string) RouteClass {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return RouteClassRead
+	default:
+		return RouteClassCreate
+	}
+}
+
+const (
+	// StoreMemory keeps the buckets in the memory of each replica
+	StoreMemory = "memory"
+	// StorePostgres keeps the buckets in the database, shared by all the replicas
+	StorePostgres = "postgres"
+)
+
+// Limit is the number of requests allowed per minute, and the number of requests that can be made at once
+type Limit struct {
+	RequestsPerMinute int `yaml:"requests_per_minute"`
+	// Burst defaults to RequestsPerMinute when it is not set
+	Burst int `yaml:"burst"`
+}
+
+// ClassLimits are the limits of each route class. Requests of a class without a limit are not limited.
+type ClassLimits struct {
+	Read   *Limit `yaml:"read,omitempty"`
+	Create *Limit `yaml:"create,omitempty"`
+}
+
+// Get returns the limit of the given route class, or nil if the class is not limited
+func (c ClassLimits) Get(class RouteClass) *Limit {
+	switch class {
+	case RouteClassRead:
+		return c.Read
+	case RouteClassCreate:
+		return c.Create
+	default:
+		return nil
+	}
+}
+
+// Limits are the limits applied to an organisation and to each of its users
+type Limits struct {
+	Organisation ClassLimits `yaml:"organisation"`
+	User         ClassLimits `yaml:"user"`
+}
+
+// RateLimits is the content of the rate limit configuration file
+type RateLimits struct {
+	Default Limits `yaml:"default"`
+	// Organisations overrides the default limits of the given organisations
+	Organisations map[string]Limits `yaml:"organisations,omitempty"`
+}
+
+// OrganisationLimit returns the limit of the given organisation for the given route class
+func (r RateLimits) OrganisationLimit(orgId string, class RouteClass) *Limit {
+	if override, ok := r.Organisations[orgId]; ok {
+		if limit := override.Organisation.Get(class); limit != nil {
+			return limit
+		}
+	}
+	return r.Default.Organisation.Get(class)
+}
+
+// UserLimit returns the limit of a user of the given organisation for the given route class
+func (r RateLimits) UserLimit(orgId string, class RouteClass) *Limit {
+	if override, ok := r.Organisations[orgId]; ok && orgId != "" {
+		if limit := override.User.Get(class); limit != nil {
+			return limit
+		}
+	}
+	return r.Default.User.Get(class)
+}
+
+type RateLimitConfig struct {
+	EnableRateLimiting  bool
+	Store               string
+	RateLimitConfigFile string
+	RateLimits          RateLimits
+}
+
+func NewRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		EnableRateLimiting:  false,
+		Store:               StoreMemory,
+		RateLimitConfigFile: "config/rate-limit-configuration.yaml",
+	}
+}
+
+func (c *RateLimitConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&c.EnableRateLimiting, "enable-rate-limiting", c.EnableRateLimiting, "Enable rate limiting of the public API requests per organisation and user")
+	fs.StringVar(&c.Store, "rate-limit-store", c.Store, fmt.Sprintf("Store of the rate limit buckets, either '%s' or '%s'", StoreMemory, StorePostgres))
+	fs.StringVar(&c.RateLimitConfigFile, "rate-limit-config-file", c.RateLimitConfigFile, "Rate limit configuration file")
+}
+
+func (c *RateLimitConfig) ReadFiles() error {
+	if !c.EnableRateLimiting {
+		return nil
+	}
+
+	if c.Store != StoreMemory && c.Store != StorePostgres {
+		return errors.Errorf("invalid rate limit store %q, must be either %q or %q", c.Store, StoreMemory, StorePostgres)
+	}
+
+	if err := readRateLimitConfigFile(c.RateLimitConfigFile, &c.RateLimits); err != nil {
+		return err
+	}
+
+	return c.RateLimits.validate()
+}
+
+func (r *RateLimits) validate() error {
+	if err := r.Default.validate("default"); err != nil {
+		return err
+	}
+	for orgId, limits := range r.Organisations {
+		if err := limits.validate(fmt.Sprintf("organisation %q", orgId)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Limits) validate(name string) error {
+	for scope, classLimits := range map[string]ClassLimits{"organisation": l.Organisation, "user": l.User} {
+		for _, class := range []RouteClass{RouteClassRead, RouteClassCreate} {
+			limit := classLimits.Get(class)
+			if limit == nil {
+				continue
+			}
+			if limit.RequestsPerMinute <= 0 {
+				return errors.Errorf("invalid %s %s %s rate limit: requests_per_minute must be greater than 0", name, scope, class)
+			}
+			if limit.Burst < 0 {
+				return errors.Errorf("invalid %s %s %s rate limit: burst must not be negative", name, scope, class)
+			}
+			if limit.Burst == 0 {
+				limit.Burst = limit.RequestsPerMinute
+			}
+		}
+	}
+	return nil
+}
+
+// Read the contents of file into the rate limits config
+func readRateLimitConfigFile(file string, val *RateLimits) error {
+	fileContents, err := shared.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	return yaml.UnmarshalStrict([]byte(fileContents), val)
+}
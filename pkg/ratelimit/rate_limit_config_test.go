@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_RateLimitConfig_ReadFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   string
+		content string
+		want    RateLimits
+		wantErr bool
+	}{
+		{
+			name:  "should read the limits and default the burst to the requests per minute",
+			store: StorePostgres,
+			content: `
+default:
+  organisation:
+    create: {requests_per_minute: 60, burst: 10}
+  user:
+    read: {requests_per_minute: 30}
+organisations:
+  "123":
+    organisation:
+      create: {requests_per_minute: 600, burst: 100}
+`,
+			want: RateLimits{
+				Default: Limits{
+					Organisation: ClassLimits{Create: &Limit{RequestsPerMinute: 60, Burst: 10}},
+					User:         ClassLimits{Read: &Limit{RequestsPerMinute: 30, Burst: 30}},
+				},
+				Organisations: map[string]Limits{
+					"123": {Organisation: ClassLimits{Create: &Limit{RequestsPerMinute: 600, Burst: 100}}},
+				},
+			},
+		},
+		{
+			name:    "should return an error when the requests per minute are not set",
+			store:   StoreMemory,
+			content: "default: {organisation: {read: {burst: 10}}}",
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when the file has unknown fields",
+			store:   StoreMemory,
+			content: "default: {organisation: {list: {requests_per_minute: 10}}}",
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when the store is not valid",
+			store:   "redis",
+			content: "default: {}",
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			file := filepath.Join(t.TempDir(), "rate-limit-configuration.yaml")
+			g.Expect(os.WriteFile(file, []byte(tt.content), 0600)).To(gomega.Succeed())
+
+			config := NewRateLimitConfig()
+			config.EnableRateLimiting = true
+			config.Store = tt.store
+			config.RateLimitConfigFile = file
+
+			err := config.ReadFiles()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(config.RateLimits).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func Test_RateLimits_Limits(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defaultLimit := &Limit{RequestsPerMinute: 60, Burst: 10}
+	overrideLimit := &Limit{RequestsPerMinute: 600, Burst: 100}
+	limits := RateLimits{
+		Default: Limits{
+			Organisation: ClassLimits{Read: defaultLimit, Create: defaultLimit},
+			User:         ClassLimits{Read: defaultLimit},
+		},
+		Organisations: map[string]Limits{
+			"123": {
+				Organisation: ClassLimits{Create: overrideLimit},
+				User:         ClassLimits{Read: overrideLimit},
+			},
+		},
+	}
+
+	g.Expect(limits.OrganisationLimit("123", RouteClassCreate)).To(gomega.Equal(overrideLimit))
+	g.Expect(limits.OrganisationLimit("123", RouteClassRead)).To(gomega.Equal(defaultLimit))
+	g.Expect(limits.OrganisationLimit("456", RouteClassCreate)).To(gomega.Equal(defaultLimit))
+	g.Expect(limits.UserLimit("123", RouteClassRead)).To(gomega.Equal(overrideLimit))
+	g.Expect(limits.UserLimit("", RouteClassRead)).To(gomega.Equal(defaultLimit))
+	g.Expect(limits.UserLimit("123", RouteClassCreate)).To(gomega.BeNil())
+}
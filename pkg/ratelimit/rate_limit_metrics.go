@@ -0,0 +1,59 @@
+// This file contains the metrics generated by the rate limit middleware:
+//
+//	api_inbound_rate_limited_request_count - Number of requests rejected because a rate limit was exceeded.
+//	api_inbound_rate_limit_error_count - Number of requests whose rate limit couldn't be applied because of a store error.
+//
+// All the metrics have the following labels:
+//
+//	scope - The scope of the limit, either organisation or user.
+//	class - The route class of the request, either read or create.
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem used to define the metrics, the same as the API request metrics
+const metricsSubsystem = "api_inbound"
+
+// Names of the labels added to metrics:
+const (
+	metricsScopeLabel = "scope"
+	metricsClassLabel = "class"
+)
+
+var metricsLabels = []string{
+	metricsScopeLabel,
+	metricsClassLabel,
+}
+
+var rateLimitedRequestsMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "rate_limited_request_count",
+		Help:      "Number of requests rejected because a rate limit was exceeded.",
+	},
+	metricsLabels,
+)
+
+var rateLimitErrorsMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "rate_limit_error_count",
+		Help:      "Number of requests whose rate limit couldn't be applied because of a store error.",
+	},
+	metricsLabels,
+)
+
+// ResetMetricCollectors resets all prometheus collectors
+func ResetMetricCollectors() {
+	rateLimitedRequestsMetric.Reset()
+	rateLimitErrorsMetric.Reset()
+}
+
+func init() {
+	// Register the metrics:
+	prometheus.MustRegister(rateLimitedRequestsMetric)
+	prometheus.MustRegister(rateLimitErrorsMetric)
+}
@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+const (
+	scopeOrganisation = "organisation"
+	scopeUser         = "user"
+)
+
+type RateLimitMiddleware struct {
+	rateLimitConfig *RateLimitConfig
+	store           Store
+	now             func() time.Time
+}
+
+func NewRateLimitMiddleware(rateLimitConfig *RateLimitConfig, store Store) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		rateLimitConfig: rateLimitConfig,
+		store:           store,
+		now:             time.Now,
+	}
+}
+
+// RateLimit limits the requests of each organisation and of each user based on the provided rate limit configuration.
+// A request is taken from the bucket of the user first, then from the bucket of the organisation, and the RateLimit-*
+// headers report the most restrictive of them. A request denied to the user isn't taken from the bucket of the
+// organisation, so that a single user can't exhaust the requests of the whole organisation, and a request denied to the
+// organisation is given back to the bucket of the user. Requests are not limited if the buckets can't be read from the store.
+func (m *RateLimitMiddleware) RateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.rateLimitConfig.EnableRateLimiting {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := auth.GetClaimsFromContext(r.Context())
+		if err != nil {
+			// requests without claims are rejected by the authorization middleware
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		orgId, _ := claims.GetOrgId()
+		username, _ := claims.GetUsername()
+		class := RouteClassFor(r.Method)
+		now := m.now()
+
+		type takenRequest struct {
+			scope string
+			key   string
+			limit Limit
+		}
+		var taken []takenRequest
+		var restrictive *Result
+		var deniedScope string
+		take := func(scope string, subject string, limit *Limit) {
+			if subject == "" || limit == nil || deniedScope != "" {
+				return
+			}
+			key := fmt.Sprintf("%s:%s:%s", scope, subject, class)
+			result, err := m.store.Take(key, *limit, now)
+			if err != nil {
+				logger.NewUHCLogger(r.Context()).Errorf("unable to apply %s rate limit: %v", scope, err)
+				rateLimitErrorsMetric.WithLabelValues(scope, string(class)).Inc()
+				return
+			}
+			if restrictive == nil || isMoreRestrictive(result, *restrictive) {
+				restrictive = &result
+			}
+			if !result.Allowed {
+				deniedScope = scope
+				return
+			}
+			taken = append(taken, takenRequest{scope: scope, key: key, limit: *limit})
+		}
+		take(scopeUser, username, m.rateLimitConfig.RateLimits.UserLimit(orgId, class))
+		take(scopeOrganisation, orgId, m.rateLimitConfig.RateLimits.OrganisationLimit(orgId, class))
+
+		if restrictive == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(restrictive.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(restrictive.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(seconds(restrictive.ResetAfter)))
+
+		if deniedScope != "" {
+			for _, t := range taken {
+				if err := m.store.Refund(t.key, t.limit); err != nil {
+					logger.NewUHCLogger(r.Context()).Errorf("unable to refund %s rate limit: %v", t.scope, err)
+					rateLimitErrorsMetric.WithLabelValues(t.scope, string(class)).Inc()
+				}
+			}
+			rateLimitedRequestsMetric.WithLabelValues(deniedScope, string(class)).Inc()
+			retryAfter := seconds(restrictive.RetryAfter)
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			shared.HandleError(r, w, errors.New(errors.ErrorTooManyRequests, "too many %s requests for the %s, retry in %d seconds", class, deniedScope, retryAfter))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isMoreRestrictive returns true if the result a is more restrictive than the result b: a denied request is more
+// restrictive than an allowed one, and the one that has to wait longer or that has fewer remaining requests is more
+// restrictive otherwise.
+func isMoreRestrictive(a Result, b Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+// seconds rounds up the given duration to seconds
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,215 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/onsi/gomega"
+)
+
+func TestRateLimitMiddleware_RateLimit(t *testing.T) {
+	now := time.Date(2023, 4, 19, 12, 0, 0, 0, time.UTC)
+	limit := &Limit{RequestsPerMinute: 60, Burst: 10}
+	config := &RateLimitConfig{
+		EnableRateLimiting: true,
+		RateLimits: RateLimits{
+			Default: Limits{
+				Organisation: ClassLimits{Create: limit},
+				User:         ClassLimits{Create: limit},
+			},
+		},
+	}
+	claims := jwt.MapClaims{
+		"org_id":   "123",
+		"username": "test-user",
+	}
+
+	tests := []struct {
+		name        string
+		config      *RateLimitConfig
+		method      string
+		claims      jwt.MapClaims
+		store       Store
+		wantStatus  int
+		wantHeaders map[string]string
+		wantTakes   []string
+		wantRefunds []string
+	}{
+		{
+			name:       "should not limit requests when rate limiting is disabled",
+			config:     &RateLimitConfig{EnableRateLimiting: false},
+			method:     http.MethodPost,
+			claims:     claims,
+			store:      &StoreMock{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should not limit requests of a route class without limits",
+			config:     config,
+			method:     http.MethodGet,
+			claims:     claims,
+			store:      &StoreMock{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "should report the most restrictive bucket when the request is allowed",
+			config: config,
+			method: http.MethodPost,
+			claims: claims,
+			store: &StoreMock{
+				TakeFunc: func(key string, limit Limit, now time.Time) (Result, error) {
+					if key == "organisation:123:create" {
+						return Result{Allowed: true, Limit: 10, Remaining: 2, ResetAfter: 7500 * time.Millisecond}, nil
+					}
+					return Result{Allowed: true, Limit: 10, Remaining: 8, ResetAfter: time.Second}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "2",
+				"RateLimit-Reset":     "8",
+				"Retry-After":         "",
+			},
+			wantTakes: []string{"user:test-user:create", "organisation:123:create"},
+		},
+		{
+			name:   "should return 429 without taking from the organisation bucket when the user bucket is exhausted",
+			config: config,
+			method: http.MethodPost,
+			claims: claims,
+			store: &StoreMock{
+				TakeFunc: func(key string, limit Limit, now time.Time) (Result, error) {
+					if key == "user:test-user:create" {
+						return Result{Allowed: false, Limit: 10, Remaining: 0, ResetAfter: 10 * time.Second, RetryAfter: 1500 * time.Millisecond}, nil
+					}
+					return Result{Allowed: true, Limit: 10, Remaining: 5, ResetAfter: 5 * time.Second}, nil
+				},
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "10",
+				"Retry-After":         "2",
+			},
+			wantTakes: []string{"user:test-user:create"},
+		},
+		{
+			name:   "should return 429 and give the request back to the user bucket when the organisation bucket is exhausted",
+			config: config,
+			method: http.MethodPost,
+			claims: claims,
+			store: &StoreMock{
+				TakeFunc: func(key string, limit Limit, now time.Time) (Result, error) {
+					if key == "organisation:123:create" {
+						return Result{Allowed: false, Limit: 10, Remaining: 0, ResetAfter: 10 * time.Second, RetryAfter: 3 * time.Second}, nil
+					}
+					return Result{Allowed: true, Limit: 10, Remaining: 5, ResetAfter: 5 * time.Second}, nil
+				},
+				RefundFunc: func(key string, limit Limit) error {
+					return nil
+				},
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"Retry-After":         "3",
+			},
+			wantTakes:   []string{"user:test-user:create", "organisation:123:create"},
+			wantRefunds: []string{"user:test-user:create"},
+		},
+		{
+			name:   "should return 429 when the organisation bucket is exhausted and the user request can't be given back",
+			config: config,
+			method: http.MethodPost,
+			claims: claims,
+			store: &StoreMock{
+				TakeFunc: func(key string, limit Limit, now time.Time) (Result, error) {
+					if key == "organisation:123:create" {
+						return Result{Allowed: false, Limit: 10, Remaining: 0, ResetAfter: 10 * time.Second, RetryAfter: 3 * time.Second}, nil
+					}
+					return Result{Allowed: true, Limit: 10, Remaining: 5, ResetAfter: 5 * time.Second}, nil
+				},
+				RefundFunc: func(key string, limit Limit) error {
+					return fmt.Errorf("connection refused")
+				},
+			},
+			wantStatus:  http.StatusTooManyRequests,
+			wantTakes:   []string{"user:test-user:create", "organisation:123:create"},
+			wantRefunds: []string{"user:test-user:create"},
+		},
+		{
+			name:   "should only take from the user bucket when the claims have no organisation",
+			config: config,
+			method: http.MethodDelete,
+			claims: jwt.MapClaims{"username": "test-user"},
+			store: &StoreMock{
+				TakeFunc: func(key string, limit Limit, now time.Time) (Result, error) {
+					return Result{Allowed: true, Limit: 10, Remaining: 9, ResetAfter: time.Second}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantTakes:  []string{"user:test-user:create"},
+		},
+		{
+			name:   "should not limit requests when the store fails",
+			config: config,
+			method: http.MethodPost,
+			claims: claims,
+			store: &StoreMock{
+				TakeFunc: func(key string, limit Limit, now time.Time) (Result, error) {
+					return Result{}, fmt.Errorf("connection refused")
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+			wantTakes: []string{"user:test-user:create", "organisation:123:create"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			middleware := NewRateLimitMiddleware(tt.config, tt.store)
+			middleware.now = func() time.Time { return now }
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			req := httptest.NewRequest(tt.method, "http://example.com", nil)
+			req = req.WithContext(auth.SetTokenInContext(req.Context(), &jwt.Token{Claims: tt.claims}))
+			recorder := httptest.NewRecorder()
+			middleware.RateLimit(next).ServeHTTP(recorder, req)
+
+			resp := recorder.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatus))
+			for header, value := range tt.wantHeaders {
+				g.Expect(resp.Header.Get(header)).To(gomega.Equal(value), header)
+			}
+
+			if mock, ok := tt.store.(*StoreMock); ok {
+				var takes []string
+				for _, call := range mock.TakeCalls() {
+					takes = append(takes, call.Key)
+				}
+				g.Expect(takes).To(gomega.Equal(tt.wantTakes))
+
+				var refunds []string
+				for _, call := range mock.RefundCalls() {
+					refunds = append(refunds, call.Key)
+				}
+				g.Expect(refunds).To(gomega.Equal(tt.wantRefunds))
+			}
+		})
+	}
+}
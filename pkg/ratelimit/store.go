@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+)
+
+// Result is the state of a bucket after a request has been taken from it
+type Result struct {
+	Allowed bool
+	// Limit is the number of requests that can be made at once
+	Limit int
+	// Remaining is the number of requests that can still be made at once
+	Remaining int
+	// ResetAfter is the time until the bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is the time until the next request is allowed, it is only set when the request is not allowed
+	RetryAfter time.Duration
+}
+
+//go:generate moq -out store_moq.go . Store
+type Store interface {
+	// Take takes a request from the bucket with the given key
+	Take(key string, limit Limit, now time.Time) (Result, error)
+	// Refund gives back a request taken from the bucket with the given key, when the request is denied by another bucket
+	Refund(key string, limit Limit) error
+}
+
+// NewStore returns the store selected in the configuration
+func NewStore(config *RateLimitConfig, connectionFactory *db.ConnectionFactory) Store {
+	if config.Store == StorePostgres {
+		return NewPostgresStore(connectionFactory)
+	}
+	return NewMemoryStore()
+}
+
+// The buckets are token buckets implemented with the generic cell rate algorithm (GCRA): instead of a number of
+// tokens, a bucket only stores the theoretical arrival time (TAT) of the next request, i.e. the time at which the
+// bucket would be full again. A request is allowed if the TAT after taking it is not further in the future than
+// the time needed to refill the whole burst.
+
+// interval is the time needed to refill one request
+func (l Limit) interval() time.Duration {
+	return time.Minute / time.Duration(l.RequestsPerMinute)
+}
+
+// burstWindow is the time needed to refill the whole burst
+func (l Limit) burstWindow() time.Duration {
+	return l.interval() * time.Duration(l.Burst)
+}
+
+// take returns the TAT of the bucket after taking a request at the given time and whether the request is allowed.
+// The TAT is unchanged if the request is not allowed.
+func (l Limit) take(tat time.Time, now time.Time) (time.Time, bool) {
+	if tat.Before(now) {
+		tat = now
+	}
+	newTAT := tat.Add(l.interval())
+	if newTAT.Sub(now) > l.burstWindow() {
+		return tat, false
+	}
+	return newTAT, true
+}
+
+// refund returns the TAT of the bucket after giving back a request taken from it
+func (l Limit) refund(tat time.Time) time.Time {
+	return tat.Add(-l.interval())
+}
+
+// result returns the state of the bucket with the given TAT
+func (l Limit) result(tat time.Time, allowed bool, now time.Time) Result {
+	result := Result{
+		Allowed: allowed,
+		Limit:   l.Burst,
+	}
+
+	used := tat.Sub(now)
+	if used < 0 {
+		used = 0
+	}
+	result.ResetAfter = used
+	result.Remaining = int((l.burstWindow() - used) / l.interval())
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+
+	if !allowed {
+		result.RetryAfter = tat.Add(l.interval()).Sub(now) - l.burstWindow()
+	}
+
+	return result
+}
+
+// staleBucketsSweepInterval is how often the buckets that are full again are removed from the stores
+const staleBucketsSweepInterval = 5 * time.Minute
+
+var _ Store = &memoryStore{}
+
+type memoryStore struct {
+	mutex     sync.Mutex
+	buckets   map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryStore returns a store that keeps the buckets in memory. Each replica of the service limits requests on its own.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: map[string]time.Time{},
+	}
+}
+
+func (s *memoryStore) Take(key string, limit Limit, now time.Time) (Result, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if now.Sub(s.lastSweep) > staleBucketsSweepInterval {
+		for k, tat := range s.buckets {
+			if !tat.After(now) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	tat, allowed := limit.take(s.buckets[key], now)
+	s.buckets[key] = tat
+
+	return limit.result(tat, allowed, now), nil
+}
+
+func (s *memoryStore) Refund(key string, limit Limit) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if tat, ok := s.buckets[key]; ok {
+		s.buckets[key] = limit.refund(tat)
+	}
+	return nil
+}
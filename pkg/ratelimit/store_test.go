@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_memoryStore_Take(t *testing.T) {
+	now := time.Date(2023, 4, 19, 12, 0, 0, 0, time.UTC)
+	limit := Limit{RequestsPerMinute: 60, Burst: 3}
+
+	type take struct {
+		at   time.Time
+		want Result
+	}
+
+	tests := []struct {
+		name  string
+		takes []take
+	}{
+		{
+			name: "should allow the burst of requests at once and then reject requests until a request is refilled",
+			takes: []take{
+				{at: now, want: Result{Allowed: true, Limit: 3, Remaining: 2, ResetAfter: time.Second}},
+				{at: now, want: Result{Allowed: true, Limit: 3, Remaining: 1, ResetAfter: 2 * time.Second}},
+				{at: now, want: Result{Allowed: true, Limit: 3, Remaining: 0, ResetAfter: 3 * time.Second}},
+				{at: now, want: Result{Allowed: false, Limit: 3, Remaining: 0, ResetAfter: 3 * time.Second, RetryAfter: time.Second}},
+				{at: now.Add(500 * time.Millisecond), want: Result{Allowed: false, Limit: 3, Remaining: 0, ResetAfter: 2500 * time.Millisecond, RetryAfter: 500 * time.Millisecond}},
+				{at: now.Add(time.Second), want: Result{Allowed: true, Limit: 3, Remaining: 0, ResetAfter: 3 * time.Second}},
+			},
+		},
+		{
+			name: "should refill the whole burst after the reset time",
+			takes: []take{
+				{at: now, want: Result{Allowed: true, Limit: 3, Remaining: 2, ResetAfter: time.Second}},
+				{at: now, want: Result{Allowed: true, Limit: 3, Remaining: 1, ResetAfter: 2 * time.Second}},
+				{at: now.Add(time.Hour), want: Result{Allowed: true, Limit: 3, Remaining: 2, ResetAfter: time.Second}},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			store := NewMemoryStore()
+			for _, take := range tt.takes {
+				result, err := store.Take("org:123:create", limit, take.at)
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(result).To(gomega.Equal(take.want))
+			}
+		})
+	}
+}
+
+func Test_memoryStore_Refund(t *testing.T) {
+	g := gomega.NewWithT(t)
+	now := time.Date(2023, 4, 19, 12, 0, 0, 0, time.UTC)
+	limit := Limit{RequestsPerMinute: 60, Burst: 3}
+	store := NewMemoryStore()
+
+	for i := 0; i < 3; i++ {
+		_, err := store.Take("user:123:create", limit, now)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}
+	g.Expect(store.Refund("user:123:create", limit)).To(gomega.Succeed())
+	g.Expect(store.Refund("user:unknown:create", limit)).To(gomega.Succeed())
+
+	result, err := store.Take("user:123:create", limit, now)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(result).To(gomega.Equal(Result{Allowed: true, Limit: 3, Remaining: 0, ResetAfter: 3 * time.Second}))
+	g.Expect(store.(*memoryStore).buckets).ToNot(gomega.HaveKey("user:unknown:create"))
+}
+
+func Test_memoryStore_TakeRemovesStaleBuckets(t *testing.T) {
+	g := gomega.NewWithT(t)
+	now := time.Date(2023, 4, 19, 12, 0, 0, 0, time.UTC)
+	limit := Limit{RequestsPerMinute: 60, Burst: 3}
+	store := NewMemoryStore().(*memoryStore)
+
+	_, err := store.Take("user:stale:read", limit, now)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	_, err = store.Take("user:active:read", limit, now.Add(staleBucketsSweepInterval+time.Second))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	g.Expect(store.buckets).ToNot(gomega.HaveKey("user:stale:read"))
+	g.Expect(store.buckets).To(gomega.HaveKey("user:active:read"))
+}
+
+func Test_RouteClassFor(t *testing.T) {
+	tests := []struct {
+		method string
+		want   RouteClass
+	}{
+		{method: "GET", want: RouteClassRead},
+		{method: "HEAD", want: RouteClassRead},
+		{method: "POST", want: RouteClassCreate},
+		{method: "PATCH", want: RouteClassCreate},
+		{method: "DELETE", want: RouteClassCreate},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.method, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(RouteClassFor(tt.method)).To(gomega.Equal(tt.want))
+		})
+	}
+}
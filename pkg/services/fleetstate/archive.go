@@ -0,0 +1,171 @@
+package fleetstate
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// ArchiveFormatVersion is the version of the layout of the archives, it has to be increased on every change that
+	// prevents an archive from being read by a previous version of the fleet manager
+	ArchiveFormatVersion = 1
+
+	manifestFile  = "manifest.json"
+	tablesDir     = "tables/"
+	sequencesFile = "sequences.json"
+	secretsFile   = "secrets.json"
+)
+
+// Manifest describes the content of an archive
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	// SchemaVersions holds the IDs of the migrations applied to the exported database, by migrations table
+	SchemaVersions map[string][]string `json:"schema_versions"`
+	// Tables lists the exported tables in the order they have to be imported
+	Tables          []TableManifest `json:"tables"`
+	SecretsIncluded bool            `json:"secrets_included"`
+}
+
+type TableManifest struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+// Sequence is the state of a database sequence, like the ones used for the resource versions
+type Sequence struct {
+	Name      string `json:"name"`
+	LastValue int64  `json:"last_value"`
+	IsCalled  bool   `json:"is_called"`
+}
+
+// Archive is the logical state of the fleet, as stored in the database and in the secret stores
+type Archive struct {
+	Manifest Manifest
+	// Tables holds the rows of each table as a JSON array of objects, whose keys are the column names
+	Tables    map[string]json.RawMessage
+	Sequences []Sequence
+	Secrets   []EncryptedSecret
+}
+
+// Write writes the archive as a gzipped tarball with the manifest, one JSON file per table, the sequences and
+// the encrypted secrets
+func (a *Archive) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := writeJSONFile(tw, manifestFile, a.Manifest); err != nil {
+		return err
+	}
+	for _, table := range a.Manifest.Tables {
+		if err := writeFile(tw, tablesDir+table.Name+".json", a.Tables[table.Name]); err != nil {
+			return err
+		}
+	}
+	if err := writeJSONFile(tw, sequencesFile, a.Sequences); err != nil {
+		return err
+	}
+	if a.Manifest.SecretsIncluded {
+		if err := writeJSONFile(tw, secretsFile, a.Secrets); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("unable to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("unable to write archive: %w", err)
+	}
+	return nil
+}
+
+// ReadArchive reads an archive written by Archive.Write. Archives of another format version are rejected.
+func ReadArchive(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read archive: %w", err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s from archive: %w", header.Name, err)
+		}
+		files[header.Name] = content
+	}
+
+	archive := &Archive{Tables: map[string]json.RawMessage{}}
+	if err := readJSONFile(files, manifestFile, &archive.Manifest); err != nil {
+		return nil, err
+	}
+	if archive.Manifest.FormatVersion != ArchiveFormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d, version %d is expected", archive.Manifest.FormatVersion, ArchiveFormatVersion)
+	}
+	for _, table := range archive.Manifest.Tables {
+		content, ok := files[tablesDir+table.Name+".json"]
+		if !ok {
+			return nil, fmt.Errorf("table %s is missing from archive", table.Name)
+		}
+		archive.Tables[table.Name] = content
+	}
+	if err := readJSONFile(files, sequencesFile, &archive.Sequences); err != nil {
+		return nil, err
+	}
+	if archive.Manifest.SecretsIncluded {
+		if err := readJSONFile(files, secretsFile, &archive.Secrets); err != nil {
+			return nil, err
+		}
+	}
+	return archive, nil
+}
+
+func writeJSONFile(tw *tar.Writer, name string, v interface{}) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal %s: %w", name, err)
+	}
+	return writeFile(tw, name, content)
+}
+
+func writeFile(tw *tar.Writer, name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("unable to write %s to archive: %w", name, err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return fmt.Errorf("unable to write %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+func readJSONFile(files map[string][]byte, name string, v interface{}) error {
+	content, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%s is missing from archive", strings.TrimSuffix(name, ".json"))
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("unable to unmarshal %s: %w", name, err)
+	}
+	return nil
+}
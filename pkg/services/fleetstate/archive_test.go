@@ -0,0 +1,87 @@
+package fleetstate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_Archive_WriteAndRead(t *testing.T) {
+	g := gomega.NewWithT(t)
+	archive := &Archive{
+		Manifest: Manifest{
+			FormatVersion:   ArchiveFormatVersion,
+			CreatedAt:       time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			SchemaVersions:  map[string][]string{"migrations": {"1", "2"}},
+			Tables:          []TableManifest{{Name: "clusters", Rows: 1}, {Name: "kafka_requests", Rows: 0}},
+			SecretsIncluded: true,
+		},
+		Tables: map[string]json.RawMessage{
+			"clusters":       json.RawMessage(`[{"id":"cluster-1"}]`),
+			"kafka_requests": json.RawMessage(`[]`),
+		},
+		Sequences: []Sequence{{Name: "connectors_version_seq", LastValue: 42, IsCalled: true}},
+		Secrets:   []EncryptedSecret{{Store: "store", Name: "secret", Value: "encrypted"}},
+	}
+
+	var buf bytes.Buffer
+	g.Expect(archive.Write(&buf)).To(gomega.Succeed())
+	read, err := ReadArchive(&buf)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(read).To(gomega.Equal(archive))
+}
+
+func newTestArchiveFile(g *gomega.WithT, files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		g.Expect(writeFile(tw, name, []byte(content))).To(gomega.Succeed())
+	}
+	g.Expect(tw.Close()).To(gomega.Succeed())
+	g.Expect(gz.Close()).To(gomega.Succeed())
+	return &buf
+}
+
+func Test_ReadArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "should return an error when the manifest is missing",
+			files:   map[string]string{sequencesFile: `[]`},
+			wantErr: "manifest is missing from archive",
+		},
+		{
+			name:    "should return an error when the format version is not supported",
+			files:   map[string]string{manifestFile: `{"format_version": 2}`, sequencesFile: `[]`},
+			wantErr: "unsupported archive format version 2, version 1 is expected",
+		},
+		{
+			name:    "should return an error when a table of the manifest is missing",
+			files:   map[string]string{manifestFile: `{"format_version": 1, "tables": [{"name": "clusters"}]}`, sequencesFile: `[]`},
+			wantErr: "table clusters is missing from archive",
+		},
+		{
+			name:    "should return an error when the secrets are missing",
+			files:   map[string]string{manifestFile: `{"format_version": 1, "secrets_included": true}`, sequencesFile: `[]`},
+			wantErr: "secrets is missing from archive",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			_, err := ReadArchive(newTestArchiveFile(g, tt.files))
+			g.Expect(err).To(gomega.MatchError(tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package fleetstate
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// SecretStore is implemented by the modules that keep secrets outside of the database, so that the secrets
+// referenced by the exported rows can be exported and imported with them
+type SecretStore interface {
+	// Name identifies the store in the archives
+	Name() string
+	ListSecrets() ([]Secret, error)
+	ImportSecret(secret Secret) error
+	// DeleteSecret removes an imported secret again when the import fails
+	DeleteSecret(name string) error
+}
+
+type Secret struct {
+	Name           string
+	OwningResource string
+	Value          string
+}
+
+// EncryptedSecret is a secret of a store whose value is encrypted with the key given to the export
+type EncryptedSecret struct {
+	Store          string `json:"store"`
+	Name           string `json:"name"`
+	OwningResource string `json:"owning_resource"`
+	// Value is the base64 encoded nonce followed by the AES-GCM encrypted value
+	Value string `json:"value"`
+}
+
+// NewSecretsKey derives the AES-256 key that encrypts the secret values from the content of a key file
+func NewSecretsKey(keyFileContent []byte) []byte {
+	key := sha256.Sum256(keyFileContent)
+	return key[:]
+}
+
+func encryptSecret(key []byte, store string, secret Secret) (EncryptedSecret, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return EncryptedSecret{}, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return EncryptedSecret{}, fmt.Errorf("unable to generate nonce: %w", err)
+	}
+	return EncryptedSecret{
+		Store:          store,
+		Name:           secret.Name,
+		OwningResource: secret.OwningResource,
+		Value:          base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(secret.Value), nil)),
+	}, nil
+}
+
+func decryptSecret(key []byte, encrypted EncryptedSecret) (Secret, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return Secret{}, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encrypted.Value)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return Secret{}, fmt.Errorf("invalid value of secret %s", encrypted.Name)
+	}
+	value, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return Secret{}, fmt.Errorf("unable to decrypt secret %s, the key is not the one used for the export", encrypted.Name)
+	}
+	return Secret{
+		Name:           encrypted.Name,
+		OwningResource: encrypted.OwningResource,
+		Value:          string(value),
+	}, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,25 @@
+package fleetstate
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_encryptSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+	key := NewSecretsKey([]byte("passphrase"))
+	secret := Secret{Name: "secret-1", OwningResource: "connector-1", Value: "value"}
+
+	encrypted, err := encryptSecret(key, "store", secret)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(encrypted.Store).To(gomega.Equal("store"))
+	g.Expect(encrypted.Value).ToNot(gomega.ContainSubstring("value"))
+
+	decrypted, err := decryptSecret(key, encrypted)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decrypted).To(gomega.Equal(secret))
+
+	_, err = decryptSecret(NewSecretsKey([]byte("other passphrase")), encrypted)
+	g.Expect(err).To(gomega.MatchError("unable to decrypt secret secret-1, the key is not the one used for the export"))
+}
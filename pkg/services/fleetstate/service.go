@@ -0,0 +1,397 @@
+package fleetstate
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/goava/di"
+	"github.com/golang/glog"
+	"gorm.io/gorm"
+)
+
+// excludedTables are the tables that hold the runtime state of the fleet manager instances rather than the state
+// of the fleet, the migrations tables are excluded too
+var excludedTables = []string{"leader_leases", "distributed_locks"}
+
+// FleetStateService exports the logical state of the fleet to an archive and imports it into an empty database of the
+// same schema version, for example to rebuild a control plane in another region
+type FleetStateService interface {
+	// Export returns the archive of the current fleet state. The values of the secrets are included, encrypted with
+	// the given key, only when a key is given.
+	Export(secretsKey []byte) (*Archive, error)
+	// Import checks that the database has the schema version of the archive and none of its rows, then imports
+	// the archive preserving the IDs and the resource versions. The key is required when the archive has secrets.
+	Import(archive *Archive, secretsKey []byte) error
+}
+
+type ServiceInjections struct {
+	di.Inject
+	ConnectionFactory *db.ConnectionFactory
+	Migrations        []*db.Migration
+	SecretStores      []SecretStore `optional:"true"`
+}
+
+type fleetStateService struct {
+	connectionFactory *db.ConnectionFactory
+	migrations        []*db.Migration
+	secretStores      []SecretStore
+}
+
+var _ FleetStateService = &fleetStateService{}
+
+func NewFleetStateService(in ServiceInjections) FleetStateService {
+	return &fleetStateService{
+		connectionFactory: in.ConnectionFactory,
+		migrations:        in.Migrations,
+		secretStores:      in.SecretStores,
+	}
+}
+
+func (s *fleetStateService) Export(secretsKey []byte) (*Archive, error) {
+	schemaVersions, err := s.schemaVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{
+		Manifest: Manifest{
+			FormatVersion:   ArchiveFormatVersion,
+			CreatedAt:       time.Now(),
+			SchemaVersions:  schemaVersions,
+			SecretsIncluded: secretsKey != nil,
+		},
+		Tables: map[string]json.RawMessage{},
+	}
+
+	// all the tables are read in a single transaction to get a consistent snapshot
+	err = s.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		tables, err := s.listTables(tx)
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			var rows string
+			if err := tx.Raw(fmt.Sprintf("SELECT coalesce(json_agg(t), '[]'::json) FROM %s t", quoteIdentifier(table))).Row().Scan(&rows); err != nil {
+				return fmt.Errorf("unable to export table %s: %w", table, err)
+			}
+			var items []json.RawMessage
+			if err := json.Unmarshal([]byte(rows), &items); err != nil {
+				return fmt.Errorf("unable to export table %s: %w", table, err)
+			}
+			archive.Tables[table] = json.RawMessage(rows)
+			archive.Manifest.Tables = append(archive.Manifest.Tables, TableManifest{Name: table, Rows: len(items)})
+		}
+
+		archive.Sequences, err = listSequences(tx)
+		return err
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if secretsKey != nil {
+		for _, store := range s.secretStores {
+			secrets, err := store.ListSecrets()
+			if err != nil {
+				return nil, fmt.Errorf("unable to list secrets of %s: %w", store.Name(), err)
+			}
+			for _, secret := range secrets {
+				encrypted, err := encryptSecret(secretsKey, store.Name(), secret)
+				if err != nil {
+					return nil, err
+				}
+				archive.Secrets = append(archive.Secrets, encrypted)
+			}
+		}
+	}
+
+	return archive, nil
+}
+
+func (s *fleetStateService) Import(archive *Archive, secretsKey []byte) error {
+	if archive.Manifest.SecretsIncluded && secretsKey == nil {
+		return fmt.Errorf("the archive contains secrets, the key used for the export is required")
+	}
+	stores := map[string]SecretStore{}
+	for _, store := range s.secretStores {
+		stores[store.Name()] = store
+	}
+	for _, secret := range archive.Secrets {
+		if _, ok := stores[secret.Store]; !ok {
+			return fmt.Errorf("unknown secret store %q of secret %s", secret.Store, secret.Name)
+		}
+	}
+
+	schemaVersions, err := s.schemaVersions()
+	if err != nil {
+		return err
+	}
+	if err := checkSchemaVersions(archive.Manifest.SchemaVersions, schemaVersions); err != nil {
+		return err
+	}
+
+	var imported []EncryptedSecret
+	err = s.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		tables, err := s.listTables(tx)
+		if err != nil {
+			return err
+		}
+		if err := checkTables(archive, tables); err != nil {
+			return err
+		}
+		if err := checkConflicts(tx, archive); err != nil {
+			return err
+		}
+
+		for _, table := range archive.Manifest.Tables {
+			// the user triggers are disabled so that the triggers that set the resource versions do not change them
+			name := quoteIdentifier(table.Name)
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s DISABLE TRIGGER USER", name)).Error; err != nil {
+				return fmt.Errorf("unable to import table %s: %w", table.Name, err)
+			}
+			if err := tx.Exec(fmt.Sprintf("INSERT INTO %s SELECT * FROM json_populate_recordset(NULL::%s, ?)", name, name), string(archive.Tables[table.Name])).Error; err != nil {
+				return fmt.Errorf("unable to import table %s: %w", table.Name, err)
+			}
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE TRIGGER USER", name)).Error; err != nil {
+				return fmt.Errorf("unable to import table %s: %w", table.Name, err)
+			}
+			glog.Infof("Imported %d rows into table %s", table.Rows, table.Name)
+		}
+
+		for _, sequence := range archive.Sequences {
+			if err := tx.Exec("SELECT setval(?, ?, ?)", quoteIdentifier(sequence.Name), sequence.LastValue, sequence.IsCalled).Error; err != nil {
+				return fmt.Errorf("unable to import sequence %s: %w", sequence.Name, err)
+			}
+		}
+
+		// the secrets are imported last so that the rows are rolled back when one of them fails,
+		// the secrets imported before the failure are deleted below
+		for _, encrypted := range archive.Secrets {
+			secret, err := decryptSecret(secretsKey, encrypted)
+			if err != nil {
+				return err
+			}
+			if err := stores[encrypted.Store].ImportSecret(secret); err != nil {
+				return fmt.Errorf("unable to import secret %s into %s: %w", secret.Name, encrypted.Store, err)
+			}
+			imported = append(imported, encrypted)
+		}
+		glog.Infof("Imported %d secrets", len(archive.Secrets))
+		return nil
+	})
+	if err != nil {
+		// the database had none of the archived rows, so the secrets they reference did not exist before the import
+		deleteSecrets(stores, imported)
+		return err
+	}
+	return nil
+}
+
+// deleteSecrets removes the secrets of a failed import, failures are only logged so that the import error is returned
+func deleteSecrets(stores map[string]SecretStore, secrets []EncryptedSecret) {
+	for _, secret := range secrets {
+		if err := stores[secret.Store].DeleteSecret(secret.Name); err != nil {
+			glog.Errorf("Unable to delete secret %s from %s after the failed import: %v", secret.Name, secret.Store, err)
+		}
+	}
+	if len(secrets) > 0 {
+		glog.Infof("Deleted %d secrets of the failed import", len(secrets))
+	}
+}
+
+// schemaVersions returns the IDs of the applied migrations of each migrations table
+func (s *fleetStateService) schemaVersions() (map[string][]string, error) {
+	versions := map[string][]string{}
+	for _, migration := range s.migrations {
+		status, err := migration.Status()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get status of %s: %w", migration.GormOptions.TableName, err)
+		}
+		applied := []string{}
+		for _, st := range status {
+			if st.Applied {
+				applied = append(applied, st.ID)
+			}
+		}
+		versions[migration.GormOptions.TableName] = applied
+	}
+	return versions, nil
+}
+
+// listTables returns the tables of the fleet state, sorted so that the tables referenced by foreign keys come before
+// the tables referencing them
+func (s *fleetStateService) listTables(tx *gorm.DB) ([]string, error) {
+	excluded := map[string]bool{}
+	for _, table := range excludedTables {
+		excluded[table] = true
+	}
+	for _, migration := range s.migrations {
+		excluded[migration.GormOptions.TableName] = true
+	}
+
+	var names []string
+	if err := tx.Raw("SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'").Scan(&names).Error; err != nil {
+		return nil, fmt.Errorf("unable to list tables: %w", err)
+	}
+	var tables []string
+	for _, name := range names {
+		if !excluded[name] {
+			tables = append(tables, name)
+		}
+	}
+
+	var references []struct {
+		TableName           string
+		ReferencedTableName string
+	}
+	err := tx.Raw(`SELECT tc.table_name AS table_name, ccu.table_name AS referenced_table_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.constraint_column_usage ccu
+		ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = current_schema()`).Scan(&references).Error
+	if err != nil {
+		return nil, fmt.Errorf("unable to list foreign keys: %w", err)
+	}
+	dependencies := map[string][]string{}
+	for _, r := range references {
+		dependencies[r.TableName] = append(dependencies[r.TableName], r.ReferencedTableName)
+	}
+
+	return sortTables(tables, dependencies)
+}
+
+// sortTables sorts the tables by name and then so that each table comes after the tables it depends on
+func sortTables(tables []string, dependencies map[string][]string) ([]string, error) {
+	sorted := make([]string, 0, len(tables))
+	remaining := append([]string{}, tables...)
+	sort.Strings(remaining)
+	known := map[string]bool{}
+	for _, table := range tables {
+		known[table] = true
+	}
+	done := map[string]bool{}
+
+	for len(remaining) > 0 {
+		var next []string
+		for _, table := range remaining {
+			ready := true
+			for _, dependency := range dependencies[table] {
+				if dependency != table && known[dependency] && !done[dependency] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				sorted = append(sorted, table)
+				done[table] = true
+			} else {
+				next = append(next, table)
+			}
+		}
+		if len(next) == len(remaining) {
+			return nil, fmt.Errorf("circular foreign keys between tables %s", strings.Join(next, ", "))
+		}
+		remaining = next
+	}
+	return sorted, nil
+}
+
+func listSequences(tx *gorm.DB) ([]Sequence, error) {
+	var names []string
+	if err := tx.Raw("SELECT sequence_name FROM information_schema.sequences WHERE sequence_schema = current_schema() ORDER BY sequence_name").Scan(&names).Error; err != nil {
+		return nil, fmt.Errorf("unable to list sequences: %w", err)
+	}
+	sequences := []Sequence{}
+	for _, name := range names {
+		sequence := Sequence{Name: name}
+		if err := tx.Raw(fmt.Sprintf("SELECT last_value, is_called FROM %s", quoteIdentifier(name))).Row().Scan(&sequence.LastValue, &sequence.IsCalled); err != nil {
+			return nil, fmt.Errorf("unable to export sequence %s: %w", name, err)
+		}
+		sequences = append(sequences, sequence)
+	}
+	return sequences, nil
+}
+
+func checkSchemaVersions(archived map[string][]string, current map[string][]string) error {
+	var mismatches []string
+	for table, ids := range archived {
+		if !reflect.DeepEqual(ids, current[table]) {
+			mismatches = append(mismatches, fmt.Sprintf("%s (archive: %s, database: %s)", table, lastID(ids), lastID(current[table])))
+		}
+	}
+	for table, ids := range current {
+		if _, ok := archived[table]; !ok && len(ids) > 0 {
+			mismatches = append(mismatches, fmt.Sprintf("%s (archive: none, database: %s)", table, lastID(ids)))
+		}
+	}
+	if len(mismatches) > 0 {
+		sort.Strings(mismatches)
+		return fmt.Errorf("the schema version of the database is not the one of the archive: %s", strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+func lastID(ids []string) string {
+	if len(ids) == 0 {
+		return "none"
+	}
+	return ids[len(ids)-1]
+}
+
+func checkTables(archive *Archive, tables []string) error {
+	existing := map[string]bool{}
+	for _, table := range tables {
+		existing[table] = true
+	}
+	var missing []string
+	for _, table := range archive.Manifest.Tables {
+		if !existing[table.Name] {
+			missing = append(missing, table.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("tables %s of the archive do not exist in the database", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkConflicts returns an error listing the tables that are not empty, with the number of their rows whose ID
+// is in the archive
+func checkConflicts(tx *gorm.DB, archive *Archive) error {
+	var conflicts []string
+	for _, table := range archive.Manifest.Tables {
+		name := quoteIdentifier(table.Name)
+		var count int64
+		if err := tx.Raw(fmt.Sprintf("SELECT count(*) FROM %s", name)).Row().Scan(&count); err != nil {
+			return fmt.Errorf("unable to check table %s: %w", table.Name, err)
+		}
+		if count == 0 {
+			continue
+		}
+
+		conflict := fmt.Sprintf("%s has %d rows", table.Name, count)
+		if tx.Migrator().HasColumn(table.Name, "id") {
+			var conflictingIDs int64
+			query := fmt.Sprintf("SELECT count(*) FROM %s WHERE id IN (SELECT id FROM json_populate_recordset(NULL::%s, ?))", name, name)
+			if err := tx.Raw(query, string(archive.Tables[table.Name])).Row().Scan(&conflictingIDs); err != nil {
+				return fmt.Errorf("unable to check table %s: %w", table.Name, err)
+			}
+			conflict = fmt.Sprintf("%s, %d of them with an ID of the archive", conflict, conflictingIDs)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("the database is not empty: %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,180 @@
+package fleetstate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_sortTables(t *testing.T) {
+	tests := []struct {
+		name         string
+		tables       []string
+		dependencies map[string][]string
+		want         []string
+		wantErr      string
+	}{
+		{
+			name:   "should sort the tables by name when there are no foreign keys",
+			tables: []string{"kafka_requests", "clusters"},
+			want:   []string{"clusters", "kafka_requests"},
+		},
+		{
+			name:   "should sort the tables after the tables they reference",
+			tables: []string{"connectors", "connector_deployments", "connector_clusters", "connector_statuses"},
+			dependencies: map[string][]string{
+				"connector_deployments": {"connectors", "connector_clusters"},
+				"connector_statuses":    {"connectors"},
+				// self references and references to excluded tables are ignored
+				"connectors": {"connectors", "leader_leases"},
+			},
+			want: []string{"connector_clusters", "connectors", "connector_deployments", "connector_statuses"},
+		},
+		{
+			name:   "should return an error when the foreign keys are circular",
+			tables: []string{"a", "b", "c"},
+			dependencies: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+			},
+			wantErr: "circular foreign keys between tables a, b",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, err := sortTables(tt.tables, tt.dependencies)
+			if tt.wantErr != "" {
+				g.Expect(err).To(gomega.MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_checkSchemaVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		archived map[string][]string
+		current  map[string][]string
+		wantErr  string
+	}{
+		{
+			name:     "should return no error when the applied migrations are the same",
+			archived: map[string][]string{"migrations": {"1", "2"}, "connector_migrations": {}},
+			current:  map[string][]string{"migrations": {"1", "2"}, "connector_migrations": {}},
+		},
+		{
+			name:     "should return an error when the applied migrations are different",
+			archived: map[string][]string{"migrations": {"1", "2"}},
+			current:  map[string][]string{"migrations": {"1"}, "connector_migrations": {"10"}},
+			wantErr:  "the schema version of the database is not the one of the archive: connector_migrations (archive: none, database: 10), migrations (archive: 2, database: 1)",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := checkSchemaVersions(tt.archived, tt.current)
+			if tt.wantErr != "" {
+				g.Expect(err).To(gomega.MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+	}
+}
+
+func Test_fleetStateService_Import(t *testing.T) {
+	g := gomega.NewWithT(t)
+	service := NewFleetStateService(ServiceInjections{})
+
+	err := service.Import(&Archive{Manifest: Manifest{SecretsIncluded: true}}, nil)
+	g.Expect(err).To(gomega.MatchError("the archive contains secrets, the key used for the export is required"))
+
+	err = service.Import(&Archive{Secrets: []EncryptedSecret{{Store: "unknown", Name: "secret-1"}}}, []byte("key"))
+	g.Expect(err).To(gomega.MatchError(`unknown secret store "unknown" of secret secret-1`))
+}
+
+// secretStoreStub keeps secrets in memory and fails to import the secret named failing
+type secretStoreStub struct {
+	secrets map[string]string
+	failing string
+}
+
+func (s *secretStoreStub) Name() string {
+	return "store"
+}
+
+func (s *secretStoreStub) ListSecrets() ([]Secret, error) {
+	return nil, nil
+}
+
+func (s *secretStoreStub) ImportSecret(secret Secret) error {
+	if secret.Name == s.failing {
+		return fmt.Errorf("store unavailable")
+	}
+	s.secrets[secret.Name] = secret.Value
+	return nil
+}
+
+func (s *secretStoreStub) DeleteSecret(name string) error {
+	delete(s.secrets, name)
+	return nil
+}
+
+func Test_fleetStateService_Import_secrets(t *testing.T) {
+	key := NewSecretsKey([]byte("key"))
+	var secrets []EncryptedSecret
+	for _, name := range []string{"secret-1", "secret-2", "secret-3"} {
+		encrypted, err := encryptSecret(key, "store", Secret{Name: name, Value: "value-" + name})
+		gomega.NewWithT(t).Expect(err).ToNot(gomega.HaveOccurred())
+		secrets = append(secrets, encrypted)
+	}
+
+	tests := []struct {
+		name        string
+		failing     string
+		wantErr     string
+		wantSecrets map[string]string
+	}{
+		{
+			name:        "should import the secrets",
+			wantSecrets: map[string]string{"secret-1": "value-secret-1", "secret-2": "value-secret-2", "secret-3": "value-secret-3"},
+		},
+		{
+			name:        "should delete the imported secrets when a secret fails to import",
+			failing:     "secret-2",
+			wantErr:     "unable to import secret secret-2 into store: store unavailable",
+			wantSecrets: map[string]string{},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			store := &secretStoreStub{secrets: map[string]string{}, failing: tt.failing}
+			service := NewFleetStateService(ServiceInjections{
+				ConnectionFactory: db.NewMockConnectionFactory(nil),
+				SecretStores:      []SecretStore{store},
+			})
+
+			err := service.Import(&Archive{Manifest: Manifest{SecretsIncluded: true}, Secrets: secrets}, key)
+			if tt.wantErr != "" {
+				g.Expect(err).To(gomega.MatchError(tt.wantErr))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			g.Expect(store.secrets).To(gomega.Equal(tt.wantSecrets))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package kafkaaccess
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// ConnectorBindingValidator is provided by the Kafka module to let other modules, such as the connector module,
+// check that an organisation is allowed to attach connectors to a Kafka instance, either because it owns the
+// instance or because it has been granted the access by the owning organisation.
+// It is not provided when the fleet manager runs without the Kafka module.
+//
+//go:generate moq -out kafka_access_moq.go . ConnectorBindingValidator
+type ConnectorBindingValidator interface {
+	ValidateConnectorBinding(kafkaID string, orgID string) *errors.ServiceError
+}
+
+// ServiceAccountScopeValidator is provided by the Kafka module to let other modules, such as the connector module,
+// check that a service account can be used with a Kafka instance and a connector cluster. Service accounts scoped to
+// some Kafka instances and connector clusters can't be used with the others.
+// It is not provided when the fleet manager runs without the Kafka module.
+//
+//go:generate moq -out service_account_scope_moq.go . ServiceAccountScopeValidator
+type ServiceAccountScopeValidator interface {
+	ValidateServiceAccountScope(clientID string, kafkaID string, connectorClusterID string) *errors.ServiceError
+}
+
+// KafkaLocator is provided by the Kafka module to let other modules, such as the connector module,
+// look up the cloud provider and region of a Kafka instance, e.g. to place connectors close to their Kafka instance.
+// It is not provided when the fleet manager runs without the Kafka module.
+//
+//go:generate moq -out kafka_locator_moq.go . KafkaLocator
+type KafkaLocator interface {
+	GetKafkaLocation(kafkaID string) (cloudProvider string, region string, err *errors.ServiceError)
+}
@@ -19,6 +19,7 @@ const (
 	opTokenFamily        = "OP"
 	logicalOpTokenFamily = "LOGICAL"
 	columnTokenFamily    = "COLUMN"
+	labelTokenFamily     = "LABEL"
 
 	othersTokenFamily      = "OTHERS"
 	valueTokenFamily       = "VALUE"
@@ -27,6 +28,7 @@ const (
 	closedBrace            = "CLOSED_BRACE"
 	comma                  = "COMMA"
 	column                 = "COLUMN"
+	labelColumn            = "LABEL_COLUMN"
 	value                  = "VALUE"
 	quotedValue            = "QUOTED_VALUE"
 	eq                     = "EQ"
@@ -43,6 +45,9 @@ const (
 )
 const MaximumComplexity = 10
 
+// labelColumnPrefix is the prefix of the columns that search the labels of a resource, e.g. `labels.cost-centre`
+const labelColumnPrefix = "labels."
+
 type checkUnbalancedBraces func() error
 
 type DBQuery struct {
@@ -50,6 +55,8 @@ type DBQuery struct {
 	Values       []interface{}
 	ValidColumns []string
 	ColumnPrefix string
+	// LabelsColumn is the JSON column holding the labels of the resource. Label columns are rejected when it is empty.
+	LabelsColumn string
 }
 
 // QueryParser - This object is to be used to parse and validate WHERE clauses (only portion after the `WHERE` is supported)
@@ -69,6 +76,7 @@ var _ QueryParser = &queryParser{}
 // OPEN_BRACE       = (
 // CLOSED_BRACE     = )
 // COLUMN -         = [A-Za-z][A-Za-z0-9_]*
+// LABEL_COLUMN     = labels\.[A-Za-z0-9][-A-Za-z0-9_./]*
 // VALUE            = [^ ^(^)]+
 // QUOTED_VALUE     = `'([^']|\\')*'`
 // EQ               = =
@@ -79,9 +87,10 @@ var _ QueryParser = &queryParser{}
 // OR               = [Oo][Rr]
 //
 // VALID TRANSITIONS:
-// START        -> COLUMN | OPEN_BRACE
-// OPEN_BRACE   -> OPEN_BRACE | COLUMN
+// START        -> COLUMN | LABEL_COLUMN | OPEN_BRACE
+// OPEN_BRACE   -> OPEN_BRACE | COLUMN | LABEL_COLUMN
 // COLUMN       -> EQ | NOT_EQ | LIKE | ILIKE
+// LABEL_COLUMN -> EQ | NOT_EQ | LIKE | ILIKE
 // EQ           -> VALUE | QUOTED_VALUE
 // NOT_EQ       -> VALUE | QUOTED_VALUE
 // LIKE         -> VALUE | QUOTED_VALUE
@@ -94,8 +103,8 @@ var _ QueryParser = &queryParser{}
 // VALUE        -> OR | AND | CLOSED_BRACE | [END]
 // QUOTED_VALUE -> OR | AND | CLOSED_BRACE | [END]
 // CLOSED_BRACE -> OR | AND | CLOSED_BRACE | [END]
-// AND          -> COLUMN | OPEN_BRACE
-// OR           -> COLUMN | OPEN_BRACE
+// AND          -> COLUMN | LABEL_COLUMN | OPEN_BRACE
+// OR           -> COLUMN | LABEL_COLUMN | OPEN_BRACE
 func (p *queryParser) initStateMachine() (*state_machine.State, checkUnbalancedBraces) {
 
 	// counts the number of joins
@@ -158,6 +167,18 @@ func (p *queryParser) initStateMachine() (*state_machine.State, checkUnbalancedB
 			}
 			p.dbqry.Query += columnName
 			return nil
+		case labelTokenFamily:
+			if p.dbqry.LabelsColumn == "" {
+				return fmt.Errorf("invalid column name: '%s', valid values are: %v", token.Value, p.dbqry.ValidColumns)
+			}
+			// the label key is case-sensitive and is passed as a value to avoid any injection
+			labelsColumn := p.dbqry.LabelsColumn
+			if p.dbqry.ColumnPrefix != "" {
+				labelsColumn = p.dbqry.ColumnPrefix + "." + labelsColumn
+			}
+			p.dbqry.Query += labelsColumn + " ->> ?"
+			p.dbqry.Values = append(p.dbqry.Values, token.Value[len(labelColumnPrefix):])
+			return nil
 		default:
 			p.dbqry.Query += " " + token.Value
 			return nil
@@ -169,6 +190,7 @@ func (p *queryParser) initStateMachine() (*state_machine.State, checkUnbalancedB
 			{Name: openBrace, Family: braceTokenFamily, AcceptPattern: `\(`},
 			{Name: closedBrace, Family: braceTokenFamily, AcceptPattern: `\)`},
 			{Name: column, Family: columnTokenFamily, AcceptPattern: `[A-Za-z][A-Za-z0-9_]*`},
+			{Name: labelColumn, Family: labelTokenFamily, AcceptPattern: `[Ll][Aa][Bb][Ee][Ll][Ss]\.[A-Za-z0-9][-A-Za-z0-9_./]*`},
 			{Name: value, Family: valueTokenFamily, AcceptPattern: `[^'][^ ^(^)]*`},
 			{Name: quotedValue, Family: quotedValueTokenFamily, AcceptPattern: `'([^']|\\')*'`},
 			{Name: eq, Family: opTokenFamily, AcceptPattern: `=`},
@@ -185,9 +207,10 @@ func (p *queryParser) initStateMachine() (*state_machine.State, checkUnbalancedB
 			{Name: not, Family: logicalOpTokenFamily, AcceptPattern: `[Nn][Oo][Tt]`},
 		},
 		Transitions: []state_machine.TokenTransitions{
-			{TokenName: state_machine.StartState, ValidTransitions: []string{column, openBrace}},
-			{TokenName: openBrace, ValidTransitions: []string{column, openBrace}},
+			{TokenName: state_machine.StartState, ValidTransitions: []string{column, labelColumn, openBrace}},
+			{TokenName: openBrace, ValidTransitions: []string{column, labelColumn, openBrace}},
 			{TokenName: column, ValidTransitions: []string{eq, notEq, like, ilike, in, not}},
+			{TokenName: labelColumn, ValidTransitions: []string{eq, notEq, like, ilike, in, not}},
 			{TokenName: eq, ValidTransitions: []string{quotedValue, value}},
 			{TokenName: notEq, ValidTransitions: []string{quotedValue, value}},
 			{TokenName: like, ValidTransitions: []string{quotedValue, value}},
@@ -195,8 +218,8 @@ func (p *queryParser) initStateMachine() (*state_machine.State, checkUnbalancedB
 			{TokenName: quotedValue, ValidTransitions: []string{or, and, closedBrace, state_machine.EndState}},
 			{TokenName: value, ValidTransitions: []string{or, and, closedBrace, state_machine.EndState}},
 			{TokenName: closedBrace, ValidTransitions: []string{or, and, closedBrace, state_machine.EndState}},
-			{TokenName: and, ValidTransitions: []string{column, openBrace}},
-			{TokenName: or, ValidTransitions: []string{column, openBrace}},
+			{TokenName: and, ValidTransitions: []string{column, labelColumn, openBrace}},
+			{TokenName: or, ValidTransitions: []string{column, labelColumn, openBrace}},
 			{TokenName: not, ValidTransitions: []string{in}},
 			{TokenName: in, ValidTransitions: []string{listOpenBrace}},
 			{TokenName: listOpenBrace, ValidTransitions: []string{quotedValueInList, valueInList}},
@@ -259,3 +282,11 @@ func NewQueryParserWithColumnPrefix(columnsPrefix string, columns ...string) Que
 	query.ColumnPrefix = columnsPrefix
 	return &queryParser{dbqry: query}
 }
+
+// NewQueryParserWithLabels returns a parser that also accepts `labels.<key>` columns, they search the labels stored
+// as a JSON object in the given column
+func NewQueryParserWithLabels(labelsColumn string, columns ...string) QueryParser {
+	parser := NewQueryParser(columns...).(*queryParser)
+	parser.dbqry.LabelsColumn = labelsColumn
+	return parser
+}
@@ -218,6 +218,34 @@ func Test_QueryParser(t *testing.T) {
 			outValues: []interface{}{"Value", "value1", "value2", "b", "c", "e", "%test%"},
 			wantErr:   false,
 		},
+		{
+			name:      "Testing label column",
+			qry:       "labels.cost-centre = '1234' and name = test",
+			qryParser: NewQueryParserWithLabels("labels"),
+			outQry:    "labels ->> ? = ? and name = ?",
+			outValues: []interface{}{"cost-centre", "1234", "test"},
+			wantErr:   false,
+		},
+		{
+			name:      "Testing label column with a prefixed key and IN",
+			qry:       "(labels.example.com/env IN (dev, test) or labels.Team ilike '%kafka%')",
+			qryParser: NewQueryParserWithLabels("labels"),
+			outQry:    "(labels ->> ? IN( ? , ?) or labels ->> ? ilike ?)",
+			outValues: []interface{}{"example.com/env", "dev", "test", "Team", "%kafka%"},
+			wantErr:   false,
+		},
+		{
+			name:      "Testing label column when labels are not supported",
+			qry:       "labels.env = dev",
+			qryParser: NewQueryParser(),
+			wantErr:   true,
+		},
+		{
+			name:      "Testing invalid label key",
+			qry:       "labels.'env' = dev",
+			qryParser: NewQueryParserWithLabels("labels"),
+			wantErr:   true,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -1,7 +1,9 @@
 package signalbus
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -43,6 +45,39 @@ func (sbw *PgSignalBus) Notify(name string) {
 	}
 }
 
+// NotifyPayload will notify all the subscriptions created across the cluster of the given named signal, and queue
+// the payload in them.
+func (sbw *PgSignalBus) NotifyPayload(name string, payload string) {
+	data, err := json.Marshal(pgSignal{Name: name, Payload: payload})
+	if err != nil {
+		glog.V(1).Info("notify failed:", err.Error())
+		return
+	}
+	dbc := sbw.connectionFactory.New()
+	if err := dbc.Exec("SELECT pg_notify('signalbus', ?)", string(data)).Error; err != nil {
+		glog.V(1).Info("notify failed:", err.Error())
+	}
+}
+
+// pgSignal is the data of the notifications of the signals with a payload, the data of the other notifications is
+// the name of the signal so that they can be read by the previous versions.
+type pgSignal struct {
+	Name    string `json:"name"`
+	Payload string `json:"payload"`
+}
+
+// notifyLocal notifies the in memory bus of the signal read from the data of a notification
+func (sbw *PgSignalBus) notifyLocal(data string) {
+	if strings.HasPrefix(data, "{") {
+		var signal pgSignal
+		if err := json.Unmarshal([]byte(data), &signal); err == nil {
+			sbw.signalBus.NotifyPayload(signal.Name, signal.Payload)
+			return
+		}
+	}
+	sbw.signalBus.Notify(data)
+}
+
 // Subscribe creates a subscription the named signal.
 // They are performed on the in memory bus.
 func (sbw *PgSignalBus) Subscribe(name string) *Subscription {
@@ -137,7 +172,7 @@ func (sbw *PgSignalBus) waitForNotification(l *pq.Listener) (exit bool, err erro
 
 			// we got the signal name from the DB... lets use the in memory signalBus
 			// to notify all the subscribers that registered for events.
-			sbw.signalBus.Notify(n.Extra)
+			sbw.notifyLocal(n.Extra)
 			return
 		case <-time.After(90 * time.Second):
 			// in case we have not received an event in a while... lets check to make sure the DB
@@ -5,9 +5,16 @@ import (
 	"sync"
 )
 
+// maxQueuedPayloads is the maximum number of payloads queued in a subscription, the payloads notified when the
+// queue is full are dropped
+const maxQueuedPayloads = 1000
+
 type SignalBus interface {
 	// Notify will notify all the subscriptions created for the given named signal.
 	Notify(name string)
+	// NotifyPayload will notify all the subscriptions created for the given named signal, and queue the payload
+	// in them, e.g. the ID of the resource that changed.
+	NotifyPayload(name string, payload string)
 	// Subscribe creates a subscription the named signal
 	Subscribe(name string) *Subscription
 }
@@ -34,10 +41,20 @@ func (sb *signalBus) Notify(name string) {
 	sb.RUnlock()
 
 	for _, sub := range result {
-		select {
-		case sub.c <- true:
-		default:
-		}
+		sub.notify()
+	}
+}
+
+// NotifyPayload will notify all the subscriptions created for the given named signal, and queue the payload in them.
+func (sb *signalBus) NotifyPayload(name string, payload string) {
+	var result []*Subscription
+	sb.RLock()
+	result = sb.signals[name]
+	sb.RUnlock()
+
+	for _, sub := range result {
+		sub.queue(payload)
+		sub.notify()
 	}
 }
 
@@ -81,6 +98,41 @@ type Subscription struct {
 	name      string
 	closeOnce sync.Once
 	c         chan bool
+
+	payloadsMutex sync.Mutex
+	payloads      []string
+	queued        map[string]bool
+}
+
+func (sub *Subscription) notify() {
+	select {
+	case sub.c <- true:
+	default:
+	}
+}
+
+func (sub *Subscription) queue(payload string) {
+	sub.payloadsMutex.Lock()
+	defer sub.payloadsMutex.Unlock()
+	if sub.queued == nil {
+		sub.queued = map[string]bool{}
+	}
+	if sub.queued[payload] || len(sub.payloads) >= maxQueuedPayloads {
+		return
+	}
+	sub.queued[payload] = true
+	sub.payloads = append(sub.payloads, payload)
+}
+
+// Payloads returns the distinct payloads notified since the last call, in the order they were first notified, and
+// removes them from the subscription.
+func (sub *Subscription) Payloads() []string {
+	sub.payloadsMutex.Lock()
+	defer sub.payloadsMutex.Unlock()
+	payloads := sub.payloads
+	sub.payloads = nil
+	sub.queued = nil
+	return payloads
 }
 
 // Signal returns a channel that receives a true message when the subscription is notified.
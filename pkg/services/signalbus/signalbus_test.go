@@ -1,6 +1,7 @@
 package signalbus
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -48,3 +49,49 @@ func TestNewSignalBus(t *testing.T) {
 	g.Expect(len(bus.signals)).Should(gomega.Equal(0))
 
 }
+
+func TestSignalBus_NotifyPayload(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	bus := NewSignalBus()
+	sub := bus.Subscribe("kafka")
+	defer sub.Close()
+
+	// payloads are queued once each, in the order they were first notified
+	bus.NotifyPayload("kafka", "1")
+	bus.NotifyPayload("kafka", "2")
+	bus.NotifyPayload("kafka", "1")
+	g.Expect(sub.IsSignaled()).Should(gomega.Equal(true))
+	g.Expect(sub.Payloads()).Should(gomega.Equal([]string{"1", "2"}))
+	g.Expect(sub.Payloads()).Should(gomega.BeEmpty())
+
+	// a signal without payload doesn't queue any
+	bus.Notify("kafka")
+	g.Expect(sub.IsSignaled()).Should(gomega.Equal(true))
+	g.Expect(sub.Payloads()).Should(gomega.BeEmpty())
+
+	// the payloads notified when the queue is full are dropped
+	for i := 0; i <= maxQueuedPayloads; i++ {
+		bus.NotifyPayload("kafka", fmt.Sprint(i))
+	}
+	g.Expect(sub.Payloads()).Should(gomega.HaveLen(maxQueuedPayloads))
+}
+
+func TestPgSignalBus_notifyLocal(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	bus := NewSignalBus()
+	pgBus := NewPgSignalBus(bus, nil)
+	reconcileSub := bus.Subscribe("reconcile:cluster")
+	defer reconcileSub.Close()
+	kafkaSub := bus.Subscribe("kafka")
+	defer kafkaSub.Close()
+
+	pgBus.notifyLocal("reconcile:cluster")
+	g.Expect(reconcileSub.IsSignaled()).Should(gomega.Equal(true))
+	g.Expect(kafkaSub.IsSignaled()).Should(gomega.Equal(false))
+
+	pgBus.notifyLocal(`{"name":"kafka","payload":"1"}`)
+	g.Expect(kafkaSub.IsSignaled()).Should(gomega.Equal(true))
+	g.Expect(kafkaSub.Payloads()).Should(gomega.Equal([]string{"1"}))
+}
@@ -75,6 +75,12 @@ var _ keycloakServiceInternal = &keycloakServiceInternalMock{}
 //			ResetServiceAccountCredentialsFunc: func(accessToken string, ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ResetServiceAccountCredentials method")
 //			},
+//			RevokeServiceAccountCredentialsInternalFunc: func(accessToken string, id string) *errors.ServiceError {
+//				panic("mock out the RevokeServiceAccountCredentialsInternal method")
+//			},
+//			UpdateServiceAccountFunc: func(accessToken string, ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the UpdateServiceAccount method")
+//			},
 //		}
 //
 //		// use mockedkeycloakServiceInternal in code that requires keycloakServiceInternal
@@ -136,6 +142,12 @@ type keycloakServiceInternalMock struct {
 	// ResetServiceAccountCredentialsFunc mocks the ResetServiceAccountCredentials method.
 	ResetServiceAccountCredentialsFunc func(accessToken string, ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError)
 
+	// RevokeServiceAccountCredentialsInternalFunc mocks the RevokeServiceAccountCredentialsInternal method.
+	RevokeServiceAccountCredentialsInternalFunc func(accessToken string, id string) *errors.ServiceError
+
+	// UpdateServiceAccountFunc mocks the UpdateServiceAccount method.
+	UpdateServiceAccountFunc func(accessToken string, ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateServiceAccount holds details about calls to the CreateServiceAccount method.
@@ -272,6 +284,26 @@ type keycloakServiceInternalMock struct {
 			// ClientId is the clientId argument value.
 			ClientId string
 		}
+		// RevokeServiceAccountCredentialsInternal holds details about calls to the RevokeServiceAccountCredentialsInternal method.
+		RevokeServiceAccountCredentialsInternal []struct {
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+			// ID is the id argument value.
+			ID string
+		}
+		// UpdateServiceAccount holds details about calls to the UpdateServiceAccount method.
+		UpdateServiceAccount []struct {
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Name is the name argument value.
+			Name *string
+			// Description is the description argument value.
+			Description *string
+		}
 	}
 	lockCreateServiceAccount                                sync.RWMutex
 	lockCreateServiceAccountInternal                        sync.RWMutex
@@ -291,6 +323,8 @@ type keycloakServiceInternalMock struct {
 	lockRegisterConnectorFleetshardOperatorServiceAccount   sync.RWMutex
 	lockRegisterKasFleetshardOperatorServiceAccount         sync.RWMutex
 	lockResetServiceAccountCredentials                      sync.RWMutex
+	lockRevokeServiceAccountCredentialsInternal             sync.RWMutex
+	lockUpdateServiceAccount                                sync.RWMutex
 }
 
 // CreateServiceAccount calls CreateServiceAccountFunc.
@@ -954,3 +988,87 @@ func (mock *keycloakServiceInternalMock) ResetServiceAccountCredentialsCalls() [
 	mock.lockResetServiceAccountCredentials.RUnlock()
 	return calls
 }
+
+// RevokeServiceAccountCredentialsInternal calls RevokeServiceAccountCredentialsInternalFunc.
+func (mock *keycloakServiceInternalMock) RevokeServiceAccountCredentialsInternal(accessToken string, id string) *errors.ServiceError {
+	if mock.RevokeServiceAccountCredentialsInternalFunc == nil {
+		panic("keycloakServiceInternalMock.RevokeServiceAccountCredentialsInternalFunc: method is nil but keycloakServiceInternal.RevokeServiceAccountCredentialsInternal was just called")
+	}
+	callInfo := struct {
+		AccessToken string
+		ID          string
+	}{
+		AccessToken: accessToken,
+		ID:          id,
+	}
+	mock.lockRevokeServiceAccountCredentialsInternal.Lock()
+	mock.calls.RevokeServiceAccountCredentialsInternal = append(mock.calls.RevokeServiceAccountCredentialsInternal, callInfo)
+	mock.lockRevokeServiceAccountCredentialsInternal.Unlock()
+	return mock.RevokeServiceAccountCredentialsInternalFunc(accessToken, id)
+}
+
+// RevokeServiceAccountCredentialsInternalCalls gets all the calls that were made to RevokeServiceAccountCredentialsInternal.
+// Check the length with:
+//
+//	len(mockedkeycloakServiceInternal.RevokeServiceAccountCredentialsInternalCalls())
+func (mock *keycloakServiceInternalMock) RevokeServiceAccountCredentialsInternalCalls() []struct {
+	AccessToken string
+	ID          string
+} {
+	var calls []struct {
+		AccessToken string
+		ID          string
+	}
+	mock.lockRevokeServiceAccountCredentialsInternal.RLock()
+	calls = mock.calls.RevokeServiceAccountCredentialsInternal
+	mock.lockRevokeServiceAccountCredentialsInternal.RUnlock()
+	return calls
+}
+
+// UpdateServiceAccount calls UpdateServiceAccountFunc.
+func (mock *keycloakServiceInternalMock) UpdateServiceAccount(accessToken string, ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+	if mock.UpdateServiceAccountFunc == nil {
+		panic("keycloakServiceInternalMock.UpdateServiceAccountFunc: method is nil but keycloakServiceInternal.UpdateServiceAccount was just called")
+	}
+	callInfo := struct {
+		AccessToken string
+		Ctx         context.Context
+		ID          string
+		Name        *string
+		Description *string
+	}{
+		AccessToken: accessToken,
+		Ctx:         ctx,
+		ID:          id,
+		Name:        name,
+		Description: description,
+	}
+	mock.lockUpdateServiceAccount.Lock()
+	mock.calls.UpdateServiceAccount = append(mock.calls.UpdateServiceAccount, callInfo)
+	mock.lockUpdateServiceAccount.Unlock()
+	return mock.UpdateServiceAccountFunc(accessToken, ctx, id, name, description)
+}
+
+// UpdateServiceAccountCalls gets all the calls that were made to UpdateServiceAccount.
+// Check the length with:
+//
+//	len(mockedkeycloakServiceInternal.UpdateServiceAccountCalls())
+func (mock *keycloakServiceInternalMock) UpdateServiceAccountCalls() []struct {
+	AccessToken string
+	Ctx         context.Context
+	ID          string
+	Name        *string
+	Description *string
+} {
+	var calls []struct {
+		AccessToken string
+		Ctx         context.Context
+		ID          string
+		Name        *string
+		Description *string
+	}
+	mock.lockUpdateServiceAccount.RLock()
+	calls = mock.calls.UpdateServiceAccount
+	mock.lockUpdateServiceAccount.RUnlock()
+	return calls
+}
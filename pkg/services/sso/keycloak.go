@@ -26,6 +26,8 @@ type KeycloakService interface {
 	CreateServiceAccount(serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError)
 	DeleteServiceAccount(ctx context.Context, clientId string) *errors.ServiceError
 	ResetServiceAccountCredentials(ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError)
+	// UpdateServiceAccount changes the name and the description of a service account, the nil values are left unchanged
+	UpdateServiceAccount(ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError)
 	ListServiceAcc(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 	RegisterKasFleetshardOperatorServiceAccount(agentClusterId string) (*api.ServiceAccount, *errors.ServiceError)
 	DeRegisterKasFleetshardOperatorServiceAccount(agentClusterId string) *errors.ServiceError
@@ -36,6 +38,9 @@ type KeycloakService interface {
 	GetKafkaClientSecret(clientId string) (string, *errors.ServiceError)
 	CreateServiceAccountInternal(request CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError)
 	DeleteServiceAccountInternal(clientId string) *errors.ServiceError
+	// RevokeServiceAccountCredentialsInternal regenerates the secret of a service account without returning it, so
+	// that the current credentials can't be used anymore. It isn't supported by the redhat_sso provider.
+	RevokeServiceAccountCredentialsInternal(id string) *errors.ServiceError
 }
 
 //go:generate moq -out osd_keycloak_service_moq.go . OSDKeycloakService
@@ -55,6 +60,7 @@ type keycloakServiceInternal interface {
 	CreateServiceAccount(accessToken string, serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError)
 	DeleteServiceAccount(accessToken string, ctx context.Context, clientId string) *errors.ServiceError
 	ResetServiceAccountCredentials(accessToken string, ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError)
+	UpdateServiceAccount(accessToken string, ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError)
 	ListServiceAcc(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 	RegisterKasFleetshardOperatorServiceAccount(accessToken string, agentClusterId string) (*api.ServiceAccount, *errors.ServiceError)
 	DeRegisterKasFleetshardOperatorServiceAccount(accessToken string, agentClusterId string) *errors.ServiceError
@@ -65,6 +71,7 @@ type keycloakServiceInternal interface {
 	GetKafkaClientSecret(accessToken string, clientId string) (string, *errors.ServiceError)
 	CreateServiceAccountInternal(accessToken string, request CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError)
 	DeleteServiceAccountInternal(accessToken string, clientId string) *errors.ServiceError
+	RevokeServiceAccountCredentialsInternal(accessToken string, id string) *errors.ServiceError
 }
 
 func NewKeycloakServiceBuilder() KeycloakServiceBuilderSelector {
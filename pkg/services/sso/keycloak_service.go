@@ -96,10 +96,13 @@ func (kc *masService) GetRealmConfig() *keycloak.KeycloakRealmConfig {
 }
 
 func (kc *masService) IsKafkaClientExist(accessToken string, clientId string) *errors.ServiceError {
-	_, err := kc.kcClient.IsClientExist(clientId, accessToken)
+	internalClientID, err := kc.kcClient.IsClientExist(clientId, accessToken)
 	if err != nil {
 		return errors.NewWithCause(errors.ErrorFailedToGetSSOClient, err, "failed to get sso client with id: %s", clientId)
 	}
+	if internalClientID == "" {
+		return errors.New(errors.ErrorNotFound, "sso client with id: %s not found", clientId)
+	}
 	return nil
 }
 
@@ -123,12 +126,13 @@ func (kc *masService) CreateServiceAccount(accessToken string, serviceAccountReq
 	orgId, _ := claims.GetOrgId()
 	ownerAccountId, _ := claims.GetAccountId()
 	owner, _ := claims.GetUsername()
-	isAllowed, err := kc.checkAllowedServiceAccountsLimits(accessToken, kc.GetConfig().MaxAllowedServiceAccounts, orgId)
+	maxAllowed := kc.GetConfig().GetMaxAllowedServiceAccounts(orgId)
+	isAllowed, err := kc.checkAllowedServiceAccountsLimits(accessToken, maxAllowed, orgId)
 	if err != nil { //5xx
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to create service account")
 	}
 	if !isAllowed { //4xx over requesters' limit
-		return nil, errors.MaxLimitForServiceAccountReached("max allowed number:%d of service accounts for user in org:%s has reached", kc.GetConfig().MaxAllowedServiceAccounts, orgId)
+		return nil, errors.MaxLimitForServiceAccountReached("max allowed number:%d of service accounts for user in org:%s has reached", maxAllowed, orgId)
 	}
 	return kc.CreateServiceAccountInternal(accessToken, CompleteServiceAccountRequest{
 		Owner:          owner,
@@ -331,6 +335,62 @@ func (kc *masService) ResetServiceAccountCredentials(accessToken string, ctx con
 	}
 }
 
+func (kc *masService) UpdateServiceAccount(accessToken string, ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil { //4xx
+		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	c, err := kc.kcClient.GetClientById(id, accessToken)
+	if err != nil { //5xx or 4xx
+		return nil, handleKeyCloakGetClientError(err, id)
+	}
+
+	if !strings.HasPrefix(shared.SafeString(c.ClientID), UserServiceAccountPrefix) {
+		return nil, errors.NewWithCause(errors.ErrorServiceAccountNotFound, err, "service account not found %s", id)
+	}
+
+	orgId, _ := claims.GetOrgId()
+	userId, _ := claims.GetAccountId()
+	if !kc.kcClient.IsSameOrg(c, orgId) || !(kc.kcClient.IsOwner(c, userId) || claims.IsOrgAdmin()) { //4xx
+		return nil, errors.NewWithCause(errors.ErrorForbidden, nil, "failed to update service account")
+	}
+
+	if name != nil {
+		c.Name = name
+	}
+	if description != nil {
+		c.Description = description
+	}
+	if err := kc.kcClient.UpdateClient(*c, accessToken); err != nil { //5xx
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update service account")
+	}
+	att := *c.Attributes
+	createdAt, err := time.Parse(time.RFC3339, att["created_at"])
+	if err != nil {
+		createdAt = time.Time{}
+	}
+	glog.V(5).Infof("Client %s with internal id = %s updated successfully ", *c.ClientID, *c.ID)
+	return &api.ServiceAccount{
+		ID:          *c.ID,
+		ClientID:    *c.ClientID,
+		CreatedAt:   createdAt,
+		CreatedBy:   att["username"],
+		Name:        shared.SafeString(c.Name),
+		Description: shared.SafeString(c.Description),
+	}, nil
+}
+
+func (kc *masService) RevokeServiceAccountCredentialsInternal(accessToken string, id string) *errors.ServiceError {
+	if _, err := kc.kcClient.RegenerateClientSecret(accessToken, id); err != nil {
+		if keyErr, ok := err.(*gocloak.APIError); ok && keyErr.Code == http.StatusNotFound {
+			return errors.NewWithCause(errors.ErrorServiceAccountNotFound, err, "service account not found %s", id)
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to revoke service account credentials")
+	}
+	glog.V(5).Infof("revoked the credentials of service account with internal id = %s", id)
+	return nil
+}
+
 // return error object for API caller facing funcs: 5xx or 4xx
 func handleKeyCloakGetClientError(err error, id string) *errors.ServiceError {
 	if keyErr, ok := err.(*gocloak.APIError); ok {
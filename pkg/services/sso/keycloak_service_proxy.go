@@ -82,6 +82,15 @@ func (r *keycloakServiceProxy) ResetServiceAccountCredentials(ctx context.Contex
 	}
 }
 
+func (r *keycloakServiceProxy) UpdateServiceAccount(ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+	if token, err := tokenForServiceAPIHandler(ctx, r); err != nil {
+		return nil, err
+	} else {
+		glog.V(5).Infof("Updating service account with id: %s", id)
+		return r.service.UpdateServiceAccount(token, ctx, id, name, description)
+	}
+}
+
 func (r *keycloakServiceProxy) ListServiceAcc(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 	if token, err := tokenForServiceAPIHandler(ctx, r); err != nil {
 		return nil, err
@@ -165,6 +174,18 @@ func (r *keycloakServiceProxy) DeleteServiceAccountInternal(clientId string) *er
 		return r.service.DeleteServiceAccountInternal(token, clientId)
 	}
 }
+func (r *keycloakServiceProxy) RevokeServiceAccountCredentialsInternal(id string) *errors.ServiceError {
+	if r.GetConfig().SelectSSOProvider == keycloak.REDHAT_SSO {
+		// the service accounts of users can only be managed with the token of the users, see tokenForServiceAPIHandler
+		return errors.GeneralError("the credentials of service account with id: %s can't be revoked with the %s provider", id, keycloak.REDHAT_SSO)
+	}
+	if token, err := r.retrieveToken(); err != nil {
+		return err
+	} else {
+		glog.V(5).Infof("Revoking the credentials of service account with id: %s", id)
+		return r.service.RevokeServiceAccountCredentialsInternal(token, id)
+	}
+}
 
 // Utility functions
 
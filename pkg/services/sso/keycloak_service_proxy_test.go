@@ -1469,3 +1469,71 @@ func Test_keycloakServiceProxy_DeleteServiceAccountInternal(t *testing.T) {
 		})
 	}
 }
+
+func Test_keycloakServiceProxy_RevokeServiceAccountCredentialsInternal(t *testing.T) {
+	tests := []struct {
+		name                     string
+		provider                 string
+		tokenProviderFail        bool
+		expectGetTokenToBeCalled bool
+		wantRevoked              bool
+		wantErr                  *errors.ServiceError
+	}{
+		{
+			name:                     "should revoke the credentials with the token of the fleet manager for MasSSO",
+			provider:                 keycloak.MAS_SSO,
+			expectGetTokenToBeCalled: true,
+			wantRevoked:              true,
+		},
+		{
+			// the service accounts of users can only be managed with the token of the users
+			name:     "should fail without revoking the credentials for RedhatSSO",
+			provider: keycloak.REDHAT_SSO,
+			wantErr:  errors.GeneralError("the credentials of service account with id: %s can't be revoked with the redhat_sso provider", testClientID),
+		},
+		{
+			name:                     "should fail retrieving token",
+			provider:                 keycloak.MAS_SSO,
+			tokenProviderFail:        true,
+			expectGetTokenToBeCalled: true,
+			wantErr:                  testTokenProviderError,
+		},
+	}
+
+	for _, tc := range tests {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			getTokenCalled := false
+			mock := &keycloakServiceInternalMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{SelectSSOProvider: tt.provider}
+				},
+				RevokeServiceAccountCredentialsInternalFunc: func(accessToken string, id string) *errors.ServiceError {
+					return nil
+				},
+			}
+			proxy := keycloakServiceProxy{
+				getToken: testTokenProvider(token, &getTokenCalled, tt.tokenProviderFail),
+				service:  mock,
+			}
+
+			err := proxy.RevokeServiceAccountCredentialsInternal(testClientID)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Error()).To(gomega.Equal(tt.wantErr.Error()))
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			if tt.wantRevoked {
+				g.Expect(mock.calls.RevokeServiceAccountCredentialsInternal).To(gomega.HaveLen(1))
+				g.Expect(mock.calls.RevokeServiceAccountCredentialsInternal[0].AccessToken).To(gomega.Equal(token))
+			} else {
+				g.Expect(mock.calls.RevokeServiceAccountCredentialsInternal).To(gomega.BeEmpty())
+			}
+			g.Expect(getTokenCalled).To(gomega.Equal(tt.expectGetTokenToBeCalled))
+		})
+	}
+}
@@ -1204,6 +1204,21 @@ func Test_masService_IsKafkaClientExist(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "should return not found if the client doesn't exist",
+			fields: fields{
+				kcClient: &keycloak.KcClientMock{
+					IsClientExistFunc: func(clientId, accessToken string) (string, error) {
+						return "", nil
+					},
+				},
+			},
+			args: args{
+				accessToken: token,
+				clientId:    testClientID,
+			},
+			want: errors.New(errors.ErrorNotFound, "sso client with id: %s not found", testClientID),
+		},
 		{
 			name: "should return an error if it fails to find the client",
 			fields: fields{
@@ -1938,3 +1953,126 @@ func Test_masService_GetServiceAccountById(t *testing.T) {
 		})
 	}
 }
+
+func Test_masService_UpdateServiceAccount(t *testing.T) {
+	clientId := UserServiceAccountPrefix + uuid.New().String()
+	name := "old-name"
+	description := "old-description"
+	newName := "new-name"
+	tests := []struct {
+		name      string
+		isOwner   bool
+		updateErr error
+		want      *api.ServiceAccount
+		wantErr   *errors.ServiceError
+	}{
+		{
+			name:    "should only update the name of the service account",
+			isOwner: true,
+			want: &api.ServiceAccount{
+				ID:          clientId,
+				ClientID:    clientId,
+				Name:        newName,
+				Description: description,
+			},
+		},
+		{
+			name:    "should return an error if the requester doesn't own the service account",
+			isOwner: false,
+			wantErr: errors.NewWithCause(errors.ErrorForbidden, nil, "failed to update service account"),
+		},
+		{
+			name:      "should return an error if it fails to update the service account",
+			isOwner:   true,
+			updateErr: errors.GeneralError("failed to update client"),
+			wantErr:   errors.NewWithCause(errors.ErrorGeneral, errors.GeneralError("failed to update client"), "failed to update service account"),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kcClient := &keycloak.KcClientMock{
+				GetClientByIdFunc: func(id, accessToken string) (*gocloak.Client, error) {
+					currentName := name
+					currentDescription := description
+					return &gocloak.Client{
+						ID:          &clientId,
+						ClientID:    &clientId,
+						Name:        &currentName,
+						Description: &currentDescription,
+						Attributes:  &map[string]string{},
+					}, nil
+				},
+				IsSameOrgFunc: func(client *gocloak.Client, orgId string) bool {
+					return true
+				},
+				IsOwnerFunc: func(client *gocloak.Client, userId string) bool {
+					return tt.isOwner
+				},
+				UpdateClientFunc: func(client gocloak.Client, accessToken string) error {
+					return tt.updateErr
+				},
+			}
+			kc := &masService{
+				kcClient: kcClient,
+			}
+			got, err := kc.UpdateServiceAccount(token, context.Background(), clientId, &newName, nil)
+			g.Expect(err).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+			if tt.want != nil {
+				g.Expect(kcClient.UpdateClientCalls()).To(gomega.HaveLen(1))
+				g.Expect(*kcClient.UpdateClientCalls()[0].Client.Name).To(gomega.Equal(newName))
+			}
+		})
+	}
+}
+
+func Test_masService_RevokeServiceAccountCredentialsInternal(t *testing.T) {
+	tests := []struct {
+		name          string
+		regenerateErr error
+		wantErrCode   errors.ServiceErrorCode
+	}{
+		{
+			name: "should regenerate the secret of the service account",
+		},
+		{
+			name:          "should return a not found error if the service account doesn't exist",
+			regenerateErr: &gocloak.APIError{Code: http.StatusNotFound},
+			wantErrCode:   errors.ErrorServiceAccountNotFound,
+		},
+		{
+			name:          "should return an error if it fails to regenerate the secret",
+			regenerateErr: errors.GeneralError("failed to regenerate secret"),
+			wantErrCode:   errors.ErrorGeneral,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kc := &masService{
+				kcClient: &keycloak.KcClientMock{
+					RegenerateClientSecretFunc: func(accessToken, id string) (*gocloak.CredentialRepresentation, error) {
+						if tt.regenerateErr != nil {
+							return nil, tt.regenerateErr
+						}
+						clientSecret := secret
+						return &gocloak.CredentialRepresentation{Value: &clientSecret}, nil
+					},
+				},
+			}
+			err := kc.RevokeServiceAccountCredentialsInternal(token, testClientID)
+			if tt.regenerateErr == nil {
+				g.Expect(err).To(gomega.BeNil())
+			} else {
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+			}
+		})
+	}
+}
@@ -69,6 +69,12 @@ var _ KeycloakService = &KeycloakServiceMock{}
 //			ResetServiceAccountCredentialsFunc: func(ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ResetServiceAccountCredentials method")
 //			},
+//			RevokeServiceAccountCredentialsInternalFunc: func(id string) *errors.ServiceError {
+//				panic("mock out the RevokeServiceAccountCredentialsInternal method")
+//			},
+//			UpdateServiceAccountFunc: func(ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the UpdateServiceAccount method")
+//			},
 //		}
 //
 //		// use mockedKeycloakService in code that requires KeycloakService
@@ -124,6 +130,12 @@ type KeycloakServiceMock struct {
 	// ResetServiceAccountCredentialsFunc mocks the ResetServiceAccountCredentials method.
 	ResetServiceAccountCredentialsFunc func(ctx context.Context, clientId string) (*api.ServiceAccount, *errors.ServiceError)
 
+	// RevokeServiceAccountCredentialsInternalFunc mocks the RevokeServiceAccountCredentialsInternal method.
+	RevokeServiceAccountCredentialsInternalFunc func(id string) *errors.ServiceError
+
+	// UpdateServiceAccountFunc mocks the UpdateServiceAccount method.
+	UpdateServiceAccountFunc func(ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateServiceAccount holds details about calls to the CreateServiceAccount method.
@@ -216,6 +228,22 @@ type KeycloakServiceMock struct {
 			// ClientId is the clientId argument value.
 			ClientId string
 		}
+		// RevokeServiceAccountCredentialsInternal holds details about calls to the RevokeServiceAccountCredentialsInternal method.
+		RevokeServiceAccountCredentialsInternal []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// UpdateServiceAccount holds details about calls to the UpdateServiceAccount method.
+		UpdateServiceAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Name is the name argument value.
+			Name *string
+			// Description is the description argument value.
+			Description *string
+		}
 	}
 	lockCreateServiceAccount                                sync.RWMutex
 	lockCreateServiceAccountInternal                        sync.RWMutex
@@ -233,6 +261,8 @@ type KeycloakServiceMock struct {
 	lockRegisterConnectorFleetshardOperatorServiceAccount   sync.RWMutex
 	lockRegisterKasFleetshardOperatorServiceAccount         sync.RWMutex
 	lockResetServiceAccountCredentials                      sync.RWMutex
+	lockRevokeServiceAccountCredentialsInternal             sync.RWMutex
+	lockUpdateServiceAccount                                sync.RWMutex
 }
 
 // CreateServiceAccount calls CreateServiceAccountFunc.
@@ -764,3 +794,79 @@ func (mock *KeycloakServiceMock) ResetServiceAccountCredentialsCalls() []struct
 	mock.lockResetServiceAccountCredentials.RUnlock()
 	return calls
 }
+
+// RevokeServiceAccountCredentialsInternal calls RevokeServiceAccountCredentialsInternalFunc.
+func (mock *KeycloakServiceMock) RevokeServiceAccountCredentialsInternal(id string) *errors.ServiceError {
+	if mock.RevokeServiceAccountCredentialsInternalFunc == nil {
+		panic("KeycloakServiceMock.RevokeServiceAccountCredentialsInternalFunc: method is nil but KeycloakService.RevokeServiceAccountCredentialsInternal was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockRevokeServiceAccountCredentialsInternal.Lock()
+	mock.calls.RevokeServiceAccountCredentialsInternal = append(mock.calls.RevokeServiceAccountCredentialsInternal, callInfo)
+	mock.lockRevokeServiceAccountCredentialsInternal.Unlock()
+	return mock.RevokeServiceAccountCredentialsInternalFunc(id)
+}
+
+// RevokeServiceAccountCredentialsInternalCalls gets all the calls that were made to RevokeServiceAccountCredentialsInternal.
+// Check the length with:
+//
+//	len(mockedKeycloakService.RevokeServiceAccountCredentialsInternalCalls())
+func (mock *KeycloakServiceMock) RevokeServiceAccountCredentialsInternalCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockRevokeServiceAccountCredentialsInternal.RLock()
+	calls = mock.calls.RevokeServiceAccountCredentialsInternal
+	mock.lockRevokeServiceAccountCredentialsInternal.RUnlock()
+	return calls
+}
+
+// UpdateServiceAccount calls UpdateServiceAccountFunc.
+func (mock *KeycloakServiceMock) UpdateServiceAccount(ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+	if mock.UpdateServiceAccountFunc == nil {
+		panic("KeycloakServiceMock.UpdateServiceAccountFunc: method is nil but KeycloakService.UpdateServiceAccount was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ID          string
+		Name        *string
+		Description *string
+	}{
+		Ctx:         ctx,
+		ID:          id,
+		Name:        name,
+		Description: description,
+	}
+	mock.lockUpdateServiceAccount.Lock()
+	mock.calls.UpdateServiceAccount = append(mock.calls.UpdateServiceAccount, callInfo)
+	mock.lockUpdateServiceAccount.Unlock()
+	return mock.UpdateServiceAccountFunc(ctx, id, name, description)
+}
+
+// UpdateServiceAccountCalls gets all the calls that were made to UpdateServiceAccount.
+// Check the length with:
+//
+//	len(mockedKeycloakService.UpdateServiceAccountCalls())
+func (mock *KeycloakServiceMock) UpdateServiceAccountCalls() []struct {
+	Ctx         context.Context
+	ID          string
+	Name        *string
+	Description *string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ID          string
+		Name        *string
+		Description *string
+	}
+	mock.lockUpdateServiceAccount.RLock()
+	calls = mock.calls.UpdateServiceAccount
+	mock.lockUpdateServiceAccount.RUnlock()
+	return calls
+}
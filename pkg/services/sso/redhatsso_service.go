@@ -8,10 +8,12 @@ import (
 	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/redhatsso"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/golang/glog"
 	serviceaccountsclient "github.com/redhat-developer/app-services-sdk-go/serviceaccounts/apiv1internal/client"
 )
@@ -104,6 +106,9 @@ func (r *redhatssoService) IsKafkaClientExist(accessToken string, clientId strin
 
 func (r *redhatssoService) CreateServiceAccount(accessToken string, serviceAccountRequest *api.ServiceAccountRequest, ctx context.Context) (*api.ServiceAccount, *errors.ServiceError) {
 	glog.V(5).Infof("Creating service account with name: %s", serviceAccountRequest.Name)
+	if limitErr := r.checkAllowedServiceAccountsLimits(accessToken, ctx); limitErr != nil {
+		return nil, limitErr
+	}
 	serviceAccount, err := r.client.CreateServiceAccount(accessToken, serviceAccountRequest.Name, serviceAccountRequest.Description)
 	if err != nil {
 		if rhErr, err1 := parseRedhatssoError(err); err1 == nil {
@@ -169,6 +174,98 @@ func (r *redhatssoService) ResetServiceAccountCredentials(accessToken string, ct
 	return convertServiceAccountDataToAPIServiceAccount(&serviceAccount), nil
 }
 
+// checkAllowedServiceAccountsLimits enforces the limits of the organisations listed in the service account limits of
+// the configuration. The default limit is enforced by the SSO provider itself so the service accounts of the other
+// organisations aren't listed. The service accounts created without requester, like the agent ones, aren't limited.
+func (r *redhatssoService) checkAllowedServiceAccountsLimits(accessToken string, ctx context.Context) *errors.ServiceError {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	orgId, _ := claims.GetOrgId()
+	if orgId == "" {
+		return nil
+	}
+	if arrays.Contains(r.GetConfig().ServiceAccounttLimitCheckSkipOrgIdList, orgId) {
+		glog.V(5).Infof("orgId = %s , present in service account limits check skip list. No limits on the number of service accounts", orgId)
+		return nil
+	}
+
+	maxAllowed, ok := r.GetConfig().ServiceAccountLimits[orgId]
+	if !ok {
+		return nil
+	}
+	pageSize := r.GetConfig().MaxLimitForGetClients
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	// the service accounts listed with the token of the requester are the ones of its organisation
+	serviceAccountCount := 0
+	for first := 0; ; first += pageSize {
+		accounts, err := r.client.GetServiceAccounts(accessToken, first, pageSize)
+		if err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "failed to create service account")
+		}
+		serviceAccountCount += len(accounts)
+		if serviceAccountCount >= maxAllowed {
+			glog.V(5).Infof("Failure creating service account: max allowed number:%d of service accounts for org:%s has reached", maxAllowed, orgId)
+			return errors.MaxLimitForServiceAccountReached("max allowed number:%d of service accounts for user in org:%s has reached", maxAllowed, orgId)
+		}
+		if len(accounts) < pageSize {
+			return nil
+		}
+	}
+}
+
+func (r *redhatssoService) UpdateServiceAccount(accessToken string, ctx context.Context, id string, name *string, description *string) (*api.ServiceAccount, *errors.ServiceError) {
+	glog.V(5).Infof("Updating service account with id: %s", id)
+	// the SSO provider replaces both the name and the description
+	if name == nil || description == nil {
+		current, found, err := r.client.GetServiceAccount(accessToken, id)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error retrieving service account with id %s", id)
+		}
+		if !found {
+			return nil, errors.NewWithCause(errors.ErrorServiceAccountNotFound, err, "service account not found %s", id)
+		}
+		if name == nil {
+			name = current.Name
+		}
+		if description == nil {
+			description = current.Description
+		}
+	}
+
+	serviceAccount, err := r.client.UpdateServiceAccount(accessToken, id, shared.SafeString(name), shared.SafeString(description))
+	if err != nil {
+		if rhErr, err1 := parseRedhatssoError(err); err1 == nil {
+			switch rhErr.Error {
+			case ServiceAccountNotFound:
+				glog.V(5).Infof("Service account not found %s", id)
+				return nil, errors.NewWithCause(errors.ErrorServiceAccountNotFound, err, "service account not found %s", id)
+			case ServiceAccountAccessInvalid:
+				glog.V(5).Infof("Service account access invalid %s", err.Error())
+				return nil, errors.NewWithCause(errors.ErrorForbidden, err, "failed to update service account")
+			}
+		}
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update service account")
+	}
+	glog.V(5).Infof("Service account with id: %s updated", id)
+	return convertServiceAccountDataToAPIServiceAccount(&serviceAccount), nil
+}
+
+func (r *redhatssoService) RevokeServiceAccountCredentialsInternal(accessToken string, id string) *errors.ServiceError {
+	glog.V(5).Infof("Revoking the credentials of service account with id: %s", id)
+	if _, err := r.client.RegenerateClientSecret(accessToken, id); err != nil {
+		if rhErr, err1 := parseRedhatssoError(err); err1 == nil && rhErr.Error == ServiceAccountNotFound {
+			return errors.NewWithCause(errors.ErrorServiceAccountNotFound, err, "service account not found %s", id)
+		}
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to revoke service account credentials")
+	}
+	glog.V(5).Infof("Credentials of service account with id: %s revoked", id)
+	return nil
+}
+
 func (r *redhatssoService) ListServiceAcc(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 	glog.V(5).Infof("Listing service accounts")
 	accounts, err := r.client.GetServiceAccounts(accessToken, first, max)
@@ -13,7 +13,9 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/redhatsso"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-sdk-go/authentication"
 	pkgErr "github.com/pkg/errors"
 	serviceaccountsclient "github.com/redhat-developer/app-services-sdk-go/serviceaccounts/apiv1internal/client"
 )
@@ -1649,3 +1651,135 @@ func Test_redhatssoService_GetKafkaClientSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_redhatssoService_UpdateServiceAccount(t *testing.T) {
+	clientId := testClientID
+	currentName := "old-name"
+	currentDescription := "old-description"
+	newDescription := "new-description"
+	updateErr := pkgErr.New("update failed")
+
+	g := gomega.NewWithT(t)
+	client := &redhatsso.SSOClientMock{
+		GetServiceAccountFunc: func(accessToken, clientId string) (*serviceaccountsclient.ServiceAccountData, bool, error) {
+			return &serviceaccountsclient.ServiceAccountData{
+				ClientId:    &clientId,
+				Name:        &currentName,
+				Description: &currentDescription,
+			}, true, nil
+		},
+		UpdateServiceAccountFunc: func(accessToken, clientId, name, description string) (serviceaccountsclient.ServiceAccountData, error) {
+			return serviceaccountsclient.ServiceAccountData{
+				ClientId:    &clientId,
+				Name:        &name,
+				Description: &description,
+			}, nil
+		},
+	}
+	r := &redhatssoService{
+		client: client,
+	}
+
+	// the name that isn't given is left unchanged
+	got, err := r.UpdateServiceAccount(token, context.Background(), clientId, nil, &newDescription)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(got.Name).To(gomega.Equal(currentName))
+	g.Expect(got.Description).To(gomega.Equal(newDescription))
+	g.Expect(client.UpdateServiceAccountCalls()[0].Name).To(gomega.Equal(currentName))
+
+	// the service account isn't fetched when both fields are given
+	got, err = r.UpdateServiceAccount(token, context.Background(), clientId, &currentName, &newDescription)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(got.Name).To(gomega.Equal(currentName))
+	g.Expect(client.GetServiceAccountCalls()).To(gomega.HaveLen(1))
+
+	client.UpdateServiceAccountFunc = func(accessToken, clientId, name, description string) (serviceaccountsclient.ServiceAccountData, error) {
+		return serviceaccountsclient.ServiceAccountData{}, updateErr
+	}
+	_, err = r.UpdateServiceAccount(token, context.Background(), clientId, &currentName, &newDescription)
+	g.Expect(err).To(gomega.Equal(errors.NewWithCause(errors.ErrorGeneral, updateErr, "failed to update service account")))
+}
+
+func Test_redhatssoService_checkAllowedServiceAccountsLimits(t *testing.T) {
+	orgId := "13640203"
+	claims := jwt.MapClaims{"org_id": orgId}
+	ctx := authentication.ContextWithToken(context.Background(), &jwt.Token{Claims: claims})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		existing int
+		limits   map[string]int
+		skipList []string
+		wantList bool
+		wantErr  bool
+	}{
+		{
+			name:     "should not list the service accounts of the organisations without a specific limit",
+			ctx:      ctx,
+			existing: 2,
+		},
+		{
+			name:     "should allow the creation under the limit of the organisation",
+			ctx:      ctx,
+			existing: 2,
+			limits:   map[string]int{orgId: 3},
+			wantList: true,
+		},
+		{
+			name:     "should refuse the creation when the limit of the organisation is reached",
+			ctx:      ctx,
+			existing: 3,
+			limits:   map[string]int{orgId: 3},
+			wantList: true,
+			wantErr:  true,
+		},
+		{
+			name:     "should not limit the organisations of the skip list",
+			ctx:      ctx,
+			existing: 3,
+			limits:   map[string]int{orgId: 3},
+			skipList: []string{orgId},
+		},
+		{
+			name:     "should not limit the service accounts created without requester",
+			ctx:      context.Background(),
+			existing: 3,
+			limits:   map[string]int{orgId: 3},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := keycloak.NewKeycloakConfig()
+			config.MaxAllowedServiceAccounts = 2
+			config.MaxLimitForGetClients = 1
+			config.ServiceAccountLimits = tt.limits
+			config.ServiceAccounttLimitCheckSkipOrgIdList = tt.skipList
+			client := &redhatsso.SSOClientMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return config
+				},
+				GetServiceAccountsFunc: func(accessToken string, first, max int) ([]serviceaccountsclient.ServiceAccountData, error) {
+					var accounts []serviceaccountsclient.ServiceAccountData
+					for i := first; i < tt.existing && i < first+max; i++ {
+						accounts = append(accounts, serviceaccountsclient.ServiceAccountData{})
+					}
+					return accounts, nil
+				},
+			}
+			r := &redhatssoService{client: client}
+			err := r.checkAllowedServiceAccountsLimits(token, tt.ctx)
+			g.Expect(len(client.GetServiceAccountsCalls()) > 0).To(gomega.Equal(tt.wantList))
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(errors.ErrorMaxLimitForServiceAccountsReached))
+			} else {
+				g.Expect(err).To(gomega.BeNil())
+			}
+		})
+	}
+}
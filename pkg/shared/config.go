@@ -2,6 +2,7 @@ package shared
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -101,7 +102,24 @@ func ReadYamlFile(filename string, out interface{}) (err error) {
 	if err != nil {
 		return err
 	}
-	return yaml.Unmarshal([]byte(fileContents), out)
+	if err := yaml.Unmarshal([]byte(fileContents), out); err != nil {
+		return fmt.Errorf("%s: %w", BuildFullFilePath(filename), err)
+	}
+	return nil
+}
+
+// ReadYamlFileStrict is like ReadYamlFile but fails when the file contains fields that
+// don't exist in the `out` argument. Errors are prefixed with the path of the file, the
+// yaml errors already contain the line where they happened.
+func ReadYamlFileStrict(filename string, out interface{}) error {
+	fileContents, err := ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if err := yaml.UnmarshalStrict([]byte(fileContents), out); err != nil {
+		return fmt.Errorf("%s: %w", BuildFullFilePath(filename), err)
+	}
+	return nil
 }
 
 // ReadJSONFile reads a JSON file located in `filename` path and
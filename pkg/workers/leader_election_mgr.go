@@ -19,6 +19,10 @@ type LeaderElectionManager struct {
 	leaderElectionReconcilerRepeatInterval time.Duration
 	leaderLeaseExpirationTime              time.Duration
 	workerGrp                              sync.WaitGroup
+	// shardedWorkers is true if the workers that support it run in the sharded mode, see ShardedWorker
+	shardedWorkers     bool
+	workerShardCount   int
+	shardLeasesCreated map[string]bool
 }
 
 // leaderLeaseAcquisition a wrapper for a lease and whether it's been acquired/is owned by another worker
@@ -35,6 +39,9 @@ func NewLeaderElectionManager(workers []Worker, connectionFactory *db.Connection
 		connectionFactory:                      connectionFactory,
 		leaderElectionReconcilerRepeatInterval: reconcilerConfig.LeaderElectionReconcilerRepeatInterval,
 		leaderLeaseExpirationTime:              reconcilerConfig.LeaderLeaseExpirationTime,
+		shardedWorkers:                         reconcilerConfig.EnableShardedWorkers,
+		workerShardCount:                       reconcilerConfig.WorkerShardCount,
+		shardLeasesCreated:                     map[string]bool{},
 	}
 }
 
@@ -60,6 +67,9 @@ func (s *LeaderElectionManager) Start() {
 						worker.Stop()
 						s.workerGrp.Done()
 					}
+					if sharded, ok := s.shardedWorker(worker); ok {
+						s.releaseShards(sharded, s.connectionFactory.New())
+					}
 				}
 				return
 			}
@@ -95,11 +105,19 @@ func (s *LeaderElectionManager) startWorkers() {
 				worker.Stop()
 				s.workerGrp.Done()
 			}
+			if sharded, ok := s.shardedWorker(worker); ok {
+				s.releaseShards(sharded, s.connectionFactory.New())
+			}
 			continue // skip terminated worker
 		}
 		newWorkers = append(newWorkers, worker)
 
-		isLeader := s.isWorkerLeader(worker)
+		var isLeader bool
+		if sharded, ok := s.shardedWorker(worker); ok {
+			isLeader = s.isWorkerShardOwner(sharded)
+		} else {
+			isLeader = s.isWorkerLeader(worker)
+		}
 		if isLeader && !worker.IsRunning() {
 			glog.V(1).Infoln(fmt.Sprintf("Running as the leader and starting worker %T [%s]", worker, worker.GetID()))
 			worker.Start()
@@ -135,6 +153,36 @@ func (s *LeaderElectionManager) isWorkerLeader(worker Worker) bool {
 	return true
 }
 
+// shardedWorker returns the worker as a sharded worker if it runs in the sharded mode
+func (s *LeaderElectionManager) shardedWorker(worker Worker) (ShardedWorker, bool) {
+	if !s.shardedWorkers {
+		return nil, false
+	}
+	sharded, ok := worker.(ShardedWorker)
+	if !ok || !sharded.IsSharded() {
+		return nil, false
+	}
+	return sharded, true
+}
+
+// isWorkerShardOwner acquires the shards of the worker and returns true if it owns any of them
+func (s *LeaderElectionManager) isWorkerShardOwner(worker ShardedWorker) bool {
+	shards, err := s.acquireShards(worker)
+	if err != nil {
+		// we don't know which shards we own, don't reconcile any of them
+		glog.V(5).Infof("failed to acquire shard leases: %s", err)
+		shards = newShards(s.workerShardCount, nil)
+	}
+	worker.SetShards(shards)
+
+	if len(shards.Owned) == 0 {
+		glog.V(5).Infof("not currently owning any shard, skipping reconcile %T [%s]", worker, worker.GetID())
+		return false
+	}
+	glog.V(5).Infof("owning shards %v of %d, reconciling %T [%s]", shards.Owned, shards.Count, worker, worker.GetID())
+	return true
+}
+
 // acquireLeaderLease attempt to claim the leader role using a provided table and return a leaderLeaseAcquisition
 // containing the lease
 func (s *LeaderElectionManager) acquireLeaderLease(workerId string, workerType string, dbConn *gorm.DB) (*leaderLeaseAcquisition, error) {
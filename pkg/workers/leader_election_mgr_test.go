@@ -217,6 +217,7 @@ func TestNewLeaderElectionManager(t *testing.T) {
 				leaderElectionReconcilerRepeatInterval: 15 * time.Second,
 				leaderLeaseExpirationTime:              1 * time.Minute,
 				workerGrp:                              sync.WaitGroup{},
+				shardLeasesCreated:                     map[string]bool{},
 			},
 		},
 	}
@@ -19,6 +19,9 @@ type Reconciler struct {
 	wakeup           chan *sync.WaitGroup
 	SignalBus        signalbus.SignalBus
 	ReconcilerConfig *ReconcilerConfig
+	// WorkerStateStore records the reconciles and holds the paused worker types, the reconciles are neither
+	// recorded nor paused when it's not set
+	WorkerStateStore WorkerStateStore `optional:"true"`
 }
 
 // Wakeup causes the worker reconcile to be performed as soon as possible.  If wait is true, the this
@@ -48,8 +51,16 @@ func (r *Reconciler) Start(worker Worker) {
 	sub := r.SignalBus.Subscribe("reconcile:" + worker.GetWorkerType())
 	ticker := time.NewTicker(r.ReconcilerConfig.ReconcilerRepeatInterval)
 
+	// the targeted workers also reconcile the resources they are notified of between the periodic reconciles
+	var targets *targetQueue
+	targetedWorker, isTargeted := worker.(TargetedWorker)
+	if isTargeted {
+		targets = newTargetQueue(r.SignalBus, targetedWorker.GetTargetSignals())
+	}
+
 	go func() {
 		defer sub.Close()
+		defer targets.Close()
 		//starts reconcile immediately and then on every repeat interval
 		glog.V(1).Infoln(fmt.Sprintf("Initial reconciliation loop for %T [%s]", worker, worker.GetID()))
 		r.runReconcile(worker)
@@ -67,6 +78,8 @@ func (r *Reconciler) Start(worker Worker) {
 			case <-sub.Signal():
 				glog.V(1).Infoln(fmt.Sprintf("Signalbus triggered reconciliation loop for %T [%s]", worker, worker.GetID()))
 				r.runReconcile(worker)
+			case <-targets.Signal():
+				r.runReconcileTargets(targetedWorker, targets.Drain())
 			case <-*worker.GetStopChan():
 				ticker.Stop()
 				defer worker.GetSyncGroup().Done()
@@ -77,7 +90,26 @@ func (r *Reconciler) Start(worker Worker) {
 	}()
 }
 
+func (r *Reconciler) isPaused(worker Worker) bool {
+	if r.WorkerStateStore == nil {
+		return false
+	}
+	paused, err := r.WorkerStateStore.IsPaused(worker.GetWorkerType())
+	if err != nil {
+		logger.Logger.Error(err)
+		return false
+	}
+	if paused {
+		glog.V(1).Infoln(fmt.Sprintf("Skipping reconciliation of paused %T [%s]", worker, worker.GetID()))
+	}
+	return paused
+}
+
 func (r *Reconciler) runReconcile(worker Worker) {
+	if r.isPaused(worker) {
+		return
+	}
+
 	start := time.Now()
 	errors := worker.Reconcile()
 	if len(errors) == 0 {
@@ -87,11 +119,28 @@ func (r *Reconciler) runReconcile(worker Worker) {
 		metrics.IncreaseReconcilerErrorsCount(worker.GetWorkerType(), len(errors))
 	}
 	metrics.UpdateReconcilerDurationMetric(worker.GetWorkerType(), time.Since(start))
+	if r.WorkerStateStore != nil {
+		if err := r.WorkerStateStore.RecordReconcile(worker, start, time.Now(), errors); err != nil {
+			logger.Logger.Error(err)
+		}
+	}
 	for _, e := range errors {
 		logger.Logger.Error(e)
 	}
 }
 
+// runReconcileTargets reconciles the resources a targeted worker has been notified of. The resources that fail to
+// be reconciled are retried by the next periodic reconcile.
+func (r *Reconciler) runReconcileTargets(worker TargetedWorker, ids []string) {
+	if len(ids) == 0 || r.isPaused(worker) {
+		return
+	}
+	glog.V(1).Infoln(fmt.Sprintf("Signalbus triggered reconciliation of %d resources for %T [%s]", len(ids), worker, worker.GetID()))
+	for _, e := range worker.ReconcileTargets(ids) {
+		logger.Logger.Error(e)
+	}
+}
+
 func (r *Reconciler) Stop(worker Worker) {
 	defer worker.SetIsRunning(false)
 	select {
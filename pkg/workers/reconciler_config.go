@@ -3,6 +3,7 @@ package workers
 import (
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 )
 
@@ -10,6 +11,9 @@ type ReconcilerConfig struct {
 	ReconcilerRepeatInterval               time.Duration `json:"reconciler_repeat_interval"`
 	LeaderLeaseExpirationTime              time.Duration `json:"leader_lease_expiration_time"`
 	LeaderElectionReconcilerRepeatInterval time.Duration `json:"leader_election_reconciler_repeat_interval"`
+	// EnableShardedWorkers runs the workers that support it on all the replicas, each replica reconciling the rows of the shards it acquired
+	EnableShardedWorkers bool `json:"enable_sharded_workers"`
+	WorkerShardCount     int  `json:"worker_shard_count"`
 }
 
 func NewReconcilerConfig() *ReconcilerConfig {
@@ -17,6 +21,8 @@ func NewReconcilerConfig() *ReconcilerConfig {
 		ReconcilerRepeatInterval:               30 * time.Second,
 		LeaderLeaseExpirationTime:              1 * time.Minute,
 		LeaderElectionReconcilerRepeatInterval: 15 * time.Second,
+		EnableShardedWorkers:                   false,
+		WorkerShardCount:                       16,
 	}
 }
 
@@ -24,8 +30,13 @@ func (r *ReconcilerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&r.ReconcilerRepeatInterval, "reconciler-repeat-interval", r.ReconcilerRepeatInterval, "The frequency at which each scheduled reconciler worker is running.")
 	fs.DurationVar(&r.LeaderLeaseExpirationTime, "leader-lease-expiration-time", r.LeaderLeaseExpirationTime, "The time before a lease expires.")
 	fs.DurationVar(&r.LeaderElectionReconcilerRepeatInterval, "leader-election-reconciler-repeat-interval", r.LeaderElectionReconcilerRepeatInterval, "The scheduled interval between leader election reconciliation.")
+	fs.BoolVar(&r.EnableShardedWorkers, "enable-sharded-workers", r.EnableShardedWorkers, "Run the workers that support it on all the replicas, each replica reconciling the rows of the shards it acquired instead of a single leader reconciling all the rows.")
+	fs.IntVar(&r.WorkerShardCount, "worker-shard-count", r.WorkerShardCount, "The number of shards the rows of the sharded workers are partitioned in. It should be greater than the number of replicas.")
 }
 
 func (c *ReconcilerConfig) ReadFiles() error {
+	if c.EnableShardedWorkers && c.WorkerShardCount < 1 {
+		return errors.Errorf("worker-shard-count must be greater than 0 when the sharded workers are enabled, got %d", c.WorkerShardCount)
+	}
 	return nil
 }
@@ -26,6 +26,8 @@ func TestReconcilerConfig_ReadFiles(t *testing.T) {
 		ReconcilerRepeatInterval               time.Duration
 		LeaderLeaseExpirationTime              time.Duration
 		LeaderElectionReconcilerRepeatInterval time.Duration
+		EnableShardedWorkers                   bool
+		WorkerShardCount                       int
 	}
 	tests := []struct {
 		name    string
@@ -33,7 +35,7 @@ func TestReconcilerConfig_ReadFiles(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "should always return nil",
+			name: "should return nil when the sharded workers are disabled",
 			fields: fields{
 				ReconcilerRepeatInterval:               30 * time.Second,
 				LeaderLeaseExpirationTime:              1 * time.Minute,
@@ -41,6 +43,22 @@ func TestReconcilerConfig_ReadFiles(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should return nil when the sharded workers are enabled with a valid shard count",
+			fields: fields{
+				EnableShardedWorkers: true,
+				WorkerShardCount:     16,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return an error when the sharded workers are enabled without shards",
+			fields: fields{
+				EnableShardedWorkers: true,
+				WorkerShardCount:     0,
+			},
+			wantErr: true,
+		},
 	}
 	for _, testcase := range tests {
 		tt := testcase
@@ -51,6 +69,8 @@ func TestReconcilerConfig_ReadFiles(t *testing.T) {
 				ReconcilerRepeatInterval:               tt.fields.ReconcilerRepeatInterval,
 				LeaderLeaseExpirationTime:              tt.fields.LeaderLeaseExpirationTime,
 				LeaderElectionReconcilerRepeatInterval: tt.fields.LeaderElectionReconcilerRepeatInterval,
+				EnableShardedWorkers:                   tt.fields.EnableShardedWorkers,
+				WorkerShardCount:                       tt.fields.WorkerShardCount,
 			}
 			g.Expect(c.ReadFiles() != nil).To(gomega.Equal(tt.wantErr))
 		})
@@ -2,6 +2,7 @@ package workers
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -79,3 +80,113 @@ func TestReconciler_Wakeup(t *testing.T) {
 	// We can use a 0 timeout here because Wakeup will wait for the reconcile to occur first.
 	g.Expect(waitForReconcile(0)).Should(gomega.Equal(false))
 }
+
+func TestReconciler_runReconcile(t *testing.T) {
+	tests := []struct {
+		name           string
+		paused         bool
+		wantReconciles int
+	}{
+		{
+			name:           "should reconcile and record the reconcile",
+			wantReconciles: 1,
+		},
+		{
+			name:           "should not reconcile a paused worker",
+			paused:         true,
+			wantReconciles: 0,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			stateStore := &WorkerStateStoreMock{
+				IsPausedFunc: func(workerType string) (bool, error) {
+					return tt.paused, nil
+				},
+				RecordReconcileFunc: func(worker Worker, start time.Time, end time.Time, reconcileErrors []error) error {
+					return nil
+				},
+			}
+			r := Reconciler{
+				SignalBus:        signalbus.NewSignalBus(),
+				ReconcilerConfig: NewReconcilerConfig(),
+				WorkerStateStore: stateStore,
+			}
+			worker := &WorkerMock{
+				GetIDFunc: func() string {
+					return "test"
+				},
+				GetWorkerTypeFunc: func() string {
+					return "test"
+				},
+				ReconcileFunc: func() []error {
+					return []error{fmt.Errorf("failed")}
+				},
+			}
+
+			r.runReconcile(worker)
+			g.Expect(worker.ReconcileCalls()).To(gomega.HaveLen(tt.wantReconciles))
+			g.Expect(stateStore.RecordReconcileCalls()).To(gomega.HaveLen(tt.wantReconciles))
+			if tt.wantReconciles > 0 {
+				g.Expect(stateStore.RecordReconcileCalls()[0].ReconcileErrors).To(gomega.HaveLen(1))
+			}
+		})
+	}
+}
+
+type testTargetedWorker struct {
+	*WorkerMock
+	targets chan []string
+}
+
+func (w *testTargetedWorker) GetTargetSignals() []string {
+	return []string{"test-resource"}
+}
+
+func (w *testTargetedWorker) ReconcileTargets(ids []string) []error {
+	w.targets <- ids
+	return nil
+}
+
+func TestReconciler_ReconcileTargets(t *testing.T) {
+	g := gomega.NewWithT(t)
+	bus := signalbus.NewSignalBus()
+	r := Reconciler{
+		SignalBus:        bus,
+		ReconcilerConfig: NewReconcilerConfig(),
+	}
+	var stopchan chan struct{}
+	var wg sync.WaitGroup
+	worker := &testTargetedWorker{
+		WorkerMock: &WorkerMock{
+			GetStopChanFunc: func() *chan struct{} {
+				return &stopchan
+			},
+			GetSyncGroupFunc: func() *sync.WaitGroup {
+				return &wg
+			},
+			SetIsRunningFunc: func(val bool) {
+			},
+			GetIDFunc: func() string {
+				return "test"
+			},
+			GetWorkerTypeFunc: func() string {
+				return "test"
+			},
+			ReconcileFunc: func() []error {
+				return nil
+			},
+		},
+		targets: make(chan []string, 10),
+	}
+
+	r.Start(worker)
+	defer r.Stop(worker)
+
+	// the notified resources are reconciled without waiting for the next periodic reconcile
+	bus.NotifyPayload("test-resource", "1")
+	g.Eventually(worker.targets, 2*time.Second).Should(gomega.Receive(gomega.Equal([]string{"1"})))
+}
@@ -0,0 +1,122 @@
+package workers
+
+import (
+	"math"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// createShardLeasesQuery creates the leases of all the shards of a worker type, the existing leases are kept
+const createShardLeasesQuery = `INSERT INTO worker_shard_leases (worker_type, shard, owner, expires)
+SELECT @workerType, shard, '', @now FROM generate_series(0, @count - 1) AS shard
+ON CONFLICT DO NOTHING`
+
+// heartbeatQuery registers the replica of the worker as alive until the given expiry time
+const heartbeatQuery = `INSERT INTO worker_replicas (worker_type, worker_id, expires)
+VALUES (@workerType, @workerId, @expires)
+ON CONFLICT (worker_type, worker_id) DO UPDATE SET expires = excluded.expires`
+
+// renewShardLeasesQuery extends the leases owned by the worker and returns their shards
+const renewShardLeasesQuery = `UPDATE worker_shard_leases SET expires = @expires
+WHERE worker_type = @workerType AND owner = @workerId AND shard < @count
+RETURNING shard`
+
+// acquireShardLeasesQuery acquires up to the given limit of shards that are not owned, or whose lease has expired.
+// The shards locked by other replicas acquiring them at the same time are skipped.
+const acquireShardLeasesQuery = `UPDATE worker_shard_leases SET owner = @workerId, expires = @expires
+WHERE worker_type = @workerType AND shard IN (
+	SELECT shard FROM worker_shard_leases
+	WHERE worker_type = @workerType AND shard < @count AND (owner = '' OR expires < @now)
+	ORDER BY shard
+	LIMIT @limit
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING shard`
+
+// acquireShards renews the shard leases of the worker, and acquires or releases shards so that each live replica of
+// the worker type owns about the same number of shards. Shards are rebalanced when replicas join or leave: the
+// replicas owning more shards than their share release them, and the leases of the replicas that left expire.
+func (s *LeaderElectionManager) acquireShards(worker Worker) (*Shards, error) {
+	dbConn := s.connectionFactory.New()
+	now := time.Now()
+	args := map[string]interface{}{
+		"workerType": worker.GetWorkerType(),
+		"workerId":   worker.GetID(),
+		"count":      s.workerShardCount,
+		"now":        now,
+		"expires":    now.Add(s.leaderLeaseExpirationTime),
+	}
+
+	if !s.shardLeasesCreated[worker.GetWorkerType()] {
+		if err := dbConn.Exec(createShardLeasesQuery, args).Error; err != nil {
+			return nil, errors.Wrap(err, "failed to create shard leases")
+		}
+		s.shardLeasesCreated[worker.GetWorkerType()] = true
+	}
+
+	if err := dbConn.Exec(heartbeatQuery, args).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to register worker replica")
+	}
+
+	var replicas int64
+	if err := dbConn.Table("worker_replicas").
+		Where("worker_type = ? AND expires >= ?", worker.GetWorkerType(), now).
+		Count(&replicas).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to count worker replicas")
+	}
+	target := shardsPerReplica(s.workerShardCount, replicas)
+
+	var owned []int
+	if err := dbConn.Raw(renewShardLeasesQuery, args).Scan(&owned).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to renew shard leases")
+	}
+
+	if len(owned) > target {
+		owned = newShards(s.workerShardCount, owned).Owned
+		released := owned[target:]
+		if err := dbConn.Table("worker_shard_leases").
+			Where("worker_type = ? AND owner = ? AND shard IN ?", worker.GetWorkerType(), worker.GetID(), released).
+			Updates(map[string]interface{}{"owner": "", "expires": now}).Error; err != nil {
+			return nil, errors.Wrap(err, "failed to release shard leases")
+		}
+		owned = owned[:target]
+	} else if len(owned) < target {
+		args["limit"] = target - len(owned)
+		var acquired []int
+		if err := dbConn.Raw(acquireShardLeasesQuery, args).Scan(&acquired).Error; err != nil {
+			return nil, errors.Wrap(err, "failed to acquire shard leases")
+		}
+		owned = append(owned, acquired...)
+	}
+
+	// forget the replicas that left, so that they are not counted anymore
+	if err := dbConn.Exec("DELETE FROM worker_replicas WHERE worker_type = ? AND expires < ?", worker.GetWorkerType(), now).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to remove expired worker replicas")
+	}
+
+	return newShards(s.workerShardCount, owned), nil
+}
+
+// shardsPerReplica returns the number of shards each replica should own
+func shardsPerReplica(shardCount int, replicas int64) int {
+	if replicas < 1 {
+		replicas = 1
+	}
+	return int(math.Ceil(float64(shardCount) / float64(replicas)))
+}
+
+// releaseShards releases the shard leases of a worker that stopped, so that other replicas can acquire them
+// without waiting for the leases to expire
+func (s *LeaderElectionManager) releaseShards(worker Worker, dbConn *gorm.DB) {
+	if err := dbConn.Table("worker_shard_leases").
+		Where("worker_type = ? AND owner = ?", worker.GetWorkerType(), worker.GetID()).
+		Updates(map[string]interface{}{"owner": "", "expires": time.Now()}).Error; err != nil {
+		glog.Errorf("failed to release shard leases of %T [%s]: %v", worker, worker.GetID(), err)
+	}
+	if err := dbConn.Exec("DELETE FROM worker_replicas WHERE worker_type = ? AND worker_id = ?", worker.GetWorkerType(), worker.GetID()).Error; err != nil {
+		glog.Errorf("failed to unregister worker replica %T [%s]: %v", worker, worker.GetID(), err)
+	}
+}
@@ -0,0 +1,109 @@
+package workers
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+)
+
+// ShardedWorker is a worker that can run on several replicas at once when the sharded mode is enabled.
+// The rows it reconciles are partitioned in shards by the hash of their ID, each replica acquires a lease on some
+// of the shards and only reconciles the rows of these shards.
+type ShardedWorker interface {
+	Worker
+	// IsSharded returns true if the worker supports the sharded mode
+	IsSharded() bool
+	// SetShards sets the shards owned by the worker, nil when the worker doesn't run in the sharded mode
+	SetShards(shards *Shards)
+}
+
+// Shards are the shards owned by a worker, out of Count shards
+type Shards struct {
+	Count int
+	Owned []int
+}
+
+// ShardOf returns the shard of the row with the given ID, out of count shards
+func ShardOf(id string, count int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return int(h.Sum32() % uint32(count))
+}
+
+// Contains returns true if the given shard is owned
+func (s *Shards) Contains(shard int) bool {
+	for _, owned := range s.Owned {
+		if owned == shard {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal returns true if both are the same shards
+func (s *Shards) Equal(other *Shards) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if s.Count != other.Count || len(s.Owned) != len(other.Owned) {
+		return false
+	}
+	for i := range s.Owned {
+		if s.Owned[i] != other.Owned[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func newShards(count int, owned []int) *Shards {
+	sort.Ints(owned)
+	return &Shards{Count: count, Owned: owned}
+}
+
+func (b *BaseWorker) IsSharded() bool {
+	return b.Sharded
+}
+
+func (b *BaseWorker) SetShards(shards *Shards) {
+	b.shardsMutex.Lock()
+	defer b.shardsMutex.Unlock()
+	if b.shards.Equal(shards) {
+		return
+	}
+	b.shards = shards
+	if shards != nil {
+		metrics.SetWorkerShardOwnerMetric(b.WorkerType, shards.Count, shards.Owned)
+		metrics.IncreaseWorkerShardRebalanceCount(b.WorkerType)
+	}
+}
+
+// GetShards returns the shards owned by the worker, or nil if it doesn't run in the sharded mode
+func (b *BaseWorker) GetShards() *Shards {
+	b.shardsMutex.RLock()
+	defer b.shardsMutex.RUnlock()
+	return b.shards
+}
+
+// InShard returns true if the row with the given ID must be reconciled by the worker, i.e. the worker doesn't run in
+// the sharded mode or owns the shard of the row
+func (b *BaseWorker) InShard(id string) bool {
+	shards := b.GetShards()
+	if shards == nil {
+		return true
+	}
+	shard := ShardOf(id, shards.Count)
+	if !shards.Contains(shard) {
+		return false
+	}
+	metrics.IncreaseWorkerShardReconciledCount(b.WorkerType, shard)
+	return true
+}
+
+// IsShardLeader returns true if the worker must do the work that can't be partitioned in shards, e.g. computing
+// metrics. It is done by the owner of the first shard when the worker runs in the sharded mode.
+func (b *BaseWorker) IsShardLeader() bool {
+	shards := b.GetShards()
+	return shards == nil || shards.Contains(0)
+}
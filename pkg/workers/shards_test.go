@@ -0,0 +1,99 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_ShardOf(t *testing.T) {
+	g := gomega.NewWithT(t)
+	for _, id := range []string{"", "1", "cdgh3jgmb5opa6m0u2b0", "cdgh3jgmb5opa6m0u2bg"} {
+		shard := ShardOf(id, 16)
+		g.Expect(shard).To(gomega.BeNumerically(">=", 0))
+		g.Expect(shard).To(gomega.BeNumerically("<", 16))
+		g.Expect(ShardOf(id, 16)).To(gomega.Equal(shard))
+		g.Expect(ShardOf(id, 1)).To(gomega.Equal(0))
+	}
+}
+
+func Test_shardsPerReplica(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		replicas int64
+		want     int
+	}{
+		{name: "should own all the shards when there is no other replica", count: 16, replicas: 1, want: 16},
+		{name: "should own all the shards when the replica isn't counted yet", count: 16, replicas: 0, want: 16},
+		{name: "should share the shards between the replicas", count: 16, replicas: 4, want: 4},
+		{name: "should round up the shards of each replica", count: 16, replicas: 3, want: 6},
+		{name: "should own one shard when there are more replicas than shards", count: 2, replicas: 3, want: 1},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(shardsPerReplica(tt.count, tt.replicas)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestBaseWorker_InShard(t *testing.T) {
+	id := "cdgh3jgmb5opa6m0u2b0"
+	shard := ShardOf(id, 4)
+
+	tests := []struct {
+		name              string
+		shards            *Shards
+		wantInShard       bool
+		wantIsShardLeader bool
+	}{
+		{
+			name:              "should reconcile every row when the worker isn't sharded",
+			wantInShard:       true,
+			wantIsShardLeader: true,
+		},
+		{
+			name:              "should reconcile the rows of the owned shards",
+			shards:            newShards(4, []int{shard}),
+			wantInShard:       true,
+			wantIsShardLeader: shard == 0,
+		},
+		{
+			name:              "should not reconcile the rows of the other shards",
+			shards:            newShards(4, []int{(shard + 1) % 4}),
+			wantInShard:       false,
+			wantIsShardLeader: shard == 3,
+		},
+		{
+			name:              "should not reconcile any row when no shard is owned",
+			shards:            newShards(4, nil),
+			wantInShard:       false,
+			wantIsShardLeader: false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			worker := &BaseWorker{WorkerType: "test", Sharded: true}
+			worker.SetShards(tt.shards)
+			g.Expect(worker.GetShards().Equal(tt.shards)).To(gomega.BeTrue())
+			g.Expect(worker.InShard(id)).To(gomega.Equal(tt.wantInShard))
+			g.Expect(worker.IsShardLeader()).To(gomega.Equal(tt.wantIsShardLeader))
+		})
+	}
+}
+
+func TestShards_Equal(t *testing.T) {
+	g := gomega.NewWithT(t)
+	var nilShards *Shards
+	g.Expect(nilShards.Equal(nil)).To(gomega.BeTrue())
+	g.Expect(nilShards.Equal(newShards(4, nil))).To(gomega.BeFalse())
+	g.Expect(newShards(4, []int{2, 1}).Equal(newShards(4, []int{1, 2}))).To(gomega.BeTrue())
+	g.Expect(newShards(4, []int{1, 2}).Equal(newShards(8, []int{1, 2}))).To(gomega.BeFalse())
+	g.Expect(newShards(4, []int{1, 2}).Equal(newShards(4, []int{1, 3}))).To(gomega.BeFalse())
+}
@@ -0,0 +1,83 @@
+package workers
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+)
+
+// TargetedWorker is a worker that reconciles the resources it is notified of as soon as possible, in addition to the
+// periodic reconcile of all its resources. The resources are notified with signalbus.SignalBus.NotifyPayload, using
+// one of the target signals of the worker as name and the ID of the resource as payload.
+type TargetedWorker interface {
+	Worker
+	// GetTargetSignals returns the names of the signals notifying the IDs of the resources to reconcile
+	GetTargetSignals() []string
+	// ReconcileTargets reconciles the resources with the given IDs. The resources the worker doesn't reconcile,
+	// e.g. because they are not in the status it handles, must be skipped.
+	ReconcileTargets(ids []string) []error
+}
+
+// targetQueue queues the IDs of the resources notified to a TargetedWorker
+type targetQueue struct {
+	subs   []*signalbus.Subscription
+	signal chan bool
+	stop   chan struct{}
+}
+
+func newTargetQueue(bus signalbus.SignalBus, names []string) *targetQueue {
+	q := &targetQueue{
+		signal: make(chan bool, 1),
+		stop:   make(chan struct{}),
+	}
+	for _, name := range names {
+		sub := bus.Subscribe(name)
+		q.subs = append(q.subs, sub)
+		go func() {
+			for {
+				select {
+				case <-sub.Signal():
+					select {
+					case q.signal <- true:
+					default:
+					}
+				case <-q.stop:
+					return
+				}
+			}
+		}()
+	}
+	return q
+}
+
+// Signal returns a channel that receives a message when IDs are queued. It never receives any message if the
+// queue is nil, i.e. the worker is not a TargetedWorker.
+func (q *targetQueue) Signal() <-chan bool {
+	if q == nil {
+		return nil
+	}
+	return q.signal
+}
+
+// Drain returns the distinct queued IDs and removes them from the queue
+func (q *targetQueue) Drain() []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, sub := range q.subs {
+		for _, id := range sub.Payloads() {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func (q *targetQueue) Close() {
+	if q == nil {
+		return
+	}
+	close(q.stop)
+	for _, sub := range q.subs {
+		sub.Close()
+	}
+}
@@ -21,12 +21,16 @@ type Worker interface {
 }
 
 type BaseWorker struct {
-	Id           string
-	WorkerType   string
-	Reconciler   Reconciler
+	Id         string
+	WorkerType string
+	Reconciler Reconciler
+	// Sharded is true if the worker supports the sharded mode, see ShardedWorker
+	Sharded      bool
 	isRunning    bool
 	imStop       chan struct{}
 	syncTeardown sync.WaitGroup
+	shardsMutex  sync.RWMutex
+	shards       *Shards
 }
 
 func (b *BaseWorker) GetID() string {
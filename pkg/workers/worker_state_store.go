@@ -0,0 +1,189 @@
+package workers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/pkg/errors"
+)
+
+// maxRecordedReconcileErrors is the maximum number of errors of the last reconcile that are stored
+const maxRecordedReconcileErrors = 10
+
+// WorkerState is the state of a worker type shared by all the replicas, stored in the `worker_states` table
+type WorkerState struct {
+	WorkerType string `gorm:"primaryKey"`
+	// Paused is true if the worker type must not reconcile on any replica
+	Paused                bool
+	LastReconcileStart    *time.Time
+	LastReconcileEnd      *time.Time
+	LastReconcileWorkerId string
+	// LastErrors holds the messages of the errors of the last reconcile, as a JSON array
+	LastErrors api.JSON
+	UpdatedAt  time.Time
+}
+
+// GetLastErrors returns the messages of the errors of the last reconcile
+func (s *WorkerState) GetLastErrors() ([]string, error) {
+	var lastErrors []string
+	if len(s.LastErrors) == 0 {
+		return lastErrors, nil
+	}
+	if err := json.Unmarshal(s.LastErrors, &lastErrors); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal last errors of worker %s", s.WorkerType)
+	}
+	return lastErrors, nil
+}
+
+// WorkerStatus is the state of a worker type along with its current leader
+type WorkerStatus struct {
+	WorkerState
+	// Leader is the ID of the worker leading the worker type, or owning its first shard in the sharded mode
+	Leader       string
+	LeaseExpires *time.Time
+}
+
+// IsRunning returns true if the worker type has a leader whose lease hasn't expired and isn't paused
+func (s *WorkerStatus) IsRunning(now time.Time) bool {
+	return !s.Paused && s.Leader != "" && s.LeaseExpires != nil && s.LeaseExpires.After(now)
+}
+
+//go:generate moq -out worker_state_store_moq.go . WorkerStateStore
+type WorkerStateStore interface {
+	// IsPaused returns true if the given worker type has been paused
+	IsPaused(workerType string) (bool, error)
+	// SetPaused pauses or resumes the given worker type on all the replicas
+	SetPaused(workerType string, paused bool) error
+	// RecordReconcile stores the time and the errors of the last reconcile of a worker
+	RecordReconcile(worker Worker, start time.Time, end time.Time, reconcileErrors []error) error
+	// List returns the status of the given worker types
+	List(workerTypes []string) ([]WorkerStatus, error)
+}
+
+const setWorkerPausedQuery = `INSERT INTO worker_states (worker_type, paused, updated_at)
+VALUES (@workerType, @paused, @now)
+ON CONFLICT (worker_type) DO UPDATE SET paused = excluded.paused, updated_at = excluded.updated_at`
+
+const recordReconcileQuery = `INSERT INTO worker_states (worker_type, paused, last_reconcile_start, last_reconcile_end, last_reconcile_worker_id, last_errors, updated_at)
+VALUES (@workerType, false, @start, @end, @workerId, @lastErrors, @end)
+ON CONFLICT (worker_type) DO UPDATE SET
+	last_reconcile_start = excluded.last_reconcile_start,
+	last_reconcile_end = excluded.last_reconcile_end,
+	last_reconcile_worker_id = excluded.last_reconcile_worker_id,
+	last_errors = excluded.last_errors,
+	updated_at = excluded.updated_at`
+
+var _ WorkerStateStore = &workerStateStore{}
+
+type workerStateStore struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+// NewWorkerStateStore returns a store that keeps the state of the worker types in the `worker_states` table.
+// The state is stored outside of any transaction, so that it is visible to all the replicas right away.
+func NewWorkerStateStore(connectionFactory *db.ConnectionFactory) WorkerStateStore {
+	return &workerStateStore{connectionFactory: connectionFactory}
+}
+
+func (s *workerStateStore) IsPaused(workerType string) (bool, error) {
+	var states []WorkerState
+	if err := s.connectionFactory.New().Where("worker_type = ?", workerType).Find(&states).Error; err != nil {
+		return false, errors.Wrapf(err, "failed to get the state of worker %s", workerType)
+	}
+	return len(states) > 0 && states[0].Paused, nil
+}
+
+func (s *workerStateStore) SetPaused(workerType string, paused bool) error {
+	err := s.connectionFactory.New().Exec(setWorkerPausedQuery, map[string]interface{}{
+		"workerType": workerType,
+		"paused":     paused,
+		"now":        time.Now(),
+	}).Error
+	if err != nil {
+		return errors.Wrapf(err, "failed to set paused state of worker %s", workerType)
+	}
+	return nil
+}
+
+func (s *workerStateStore) RecordReconcile(worker Worker, start time.Time, end time.Time, reconcileErrors []error) error {
+	messages := []string{}
+	for _, e := range reconcileErrors {
+		if len(messages) == maxRecordedReconcileErrors {
+			break
+		}
+		messages = append(messages, e.Error())
+	}
+	lastErrors, err := json.Marshal(messages)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal reconcile errors")
+	}
+
+	err = s.connectionFactory.New().Exec(recordReconcileQuery, map[string]interface{}{
+		"workerType": worker.GetWorkerType(),
+		"workerId":   worker.GetID(),
+		"start":      start,
+		"end":        end,
+		"lastErrors": api.JSON(lastErrors),
+	}).Error
+	if err != nil {
+		return errors.Wrapf(err, "failed to record reconcile of worker %s", worker.GetWorkerType())
+	}
+	return nil
+}
+
+func (s *workerStateStore) List(workerTypes []string) ([]WorkerStatus, error) {
+	dbConn := s.connectionFactory.New()
+
+	var states []WorkerState
+	if err := dbConn.Where("worker_type IN ?", workerTypes).Find(&states).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to list worker states")
+	}
+	statesByType := map[string]WorkerState{}
+	for _, state := range states {
+		statesByType[state.WorkerType] = state
+	}
+
+	var leases api.LeaderLeaseList
+	if err := dbConn.Where("lease_type IN ?", workerTypes).Find(&leases).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to list leader leases")
+	}
+	leasesByType := map[string]*api.LeaderLease{}
+	for _, lease := range leases {
+		leasesByType[lease.LeaseType] = lease
+	}
+
+	// in the sharded mode the owner of the first shard does the work of the leader, see BaseWorker.IsShardLeader
+	var shardLeases []struct {
+		WorkerType string
+		Owner      string
+		Expires    *time.Time
+	}
+	if err := dbConn.Table("worker_shard_leases").
+		Where("worker_type IN ? AND shard = 0 AND owner <> ''", workerTypes).
+		Find(&shardLeases).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to list worker shard leases")
+	}
+	shardLeasesByType := map[string]int{}
+	for i := range shardLeases {
+		shardLeasesByType[shardLeases[i].WorkerType] = i
+	}
+
+	var statuses []WorkerStatus
+	for _, workerType := range workerTypes {
+		status := WorkerStatus{WorkerState: WorkerState{WorkerType: workerType}}
+		if state, ok := statesByType[workerType]; ok {
+			status.WorkerState = state
+		}
+		if i, ok := shardLeasesByType[workerType]; ok {
+			status.Leader = shardLeases[i].Owner
+			status.LeaseExpires = shardLeases[i].Expires
+		} else if lease, ok := leasesByType[workerType]; ok {
+			status.Leader = lease.Leader
+			status.LeaseExpires = lease.Expires
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}